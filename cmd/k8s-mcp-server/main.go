@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,20 +12,45 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
-
-	stdlog "log"
-
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/audit"
+	"github.com/briankscheong/k8s-mcp-server/pkg/auth"
+	"github.com/briankscheong/k8s-mcp-server/pkg/banner"
+	"github.com/briankscheong/k8s-mcp-server/pkg/completion"
+	"github.com/briankscheong/k8s-mcp-server/pkg/confirm"
+	"github.com/briankscheong/k8s-mcp-server/pkg/health"
+	"github.com/briankscheong/k8s-mcp-server/pkg/incident"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s"
-	iolog "github.com/briankscheong/k8s-mcp-server/pkg/log"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/informercache"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/limits"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/scope"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/verbs"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/visibility"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/warmup"
+	"github.com/briankscheong/k8s-mcp-server/pkg/log"
+	"github.com/briankscheong/k8s-mcp-server/pkg/metrics"
+	"github.com/briankscheong/k8s-mcp-server/pkg/output"
+	"github.com/briankscheong/k8s-mcp-server/pkg/prompts"
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/briankscheong/k8s-mcp-server/pkg/scanner"
+	"github.com/briankscheong/k8s-mcp-server/pkg/secret"
+	"github.com/briankscheong/k8s-mcp-server/pkg/servertls"
+	"github.com/briankscheong/k8s-mcp-server/pkg/throttle"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/tracing"
+	"github.com/briankscheong/k8s-mcp-server/pkg/transcript"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/rs/zerolog/log"
-	logrus "github.com/sirupsen/logrus"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
@@ -35,46 +61,255 @@ var (
 	date    = "unknown"
 )
 
+// warmUpTimeout bounds the background warm-up so a slow API server does not keep it running
+const warmUpTimeout = 2 * time.Minute
+
+// permissionProbeTimeout bounds each probe of the permissions tools need
+const permissionProbeTimeout = 30 * time.Second
+
+// defaultKubeAPIQPS and defaultKubeAPIBurst raise the client-go defaults of 5 and 10, which
+// throttle agents that issue many API requests in a row
+const (
+	defaultKubeAPIQPS   = 50
+	defaultKubeAPIBurst = 100
+)
+
+// traceShutdownTimeout bounds the export of the remaining spans on shutdown
+const traceShutdownTimeout = 5 * time.Second
+
+// secretLoadTimeout bounds each load of a sensitive setting from its backend
+const secretLoadTimeout = 10 * time.Second
+
 // Environment variable names - grouped by purpose
 const (
 	// Env prefix
 	EnvPrefix = "K8S_MCP"
 
+	// Config file
+	EnvConfig = "CONFIG"
+
 	// Kubernetes connection
-	EnvKubeConfig = "KUBECONFIG"
-	EnvNamespace  = "NAMESPACE"
-	EnvInCluster  = "IN_CLUSTER"
+	EnvKubeConfig    = "KUBECONFIG"
+	EnvKubeConfigDir = "KUBECONFIG_DIR"
+	EnvContext       = "CONTEXT"
+	EnvNamespace     = "NAMESPACE"
+	EnvInCluster     = "IN_CLUSTER"
+
+	// Impersonation
+	EnvAs                 = "AS"
+	EnvAsGroup            = "AS_GROUP"
+	EnvImpersonatePerCall = "IMPERSONATE_PER_CALL"
+
+	// Scoping
+	EnvDefaultLabelSelector = "DEFAULT_LABEL_SELECTOR"
+
+	// Kubernetes API client
+	EnvKubeAPIQPS      = "KUBE_API_QPS"
+	EnvKubeAPIBurst    = "KUBE_API_BURST"
+	EnvRequestTimeout  = "REQUEST_TIMEOUT"
+	EnvKubeAPIProtobuf = "KUBE_API_PROTOBUF"
 
 	// Feature flags
 	EnvReadOnly           = "READ_ONLY"
 	EnvResourceTypes      = "RESOURCE_TYPES"
 	EnvToolsets           = "TOOLSETS"
+	EnvToolsetReadOnly    = "TOOLSET_READ_ONLY"
+	EnvDisabledTools      = "DISABLED_TOOLS"
+	EnvDynamicToolsets    = "DYNAMIC_TOOLSETS"
 	EnvExportTranslations = "EXPORT_TRANSLATIONS"
+	EnvWarmUp             = "WARM_UP"
+	EnvInformerCache      = "INFORMER_CACHE"
+	EnvHideForbiddenTools = "HIDE_FORBIDDEN_TOOLS"
+
+	// Integrations
+	EnvImageScannerURL   = "IMAGE_SCANNER_URL"
+	EnvImageScannerToken = "IMAGE_SCANNER_TOKEN"
+
+	// Banner
+	EnvBannerEnvironment = "BANNER_ENVIRONMENT"
+	EnvBannerTeam        = "BANNER_TEAM"
+	EnvBannerContact     = "BANNER_CONTACT"
+
+	// Incident mode
+	EnvIncidentID           = "INCIDENT_ID"
+	EnvIncidentAllowedTools = "INCIDENT_ALLOWED_TOOLS"
+
+	// Confirmation of destructive operations
+	EnvConfirmDestructive = "CONFIRM_DESTRUCTIVE"
+	EnvConfirmationTTL    = "CONFIRMATION_TTL"
+
+	// Redaction of tool results
+	EnvRedactSecrets     = "REDACT_SECRETS"
+	EnvRedactEnvPatterns = "REDACT_ENV_PATTERNS"
+
+	// Shape of tool results
+	EnvRawResults       = "RAW_RESULTS"
+	EnvMaxResponseBytes = "MAX_RESPONSE_BYTES"
+
+	// Throttling of tool calls
+	EnvMaxConcurrentCalls = "MAX_CONCURRENT_CALLS"
+	EnvSessionRateLimit   = "SESSION_RATE_LIMIT"
+
+	// Logging
+	EnvLogLevel  = "LOG_LEVEL"
+	EnvLogFormat = "LOG_FORMAT"
+
+	// Audit log
+	EnvAuditLog           = "AUDIT_LOG"
+	EnvAuditLogMaxSize    = "AUDIT_LOG_MAX_SIZE"
+	EnvAuditLogMaxBackups = "AUDIT_LOG_MAX_BACKUPS"
+	EnvAuditLogMaxAge     = "AUDIT_LOG_MAX_AGE"
+
+	// Tracing
+	EnvOTLPEndpoint = "OTLP_ENDPOINT"
+	EnvOTLPProtocol = "OTLP_PROTOCOL"
+	EnvOTLPInsecure = "OTLP_INSECURE"
 
 	// stdio specific
 	EnvLogFile     = "LOG_FILE"
 	EnvLogCommands = "LOG_COMMANDS"
 
-	// SSE specific
-	EnvPort = "PORT"
+	// SSE and HTTP specific
+	EnvPort             = "PORT"
+	EnvTokenPassthrough = "TOKEN_PASSTHROUGH"
+	EnvTLSCert          = "TLS_CERT"
+	EnvTLSKey           = "TLS_KEY"
+	EnvTLSClientCA      = "TLS_CLIENT_CA"
+	EnvAPIKeys          = "API_KEYS"
+	EnvAuthTokenFile    = "AUTH_TOKEN_FILE"
+	EnvOIDCIssuer       = "OIDC_ISSUER"
+	EnvOIDCAudience     = "OIDC_AUDIENCE"
+
+	// HTTP specific
+	EnvBasePath  = "BASE_PATH"
+	EnvStateless = "STATELESS"
 )
 
 // Config holds the common configuration for the server
 type Config struct {
 	// Kubernetes connection settings
-	KubeConfig string `mapstructure:"kubeconfig"`
-	Namespace  string `mapstructure:"namespace"`
-	InCluster  bool   `mapstructure:"in-cluster"`
+	KubeConfig    string `mapstructure:"kubeconfig"`
+	KubeConfigDir string `mapstructure:"kubeconfig-dir"`
+	Context       string `mapstructure:"context"`
+	Namespace     string `mapstructure:"namespace"`
+	InCluster     bool   `mapstructure:"in-cluster"`
+
+	// Impersonation
+	As                 string   `mapstructure:"as"`
+	AsGroups           []string `mapstructure:"as-group"`
+	ImpersonatePerCall bool     `mapstructure:"impersonate-per-call"`
+
+	// Scoping
+	DefaultLabelSelector string `mapstructure:"default-label-selector"`
+
+	// Kubernetes API client settings: the client-side rate limit of each client, off when
+	// KubeAPIQPS is negative, and the timeout of a single request, none when 0
+	KubeAPIQPS     float32       `mapstructure:"kube-api-qps"`
+	KubeAPIBurst   int           `mapstructure:"kube-api-burst"`
+	RequestTimeout time.Duration `mapstructure:"request-timeout"`
+	// KubeAPIProtobuf exchanges built-in objects with the API server as protobuf instead of JSON
+	KubeAPIProtobuf bool `mapstructure:"kube-api-protobuf"`
 
 	// Feature flags
 	ReadOnly            bool     `mapstructure:"read-only"`
 	EnabledK8sResources []string `mapstructure:"resource-types"`
 	ExportTranslations  bool     `mapstructure:"export-translations"`
+	WarmUp              bool     `mapstructure:"warm-up"`
+	InformerCache       bool     `mapstructure:"informer-cache"`
+	HideForbiddenTools  bool     `mapstructure:"hide-forbidden-tools"`
+	// Toolsets are the named groups of resource types to enable, such as core or workloads
+	Toolsets []string `mapstructure:"toolsets"`
+	// ToolsetReadOnly overrides ReadOnly for the tools of a toolset
+	ToolsetReadOnly map[string]bool `mapstructure:"toolset-read-only"`
+	// DisabledTools are tools never registered, while the rest of their toolset is
+	DisabledTools []string `mapstructure:"disabled-tools"`
+	// DynamicToolsets registers the tools of each toolset only once an agent enables it with
+	// enable_toolset
+	DynamicToolsets bool `mapstructure:"dynamic-toolsets"`
+
+	// Integrations
+	ImageScannerURL   string `mapstructure:"image-scanner-url"`
+	ImageScannerToken string `mapstructure:"image-scanner-token"`
+
+	// Banner
+	BannerEnvironment string `mapstructure:"banner-environment"`
+	BannerTeam        string `mapstructure:"banner-team"`
+	BannerContact     string `mapstructure:"banner-contact"`
+
+	// Incident mode
+	IncidentID           string   `mapstructure:"incident-id"`
+	IncidentAllowedTools []string `mapstructure:"incident-allowed-tools"`
+
+	// ConfirmDestructive makes delete, drain and scale-to-zero calls return a confirmation token
+	// that must be passed back within ConfirmationTTL to run them
+	ConfirmDestructive bool          `mapstructure:"confirm-destructive"`
+	ConfirmationTTL    time.Duration `mapstructure:"confirmation-ttl"`
+
+	// RedactSecrets removes Secret data and the values of environment variables whose names match
+	// RedactEnvPatterns from tool results
+	RedactSecrets     bool     `mapstructure:"redact-secrets"`
+	RedactEnvPatterns []string `mapstructure:"redact-env-patterns"`
+
+	// RawResults returns tool results as they are instead of wrapping JSON results in an envelope
+	// reporting their kind, count and completeness
+	RawResults bool `mapstructure:"raw-results"`
+	// MaxResponseBytes is the size budget of a tool result, which larger results are summarized
+	// or truncated to fit, unlimited when 0
+	MaxResponseBytes int `mapstructure:"max-response-bytes"`
+
+	// MaxConcurrentCalls caps the tool calls in flight and SessionRateLimit the calls per minute of
+	// each client session, unlimited when 0
+	MaxConcurrentCalls int `mapstructure:"max-concurrent-calls"`
+	SessionRateLimit   int `mapstructure:"session-rate-limit"`
+
+	// Logging of every transport
+	LogLevel  string `mapstructure:"log-level"`
+	LogFormat string `mapstructure:"log-format"`
+
+	// AuditLog records every write tool call to a file rotated at AuditLogMaxSize megabytes, or to
+	// stdout when "-"
+	AuditLog           string `mapstructure:"audit-log"`
+	AuditLogMaxSize    int    `mapstructure:"audit-log-max-size"`
+	AuditLogMaxBackups int    `mapstructure:"audit-log-max-backups"`
+	AuditLogMaxAge     int    `mapstructure:"audit-log-max-age"`
+
+	// Tracing exports spans of tool calls and Kubernetes API requests to an OTLP collector
+	OTLPEndpoint string `mapstructure:"otlp-endpoint"`
+	OTLPProtocol string `mapstructure:"otlp-protocol"`
+	OTLPInsecure bool   `mapstructure:"otlp-insecure"`
+
+	// Resources holds the settings of each resource type, such as the namespaces its tools may
+	// target and their rate limit. It is only set in the config file.
+	Resources map[string]limits.Settings `mapstructure:"resources"`
+
+	// Policy lists the verbs allowed on each resource type it restricts, such as get, list and
+	// scale, deciding their tools in place of read-only mode. It is only set in the config file.
+	Policy verbs.Policy `mapstructure:"policy"`
 
 	// Transport-specific config
 	LogFile     string `mapstructure:"log-file"`
 	LogCommands bool   `mapstructure:"log-commands"`
 	Port        string `mapstructure:"port"`
+
+	// TokenPassthrough makes SSE and HTTP clients act with the bearer token they send
+	TokenPassthrough bool `mapstructure:"token-passthrough"`
+
+	// TLS serves the SSE and HTTP transports over HTTPS, requiring client certificates signed by
+	// TLSClientCA when set
+	TLSCert     string `mapstructure:"tls-cert"`
+	TLSKey      string `mapstructure:"tls-key"`
+	TLSClientCA string `mapstructure:"tls-client-ca"`
+
+	// Authentication of SSE and HTTP clients
+	APIKeys       []string `mapstructure:"api-keys"`
+	AuthTokenFile string   `mapstructure:"auth-token-file"`
+	OIDCIssuer    string   `mapstructure:"oidc-issuer"`
+	OIDCAudience  string   `mapstructure:"oidc-audience"`
+
+	// BasePath is the endpoint of the streamable HTTP transport
+	BasePath string `mapstructure:"base-path"`
+	// Stateless makes the streamable HTTP transport keep no sessions
+	Stateless bool `mapstructure:"stateless"`
 }
 
 // Validate checks that the configuration is valid
@@ -89,7 +324,19 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("at least one resource type must be enabled")
 	}
 
-	// For SSE, validate the port
+	// Groups are impersonated along with a user, the API server rejects them on their own
+	if len(c.AsGroups) > 0 && c.As == "" {
+		return fmt.Errorf("--as-group requires --as")
+	}
+
+	// Validate the default label selector, it is ANDed to every list request
+	if c.DefaultLabelSelector != "" {
+		if _, err := labels.Parse(c.DefaultLabelSelector); err != nil {
+			return fmt.Errorf("invalid default label selector %q: %w", c.DefaultLabelSelector, err)
+		}
+	}
+
+	// For SSE and HTTP, validate the port
 	if c.Port != "" {
 		// Check if the port is a valid number
 		if _, err := strconv.Atoi(c.Port); err != nil {
@@ -97,14 +344,101 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// For SSE and HTTP, TLS needs both a certificate and its key
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be set together")
+	}
+	if c.TLSClientCA != "" && c.TLSCert == "" {
+		return fmt.Errorf("--tls-client-ca requires --tls-cert and --tls-key")
+	}
+
+	// For SSE and HTTP, ID tokens are only accepted for this server's audience
+	if (c.OIDCIssuer == "") != (c.OIDCAudience == "") {
+		return fmt.Errorf("--oidc-issuer and --oidc-audience must be set together")
+	}
+
+	// Client-go replaces a zero burst with its own default, which a negative one would not
+	if c.KubeAPIBurst < 0 {
+		return fmt.Errorf("--kube-api-burst must not be negative")
+	}
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("--request-timeout must not be negative")
+	}
+
+	// Confirmation tokens must stay valid long enough for the user to approve the call
+	if c.ConfirmDestructive && c.ConfirmationTTL <= 0 {
+		return fmt.Errorf("--confirmation-ttl must be positive")
+	}
+
+	// Redaction patterns are regular expressions
+	if c.RedactSecrets {
+		if _, err := redact.New(c.RedactEnvPatterns); err != nil {
+			return err
+		}
+	}
+
+	// The response budget counts bytes, with 0 turning it off
+	if c.MaxResponseBytes < 0 {
+		return fmt.Errorf("--max-response-bytes must not be negative")
+	}
+
+	// Throttling limits count calls, with 0 turning them off
+	if c.MaxConcurrentCalls < 0 {
+		return fmt.Errorf("--max-concurrent-calls must not be negative")
+	}
+	if c.SessionRateLimit < 0 {
+		return fmt.Errorf("--session-rate-limit must not be negative")
+	}
+
+	// Logging settings are checked before any component logs
+	if _, err := log.ParseLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if c.LogFormat != "" && c.LogFormat != log.FormatJSON && c.LogFormat != log.FormatConsole {
+		return fmt.Errorf("unsupported log format %q, expected %s or %s", c.LogFormat, log.FormatJSON, log.FormatConsole)
+	}
+
+	// Rotation settings count megabytes, files and days
+	if c.AuditLogMaxSize < 0 || c.AuditLogMaxBackups < 0 || c.AuditLogMaxAge < 0 {
+		return fmt.Errorf("--audit-log-max-size, --audit-log-max-backups and --audit-log-max-age must not be negative")
+	}
+
+	// Settings of each resource type restrict its tools
+	for resourceType, settings := range c.Resources {
+		if err := settings.Validate(); err != nil {
+			return fmt.Errorf("invalid settings of resource type %q: %w", resourceType, err)
+		}
+	}
+
+	if err := c.Policy.Validate(); err != nil {
+		return err
+	}
+
+	// For HTTP, the transcript export is served below the base path
+	if c.BasePath != "" && !strings.HasPrefix(c.BasePath, "/") {
+		return fmt.Errorf("base path %q must start with /", c.BasePath)
+	}
+
 	return nil
 }
 
+// Banner returns the configured environment banner
+func (c *Config) Banner() banner.Banner {
+	return banner.Banner{
+		Environment: c.BannerEnvironment,
+		Team:        c.BannerTeam,
+		Contact:     c.BannerContact,
+	}
+}
+
 var rootCmd = &cobra.Command{
 	Use:     "k8smcp",
 	Short:   "Kubernetes MCP Server",
 	Long:    `A Kubernetes MCP Server that provides tools for interacting with Kubernetes clusters.`,
 	Version: fmt.Sprintf("Version: %s\nCommit: %s\nBuild Date: %s", version, commit, date),
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return loadConfigFile()
+	},
 }
 
 var stdioCmd = &cobra.Command{
@@ -134,7 +468,12 @@ var sseCmd = &cobra.Command{
 	Use:   "sse",
 	Short: "Start sse server",
 	Long:  `Start a server that communicates via HTTP with Server-Sent Events (SSE).`,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Bind the flags shared with the http command to this command's values
+		if err := viper.BindPFlags(cmd.PersistentFlags()); err != nil {
+			return fmt.Errorf("failed to bind sse flags: %w", err)
+		}
+
 		// Load the configuration
 		var cfg Config
 		if err := viper.Unmarshal(&cfg); err != nil {
@@ -153,6 +492,34 @@ var sseCmd = &cobra.Command{
 	},
 }
 
+var httpCmd = &cobra.Command{
+	Use:   "http",
+	Short: "Start streamable HTTP server",
+	Long:  `Start a server that communicates via the MCP streamable HTTP transport, with a single endpoint for requests and streamed responses.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Bind the flags shared with the sse command to this command's values
+		if err := viper.BindPFlags(cmd.PersistentFlags()); err != nil {
+			return fmt.Errorf("failed to bind http flags: %w", err)
+		}
+
+		// Load the configuration
+		var cfg Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return fmt.Errorf("failed to parse configuration: %w", err)
+		}
+
+		// Override with environment variables
+		loadEnvOverrides(&cfg)
+
+		// Validate the configuration
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+
+		return runHTTPServer(cfg)
+	},
+}
+
 func init() {
 	// Find default kubeconfig location
 	defaultKubeconfig := ""
@@ -167,20 +534,102 @@ func init() {
 	rootCmd.SilenceErrors = false
 
 	// Add global flags for all commands
+	rootCmd.PersistentFlags().String("config", "",
+		"Path to a YAML, TOML or JSON config file (defaults to k8smcp.yaml in the working directory or the user config directory)")
 	rootCmd.PersistentFlags().StringSlice("resource-types", []string{"all"},
-		"Comma separated list of Kubernetes resource types to enable (pods,deployments,services,configmaps,namespaces,nodes)")
+		"Comma separated list of Kubernetes resource types to enable (pod,logs,deployment,service,configmap,namespace,node,pdb,image,storage,policy,scheduling,webhook,dns,lease,cluster,gateway,bundle,generic,security,workload,cronjob,metrics,diagnose)")
 	rootCmd.PersistentFlags().Bool("read-only", true,
 		"Restrict operations to read-only (no create, update, delete)")
 	rootCmd.PersistentFlags().String("namespace", "default",
 		"Default Kubernetes namespace to target")
 	rootCmd.PersistentFlags().Bool("export-translations", false,
 		"Save translations to a JSON file")
-	rootCmd.PersistentFlags().StringSlice("toolsets", []string{"all"},
-		"Comma separated list of tools to enable")
+	rootCmd.PersistentFlags().Bool("warm-up", false,
+		"Cache API discovery and OpenAPI schemas, pre-populating them in the background on startup")
+	rootCmd.PersistentFlags().Bool("informer-cache", false,
+		"Serve the get and list calls of read tools for pods, services, ConfigMaps, namespaces, nodes and deployments from shared informers")
+	rootCmd.PersistentFlags().Bool("hide-forbidden-tools", true,
+		"Hide tools the server identity lacks permissions for, probing them on startup and every 5 minutes")
+	rootCmd.PersistentFlags().StringSlice("toolsets", k8s.DefaultTools,
+		"Comma separated list of toolsets to enable (all,core,workloads,networking,storage,rbac,diagnostics,admin), combined with --resource-types")
+	rootCmd.PersistentFlags().StringToString("toolset-read-only", nil,
+		"Comma separated list of toolset=true|false pairs overriding --read-only for the tools of a toolset (e.g. workloads=false)")
+	rootCmd.PersistentFlags().StringSlice("disabled-tools", nil,
+		"Comma separated list of tools never to register, keeping the rest of their toolset (e.g. delete_pod,delete_deployment)")
+	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false,
+		"Start with a minimal set of tools and let agents enable the tools of each toolset with enable_toolset")
 	rootCmd.PersistentFlags().String("kubeconfig", defaultKubeconfig,
-		"Path to the kubeconfig file")
+		"Path to the kubeconfig file, or a list of files separated like $KUBECONFIG")
+	rootCmd.PersistentFlags().String("kubeconfig-dir", "",
+		"Directory of additional kubeconfig files whose contexts tools can target with the cluster parameter")
+	rootCmd.PersistentFlags().String("context", "",
+		"Kubeconfig context to use instead of the current context")
 	rootCmd.PersistentFlags().Bool("in-cluster", false,
 		"Use in-cluster config instead of kubeconfig file")
+	rootCmd.PersistentFlags().String("as", "",
+		"User to impersonate for every request, so the server acts with that user's permissions")
+	rootCmd.PersistentFlags().StringSlice("as-group", nil,
+		"Comma separated list of groups to impersonate along with --as")
+	rootCmd.PersistentFlags().Bool("impersonate-per-call", false,
+		"Add impersonateUser and impersonateGroups parameters to every tool, running each call as the user it names")
+	rootCmd.PersistentFlags().String("default-label-selector", "",
+		"Label selector ANDed to every list request (e.g. team=payments), scoping the server to matching objects")
+	rootCmd.PersistentFlags().Float32("kube-api-qps", defaultKubeAPIQPS,
+		"Maximum queries per second of each Kubernetes API client, past which requests wait (negative to turn the limit off)")
+	rootCmd.PersistentFlags().Int("kube-api-burst", defaultKubeAPIBurst,
+		"Maximum burst of queries of each Kubernetes API client above --kube-api-qps")
+	rootCmd.PersistentFlags().Duration("request-timeout", 0,
+		"Timeout of a single Kubernetes API request, including watches and log streams (0 for no timeout)")
+	rootCmd.PersistentFlags().Bool("kube-api-protobuf", true,
+		"Exchange built-in objects with the Kubernetes API server as protobuf, which is smaller and faster to decode than JSON on large lists (false to use JSON)")
+	rootCmd.PersistentFlags().String("image-scanner-url", "",
+		"URL of a vulnerability scanner endpoint returning Trivy JSON reports, enables the scan_images tool")
+	rootCmd.PersistentFlags().String("image-scanner-token", "",
+		"Bearer token sent to the vulnerability scanner endpoint, or a file:, env: or secret:namespace/name/key reference to load it from")
+	rootCmd.PersistentFlags().String("banner-environment", "",
+		"Name of the environment this server manages (e.g. production), shown by get_server_info and in write tool descriptions")
+	rootCmd.PersistentFlags().String("banner-team", "",
+		"Team owning the environment, shown by get_server_info and in write tool descriptions")
+	rootCmd.PersistentFlags().String("banner-contact", "",
+		"Escalation contact for the environment, shown by get_server_info and in write tool descriptions")
+	rootCmd.PersistentFlags().String("incident-id", "",
+		"Start the server in incident mode for this incident ID, locking down write tools other than --incident-allowed-tools")
+	rootCmd.PersistentFlags().StringSlice("incident-allowed-tools", incident.DefaultAllowedTools,
+		"Comma separated list of write tools left enabled during an incident")
+	rootCmd.PersistentFlags().Bool("confirm-destructive", true,
+		"Make delete, drain and scale-to-zero tools return an impact summary and a confirmation token, running only when called again with the token")
+	rootCmd.PersistentFlags().Duration("confirmation-ttl", confirm.DefaultTTL,
+		"How long a confirmation token of --confirm-destructive can be used")
+	rootCmd.PersistentFlags().Bool("redact-secrets", true,
+		"Redact Secret data and the values of environment variables matching --redact-env-patterns in tool results")
+	rootCmd.PersistentFlags().StringSlice("redact-env-patterns", redact.DefaultEnvPatterns,
+		"Comma separated list of case-insensitive regular expressions matching the names of environment variables and ConfigMap keys to redact")
+	rootCmd.PersistentFlags().Bool("raw-results", false,
+		"Return tool results as they are, without the envelope reporting their kind, count, continue token and duration")
+	rootCmd.PersistentFlags().Int("max-response-bytes", output.DefaultMaxResponseBytes,
+		"Size budget of a tool result in bytes; larger results are returned as summaries or with fewer list items (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("max-concurrent-calls", throttle.DefaultMaxInFlight,
+		"Maximum number of tool calls running at once across all clients; further calls are rejected with a slow down error (0 for unlimited)")
+	rootCmd.PersistentFlags().Int("session-rate-limit", 0,
+		"Maximum number of tool calls per minute of each client session; further calls are rejected with a slow down error (0 for unlimited)")
+	rootCmd.PersistentFlags().String("log-level", log.LevelInfo,
+		"Minimum level of the server logs (debug, info, warn, error); debug adds tool arguments and Kubernetes API requests")
+	rootCmd.PersistentFlags().String("log-format", log.FormatJSON,
+		"Format of the server logs (json, console)")
+	rootCmd.PersistentFlags().String("audit-log", "",
+		"Record every write tool call as a JSON line in this file, or on stdout with \"-\" for the sse and http transports")
+	rootCmd.PersistentFlags().Int("audit-log-max-size", audit.DefaultMaxSizeMB,
+		"Size in megabytes at which the audit log file is rotated")
+	rootCmd.PersistentFlags().Int("audit-log-max-backups", audit.DefaultMaxBackups,
+		"Number of rotated audit log files to keep, 0 to keep all")
+	rootCmd.PersistentFlags().Int("audit-log-max-age", 0,
+		"Days to keep rotated audit log files, 0 to keep them regardless of age")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "",
+		"OTLP collector host:port or URL to export traces of tool calls and Kubernetes API requests to")
+	rootCmd.PersistentFlags().String("otlp-protocol", tracing.ProtocolGRPC,
+		"OTLP protocol of --otlp-endpoint (grpc, http)")
+	rootCmd.PersistentFlags().Bool("otlp-insecure", false,
+		"Export traces to a host:port --otlp-endpoint without TLS")
 
 	// Add stdio-specific flags
 	stdioCmd.PersistentFlags().String("log-file", "",
@@ -191,6 +640,22 @@ func init() {
 	// Add SSE-specific flags
 	sseCmd.PersistentFlags().String("port", "8080",
 		"Port for SSE connections to be served")
+	sseCmd.PersistentFlags().Bool("token-passthrough", false,
+		"Run each tool call with the bearer token of the client's Authorization header instead of the server's credentials")
+	addTLSFlags(sseCmd)
+	addAuthFlags(sseCmd)
+
+	// Add HTTP-specific flags
+	httpCmd.PersistentFlags().String("port", "8080",
+		"Port for HTTP connections to be served")
+	httpCmd.PersistentFlags().String("base-path", "/mcp",
+		"Path of the MCP endpoint")
+	httpCmd.PersistentFlags().Bool("stateless", false,
+		"Keep no sessions, handling every request on its own (for load-balanced replicas without session affinity)")
+	httpCmd.PersistentFlags().Bool("token-passthrough", false,
+		"Run each tool call with the bearer token of the client's Authorization header instead of the server's credentials")
+	addTLSFlags(httpCmd)
+	addAuthFlags(httpCmd)
 
 	// Bind all flags to viper
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
@@ -199,18 +664,39 @@ func init() {
 	if err := viper.BindPFlags(stdioCmd.PersistentFlags()); err != nil {
 		log.Fatal().Err(err).Msg("failed to bind stdio flags")
 	}
-	if err := viper.BindPFlags(sseCmd.PersistentFlags()); err != nil {
-		log.Fatal().Err(err).Msg("failed to bind sse flags")
-	}
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(sseCmd)
+	rootCmd.AddCommand(httpCmd)
 
 	// Update command help with environment variable information
 	addEnvHelpToCommand(rootCmd)
 	addEnvHelpToCommand(stdioCmd)
 	addEnvHelpToCommand(sseCmd)
+	addEnvHelpToCommand(httpCmd)
+}
+
+// addTLSFlags adds the TLS flags of the HTTP-based transports to a command
+func addTLSFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("tls-cert", "",
+		"Path to a PEM certificate to serve HTTPS with, reloaded when the file changes")
+	cmd.PersistentFlags().String("tls-key", "",
+		"Path to the PEM private key of --tls-cert")
+	cmd.PersistentFlags().String("tls-client-ca", "",
+		"Path to a PEM CA bundle; clients must present a certificate signed by it (mTLS)")
+}
+
+// addAuthFlags adds the client authentication flags of the HTTP-based transports to a command
+func addAuthFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice("api-keys", nil,
+		"Comma separated list of API keys clients may send in the X-API-Key header or as a bearer token, each a literal key or a file:, env: or secret:namespace/name/key reference to load it from")
+	cmd.PersistentFlags().String("auth-token-file", "",
+		"Path to a file of accepted client tokens, one per line as token[,name], reloaded when it changes")
+	cmd.PersistentFlags().String("oidc-issuer", "",
+		"OIDC issuer URL whose ID tokens clients may send as a bearer token")
+	cmd.PersistentFlags().String("oidc-audience", "",
+		"Audience (client ID) accepted ID tokens must be issued for")
 }
 
 // initConfig sets up viper for config handling
@@ -223,12 +709,43 @@ func initConfig() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 }
 
+// loadConfigFile reads the config file named by --config, or a k8smcp config file found in the
+// working directory or the user config directory. Flags and environment variables take precedence
+// over its settings, whose keys are the flag names.
+func loadConfigFile() error {
+	if path := viper.GetString("config"); path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("k8smcp")
+		viper.AddConfigPath(".")
+		if dir, err := os.UserConfigDir(); err == nil {
+			viper.AddConfigPath(filepath.Join(dir, "k8smcp"))
+		}
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		// The config file is optional unless one is named
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	return nil
+}
+
 // loadEnvOverrides manually checks for environment variables and overrides config values
 func loadEnvOverrides(cfg *Config) {
 	// Check for kubernetes connection env vars
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvKubeConfig); exists {
 		cfg.KubeConfig = val
 	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvKubeConfigDir); exists {
+		cfg.KubeConfigDir = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvContext); exists {
+		cfg.Context = val
+	}
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvNamespace); exists {
 		cfg.Namespace = val
 	}
@@ -236,6 +753,42 @@ func loadEnvOverrides(cfg *Config) {
 		cfg.InCluster = strings.ToLower(val) == "true" || val == "1"
 	}
 
+	// Check for impersonation env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAs); exists {
+		cfg.As = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAsGroup); exists && val != "" {
+		cfg.AsGroups = strings.Split(val, ",")
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvImpersonatePerCall); exists {
+		cfg.ImpersonatePerCall = strings.ToLower(val) == "true" || val == "1"
+	}
+
+	// Check for scoping env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvDefaultLabelSelector); exists {
+		cfg.DefaultLabelSelector = val
+	}
+
+	// Check for Kubernetes API client env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvKubeAPIQPS); exists {
+		if qps, err := strconv.ParseFloat(val, 32); err == nil {
+			cfg.KubeAPIQPS = float32(qps)
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvKubeAPIBurst); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.KubeAPIBurst = n
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvRequestTimeout); exists {
+		if d, err := time.ParseDuration(val); err == nil {
+			cfg.RequestTimeout = d
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvKubeAPIProtobuf); exists {
+		cfg.KubeAPIProtobuf = strings.ToLower(val) == "true" || val == "1"
+	}
+
 	// Check for feature flags
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvReadOnly); exists {
 		cfg.ReadOnly = strings.ToLower(val) == "true" || val == "1"
@@ -243,9 +796,142 @@ func loadEnvOverrides(cfg *Config) {
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvResourceTypes); exists && val != "" {
 		cfg.EnabledK8sResources = strings.Split(val, ",")
 	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvToolsets); exists && val != "" {
+		cfg.Toolsets = strings.Split(val, ",")
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvDisabledTools); exists && val != "" {
+		cfg.DisabledTools = strings.Split(val, ",")
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvToolsetReadOnly); exists && val != "" {
+		cfg.ToolsetReadOnly = map[string]bool{}
+		for _, pair := range strings.Split(val, ",") {
+			name, value, _ := strings.Cut(pair, "=")
+			if readOnly, err := strconv.ParseBool(value); err == nil {
+				cfg.ToolsetReadOnly[name] = readOnly
+			}
+		}
+	}
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvExportTranslations); exists {
 		cfg.ExportTranslations = strings.ToLower(val) == "true" || val == "1"
 	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvWarmUp); exists {
+		cfg.WarmUp = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvInformerCache); exists {
+		cfg.InformerCache = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvHideForbiddenTools); exists {
+		cfg.HideForbiddenTools = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvDynamicToolsets); exists {
+		cfg.DynamicToolsets = strings.ToLower(val) == "true" || val == "1"
+	}
+
+	// Check for integration env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvImageScannerURL); exists {
+		cfg.ImageScannerURL = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvImageScannerToken); exists {
+		cfg.ImageScannerToken = val
+	}
+
+	// Check for banner env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvBannerEnvironment); exists {
+		cfg.BannerEnvironment = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvBannerTeam); exists {
+		cfg.BannerTeam = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvBannerContact); exists {
+		cfg.BannerContact = val
+	}
+
+	// Check for incident mode env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvIncidentID); exists {
+		cfg.IncidentID = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvIncidentAllowedTools); exists {
+		cfg.IncidentAllowedTools = strings.Split(val, ",")
+	}
+
+	// Check for confirmation env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvConfirmDestructive); exists {
+		cfg.ConfirmDestructive = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvConfirmationTTL); exists {
+		if d, err := time.ParseDuration(val); err == nil {
+			cfg.ConfirmationTTL = d
+		}
+	}
+
+	// Check for redaction env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvRedactSecrets); exists {
+		cfg.RedactSecrets = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvRedactEnvPatterns); exists {
+		cfg.RedactEnvPatterns = strings.Split(val, ",")
+	}
+
+	// Check for result shape env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvRawResults); exists {
+		cfg.RawResults = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvMaxResponseBytes); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.MaxResponseBytes = n
+		}
+	}
+
+	// Check for throttling env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvMaxConcurrentCalls); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.MaxConcurrentCalls = n
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvSessionRateLimit); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.SessionRateLimit = n
+		}
+	}
+
+	// Check for logging env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvLogLevel); exists {
+		cfg.LogLevel = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvLogFormat); exists {
+		cfg.LogFormat = val
+	}
+
+	// Check for audit log env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAuditLog); exists {
+		cfg.AuditLog = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAuditLogMaxSize); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.AuditLogMaxSize = n
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAuditLogMaxBackups); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.AuditLogMaxBackups = n
+		}
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAuditLogMaxAge); exists {
+		if n, err := strconv.Atoi(val); err == nil {
+			cfg.AuditLogMaxAge = n
+		}
+	}
+
+	// Check for tracing env vars
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvOTLPEndpoint); exists {
+		cfg.OTLPEndpoint = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvOTLPProtocol); exists {
+		cfg.OTLPProtocol = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvOTLPInsecure); exists {
+		cfg.OTLPInsecure = strings.ToLower(val) == "true" || val == "1"
+	}
 
 	// Check for transport-specific env vars
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvLogFile); exists {
@@ -257,6 +943,36 @@ func loadEnvOverrides(cfg *Config) {
 	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvPort); exists {
 		cfg.Port = val
 	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvTokenPassthrough); exists {
+		cfg.TokenPassthrough = strings.ToLower(val) == "true" || val == "1"
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvTLSCert); exists {
+		cfg.TLSCert = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvTLSKey); exists {
+		cfg.TLSKey = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvTLSClientCA); exists {
+		cfg.TLSClientCA = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAPIKeys); exists && val != "" {
+		cfg.APIKeys = strings.Split(val, ",")
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvAuthTokenFile); exists {
+		cfg.AuthTokenFile = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvOIDCIssuer); exists {
+		cfg.OIDCIssuer = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvOIDCAudience); exists {
+		cfg.OIDCAudience = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvBasePath); exists {
+		cfg.BasePath = val
+	}
+	if val, exists := os.LookupEnv(EnvPrefix + "_" + EnvStateless); exists {
+		cfg.Stateless = strings.ToLower(val) == "true" || val == "1"
+	}
 }
 
 // addEnvHelpToCommand adds environment variable documentation to command help text
@@ -269,23 +985,105 @@ func addEnvHelpToCommand(cmd *cobra.Command) {
 
 	// Common env vars for all commands
 	envVarNames = append(envVarNames,
+		EnvConfig,
 		EnvKubeConfig,
+		EnvKubeConfigDir,
+		EnvContext,
 		EnvNamespace,
 		EnvInCluster,
+		EnvAs,
+		EnvAsGroup,
+		EnvImpersonatePerCall,
+		EnvDefaultLabelSelector,
+		EnvKubeAPIQPS,
+		EnvKubeAPIBurst,
+		EnvRequestTimeout,
+		EnvKubeAPIProtobuf,
 		EnvReadOnly,
 		EnvResourceTypes,
 		EnvToolsets,
+		EnvToolsetReadOnly,
+		EnvDisabledTools,
+		EnvDynamicToolsets,
 		EnvExportTranslations,
+		EnvWarmUp,
+		EnvInformerCache,
+		EnvHideForbiddenTools,
+		EnvImageScannerURL,
+		EnvImageScannerToken,
+		EnvBannerEnvironment,
+		EnvBannerTeam,
+		EnvBannerContact,
+		EnvIncidentID,
+		EnvIncidentAllowedTools,
+		EnvConfirmDestructive,
+		EnvConfirmationTTL,
+		EnvRedactSecrets,
+		EnvRedactEnvPatterns,
+		EnvRawResults,
+		EnvMaxResponseBytes,
+		EnvMaxConcurrentCalls,
+		EnvSessionRateLimit,
+		EnvLogLevel,
+		EnvLogFormat,
+		EnvAuditLog,
+		EnvAuditLogMaxSize,
+		EnvAuditLogMaxBackups,
+		EnvAuditLogMaxAge,
+		EnvOTLPEndpoint,
+		EnvOTLPProtocol,
+		EnvOTLPInsecure,
 	)
 
 	envVarDescs = append(envVarDescs,
+		"Path to the config file",
 		"Path to kubeconfig file",
+		"Directory of additional kubeconfig files",
+		"Kubeconfig context to use",
 		"Default Kubernetes namespace",
 		"Use in-cluster config (true/false)",
+		"User to impersonate",
+		"Comma-separated list of groups to impersonate",
+		"Add per-call impersonation parameters (true/false)",
+		"Label selector ANDed to every list request",
+		"Maximum queries per second of each Kubernetes API client",
+		"Maximum burst of queries of each Kubernetes API client",
+		"Timeout of a single Kubernetes API request (e.g. 30s)",
+		"Use protobuf for built-in objects (true/false)",
 		"Restrict to read-only operations (true/false)",
 		"Comma-separated list of resource types",
 		"Comma-separated list of toolsets to enable",
+		"Comma-separated list of toolset=true|false read-only overrides",
+		"Comma-separated list of tools never to register",
+		"Enable the tools of each toolset on demand (true/false)",
 		"Export translations (true/false)",
+		"Warm up discovery and schema caches on startup (true/false)",
+		"Serve read tools from shared informers (true/false)",
+		"Hide tools lacking permissions (true/false)",
+		"Vulnerability scanner endpoint URL",
+		"Vulnerability scanner bearer token",
+		"Name of the environment this server manages",
+		"Team owning the environment",
+		"Escalation contact for the environment",
+		"Start in incident mode for this incident ID",
+		"Comma-separated list of write tools allowed during an incident",
+		"Confirm destructive tool calls with a token (true/false)",
+		"Validity of confirmation tokens (e.g. 5m)",
+		"Redact secret values in tool results (true/false)",
+		"Comma-separated list of sensitive environment variable name patterns",
+		"Return tool results without the result envelope (true/false)",
+		"Size budget of a tool result in bytes (0 for unlimited)",
+		"Maximum number of tool calls running at once (0 for unlimited)",
+		"Maximum number of tool calls per minute of each session (0 for unlimited)",
+		"Minimum log level (debug/info/warn/error)",
+		"Log format (json/console)",
+		"Audit log file of write tool calls, or - for stdout",
+		"Audit log size in megabytes that triggers rotation",
+		"Number of rotated audit log files to keep",
+		"Days to keep rotated audit log files",
+		"OTLP collector endpoint for traces",
+		"OTLP protocol (grpc/http)",
+		"Export traces without TLS (true/false)",
 	)
 
 	// stdio specific env vars
@@ -303,8 +1101,38 @@ func addEnvHelpToCommand(cmd *cobra.Command) {
 
 	// SSE specific env vars
 	if cmd == sseCmd {
-		envVarNames = append(envVarNames, EnvPort)
-		envVarDescs = append(envVarDescs, "Port for SSE server")
+		envVarNames = append(envVarNames, EnvPort, EnvTokenPassthrough, EnvTLSCert, EnvTLSKey, EnvTLSClientCA,
+			EnvAPIKeys, EnvAuthTokenFile, EnvOIDCIssuer, EnvOIDCAudience)
+		envVarDescs = append(envVarDescs,
+			"Port for SSE server",
+			"Use the client's bearer token for tool calls (true/false)",
+			"Path to the TLS certificate",
+			"Path to the TLS private key",
+			"Path to the CA bundle verifying client certificates",
+			"Comma-separated list of client API keys",
+			"Path to the file of accepted client tokens",
+			"OIDC issuer URL of accepted ID tokens",
+			"Audience of accepted ID tokens",
+		)
+	}
+
+	// HTTP specific env vars
+	if cmd == httpCmd {
+		envVarNames = append(envVarNames, EnvPort, EnvBasePath, EnvStateless, EnvTokenPassthrough, EnvTLSCert, EnvTLSKey, EnvTLSClientCA,
+			EnvAPIKeys, EnvAuthTokenFile, EnvOIDCIssuer, EnvOIDCAudience)
+		envVarDescs = append(envVarDescs,
+			"Port for HTTP server",
+			"Path of the MCP endpoint",
+			"Keep no sessions (true/false)",
+			"Use the client's bearer token for tool calls (true/false)",
+			"Path to the TLS certificate",
+			"Path to the TLS private key",
+			"Path to the CA bundle verifying client certificates",
+			"Comma-separated list of client API keys",
+			"Path to the file of accepted client tokens",
+			"OIDC issuer URL of accepted ID tokens",
+			"Audience of accepted ID tokens",
+		)
 	}
 
 	// Calculate the maximum width needed for alignment
@@ -329,139 +1157,580 @@ func addEnvHelpToCommand(cmd *cobra.Command) {
 	cmd.Long = originalHelp + envHelp
 }
 
-// initStdioLogger creates and configures a logger for the stdio server
-func initStdioLogger(outPath string) (*logrus.Logger, error) {
-	logger := logrus.New()
-	logger.SetLevel(logrus.InfoLevel)
+// initLogger configures the logger of every component, writing to the stdio log file when set
+// and to stderr otherwise
+func initLogger(cfg Config) error {
+	var out io.Writer = os.Stderr
+	if cfg.LogFile != "" {
+		file, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		out = file
+	}
+	return log.Setup(log.Options{Level: cfg.LogLevel, Format: cfg.LogFormat, Output: out})
+}
 
-	if outPath == "" {
-		// Default to stderr
-		logger.SetOutput(os.Stderr)
-		return logger, nil
+// loadK8sContexts loads the kubeconfig contexts the server can target based on configuration,
+// returning them with the context used by calls that select no cluster: contextName when set,
+// otherwise the kubeconfig's current context
+func loadK8sContexts(kubeconfig, kubeconfigDir, contextName string, inCluster bool) ([]multicluster.Context, string, error) {
+	inClusterContext := func(config *rest.Config, source string) ([]multicluster.Context, string, error) {
+		log.Component("kubernetes").Info().Str("source", source).Msg("Kubernetes client config loaded")
+		return []multicluster.Context{{Name: multicluster.InClusterContext, Server: config.Host, Config: config}}, multicluster.InClusterContext, nil
 	}
 
-	file, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+	// First priority: explicitly set inCluster flag
+	if inCluster {
+		if contextName != "" {
+			return nil, "", fmt.Errorf("context %q cannot be used with the in-cluster config", contextName)
+		}
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create in-cluster config: %w", err)
+		}
+		return inClusterContext(config, "in-cluster (explicitly configured)")
 	}
 
-	logger.SetOutput(file)
-	logger.SetFormatter(&logrus.JSONFormatter{})
+	// Second priority: the valid contexts of the kubeconfig files and directory
+	loaded, loadErr := multicluster.Load(kubeconfig, kubeconfigDir)
+	if loadErr == nil {
+		for _, invalid := range loaded.Invalid {
+			log.Component("kubernetes").Warn().Err(invalid).Msg("Skipping kubeconfig context")
+		}
+		if contextName != "" {
+			found := false
+			for _, c := range loaded.Contexts {
+				found = found || c.Name == contextName
+			}
+			if !found {
+				return nil, "", fmt.Errorf("context %q not found in kubeconfig %q", contextName, kubeconfig)
+			}
+			loaded.Current = contextName
+		}
+		if len(loaded.Contexts) > 0 {
+			log.Component("kubernetes").Info().Str("source", fmt.Sprintf("kubeconfig file: %s", kubeconfig)).Str("dir", kubeconfigDir).
+				Int("contexts", len(loaded.Contexts)).Str("current", loaded.Current).Msg("Kubernetes client config loaded")
+			return loaded.Contexts, loaded.Current, nil
+		}
+	}
 
-	return logger, nil
+	// Third priority: fallback to in-cluster if kubeconfig not valid
+	if contextName != "" {
+		if loadErr != nil {
+			return nil, "", loadErr
+		}
+		return nil, "", fmt.Errorf("context %q not found in kubeconfig %q", contextName, kubeconfig)
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		// If all methods fail, provide a comprehensive error message
+		if loadErr != nil {
+			err = fmt.Errorf("%v; %w", loadErr, err)
+		}
+		return nil, "", fmt.Errorf("could not find valid authentication method: "+
+			"kubeconfig file %q is invalid or missing and in-cluster config failed: %w",
+			kubeconfig, err)
+	}
+	return inClusterContext(config, "in-cluster (fallback)")
 }
 
-// createK8sClient creates a Kubernetes clientset based on configuration
-func createK8sClient(kubeconfig string, inCluster bool) (*kubernetes.Clientset, error) {
-	var config *rest.Config
-	var err error
-	var configSource string
+// createClusterManager creates the clients of every cluster the server can target
+func createClusterManager(cfg Config, tracer *tracing.Tracer) (*multicluster.Manager, error) {
+	contexts, current, err := loadK8sContexts(cfg.KubeConfig, cfg.KubeConfigDir, cfg.Context, cfg.InCluster)
+	if err != nil {
+		return nil, err
+	}
 
-	// First priority: explicitly set inCluster flag
-	if inCluster {
-		config, err = rest.InClusterConfig()
+	// Trace and log the API requests of every client
+	instrument := func(config *rest.Config) {
+		if tracer != nil {
+			tracer.WrapConfig(config)
+		}
+		config.Wrap(log.WrapTransport)
+	}
+
+	var labelSelector func(http.RoundTripper) http.RoundTripper
+	if cfg.DefaultLabelSelector != "" {
+		if labelSelector, err = scope.LabelSelector(cfg.DefaultLabelSelector); err != nil {
+			return nil, err
+		}
+	}
+	prepare := func(contexts []multicluster.Context) []multicluster.Context {
+		for _, c := range contexts {
+			if cfg.As != "" {
+				c.Config.Impersonate = rest.ImpersonationConfig{UserName: cfg.As, Groups: cfg.AsGroups}
+			}
+			if labelSelector != nil {
+				c.Config.Wrap(labelSelector)
+			}
+			// Clients of passed through tokens and impersonated users copy these settings
+			c.Config.QPS = cfg.KubeAPIQPS
+			c.Config.Burst = cfg.KubeAPIBurst
+			c.Config.Timeout = cfg.RequestTimeout
+			instrument(c.Config)
+		}
+		return contexts
+	}
+	// Reload the contexts when the credentials of one change, such as a kubeconfig rewritten by a
+	// credential refresh or a rotated service account token
+	reload := func() ([]multicluster.Context, error) {
+		contexts, _, err := loadK8sContexts(cfg.KubeConfig, cfg.KubeConfigDir, cfg.Context, cfg.InCluster)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+			return nil, err
 		}
-		configSource = "in-cluster (explicitly configured)"
-	} else {
-		// Second priority: valid kubeconfig file
-		kubeconfigValid := false
-		if kubeconfig != "" {
-			if stat, statErr := os.Stat(kubeconfig); statErr == nil && stat.Size() > 0 {
-				if cfg, cfgErr := clientcmd.BuildConfigFromFlags("", kubeconfig); cfgErr == nil {
-					config = cfg
-					kubeconfigValid = true
-					configSource = fmt.Sprintf("kubeconfig file: %s", kubeconfig)
+		return prepare(contexts), nil
+	}
+	// Create the clients of each cluster on its first tool call, and again after a reload
+	create := func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		clientset, dynamicClient, err := createK8sClients(config, cfg.KubeAPIProtobuf)
+		if err != nil {
+			return nil, nil, err
+		}
+		var k8sClient kubernetes.Interface = clientset
+		if cfg.WarmUp {
+			k8sClient = warmup.WithCachedDiscovery(clientset)
+			go warmUp(k8sClient)
+		}
+		// Informers run as the server identity, so clients of passed through tokens and
+		// impersonated users are never served from them
+		if cfg.InformerCache {
+			k8sClient = informercache.WithCache(k8sClient)
+		}
+		return k8sClient, dynamicClient, nil
+	}
+	manager, err := multicluster.NewLazyManager(prepare(contexts), current, create, reload)
+	if err != nil {
+		return nil, err
+	}
+	manager.OnRefresh(func(name string, err error) {
+		if err != nil {
+			log.Component("kubernetes").Warn().Err(err).Str("context", name).Msg("Failed to recreate Kubernetes clients after their credentials changed, keeping the previous clients")
+			return
+		}
+		log.Component("kubernetes").Info().Str("context", name).Msg("Kubernetes clients recreated after their credentials changed")
+	})
+	// Warming up creates the clients of every cluster in the background, without holding up startup
+	if cfg.WarmUp {
+		for _, name := range manager.Names() {
+			go func() {
+				if _, err := manager.ServerClient(multicluster.WithCluster(context.Background(), name)); err != nil {
+					log.Component("warmup").Warn().Err(err).Str("context", name).Msg("Failed to create Kubernetes clients")
 				}
-			}
+			}()
 		}
+	}
 
-		// Third priority: fallback to in-cluster if kubeconfig not valid
-		if !kubeconfigValid {
-			config, err = rest.InClusterConfig()
-			if err != nil {
-				// If all methods fail, provide a comprehensive error message
-				return nil, fmt.Errorf("could not find valid authentication method: "+
-					"kubeconfig file %q is invalid or missing and in-cluster config failed: %w",
-					kubeconfig, err)
-			}
-			configSource = "in-cluster (fallback)"
+	if cfg.As != "" {
+		log.Component("kubernetes").Info().Str("user", cfg.As).Strs("groups", cfg.AsGroups).Msg("Impersonating user for every request")
+	}
+
+	// Create clients for the token of each SSE client, or the user a call impersonates, on first use
+	build := func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		clientset, dynamicClient, err := createK8sClients(config, cfg.KubeAPIProtobuf)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.WarmUp {
+			return warmup.WithCachedDiscovery(clientset), dynamicClient, nil
 		}
+		return clientset, dynamicClient, nil
+	}
+	if cfg.TokenPassthrough {
+		manager.EnableTokenPassthrough(build)
+		log.Component("kubernetes").Info().Msg("Token passthrough enabled, tool calls use the bearer token of each client")
 	}
+	if cfg.ImpersonatePerCall {
+		manager.EnableImpersonation(build)
+		log.Component("kubernetes").Info().Msg("Per-call impersonation enabled, tool calls may run as the user they name")
+	}
+	return manager, nil
+}
 
+// createK8sClients creates the typed and dynamic Kubernetes clients from a REST config. With
+// protobuf, the typed client exchanges built-in objects as protobuf, accepting JSON from APIs that
+// do not serve it; the dynamic client always uses JSON, as custom resources have no protobuf form.
+func createK8sClients(config *rest.Config, protobuf bool) (*kubernetes.Clientset, *dynamic.DynamicClient, error) {
 	// Create clientset
-	clientset, err := kubernetes.NewForConfig(config)
+	typedConfig := config
+	if protobuf {
+		typedConfig = rest.CopyConfig(config)
+		typedConfig.ContentType = runtime.ContentTypeProtobuf
+		typedConfig.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+	}
+	clientset, err := kubernetes.NewForConfig(typedConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	// Log the config source for easier debugging
-	log.Info().Str("source", configSource).Msg("Kubernetes client initialized")
+	// Create dynamic client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Kubernetes dynamic client: %w", err)
+	}
 
-	return clientset, nil
+	return clientset, dynamicClient, nil
 }
 
-// setupK8sServer creates and configures the MCP server with K8s tools
-func setupK8sServer(cfg Config) (*server.MCPServer, error) {
-	// Create Kubernetes client
-	k8sClient, err := createK8sClient(cfg.KubeConfig, cfg.InCluster)
+// serverComponents are the parts of the MCP server the network transports serve next to it
+type serverComponents struct {
+	// recorder records tool calls for the transcript export
+	recorder *transcript.Recorder
+	// clusters holds the clients of the clusters the server targets
+	clusters *multicluster.Manager
+	// metrics instruments tool calls and Kubernetes API requests
+	metrics *metrics.Metrics
+	// tracer exports spans of tool calls and Kubernetes API requests, nil without an OTLP endpoint
+	tracer *tracing.Tracer
+	// auditLog records write tool calls, nil without an audit log
+	auditLog *audit.Logger
+}
+
+// shutdown exports the spans not exported yet and closes the audit log
+func (c *serverComponents) shutdown() {
+	if c.auditLog != nil {
+		if err := c.auditLog.Close(); err != nil {
+			log.Component("audit").Warn().Err(err).Msg("Failed to close audit log")
+		}
+	}
+	if c.tracer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), traceShutdownTimeout)
+	defer cancel()
+	if err := c.tracer.Shutdown(ctx); err != nil {
+		log.Component("tracing").Warn().Err(err).Msg("Failed to export remaining spans")
+	}
+}
+
+// setupK8sServer creates and configures the MCP server with K8s tools, returning the components
+// the network transports serve next to it
+func setupK8sServer(cfg Config) (*server.MCPServer, *serverComponents, error) {
+	if file := viper.ConfigFileUsed(); file != "" {
+		log.Component("config").Info().Str("file", file).Msg("Loaded config file")
+	}
+
+	// Record the requests of every Kubernetes client created below
+	serverMetrics := metrics.New()
+	serverMetrics.RegisterClientMetrics()
+
+	// Trace tool calls and the Kubernetes API requests they make
+	var tracer *tracing.Tracer
+	if cfg.OTLPEndpoint != "" {
+		var err error
+		tracer, err = tracing.New(context.Background(), tracing.Config{
+			Endpoint: cfg.OTLPEndpoint,
+			Protocol: cfg.OTLPProtocol,
+			Insecure: cfg.OTLPInsecure,
+		}, version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		log.Component("tracing").Info().Str("endpoint", cfg.OTLPEndpoint).Str("protocol", cfg.OTLPProtocol).Msg("Exporting traces")
+	}
+
+	// Load the clusters, whose clients are created on first use. Server-wide features such as
+	// permission probing and secret loading use the current cluster.
+	clusters, err := createClusterManager(cfg, tracer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		return nil, nil, err
 	}
+	apiServer := clusters.CurrentContext().Server
 
 	// Initialize translation helper
 	t, dumpTranslations := translations.TranslationHelper()
 
-	// Create client getter function
-	getClient := func(_ context.Context) (kubernetes.Interface, error) {
-		return k8sClient, nil
+	// Create client getter functions, resolving the cluster each tool call targets
+	getClient := clusters.GetClient
+	getDynamicClient := clusters.GetDynamicClient
+	getRESTConfig := clusters.GetRESTConfig
+
+	// Create the optional image vulnerability scanner
+	var imageScanner scanner.Scanner
+	if cfg.ImageScannerURL != "" {
+		token, err := resolveSecret(cfg.ImageScannerToken, clusters.ServerClient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve image scanner token: %w", err)
+		}
+		imageScanner = scanner.NewHTTPScannerWithTokenSource(cfg.ImageScannerURL, token.Get)
 	}
 
-	// Create MCP server
-	k8sServer := k8s.NewServer(version)
+	// Create MCP server, with logging so incident mode can report tool calls to clients, and
+	// completions of the namespaces, names and clusters prompts and resources take
+	completer := completion.New(getClient, clusters.Names(), completion.DefaultTTL)
+	k8sServer := k8s.NewServer(version,
+		server.WithLogging(),
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(completer),
+		server.WithResourceCompletionProvider(completer),
+	)
+
+	// Remove secret values from tool results unless disabled
+	var redactor *redact.Filter
+	if cfg.RedactSecrets {
+		redactor, err = redact.New(cfg.RedactEnvPatterns)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// Create toolset
-	k8sToolset, err := k8s.InitToolset(cfg.ReadOnly, getClient, t, cfg.EnabledK8sResources)
+	k8sToolset, resourceSet, err := k8s.InitToolset(cfg.ReadOnly, getClient, getDynamicClient, getRESTConfig, t, cfg.EnabledK8sResources, cfg.Toolsets, cfg.ToolsetReadOnly, imageScanner, cfg.Resources, cfg.Policy, redactor)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize toolsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize toolsets: %w", err)
+	}
+
+	// Never offer the disabled tools, including tools added below
+	k8sToolset.DisableTools(cfg.DisabledTools...)
+
+	// Make destructive calls return their impact and a token the agent must pass back, so a
+	// single mistaken call cannot delete anything. Impact is read from the
+	// cluster and as the user each call targets, resolved by the wrappers below
+	if cfg.ConfirmDestructive {
+		confirmer := confirm.New(cfg.ConfirmationTTL)
+		confirmer.SetKubernetesImpact(getClient)
+		k8sToolset.WrapWriteTools(confirmer.Wrap)
 	}
 
-	// Register tools with the server
-	k8sToolset.RegisterTools(k8sServer)
+	// Let every call bound how long it may run
+	k8sToolset.WrapTools(limits.WithTimeoutParam)
+
+	// Let every tool target another loaded cluster and impersonate a user, and list the clusters
+	if len(clusters.Names()) > 1 {
+		k8sToolset.WrapTools(clusters.WithClusterParam)
+	}
+	if cfg.ImpersonatePerCall {
+		k8sToolset.WrapTools(clusters.WithImpersonationParams)
+	}
+	k8sToolset.AddReadTool(clusters.ListTool())
+	k8sToolset.AddReadTool(clusters.CurrentContextTool())
+
+	// Let agents list the toolsets of the resource types and enable the ones their task needs
+	var dynamicToolsets *toolsets.DynamicToolsets
+	if cfg.DynamicToolsets {
+		dynamicToolsets = toolsets.NewDynamicToolsets(k8sServer)
+		k8sToolset.AddReadTool(dynamicToolsets.ListTool())
+		k8sToolset.AddReadTool(dynamicToolsets.EnableTool())
+	}
+
+	// Name the environment in write tool descriptions, which clients show when confirming a call
+	k8sToolset.WrapWriteTools(cfg.Banner().Wrap)
+	k8sToolset.AddReadTool(banner.InfoTool(banner.ServerInfo{
+		Banner:          cfg.Banner(),
+		Version:         version,
+		Cluster:         apiServer,
+		ReadOnly:        cfg.ReadOnly,
+		OperationPolicy: cfg.Policy,
+	}))
+
+	// Lock down write tools and report every tool call while an incident is active. Starting an
+	// incident locks down the other sessions too, so incident_mode is a write tool, left out in
+	// read-only mode and audited, and added after the guard so its status stays available.
+	incidentMode := incident.New(cfg.IncidentAllowedTools)
+	if cfg.IncidentID != "" {
+		if err := incidentMode.Start(cfg.IncidentID); err != nil {
+			return nil, nil, err
+		}
+	}
+	k8sToolset.WrapWriteTools(incidentMode.Guard)
+	k8sToolset.AddWriteTool(incidentMode.Tool())
+	k8sToolset.WrapTools(incidentMode.Notify)
+
+	// Let read tools be served from the informers of their cluster, noting results that may lag it
+	if cfg.InformerCache {
+		k8sToolset.WrapReadTools(informercache.Wrap)
+	}
+
+	// Summarize or truncate results over the size budget rather than overflow the client's message
+	k8sToolset.WrapTools(output.WithBudget(cfg.MaxResponseBytes))
+
+	// Serve resources/read with the read tools, as wrapped so far, so resources get the same
+	// policy, limits, redaction and size budget but not the envelope made for tool clients
+	resourceSet.RegisterResources(k8sServer, k8sToolset.GetActiveTools())
+
+	// Offer runbooks as prompts, with only the steps whose tools this server provides, and complete
+	// the names of the objects those tools list
+	prompts.Register(k8sServer, k8sToolset.GetActiveTools())
+	completer.SetTools(k8sToolset.GetActiveTools())
+
+	// Wrap JSON results in an envelope reporting what they hold and whether they are complete,
+	// as the transcript records them
+	if !cfg.RawResults {
+		k8sToolset.WrapTools(output.WithEnvelope)
+	}
+
+	// Reject calls over the concurrency and session rate limits before they reach the cluster,
+	// while still recording, auditing and instrumenting them
+	k8sToolset.WrapTools(throttle.New(cfg.MaxConcurrentCalls, cfg.SessionRateLimit).Wrap)
+
+	// Record every tool call for the session transcript export
+	recorder := transcript.NewRecorder(version, transcript.ClusterIdentity{Server: apiServer})
+	recorder.SetIncidentID(incidentMode.ID)
+	k8sToolset.WrapTools(recorder.Wrap)
+	k8sToolset.AddReadTool(recorder.ExportTool())
+
+	// Record every write tool call in the audit log
+	var auditLog *audit.Logger
+	if cfg.AuditLog != "" {
+		auditLog, err = audit.New(audit.Config{
+			Path:       cfg.AuditLog,
+			MaxSizeMB:  cfg.AuditLogMaxSize,
+			MaxBackups: cfg.AuditLogMaxBackups,
+			MaxAgeDays: cfg.AuditLogMaxAge,
+		}, func(err error) {
+			log.Component("audit").Error().Err(err).Msg("Failed to record audit event")
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		auditLog.SetIncidentID(incidentMode.ID)
+		k8sToolset.WrapWriteTools(auditLog.Wrap)
+		log.Component("audit").Info().Str("path", cfg.AuditLog).Msg("Audit log enabled")
+	}
+
+	// Count, time and log every tool call, including calls rejected by the wrappers above
+	k8sToolset.WrapTools(serverMetrics.Wrap)
+	k8sToolset.WrapTools(log.Wrap)
+	if tracer != nil {
+		k8sToolset.WrapTools(tracer.Wrap)
+	}
+
+	// A disabled tool the server does not have is most likely misspelled, or left out by read-only
+	// mode or the toolsets
+	available := make(map[string]bool)
+	for _, tool := range k8sToolset.GetAvailableTools() {
+		available[tool.Tool.Name] = true
+	}
+	for _, name := range cfg.DisabledTools {
+		if !available[name] {
+			log.Component("toolsets").Warn().Str("tool", name).Msg("Disabled tool is not offered by this server")
+		}
+	}
+
+	// Register tools with the server, in dynamic mode only those belonging to no toolset
+	if dynamicToolsets != nil {
+		dynamicToolsets.RegisterTools(k8sToolset)
+	} else {
+		k8sToolset.RegisterTools(k8sServer)
+	}
+
+	// Hide tools that would always be denied before the first client lists them, leaving the
+	// tools of toolsets not enabled yet unregistered and hidden tools out of the ones enabled.
+	// With token passthrough calls run with each client's token, whose permissions the server's
+	// own identity says nothing about, so every tool stays visible.
+	switch {
+	case cfg.HideForbiddenTools && cfg.TokenPassthrough:
+		log.Component("visibility").Info().Msg("Permission probing disabled, tool calls use the bearer token of each client")
+	case cfg.HideForbiddenTools:
+		prober := visibility.NewProber(k8sServer, clusters.ServerClient, cfg.Namespace, k8sToolset.GetActiveTools())
+		if dynamicToolsets != nil {
+			prober.SetRegistered(dynamicToolsets.Enabled)
+			dynamicToolsets.SetVisible(func(name string) bool { return !prober.Hidden(name) })
+		}
+		probePermissions(prober)
+		go func() {
+			for range time.Tick(visibility.DefaultInterval) {
+				probePermissions(prober)
+			}
+		}()
+	}
 
 	// Export translations if requested
 	if cfg.ExportTranslations {
 		dumpTranslations()
 	}
 
-	return k8sServer, nil
+	return k8sServer, &serverComponents{recorder: recorder, clusters: clusters, metrics: serverMetrics, tracer: tracer, auditLog: auditLog}, nil
+}
+
+// resolveSecret loads a sensitive setting from its backend, reloading it in the background so
+// rotated values are picked up without a restart
+func resolveSecret(ref string, getClient secret.ClientFunc) (*secret.Value, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretLoadTimeout)
+	defer cancel()
+
+	value, err := secret.Resolve(ctx, ref, getClient)
+	if err != nil || !value.Reloadable() {
+		return value, err
+	}
+
+	go func() {
+		for range time.Tick(secret.DefaultReloadInterval) {
+			ctx, cancel := context.WithTimeout(context.Background(), secretLoadTimeout)
+			changed, err := value.Refresh(ctx)
+			cancel()
+			if err != nil {
+				log.Component("secret").Warn().Err(err).Str("secret", value.String()).Msg("Failed to reload secret, keeping the previous value")
+				continue
+			}
+			if changed {
+				log.Component("secret").Info().Str("secret", value.String()).Msg("Secret reloaded")
+			}
+		}
+	}()
+	return value, nil
+}
+
+// warmUp pre-populates the client caches, logging how long each step took
+func warmUp(client kubernetes.Interface) {
+	ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+	defer cancel()
+
+	for _, result := range warmup.Run(ctx, warmup.Tasks(client)) {
+		if result.Err != nil {
+			log.Component("warmup").Warn().Err(result.Err).Str("task", result.Task).Dur("duration", result.Duration).Msg("Warm-up task failed")
+			continue
+		}
+		log.Component("warmup").Info().Str("task", result.Task).Dur("duration", result.Duration).Msg("Warm-up task finished")
+	}
+}
+
+// probePermissions updates the visible tools, logging the tools hidden for lack of permissions
+func probePermissions(prober *visibility.Prober) {
+	ctx, cancel := context.WithTimeout(context.Background(), permissionProbeTimeout)
+	defer cancel()
+
+	hidden, err := prober.Probe(ctx)
+	if err != nil {
+		log.Component("visibility").Warn().Err(err).Msg("Permission probe failed, visible tools left unchanged")
+		return
+	}
+	log.Component("visibility").Info().Strs("hidden", hidden).Msg("Permission probe finished")
 }
 
 // runStdioServer starts an MCP server using stdio transport
 func runStdioServer(cfg Config) error {
+	// A stdio client sends no HTTP headers to take a token from
+	if cfg.TokenPassthrough {
+		return fmt.Errorf("token passthrough is only supported by the sse and http transports")
+	}
+	// stdout carries the MCP messages of a stdio client
+	if cfg.AuditLog == audit.Stdout {
+		return fmt.Errorf("the audit log can only be written to stdout by the sse and http transports")
+	}
+
 	// Create app context with signal handling
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	// Initialize logger
-	logger, err := initStdioLogger(cfg.LogFile)
-	if err != nil {
+	if err := initLogger(cfg); err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
+	logger := log.Component("stdio")
 
 	// Create MCP server
-	k8sServer, err := setupK8sServer(cfg)
+	k8sServer, components, err := setupK8sServer(cfg)
 	if err != nil {
 		return err
 	}
+	defer components.shutdown()
 
 	// Create stdio server
 	stdioServer := server.NewStdioServer(k8sServer)
 
 	// Configure logger
-	stdLogger := stdlog.New(logger.Writer(), "k8s-mcp-stdio: ", 0)
-	stdioServer.SetErrorLogger(stdLogger)
+	stdioServer.SetErrorLogger(log.StdLogger(logger, zerolog.ErrorLevel))
 
 	// Start listening for messages
 	errC := make(chan error, 1)
@@ -469,7 +1738,7 @@ func runStdioServer(cfg Config) error {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
 		if cfg.LogCommands {
-			loggedIO := iolog.NewIOLogger(in, out, logger)
+			loggedIO := log.NewIOLogger(in, out, logger)
 			in, out = loggedIO, loggedIO
 		}
 
@@ -477,13 +1746,13 @@ func runStdioServer(cfg Config) error {
 	}()
 
 	// Log startup message
-	logger.Infof("Kubernetes MCP Server running on stdio")
+	logger.Info().Msg("Kubernetes MCP Server running on stdio")
 	fmt.Fprintf(os.Stderr, "Kubernetes MCP Server running on stdio\n")
 
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
-		logger.Infof("Shutting down server...")
+		logger.Info().Msg("Shutting down server...")
 	case err := <-errC:
 		if err != nil {
 			return fmt.Errorf("error running server: %w", err)
@@ -499,47 +1768,262 @@ func runSSEServer(cfg Config) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Initialize logger
+	if err := initLogger(cfg); err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
 	// Create MCP server
-	k8sServer, err := setupK8sServer(cfg)
+	k8sServer, components, err := setupK8sServer(cfg)
 	if err != nil {
 		return err
 	}
+	defer components.shutdown()
+
+	// Serve the transcript export next to the SSE endpoints
+	mux := http.NewServeMux()
+	httpServer := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: mux,
+	}
 
 	// Create SSE server with options
 	sseServer := server.NewSSEServer(k8sServer,
+		server.WithHTTPServer(httpServer),
 		server.WithBasePath("/mcp"),
 		server.WithKeepAlive(true),
-		server.WithSSEContextFunc(func(ctx context.Context, r *http.Request) context.Context {
-			// Can add request-specific context values
-			return ctx
-		}),
+		// Pass the client's bearer token, selected cluster and credentials to its tool calls
+		server.WithSSEContextFunc(requestContext),
 	)
 
+	mux.Handle("/mcp/transcript", components.recorder)
+	mux.Handle("/mcp/", sseServer)
+
+	if err := configureTLS(httpServer, cfg); err != nil {
+		return err
+	}
+	if err := configureAuth(httpServer, cfg, components.clusters); err != nil {
+		return err
+	}
+	configureHealth(httpServer, components.clusters)
+	configureMetrics(httpServer, components.metrics)
+
 	// Create error channel
 	errC := make(chan error, 1)
 
-	// Format port
-	formattedPort := ":" + cfg.Port
-
 	// Start the server in a goroutine
 	go func() {
-		log.Info().Str("port", cfg.Port).Msg("Starting SSE server")
-		errC <- sseServer.Start(formattedPort)
+		log.Component("sse").Info().Str("port", cfg.Port).Bool("tls", httpServer.TLSConfig != nil).Msg("Starting SSE server")
+		errC <- listenAndServe(httpServer)
 	}()
 
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
-		log.Info().Msg("Shutting down server...")
+		log.Component("sse").Info().Msg("Shutting down server...")
 		if err := sseServer.Shutdown(ctx); err != nil {
-			log.Error().Err(err).Msg("Error during server shutdown")
+			log.Component("sse").Error().Err(err).Msg("Error during server shutdown")
 		}
 	case err := <-errC:
 		if err != nil {
-			log.Error().Err(err).Msg("Server error")
+			log.Component("sse").Error().Err(err).Msg("Server error")
 			if err := sseServer.Shutdown(ctx); err != nil {
-				log.Error().Err(err).Msg("Error during server shutdown")
+				log.Component("sse").Error().Err(err).Msg("Error during server shutdown")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configureTLS serves httpServer over TLS when a certificate is configured, reloading the
+// certificate files in the background so rotated certificates are picked up without a restart
+func configureTLS(httpServer *http.Server, cfg Config) error {
+	if cfg.TLSCert == "" {
+		return nil
+	}
+	certs, err := servertls.Load(cfg.TLSCert, cfg.TLSKey, cfg.TLSClientCA)
+	if err != nil {
+		return err
+	}
+	httpServer.TLSConfig = certs.TLSConfig()
+
+	go func() {
+		for range time.Tick(servertls.DefaultReloadInterval) {
+			changed, err := certs.Refresh()
+			if err != nil {
+				log.Component("tls").Warn().Err(err).Msg("Failed to reload TLS certificates, keeping the previous ones")
+				continue
+			}
+			if changed {
+				log.Component("tls").Info().Str("cert", cfg.TLSCert).Msg("TLS certificates reloaded")
+			}
+		}
+	}()
+	return nil
+}
+
+// configureAuth rejects the requests to httpServer that carry no accepted client credential,
+// reloading the token file and referenced API keys in the background so they can be rotated without
+// a restart
+func configureAuth(httpServer *http.Server, cfg Config, clusters *multicluster.Manager) error {
+	// API keys may be references, reloaded so rotated keys are accepted without a restart
+	var apiKeys []func() string
+	for _, ref := range cfg.APIKeys {
+		if ref = strings.TrimSpace(ref); ref == "" {
+			continue
+		}
+		key, err := resolveSecret(ref, clusters.ServerClient)
+		if err != nil {
+			return fmt.Errorf("failed to resolve API key: %w", err)
+		}
+		apiKeys = append(apiKeys, key.Get)
+	}
+	authConfig := auth.Config{
+		APIKeys:      apiKeys,
+		TokenFile:    cfg.AuthTokenFile,
+		OIDCIssuer:   cfg.OIDCIssuer,
+		OIDCAudience: cfg.OIDCAudience,
+	}
+	if !authConfig.Enabled() {
+		log.Component("auth").Warn().Msg("No client authentication configured, any network peer can call the tools")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretLoadTimeout)
+	defer cancel()
+	authenticator, err := auth.New(ctx, authConfig)
+	if err != nil {
+		return fmt.Errorf("failed to configure client authentication: %w", err)
+	}
+	httpServer.Handler = authenticator.Wrap(httpServer.Handler)
+
+	if cfg.AuthTokenFile != "" {
+		go func() {
+			for range time.Tick(secret.DefaultReloadInterval) {
+				changed, err := authenticator.Refresh()
+				if err != nil {
+					log.Component("auth").Warn().Err(err).Msg("Failed to reload the auth token file, keeping the previous tokens")
+					continue
+				}
+				if changed {
+					log.Component("auth").Info().Str("file", cfg.AuthTokenFile).Msg("Auth token file reloaded")
+				}
 			}
+		}()
+	}
+	log.Component("auth").Info().Int("apiKeys", len(apiKeys)).Str("tokenFile", cfg.AuthTokenFile).Str("oidcIssuer", cfg.OIDCIssuer).
+		Msg("Client authentication enabled")
+	return nil
+}
+
+// requestContext passes the bearer token and cluster of an HTTP request to the tool calls it
+// carries, and its credentials so only they can download the transcript of the session
+func requestContext(ctx context.Context, r *http.Request) context.Context {
+	return transcript.ContextFromRequest(multicluster.ContextFromRequest(ctx, r), r)
+}
+
+// configureHealth serves the liveness, readiness and version endpoints of httpServer ahead of
+// client authentication, checking readiness against the API server of the current cluster
+func configureHealth(httpServer *http.Server, clusters *multicluster.Manager) {
+	checker := health.NewHandler(
+		health.Version{Version: version, Commit: commit, Date: date},
+		func(ctx context.Context) error {
+			client, err := clusters.ServerClient(ctx)
+			if err != nil {
+				return err
+			}
+			return health.DiscoveryPing(client.Discovery())(ctx)
+		},
+	)
+	httpServer.Handler = checker.Wrap(httpServer.Handler)
+}
+
+// configureMetrics serves the Prometheus metrics of httpServer ahead of client authentication, so
+// scrapers need no MCP credentials
+func configureMetrics(httpServer *http.Server, serverMetrics *metrics.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, serverMetrics.Handler())
+	mux.Handle("/", httpServer.Handler)
+	httpServer.Handler = mux
+}
+
+// listenAndServe serves httpServer, over TLS when configureTLS set its TLS config
+func listenAndServe(httpServer *http.Server) error {
+	if httpServer.TLSConfig != nil {
+		return httpServer.ListenAndServeTLS("", "")
+	}
+	return httpServer.ListenAndServe()
+}
+
+// runHTTPServer starts an MCP server using the streamable HTTP transport
+func runHTTPServer(cfg Config) error {
+	// Create app context with signal handling
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize logger
+	if err := initLogger(cfg); err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	// Create MCP server
+	k8sServer, components, err := setupK8sServer(cfg)
+	if err != nil {
+		return err
+	}
+	defer components.shutdown()
+
+	// Serve the transcript export next to the MCP endpoint
+	basePath := "/" + strings.Trim(cfg.BasePath, "/")
+	mux := http.NewServeMux()
+	httpServer := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: mux,
+	}
+
+	// Create streamable HTTP server with options
+	httpTransport := server.NewStreamableHTTPServer(k8sServer,
+		server.WithStreamableHTTPServer(httpServer),
+		server.WithEndpointPath(basePath),
+		server.WithStateLess(cfg.Stateless),
+		// Pass the client's bearer token, selected cluster and credentials to its tool calls
+		server.WithHTTPContextFunc(requestContext),
+	)
+
+	mux.Handle(strings.TrimSuffix(basePath, "/")+"/transcript", components.recorder)
+	mux.Handle(basePath, httpTransport)
+
+	if err := configureTLS(httpServer, cfg); err != nil {
+		return err
+	}
+	if err := configureAuth(httpServer, cfg, components.clusters); err != nil {
+		return err
+	}
+	configureHealth(httpServer, components.clusters)
+	configureMetrics(httpServer, components.metrics)
+
+	// Create error channel
+	errC := make(chan error, 1)
+
+	// Start the server in a goroutine
+	go func() {
+		log.Component("http").Info().Str("port", cfg.Port).Str("path", basePath).Bool("stateless", cfg.Stateless).
+			Bool("tls", httpServer.TLSConfig != nil).Msg("Starting streamable HTTP server")
+		errC <- listenAndServe(httpServer)
+	}()
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		log.Component("http").Info().Msg("Shutting down server...")
+		if err := httpTransport.Shutdown(ctx); err != nil {
+			log.Component("http").Error().Err(err).Msg("Error during server shutdown")
+		}
+	case err := <-errC:
+		if err != nil {
+			log.Component("http").Error().Err(err).Msg("Server error")
 			return err
 		}
 	}
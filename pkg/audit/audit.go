@@ -0,0 +1,203 @@
+// Package audit records every call of a write tool as a JSON line: the tool, its arguments with
+// sensitive values redacted, the authenticated caller and MCP session, the cluster, the outcome and
+// the time of the call. Events are written to stdout or to a file rotated by size.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/auth"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/briankscheong/k8s-mcp-server/pkg/transcript"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Stdout is the audit log path writing events to standard output
+const Stdout = "-"
+
+// Defaults of the audit log rotation
+const (
+	DefaultMaxSizeMB  = 100
+	DefaultMaxBackups = 10
+)
+
+// maxErrorLength bounds the error message recorded for a failed call
+const maxErrorLength = 1024
+
+// Outcomes of a tool call
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Config selects where audit events are written
+type Config struct {
+	// Path is the file events are appended to, or "-" for stdout
+	Path string
+	// MaxSizeMB is the size in megabytes at which the file is rotated
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept, 0 keeping all of them
+	MaxBackups int
+	// MaxAgeDays is the number of days rotated files are kept, 0 keeping them regardless of age
+	MaxAgeDays int
+}
+
+// Event is the audit record of a tool call
+type Event struct {
+	Time       time.Time              `json:"time"`
+	Tool       string                 `json:"tool"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Caller     string                 `json:"caller,omitempty"`
+	AuthMethod string                 `json:"authMethod,omitempty"`
+	SessionID  string                 `json:"sessionId,omitempty"`
+	Cluster    string                 `json:"cluster,omitempty"`
+	IncidentID string                 `json:"incidentId,omitempty"`
+	Outcome    string                 `json:"outcome"`
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"durationMs"`
+}
+
+// Logger writes audit events
+type Logger struct {
+	mu         sync.Mutex
+	w          io.Writer
+	closer     io.Closer
+	now        func() time.Time
+	incidentID func() string
+	onError    func(error)
+}
+
+// New creates a logger writing to the file or stream of cfg. Events that cannot be written are
+// passed to onError.
+func New(cfg Config, onError func(error)) (*Logger, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("an audit log path is required")
+	}
+	if cfg.Path == Stdout {
+		return newLogger(os.Stdout, nil, onError), nil
+	}
+
+	// Fail on startup rather than on the first write when the file cannot be opened
+	f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", cfg.Path, err)
+	}
+	_ = f.Close()
+
+	file := &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+	}
+	return newLogger(file, file, onError), nil
+}
+
+func newLogger(w io.Writer, closer io.Closer, onError func(error)) *Logger {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	return &Logger{w: w, closer: closer, now: time.Now, onError: onError}
+}
+
+// SetIncidentID tags every event recorded from now on with the ID returned by incidentID, if any
+func (l *Logger) SetIncidentID(incidentID func() string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.incidentID = incidentID
+}
+
+// Close closes the audit log file
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Wrap records every call of a tool, including calls rejected before they reach the cluster
+func (l *Logger) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := l.now()
+		result, err := next(ctx, request)
+
+		event := l.event(ctx, name, request.GetArguments())
+		event.Time = start
+		event.DurationMs = l.now().Sub(start).Milliseconds()
+		event.Outcome = OutcomeSuccess
+		switch {
+		case err != nil:
+			event.Outcome = OutcomeError
+			event.Error = err.Error()
+		case result != nil && result.IsError:
+			event.Outcome = OutcomeError
+			event.Error = resultText(result)
+		}
+		if len(event.Error) > maxErrorLength {
+			event.Error = event.Error[:maxErrorLength]
+		}
+		l.write(event)
+
+		return result, err
+	}
+	return tool
+}
+
+// event describes the caller and target of a tool call
+func (l *Logger) event(ctx context.Context, tool string, args map[string]interface{}) Event {
+	event := Event{
+		Tool:      tool,
+		Arguments: redact.Arguments(args),
+		SessionID: transcript.SessionID(ctx),
+		Cluster:   multicluster.ClusterFromContext(ctx),
+	}
+	if cluster, ok := args[multicluster.ClusterParam].(string); ok && cluster != "" {
+		event.Cluster = cluster
+	}
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		event.Caller = identity.Name
+		event.AuthMethod = identity.Method
+	}
+
+	l.mu.Lock()
+	incidentID := l.incidentID
+	l.mu.Unlock()
+	if incidentID != nil {
+		event.IncidentID = incidentID()
+	}
+	return event
+}
+
+// write appends an event to the log as a single line
+func (l *Logger) write(event Event) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		l.onError(fmt.Errorf("failed to marshal audit event: %w", err))
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		l.onError(fmt.Errorf("failed to write audit event: %w", err))
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/auth"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession is a client session with a fixed ID
+type fakeSession string
+
+func (s fakeSession) SessionID() string                                   { return string(s) }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+// newTestLogger creates a logger writing to a buffer, with a fake clock advancing 5ms per reading
+func newTestLogger(w *bytes.Buffer) *Logger {
+	l := newLogger(w, nil, nil)
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time {
+		clock = clock.Add(5 * time.Millisecond)
+		return clock
+	}
+	return l
+}
+
+func readEvents(t *testing.T, data string) []Event {
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestWrap(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+	logger.SetIncidentID(func() string { return "INC-42" })
+
+	applyManifest := logger.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("apply_manifest"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("created"), nil
+		},
+	})
+	deletePod := logger.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("delete_pod"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("failed to delete pod: forbidden"), nil
+		},
+	})
+	broken := logger.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("scale_deployment"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, errors.New("failed to get Kubernetes client")
+		},
+	})
+
+	srv := server.NewMCPServer("test", "1.0")
+	ctx := srv.WithContext(context.Background(), fakeSession("session-1"))
+	ctx = auth.WithIdentity(ctx, auth.Identity{Name: "jane@example.com", Method: auth.MethodOIDC})
+	ctx = multicluster.WithCluster(ctx, "staging")
+
+	_, err := applyManifest.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"manifest": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\n",
+		"token":    "abc",
+		"cluster":  "production",
+	}}})
+	require.NoError(t, err)
+	_, err = deletePod.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"name": "web-0",
+	}}})
+	require.NoError(t, err)
+	_, err = broken.Handler(context.Background(), mcp.CallToolRequest{})
+	require.Error(t, err)
+
+	events := readEvents(t, buf.String())
+	require.Len(t, events, 3)
+
+	assert.Equal(t, Event{
+		Time:       time.Date(2024, 5, 1, 12, 0, 0, 5000000, time.UTC),
+		Tool:       "apply_manifest",
+		Arguments:  map[string]interface{}{"manifest": "[REDACTED]", "token": "[REDACTED]", "cluster": "production"},
+		Caller:     "jane@example.com",
+		AuthMethod: auth.MethodOIDC,
+		SessionID:  "session-1",
+		Cluster:    "production",
+		IncidentID: "INC-42",
+		Outcome:    OutcomeSuccess,
+		DurationMs: 5,
+	}, events[0])
+
+	assert.Equal(t, "staging", events[1].Cluster)
+	assert.Equal(t, OutcomeError, events[1].Outcome)
+	assert.Equal(t, "failed to delete pod: forbidden", events[1].Error)
+
+	assert.Equal(t, OutcomeError, events[2].Outcome)
+	assert.Equal(t, "failed to get Kubernetes client", events[2].Error)
+	assert.Empty(t, events[2].Caller)
+	assert.Empty(t, events[2].SessionID)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{}, nil)
+	assert.ErrorContains(t, err, "an audit log path is required")
+
+	_, err = New(Config{Path: filepath.Join(t.TempDir(), "missing", "audit.log")}, nil)
+	assert.ErrorContains(t, err, "failed to open audit log")
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := New(Config{Path: path, MaxSizeMB: DefaultMaxSizeMB, MaxBackups: DefaultMaxBackups}, nil)
+	require.NoError(t, err)
+	tool := logger.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("delete_pod"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("deleted"), nil
+		},
+	})
+	_, err = tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	events := readEvents(t, string(data))
+	require.Len(t, events, 1)
+	assert.Equal(t, "delete_pod", events[0].Tool)
+	assert.Equal(t, OutcomeSuccess, events[0].Outcome)
+}
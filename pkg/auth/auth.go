@@ -0,0 +1,223 @@
+// Package auth authenticates the clients of the network transports. A request is accepted when it
+// carries one of the API keys, a token listed in the token file, or an OIDC ID token issued
+// for the configured audience. Other requests are rejected with 401 Unauthorized before they reach
+// the MCP server.
+//
+// Clients send their credential in the X-API-Key header or as a bearer token in the Authorization
+// header. With token passthrough, the Authorization header carries the client's Kubernetes token,
+// so API keys go in X-API-Key.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// APIKeyHeader is the HTTP header carrying an API key or token
+const APIKeyHeader = "X-API-Key"
+
+// Authentication methods reported in an Identity
+const (
+	MethodAPIKey    = "api-key"
+	MethodTokenFile = "token-file"
+	MethodOIDC      = "oidc"
+)
+
+// Config selects the credentials accepted from clients
+type Config struct {
+	// APIKeys return the accepted API keys, read on every request so rotated keys are accepted as
+	// soon as they are reloaded
+	APIKeys []func() string
+	// TokenFile lists accepted tokens one per line, optionally followed by a comma and the name of
+	// the client, like the Kubernetes static token file. Empty lines and lines starting with # are
+	// ignored.
+	TokenFile string
+	// OIDCIssuer and OIDCAudience accept ID tokens signed by the issuer for the audience
+	OIDCIssuer   string
+	OIDCAudience string
+}
+
+// Enabled reports whether any credential is configured
+func (c Config) Enabled() bool {
+	return len(c.APIKeys) > 0 || c.TokenFile != "" || c.OIDCIssuer != ""
+}
+
+// Identity is the authenticated client of a request
+type Identity struct {
+	// Name is the client named by the token file, or the email or subject of an ID token
+	Name   string
+	Method string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the authenticated client of a request
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the authenticated client of a request, if any
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// Authenticator checks the credentials of HTTP requests
+type Authenticator struct {
+	apiKeys   []func() string
+	tokenFile string
+	verifier  *oidc.IDTokenVerifier
+
+	mu     sync.RWMutex
+	raw    string
+	tokens map[[sha256.Size]byte]string
+}
+
+// New creates an authenticator for cfg. With an OIDC issuer, its discovery document is fetched
+// with ctx.
+func New(ctx context.Context, cfg Config) (*Authenticator, error) {
+	a := &Authenticator{apiKeys: cfg.APIKeys, tokenFile: cfg.TokenFile}
+	if cfg.TokenFile != "" {
+		if _, err := a.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.OIDCIssuer != "" {
+		if cfg.OIDCAudience == "" {
+			return nil, fmt.Errorf("an OIDC audience is required with the OIDC issuer %s", cfg.OIDCIssuer)
+		}
+		provider, err := oidc.NewProvider(ctx, cfg.OIDCIssuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover OIDC issuer %s: %w", cfg.OIDCIssuer, err)
+		}
+		a.verifier = provider.Verifier(&oidc.Config{ClientID: cfg.OIDCAudience})
+	}
+	return a, nil
+}
+
+// Refresh reloads the token file, reporting whether it changed. On error the tokens last loaded
+// are kept.
+func (a *Authenticator) Refresh() (bool, error) {
+	if a.tokenFile == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(a.tokenFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read token file %s: %w", a.tokenFile, err)
+	}
+	raw := string(data)
+
+	a.mu.RLock()
+	unchanged := a.tokens != nil && raw == a.raw
+	a.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	tokens := map[[sha256.Size]byte]string{}
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		token, name, _ := strings.Cut(line, ",")
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return false, fmt.Errorf("invalid token file %s: line %d has an empty token", a.tokenFile, i+1)
+		}
+		name, _, _ = strings.Cut(name, ",")
+		tokens[sha256.Sum256([]byte(token))] = strings.TrimSpace(name)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.raw = raw
+	a.tokens = tokens
+	return true, nil
+}
+
+// Authenticate returns the client of a request, or an error when it carries no accepted credential
+func (a *Authenticator) Authenticate(r *http.Request) (Identity, error) {
+	credentials := []string{strings.TrimSpace(r.Header.Get(APIKeyHeader))}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		credentials = append(credentials, strings.TrimSpace(token))
+	}
+
+	missing := true
+	for _, credential := range credentials {
+		if credential == "" {
+			continue
+		}
+		missing = false
+		sum := sha256.Sum256([]byte(credential))
+		if a.isAPIKey(sum) {
+			return Identity{Method: MethodAPIKey}, nil
+		}
+		a.mu.RLock()
+		name, ok := a.tokens[sum]
+		a.mu.RUnlock()
+		if ok {
+			return Identity{Name: name, Method: MethodTokenFile}, nil
+		}
+		if a.verifier != nil {
+			if identity, err := a.verifyIDToken(r.Context(), credential); err == nil {
+				return identity, nil
+			}
+		}
+	}
+	if missing {
+		return Identity{}, fmt.Errorf("missing credentials")
+	}
+	return Identity{}, fmt.Errorf("invalid credentials")
+}
+
+// isAPIKey reports whether a credential hash is the hash of a current API key
+func (a *Authenticator) isAPIKey(sum [sha256.Size]byte) bool {
+	for _, get := range a.apiKeys {
+		if key := strings.TrimSpace(get()); key != "" && sha256.Sum256([]byte(key)) == sum {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyIDToken checks the signature, issuer, audience and expiry of an OIDC ID token
+func (a *Authenticator) verifyIDToken(ctx context.Context, raw string) (Identity, error) {
+	token, err := a.verifier.Verify(ctx, raw)
+	if err != nil {
+		return Identity{}, err
+	}
+	var claims struct {
+		Email string `json:"email"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+	name := claims.Email
+	if name == "" {
+		name = token.Subject
+	}
+	return Identity{Name: name, Method: MethodOIDC}, nil
+}
+
+// Wrap rejects the requests to next that carry no accepted credential with 401 Unauthorized, and
+// passes the client of the others in the request context
+func (a *Authenticator) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := a.Authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="k8s-mcp-server"`)
+			http.Error(w, fmt.Sprintf("unauthorized: %v", err), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+	})
+}
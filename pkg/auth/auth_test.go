@@ -0,0 +1,284 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issuer is a fake OIDC issuer serving its discovery document and signing key
+type issuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	i := &issuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                i.URL,
+			"jwks_uri":                              i.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	i.Server = httptest.NewServer(mux)
+	t.Cleanup(i.Close)
+	return i
+}
+
+// token signs an ID token with the issuer's key
+func (i *issuer) token(t *testing.T, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestAuthenticator(t *testing.T) {
+	oidcIssuer := newIssuer(t)
+	tokenFile := filepath.Join(t.TempDir(), "tokens.csv")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("# clients\nci-token,ci,1001\n\nops-token\n"), 0600))
+
+	a, err := New(context.Background(), Config{
+		APIKeys:      []func() string{apiKey("static-key")},
+		TokenFile:    tokenFile,
+		OIDCIssuer:   oidcIssuer.URL,
+		OIDCAudience: "k8s-mcp",
+	})
+	require.NoError(t, err)
+
+	valid := func(claims map[string]interface{}) string {
+		base := map[string]interface{}{
+			"iss": oidcIssuer.URL,
+			"aud": "k8s-mcp",
+			"sub": "user-1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"iat": time.Now().Unix(),
+		}
+		for k, v := range claims {
+			base[k] = v
+		}
+		return oidcIssuer.token(t, base)
+	}
+
+	tests := []struct {
+		name             string
+		headers          map[string]string
+		expectedIdentity Identity
+		expectedErrMsg   string
+	}{
+		{
+			name:             "api key header",
+			headers:          map[string]string{APIKeyHeader: "static-key"},
+			expectedIdentity: Identity{Method: MethodAPIKey},
+		},
+		{
+			name:             "api key as bearer token",
+			headers:          map[string]string{"Authorization": "Bearer static-key"},
+			expectedIdentity: Identity{Method: MethodAPIKey},
+		},
+		{
+			name:             "named token from file",
+			headers:          map[string]string{"Authorization": "Bearer ci-token"},
+			expectedIdentity: Identity{Name: "ci", Method: MethodTokenFile},
+		},
+		{
+			name:             "unnamed token from file",
+			headers:          map[string]string{APIKeyHeader: "ops-token"},
+			expectedIdentity: Identity{Method: MethodTokenFile},
+		},
+		{
+			name:             "oidc token with email",
+			headers:          map[string]string{"Authorization": "Bearer " + valid(map[string]interface{}{"email": "jane@example.com"})},
+			expectedIdentity: Identity{Name: "jane@example.com", Method: MethodOIDC},
+		},
+		{
+			name:             "oidc token with subject",
+			headers:          map[string]string{"Authorization": "Bearer " + valid(nil)},
+			expectedIdentity: Identity{Name: "user-1", Method: MethodOIDC},
+		},
+		{
+			name: "api key next to a passthrough token",
+			headers: map[string]string{
+				APIKeyHeader:    "static-key",
+				"Authorization": "Bearer kubernetes-token",
+			},
+			expectedIdentity: Identity{Method: MethodAPIKey},
+		},
+		{
+			name:           "oidc token for another audience",
+			headers:        map[string]string{"Authorization": "Bearer " + valid(map[string]interface{}{"aud": "other"})},
+			expectedErrMsg: "invalid credentials",
+		},
+		{
+			name:           "expired oidc token",
+			headers:        map[string]string{"Authorization": "Bearer " + valid(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})},
+			expectedErrMsg: "invalid credentials",
+		},
+		{
+			name:           "unknown key",
+			headers:        map[string]string{APIKeyHeader: "guess"},
+			expectedErrMsg: "invalid credentials",
+		},
+		{
+			name:           "basic credentials",
+			headers:        map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			expectedErrMsg: "missing credentials",
+		},
+		{
+			name:           "no credentials",
+			expectedErrMsg: "missing credentials",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			identity, err := a.Authenticate(r)
+
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedIdentity, identity)
+		})
+	}
+
+	t.Run("token file reload", func(t *testing.T) {
+		changed, err := a.Refresh()
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		require.NoError(t, os.WriteFile(tokenFile, []byte("new-token,deploy-bot\n"), 0600))
+		changed, err = a.Refresh()
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.Header.Set(APIKeyHeader, "ci-token")
+		_, err = a.Authenticate(r)
+		assert.Error(t, err)
+		r.Header.Set(APIKeyHeader, "new-token")
+		identity, err := a.Authenticate(r)
+		require.NoError(t, err)
+		assert.Equal(t, "deploy-bot", identity.Name)
+
+		// An invalid file keeps the tokens last loaded
+		require.NoError(t, os.WriteFile(tokenFile, []byte(",nameless\n"), 0600))
+		_, err = a.Refresh()
+		assert.ErrorContains(t, err, "line 1 has an empty token")
+		_, err = a.Authenticate(r)
+		assert.NoError(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), Config{OIDCIssuer: "https://issuer.example.com"})
+	assert.ErrorContains(t, err, "an OIDC audience is required")
+
+	_, err = New(context.Background(), Config{TokenFile: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to read token file")
+
+	assert.False(t, Config{}.Enabled())
+	assert.True(t, Config{APIKeys: []func() string{apiKey("key")}}.Enabled())
+}
+
+func TestRotatedAPIKey(t *testing.T) {
+	key := "old-key"
+	a, err := New(context.Background(), Config{APIKeys: []func() string{func() string { return key }}})
+	require.NoError(t, err)
+
+	request := func(credential string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.Header.Set(APIKeyHeader, credential)
+		return r
+	}
+	_, err = a.Authenticate(request("old-key"))
+	assert.NoError(t, err)
+
+	key = "new-key\n"
+	_, err = a.Authenticate(request("old-key"))
+	assert.ErrorContains(t, err, "invalid credentials")
+	_, err = a.Authenticate(request("new-key"))
+	assert.NoError(t, err)
+
+	// An empty key, such as a Secret being rewritten, accepts nothing
+	key = ""
+	_, err = a.Authenticate(request("new-key"))
+	assert.ErrorContains(t, err, "invalid credentials")
+}
+
+func TestWrap(t *testing.T) {
+	a, err := New(context.Background(), Config{TokenFile: writeTokens(t, "ci-token,ci")})
+	require.NoError(t, err)
+
+	handler := a.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := IdentityFromContext(r.Context())
+		require.True(t, ok)
+		_, _ = w.Write([]byte(identity.Name))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="k8s-mcp-server"`, w.Header().Get("WWW-Authenticate"))
+	assert.Contains(t, w.Body.String(), "missing credentials")
+
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("Authorization", "Bearer ci-token")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ci", w.Body.String())
+}
+
+func apiKey(key string) func() string {
+	return func() string { return key }
+}
+
+func writeTokens(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
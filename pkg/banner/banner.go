@@ -0,0 +1,75 @@
+// Package banner describes the deployment a server manages, its environment, owning team and
+// escalation contact, so users always know which environment a tool call is about to modify.
+package banner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Banner identifies the environment a server instance manages
+type Banner struct {
+	Environment string `json:"environment,omitempty"`
+	Team        string `json:"team,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+}
+
+// ServerInfo is the result of the get_server_info tool
+type ServerInfo struct {
+	Banner
+	Version  string `json:"version"`
+	Cluster  string `json:"cluster"`
+	ReadOnly bool   `json:"readOnly"`
+	// OperationPolicy lists the verbs allowed on each resource type it restricts
+	OperationPolicy map[string][]string `json:"operationPolicy,omitempty"`
+}
+
+// IsZero reports whether no banner field is set
+func (b Banner) IsZero() bool {
+	return b == Banner{}
+}
+
+// String formats the set banner fields, e.g. "environment: production, team: payments"
+func (b Banner) String() string {
+	var parts []string
+	for _, field := range []struct{ name, value string }{
+		{"environment", b.Environment},
+		{"team", b.Team},
+		{"contact", b.Contact},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.name+": "+field.value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Wrap prefixes the description of a tool with the banner. Clients show the description when
+// asking users to confirm a call, so wrapping write tools names the environment being modified.
+func (b Banner) Wrap(tool server.ServerTool) server.ServerTool {
+	if b.IsZero() {
+		return tool
+	}
+	tool.Tool.Description = fmt.Sprintf("[%s] %s", b, tool.Tool.Description)
+	return tool
+}
+
+// InfoTool creates a tool that reports the server version, cluster, mode and banner
+func InfoTool(info ServerInfo) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_server_info",
+			mcp.WithDescription("Get the environment this server manages, its owning team and escalation contact, along with the server version, cluster API server and whether write tools are disabled"),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			r, err := json.Marshal(info)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,73 @@
+package banner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannerWrap(t *testing.T) {
+	tool := server.ServerTool{Tool: mcp.NewTool("delete_resource", mcp.WithDescription("Delete a single resource of any kind"))}
+
+	tests := []struct {
+		name     string
+		banner   Banner
+		expected string
+	}{
+		{
+			name:     "all fields",
+			banner:   Banner{Environment: "production", Team: "payments", Contact: "#payments-oncall"},
+			expected: "[environment: production, team: payments, contact: #payments-oncall] Delete a single resource of any kind",
+		},
+		{
+			name:     "environment only",
+			banner:   Banner{Environment: "staging"},
+			expected: "[environment: staging] Delete a single resource of any kind",
+		},
+		{
+			name:     "empty banner",
+			expected: "Delete a single resource of any kind",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := tc.banner.Wrap(tool)
+			assert.Equal(t, tc.expected, wrapped.Tool.Description)
+			assert.Equal(t, "Delete a single resource of any kind", tool.Tool.Description)
+		})
+	}
+}
+
+func TestInfoTool(t *testing.T) {
+	info := ServerInfo{
+		Banner:   Banner{Environment: "production", Team: "payments", Contact: "#payments-oncall"},
+		Version:  "1.2.3",
+		Cluster:  "https://prod.example.com:6443",
+		ReadOnly: true,
+	}
+
+	tool, handler := InfoTool(info)
+	assert.Equal(t, "get_server_info", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got))
+	assert.Equal(t, map[string]interface{}{
+		"environment": "production",
+		"team":        "payments",
+		"contact":     "#payments-oncall",
+		"version":     "1.2.3",
+		"cluster":     "https://prod.example.com:6443",
+		"readOnly":    true,
+	}, got)
+}
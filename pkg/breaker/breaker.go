@@ -0,0 +1,195 @@
+// Package breaker stops write tools that keep failing against the same target. After repeated
+// failures within a short window the target cools down and further calls are rejected without
+// reaching the API server, so agent retry storms do not flood it.
+package breaker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Defaults used by the server: three failures within a minute cool a target down for two minutes
+const (
+	DefaultThreshold = 3
+	DefaultWindow    = time.Minute
+	DefaultCooldown  = 2 * time.Minute
+)
+
+// targetParams are the tool parameters identifying the object a call acts on, by kind for
+// manifests and by group, version and resource for the tools acting on any resource
+var targetParams = []string{"apiVersion", "kind", "group", "version", "resource", "namespace", "name"}
+
+// CoolingDown is the error returned, JSON encoded, for calls to a target that is cooling down
+type CoolingDown struct {
+	Error             string `json:"error"`
+	Tool              string `json:"tool"`
+	Target            string `json:"target"`
+	Failures          int    `json:"failures"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+	LastError         string `json:"lastError,omitempty"`
+}
+
+// Breaker tracks failures of tool calls per tool and target
+type Breaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	targets map[string]*target
+}
+
+type target struct {
+	failures  []time.Time
+	lastError string
+	openUntil time.Time
+}
+
+// New creates a Breaker that cools a target down for cooldown after threshold failures within window
+func New(threshold int, window, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+		now:       time.Now,
+		targets:   map[string]*target{},
+	}
+}
+
+// Wrap guards a tool with the breaker. Error results and handler errors count as failures, a
+// successful call clears the failures of its target.
+func (b *Breaker) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key := targetKey(multicluster.ClusterFromContext(ctx), request.GetArguments())
+		if rejection := b.check(name, key); rejection != nil {
+			r, err := json.Marshal(rejection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultError(string(r)), nil
+		}
+
+		result, err := next(ctx, request)
+		switch {
+		case err != nil:
+			b.record(name, key, err.Error())
+		case result != nil && result.IsError:
+			b.record(name, key, resultText(result))
+		default:
+			b.reset(name, key)
+		}
+		return result, err
+	}
+	return tool
+}
+
+// check returns the rejection for a call to a target that is cooling down
+func (b *Breaker) check(tool, key string) *CoolingDown {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.targets[tool+" "+key]
+	now := b.now()
+	if !ok || !now.Before(t.openUntil) {
+		return nil
+	}
+	return &CoolingDown{
+		Error:             fmt.Sprintf("%s is cooling down for this target after %d failures within %s; fix the cause before retrying", tool, b.threshold, b.window),
+		Tool:              tool,
+		Target:            key,
+		Failures:          b.threshold,
+		RetryAfterSeconds: int(math.Ceil(t.openUntil.Sub(now).Seconds())),
+		LastError:         t.lastError,
+	}
+}
+
+// record adds a failure, tripping the cool-down once the threshold is reached within the window
+func (b *Breaker) record(tool, key, message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+	t, ok := b.targets[tool+" "+key]
+	if !ok {
+		t = &target{}
+		b.targets[tool+" "+key] = t
+	}
+	t.failures = append(recent(t.failures, now.Add(-b.window)), now)
+	t.lastError = message
+	if len(t.failures) >= b.threshold {
+		t.openUntil = now.Add(b.cooldown)
+		t.failures = nil
+	}
+}
+
+func (b *Breaker) reset(tool, key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.targets, tool+" "+key)
+}
+
+// prune forgets targets with no failures in the window and no active cool-down
+func (b *Breaker) prune(now time.Time) {
+	for key, t := range b.targets {
+		t.failures = recent(t.failures, now.Add(-b.window))
+		if len(t.failures) == 0 && !now.Before(t.openUntil) {
+			delete(b.targets, key)
+		}
+	}
+}
+
+func recent(failures []time.Time, since time.Time) []time.Time {
+	kept := failures[:0]
+	for _, failure := range failures {
+		if failure.After(since) {
+			kept = append(kept, failure)
+		}
+	}
+	return kept
+}
+
+// targetKey identifies the target of a call by its cluster, selected by the cluster parameter
+// or the request, and its object parameters, falling back to all arguments for tools such as
+// apply_manifest that take the object in their content
+func targetKey(cluster string, arguments map[string]interface{}) string {
+	identity := map[string]interface{}{}
+	for _, param := range targetParams {
+		if value, ok := arguments[param]; ok {
+			identity[param] = value
+		}
+	}
+	// Maps are encoded with sorted keys, so equal arguments give equal keys
+	if len(identity) > 0 {
+		if cluster != "" {
+			identity[multicluster.ClusterParam] = cluster
+		}
+		key, _ := json.Marshal(identity)
+		return string(key)
+	}
+	key, _ := json.Marshal(arguments)
+	sum := sha256.Sum256(append([]byte(cluster+"\x00"), key...))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
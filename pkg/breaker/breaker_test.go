@@ -0,0 +1,155 @@
+package breaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := New(3, time.Minute, 2*time.Minute)
+	b.now = func() time.Time { return now }
+
+	var calls int
+	failing := true
+	tool := b.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("delete_pod"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			if failing {
+				return mcp.NewToolResultError("failed to delete pod: forbidden"), nil
+			}
+			return mcp.NewToolResultText("deleted"), nil
+		},
+	})
+	web := createMCPRequest(map[string]interface{}{"namespace": "shop", "name": "web", "gracePeriodSeconds": float64(0)})
+	db := createMCPRequest(map[string]interface{}{"namespace": "shop", "name": "db"})
+	call := func(request mcp.CallToolRequest) *mcp.CallToolResult {
+		result, err := tool.Handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Failures spread beyond the window do not trip the breaker
+	call(web)
+	now = now.Add(50 * time.Second)
+	call(web)
+	now = now.Add(20 * time.Second)
+	call(web)
+	assert.Equal(t, 3, calls)
+
+	// The third failure within the window trips it
+	call(web)
+	assert.Equal(t, 4, calls)
+	rejected := call(web)
+	assert.Equal(t, 4, calls, "calls are rejected while cooling down")
+	require.True(t, rejected.IsError)
+	var coolingDown CoolingDown
+	require.NoError(t, json.Unmarshal([]byte(rejected.Content[0].(mcp.TextContent).Text), &coolingDown))
+	assert.Equal(t, CoolingDown{
+		Error:             "delete_pod is cooling down for this target after 3 failures within 1m0s; fix the cause before retrying",
+		Tool:              "delete_pod",
+		Target:            `{"name":"web","namespace":"shop"}`,
+		Failures:          3,
+		RetryAfterSeconds: 120,
+		LastError:         "failed to delete pod: forbidden",
+	}, coolingDown)
+
+	// Other targets are not affected
+	call(db)
+	assert.Equal(t, 5, calls)
+
+	// After the cool-down calls go through again, and a success clears the failures
+	now = now.Add(2 * time.Minute)
+	failing = false
+	assert.False(t, call(web).IsError)
+	failing = true
+	call(web)
+	call(web)
+	call(web)
+	assert.Equal(t, 9, calls)
+	assert.True(t, call(web).IsError)
+	assert.Equal(t, 9, calls)
+}
+
+func TestBreakerTargetsResources(t *testing.T) {
+	b := New(2, time.Minute, time.Minute)
+	var calls int
+	tool := b.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("delete_resource"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultError("forbidden"), nil
+		},
+	})
+	call := func(resource string, cluster ...string) *mcp.CallToolResult {
+		ctx := context.Background()
+		if len(cluster) > 0 {
+			ctx = multicluster.WithCluster(ctx, cluster[0])
+		}
+		result, err := tool.Handler(ctx, createMCPRequest(map[string]interface{}{
+			"group": "", "version": "v1", "resource": resource, "namespace": "shop", "name": "web",
+		}))
+		require.NoError(t, err)
+		return result
+	}
+
+	call("configmaps")
+	call("configmaps")
+	assert.Contains(t, call("configmaps").Content[0].(mcp.TextContent).Text, "is cooling down")
+	assert.Equal(t, 2, calls)
+
+	// An object of another resource with the same name is a different target
+	assert.Equal(t, "forbidden", call("services").Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 3, calls)
+
+	// So is the same object on another cluster
+	call("configmaps", "production")
+	call("configmaps", "production")
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, "forbidden", call("configmaps", "staging").Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 6, calls)
+	rejected := call("configmaps", "production").Content[0].(mcp.TextContent).Text
+	assert.Contains(t, rejected, `"target":"{\"cluster\":\"production\",\"group\":\"\",\"name\":\"web\",\"namespace\":\"shop\",\"resource\":\"configmaps\",\"version\":\"v1\"}"`)
+	assert.Equal(t, 6, calls)
+}
+
+func TestBreakerCountsHandlerErrors(t *testing.T) {
+	b := New(2, time.Minute, time.Minute)
+	tool := b.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("apply_manifest"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, errors.New("failed to get Kubernetes client")
+		},
+	})
+	request := createMCPRequest(map[string]interface{}{"manifest": "kind: ConfigMap"})
+
+	for i := 0; i < 2; i++ {
+		_, err := tool.Handler(context.Background(), request)
+		require.Error(t, err)
+	}
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, `"target":"sha256:`)
+	assert.Contains(t, text, `"lastError":"failed to get Kubernetes client"`)
+}
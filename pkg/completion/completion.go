@@ -0,0 +1,243 @@
+// Package completion suggests values for the arguments of prompts and resource templates, such as
+// namespaces, object names and clusters. Names are listed from the cluster and reused for a short
+// time, so completing an argument as the user types does not list the cluster on every keystroke.
+package completion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/briankscheong/k8s-mcp-server/pkg/prompts"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultTTL is how long listed names are reused
+const DefaultTTL = 30 * time.Second
+
+// maxValues is the number of values MCP allows in a completion
+const maxValues = 100
+
+// listTools are the tools listing the objects of each resource whose names are completed. Names
+// are only completed while the server offers the list tool, so completions reveal no more than
+// the tools the toolsets, operation policy and read-only mode leave.
+var listTools = map[string]string{
+	"namespaces":  "list_namespaces",
+	"nodes":       "list_nodes",
+	"pods":        "list_pods",
+	"deployments": "list_deployments",
+}
+
+// Provider completes the arguments of prompts and resource templates. It implements
+// server.PromptCompletionProvider and server.ResourceCompletionProvider.
+type Provider struct {
+	getClient toolsets.GetClientFn
+	clusters  []string
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	available map[string]bool
+	cache     map[cacheKey]cachedNames
+}
+
+// cacheKey identifies a list by the client it was made with, so clients of different clusters or
+// passed-through tokens never see each other's names
+type cacheKey struct {
+	client    kubernetes.Interface
+	resource  string
+	namespace string
+}
+
+type cachedNames struct {
+	names   []string
+	expires time.Time
+}
+
+// New creates a provider listing names with getClient and reusing them for ttl. Cluster arguments
+// complete to clusters.
+func New(getClient toolsets.GetClientFn, clusters []string, ttl time.Duration) *Provider {
+	return &Provider{
+		getClient: getClient,
+		clusters:  clusters,
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     map[cacheKey]cachedNames{},
+	}
+}
+
+// SetTools sets the tools the server offers, deciding the resources whose names are completed
+func (p *Provider) SetTools(tools []server.ServerTool) {
+	available := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		available[tool.Tool.Name] = true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.available = available
+}
+
+// CompletePromptArgument completes an argument of a runbook prompt
+func (p *Provider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	resource := ""
+	if runbook, ok := prompts.Find(promptName); ok {
+		for _, a := range runbook.Arguments {
+			if a.Name == argument.Name {
+				resource = a.Resource
+			}
+		}
+	}
+	return p.complete(ctx, resource, argument, completeContext.Arguments)
+}
+
+// CompleteResourceArgument completes a variable of a resource template
+func (p *Provider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, completeContext mcp.CompleteContext) (*mcp.Completion, error) {
+	return p.complete(ctx, templateResource(uri, argument.Name), argument, completeContext.Arguments)
+}
+
+// templateResource returns the resource a variable of a URI template such as
+// k8s://{namespace}/pods/{name} names: namespaces for {namespace}, otherwise the resource of the
+// path segment before it
+func templateResource(uri, variable string) string {
+	if variable == "namespace" {
+		return "namespaces"
+	}
+	segments := strings.Split(strings.TrimPrefix(uri, "k8s://"), "/")
+	for i, segment := range segments {
+		if segment == "{"+variable+"}" && i > 0 {
+			return segments[i-1]
+		}
+	}
+	return ""
+}
+
+func (p *Provider) complete(ctx context.Context, resource string, argument mcp.CompleteArgument, arguments map[string]string) (*mcp.Completion, error) {
+	if argument.Name == prompts.ClusterArgument {
+		return match(p.clusters, argument.Value), nil
+	}
+	if resource == "" {
+		return match(nil, argument.Value), nil
+	}
+	// Names of objects are listed from the cluster the other arguments selected
+	if cluster := arguments[prompts.ClusterArgument]; cluster != "" {
+		if !slices.Contains(p.clusters, cluster) {
+			return match(nil, argument.Value), nil
+		}
+		ctx = multicluster.WithCluster(ctx, cluster)
+	}
+	names, err := p.names(ctx, resource, arguments["namespace"])
+	if err != nil {
+		return nil, err
+	}
+	return match(names, argument.Value), nil
+}
+
+// names returns the names of the objects of a resource, from the cache while they are fresh
+func (p *Provider) names(ctx context.Context, resource, namespace string) ([]string, error) {
+	p.mu.Lock()
+	available := p.available[listTools[resource]]
+	p.mu.Unlock()
+	if !available {
+		return nil, nil
+	}
+	// Names of namespaced objects are only completed once the namespace is known
+	if resource != "namespaces" && resource != "nodes" {
+		if namespace == "" {
+			return nil, nil
+		}
+	} else {
+		namespace = ""
+	}
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	key := cacheKey{client: client, resource: resource, namespace: namespace}
+	now := p.now()
+	p.mu.Lock()
+	cached, ok := p.cache[key]
+	p.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.names, nil
+	}
+
+	names, err := list(ctx, client, resource, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k, c := range p.cache {
+		if !now.Before(c.expires) {
+			delete(p.cache, k)
+		}
+	}
+	p.cache[key] = cachedNames{names: names, expires: now.Add(p.ttl)}
+	return names, nil
+}
+
+// list returns the sorted names of the objects of a resource
+func list(ctx context.Context, client kubernetes.Interface, resource, namespace string) ([]string, error) {
+	var names []string
+	switch resource {
+	case "namespaces":
+		list, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case "nodes":
+		list, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case "pods":
+		list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case "deployments":
+		list, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// match returns the values starting with prefix, at most as many as MCP allows
+func match(values []string, prefix string) *mcp.Completion {
+	matched := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			matched = append(matched, value)
+		}
+	}
+	completion := &mcp.Completion{Values: matched, Total: len(matched)}
+	if len(matched) > maxValues {
+		completion.Values = matched[:maxValues]
+		completion.HasMore = true
+	}
+	return completion
+}
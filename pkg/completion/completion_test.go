@@ -0,0 +1,234 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/multicluster"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+func tools(names ...string) []server.ServerTool {
+	var tools []server.ServerTool
+	for _, name := range names {
+		tools = append(tools, server.ServerTool{Tool: mcp.NewTool(name)})
+	}
+	return tools
+}
+
+func newClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	)
+}
+
+func completePrompt(t *testing.T, p *Provider, prompt, name, value string, arguments map[string]string) *mcp.Completion {
+	completion, err := p.CompletePromptArgument(context.Background(), prompt, mcp.CompleteArgument{Name: name, Value: value}, mcp.CompleteContext{Arguments: arguments})
+	require.NoError(t, err)
+	return completion
+}
+
+func TestCompletePromptArgument(t *testing.T) {
+	p := New(stubGetClientFn(newClient()), []string{"staging", "prod"}, DefaultTTL)
+	p.SetTools(tools("list_namespaces", "list_pods", "list_nodes"))
+
+	tests := []struct {
+		name      string
+		prompt    string
+		argument  string
+		value     string
+		arguments map[string]string
+		expected  []string
+	}{
+		{
+			name:     "namespaces",
+			prompt:   "debug-pod",
+			argument: "namespace",
+			expected: []string{"default", "kube-system", "shop"},
+		},
+		{
+			name:     "namespaces by prefix",
+			prompt:   "debug-pod",
+			argument: "namespace",
+			value:    "sh",
+			expected: []string{"shop"},
+		},
+		{
+			name:      "pods of the namespace",
+			prompt:    "investigate-crashloop",
+			argument:  "name",
+			value:     "web",
+			arguments: map[string]string{"namespace": "shop"},
+			expected:  []string{"web-0", "web-1"},
+		},
+		{
+			name:     "pods without a namespace",
+			prompt:   "debug-pod",
+			argument: "name",
+			expected: []string{},
+		},
+		{
+			name:     "nodes",
+			prompt:   "plan-node-drain",
+			argument: "node",
+			expected: []string{"worker-1"},
+		},
+		{
+			name:     "clusters",
+			prompt:   "debug-pod",
+			argument: "cluster",
+			value:    "p",
+			expected: []string{"prod"},
+		},
+		{
+			name:      "unknown cluster",
+			prompt:    "debug-pod",
+			argument:  "namespace",
+			arguments: map[string]string{"cluster": "dev"},
+			expected:  []string{},
+		},
+		{
+			name:     "unknown prompt",
+			prompt:   "restart",
+			argument: "namespace",
+			expected: []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			completion := completePrompt(t, p, tc.prompt, tc.argument, tc.value, tc.arguments)
+			assert.Equal(t, tc.expected, completion.Values)
+			assert.Equal(t, len(tc.expected), completion.Total)
+			assert.False(t, completion.HasMore)
+		})
+	}
+}
+
+func TestCompleteSelectedCluster(t *testing.T) {
+	clients := map[string]kubernetes.Interface{
+		"staging": fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "staging-apps"}}),
+		"prod":    fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-apps"}}),
+	}
+	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
+		name := multicluster.ClusterFromContext(ctx)
+		if name == "" {
+			name = "staging"
+		}
+		return clients[name], nil
+	}
+	p := New(getClient, []string{"staging", "prod"}, DefaultTTL)
+	p.SetTools(tools("list_namespaces"))
+
+	// Names are cached per client, so the clusters never see each other's names
+	assert.Equal(t, []string{"staging-apps"}, completePrompt(t, p, "debug-pod", "namespace", "", nil).Values)
+	assert.Equal(t, []string{"prod-apps"}, completePrompt(t, p, "debug-pod", "namespace", "", map[string]string{"cluster": "prod"}).Values)
+	assert.Equal(t, []string{"staging-apps"}, completePrompt(t, p, "debug-pod", "namespace", "", map[string]string{"cluster": "staging"}).Values)
+}
+
+func TestCompleteResourceArgument(t *testing.T) {
+	p := New(stubGetClientFn(newClient()), nil, DefaultTTL)
+	p.SetTools(tools("list_namespaces", "list_pods", "list_deployments"))
+
+	completion, err := p.CompleteResourceArgument(context.Background(), "k8s://{namespace}/deployments/{name}",
+		mcp.CompleteArgument{Name: "name", Value: "w"}, mcp.CompleteContext{Arguments: map[string]string{"namespace": "shop"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, completion.Values)
+
+	completion, err = p.CompleteResourceArgument(context.Background(), "k8s://{namespace}/pods/{name}",
+		mcp.CompleteArgument{Name: "namespace", Value: "d"}, mcp.CompleteContext{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, completion.Values)
+
+	// Nodes are not completed without list_nodes
+	completion, err = p.CompleteResourceArgument(context.Background(), "k8s://nodes/{name}",
+		mcp.CompleteArgument{Name: "name"}, mcp.CompleteContext{})
+	require.NoError(t, err)
+	assert.Empty(t, completion.Values)
+}
+
+func TestTemplateResource(t *testing.T) {
+	assert.Equal(t, "namespaces", templateResource("k8s://{namespace}/pods/{name}", "namespace"))
+	assert.Equal(t, "pods", templateResource("k8s://{namespace}/pods/{name}", "name"))
+	assert.Equal(t, "nodes", templateResource("k8s://nodes/{name}", "name"))
+	assert.Equal(t, "", templateResource("k8s://{namespace}/pods/{name}", "container"))
+}
+
+func TestCompletionCache(t *testing.T) {
+	client := newClient()
+	p := New(stubGetClientFn(client), nil, time.Minute)
+	p.SetTools(tools("list_namespaces", "list_pods"))
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	lists := func() int {
+		n := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "list" {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Typing a name completes it several times from a single list
+	for _, value := range []string{"", "s", "sh"} {
+		completePrompt(t, p, "debug-pod", "namespace", value, nil)
+	}
+	assert.Equal(t, 1, lists())
+
+	// Pods of another namespace are a list of their own
+	completePrompt(t, p, "debug-pod", "name", "", map[string]string{"namespace": "shop"})
+	completePrompt(t, p, "debug-pod", "name", "", map[string]string{"namespace": "default"})
+	assert.Equal(t, 3, lists())
+
+	// Once expired, names are listed again and new objects show up
+	_, err := client.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shop"}, completePrompt(t, p, "debug-pod", "namespace", "s", nil).Values)
+	now = now.Add(time.Minute)
+	assert.Equal(t, []string{"search", "shop"}, completePrompt(t, p, "debug-pod", "namespace", "s", nil).Values)
+	assert.Equal(t, 4, lists())
+	assert.Len(t, p.cache, 1)
+}
+
+func TestCompletionLimit(t *testing.T) {
+	var objects []runtime.Object
+	for i := 0; i < 150; i++ {
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("team-%03d", i)}})
+	}
+	p := New(stubGetClientFn(fake.NewSimpleClientset(objects...)), nil, DefaultTTL)
+	p.SetTools(tools("list_namespaces"))
+
+	completion := completePrompt(t, p, "debug-pod", "namespace", "team-", nil)
+	assert.Len(t, completion.Values, 100)
+	assert.Equal(t, 150, completion.Total)
+	assert.True(t, completion.HasMore)
+	assert.Equal(t, "team-000", completion.Values[0])
+}
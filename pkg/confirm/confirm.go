@@ -0,0 +1,264 @@
+// Package confirm makes destructive tools ask for confirmation. The first call of a delete, drain
+// or scale-to-zero tool returns a summary of its impact and a confirmation token instead of
+// running; only a second call with the same arguments and the token, within the token's time to
+// live, performs the operation. A single hallucinated call can then no longer delete anything.
+package confirm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/transcript"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TokenParam is the parameter carrying the confirmation token of a call
+const TokenParam = "confirmationToken"
+
+// DefaultTTL is how long a confirmation token can be used
+const DefaultTTL = 5 * time.Minute
+
+// Tools are the destructive tools calls of which must be confirmed. scale_deployment is only
+// confirmed when it scales to zero replicas.
+var Tools = []string{
+	"delete_pod",
+	"delete_deployment",
+	"delete_service",
+	"delete_configmap",
+	"delete_namespace",
+	"delete_resource",
+	"drain_node",
+	"scale_deployment",
+	"hibernate_namespace",
+}
+
+// Request is the result, JSON encoded, of a call awaiting confirmation
+type Request struct {
+	ConfirmationRequired bool      `json:"confirmationRequired"`
+	Tool                 string    `json:"tool"`
+	Summary              string    `json:"summary"`
+	Impact               []string  `json:"impact,omitempty"`
+	Token                string    `json:"confirmationToken"`
+	ExpiresAt            time.Time `json:"expiresAt"`
+	Instructions         string    `json:"instructions"`
+}
+
+// ImpactFn lists what a call of a tool will affect, such as the pods a drain evicts
+type ImpactFn func(ctx context.Context, args map[string]interface{}) ([]string, error)
+
+// Confirmer issues and redeems confirmation tokens
+type Confirmer struct {
+	ttl    time.Duration
+	now    func() time.Time
+	impact map[string]ImpactFn
+
+	mu      sync.Mutex
+	pending map[string]pending
+}
+
+// pending is an issued token, valid for a single call matching key
+type pending struct {
+	key     string
+	expires time.Time
+}
+
+// New creates a Confirmer issuing tokens valid for ttl
+func New(ttl time.Duration) *Confirmer {
+	return &Confirmer{
+		ttl:     ttl,
+		now:     time.Now,
+		impact:  map[string]ImpactFn{},
+		pending: map[string]pending{},
+	}
+}
+
+// SetImpact describes the impact of calls of a tool with impact in their confirmation request
+func (c *Confirmer) SetImpact(tool string, impact ImpactFn) {
+	c.impact[tool] = impact
+}
+
+// Wrap adds the confirmationToken parameter to a destructive tool and makes its calls return a
+// confirmation request until they pass a token issued for the same arguments and MCP session.
+// Other tools are returned unchanged.
+func (c *Confirmer) Wrap(tool server.ServerTool) server.ServerTool {
+	name := tool.Tool.Name
+	if !contains(Tools, name) {
+		return tool
+	}
+	mcp.WithString(TokenParam,
+		mcp.Description("Token returned by a previous call with the same arguments, confirming this destructive operation"),
+	)(&tool.Tool)
+
+	next := tool.Handler
+	required := tool.Tool.InputSchema.Required
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Calls missing a required argument are left to the tool to reject
+		args := request.GetArguments()
+		if !destructive(name, args) || missingRequired(required, args) {
+			return next(ctx, request)
+		}
+
+		key := callKey(transcript.SessionID(ctx), name, args)
+		token, _ := args[TokenParam].(string)
+		if token == "" {
+			return c.request(ctx, name, args, key)
+		}
+		if !c.redeem(token, key) {
+			return mcp.NewToolResultError(fmt.Sprintf("confirmation token is invalid, expired, already used or was issued for other arguments; call %s again without %s to get a new one", name, TokenParam)), nil
+		}
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// request issues a token for a call and describes what confirming it will do
+func (c *Confirmer) request(ctx context.Context, tool string, args map[string]interface{}, key string) (*mcp.CallToolResult, error) {
+	token, expires, err := c.issue(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req := Request{
+		ConfirmationRequired: true,
+		Tool:                 tool,
+		Summary:              summary(tool, args),
+		Token:                token,
+		ExpiresAt:            expires,
+		Instructions: fmt.Sprintf("Nothing was changed. Show the summary to the user and, once they approve, call %s again with the same arguments and %s set to this token before %s.",
+			tool, TokenParam, expires.Format(time.RFC3339)),
+	}
+	if impact, ok := c.impact[tool]; ok {
+		lines, err := impact(ctx, args)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("impact could not be determined: %v", err))
+		}
+		req.Impact = lines
+	}
+
+	r, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// issue creates a single-use token for a call, forgetting expired tokens
+func (c *Confirmer) issue(key string) (string, time.Time, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for t, p := range c.pending {
+		if !now.Before(p.expires) {
+			delete(c.pending, t)
+		}
+	}
+	expires := now.Add(c.ttl)
+	c.pending[token] = pending{key: key, expires: expires}
+	return token, expires, nil
+}
+
+// redeem uses up a token, reporting whether it was issued for the call and has not expired
+func (c *Confirmer) redeem(token, key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[token]
+	if !ok || p.key != key {
+		return false
+	}
+	delete(c.pending, token)
+	return c.now().Before(p.expires)
+}
+
+// destructive reports whether a call of a tool must be confirmed
+func destructive(tool string, args map[string]interface{}) bool {
+	if tool != "scale_deployment" {
+		return true
+	}
+	replicas, ok := args["replicas"].(float64)
+	return ok && replicas == 0
+}
+
+// callKey identifies a call by its session, tool and arguments other than the token
+func callKey(session, tool string, args map[string]interface{}) string {
+	call := map[string]interface{}{}
+	for k, v := range args {
+		if k != TokenParam {
+			call[k] = v
+		}
+	}
+	// Maps are encoded with sorted keys, so equal arguments give equal keys
+	encoded, _ := json.Marshal(call)
+	sum := sha256.Sum256(append([]byte(session+"\x00"+tool+"\x00"), encoded...))
+	return hex.EncodeToString(sum[:])
+}
+
+// summary describes the operation a call performs
+func summary(tool string, args map[string]interface{}) string {
+	str := func(key string) string {
+		s, _ := args[key].(string)
+		return s
+	}
+	object := func(kind string) string {
+		ref := fmt.Sprintf("%s %q", kind, str("name"))
+		if namespace := str("namespace"); namespace != "" {
+			ref += fmt.Sprintf(" in namespace %q", namespace)
+		}
+		if cluster := str("cluster"); cluster != "" {
+			ref += fmt.Sprintf(" on cluster %q", cluster)
+		}
+		return ref
+	}
+
+	switch tool {
+	case "delete_pod":
+		return "Delete " + object("pod")
+	case "delete_deployment":
+		return "Delete " + object("deployment") + " and its pods"
+	case "delete_service":
+		return "Delete " + object("service")
+	case "delete_configmap":
+		return "Delete " + object("configmap")
+	case "delete_namespace":
+		return "Delete " + object("namespace") + " and every object in it"
+	case "delete_resource":
+		return "Delete " + object(str("resource"))
+	case "drain_node":
+		return "Cordon " + object("node") + " and evict its pods"
+	case "scale_deployment":
+		return "Scale " + object("deployment") + " to 0 replicas, stopping all of its pods"
+	case "hibernate_namespace":
+		return fmt.Sprintf("Scale every deployment and statefulset in namespace %q to 0 replicas", str("namespace"))
+	}
+	return "Run " + tool
+}
+
+func missingRequired(required []string, args map[string]interface{}) bool {
+	for _, param := range required {
+		if _, ok := args[param]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
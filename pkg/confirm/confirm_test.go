@@ -0,0 +1,182 @@
+package confirm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeSession is a client session with a fixed ID
+type fakeSession string
+
+func (s fakeSession) SessionID() string                                   { return string(s) }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+// newTestTool wraps a tool counting the calls that reach it
+func newTestTool(c *Confirmer, name string, calls *int) server.ServerTool {
+	return c.Wrap(server.ServerTool{
+		Tool: mcp.NewTool(name,
+			mcp.WithString("namespace", mcp.Required()),
+			mcp.WithString("name", mcp.Required()),
+			mcp.WithNumber("replicas"),
+		),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*calls++
+			return mcp.NewToolResultText("done"), nil
+		},
+	})
+}
+
+func call(t *testing.T, ctx context.Context, tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+	result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	return result
+}
+
+func readRequest(t *testing.T, result *mcp.CallToolResult) Request {
+	require.False(t, result.IsError)
+	var req Request
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &req))
+	require.True(t, req.ConfirmationRequired)
+	return req
+}
+
+func withToken(args map[string]interface{}, token string) map[string]interface{} {
+	confirmed := map[string]interface{}{TokenParam: token}
+	for k, v := range args {
+		confirmed[k] = v
+	}
+	return confirmed
+}
+
+func TestWrap(t *testing.T) {
+	c := New(DefaultTTL)
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return clock }
+
+	var calls int
+	deletePod := newTestTool(c, "delete_pod", &calls)
+	_, ok := deletePod.Tool.InputSchema.Properties[TokenParam]
+	assert.True(t, ok)
+
+	srv := server.NewMCPServer("test", "1.0")
+	ctx := srv.WithContext(context.Background(), fakeSession("session-1"))
+	args := map[string]interface{}{"namespace": "shop", "name": "web-0"}
+
+	// The first call only describes the operation
+	req := readRequest(t, call(t, ctx, deletePod, args))
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, "delete_pod", req.Tool)
+	assert.Equal(t, `Delete pod "web-0" in namespace "shop"`, req.Summary)
+	assert.Equal(t, clock.Add(DefaultTTL), req.ExpiresAt)
+	assert.NotEmpty(t, req.Token)
+
+	// The token is only valid for the same arguments and session
+	assert.True(t, call(t, ctx, deletePod, withToken(map[string]interface{}{"namespace": "shop", "name": "web-1"}, req.Token)).IsError)
+	otherSession := srv.WithContext(context.Background(), fakeSession("session-2"))
+	assert.True(t, call(t, otherSession, deletePod, withToken(args, req.Token)).IsError)
+	assert.Equal(t, 0, calls)
+
+	result := call(t, ctx, deletePod, withToken(args, req.Token))
+	assert.False(t, result.IsError)
+	assert.Equal(t, 1, calls)
+
+	// Tokens are single use
+	result = call(t, ctx, deletePod, withToken(args, req.Token))
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "confirmation token is invalid, expired, already used or was issued for other arguments")
+	assert.Equal(t, 1, calls)
+
+	// Tokens expire
+	req = readRequest(t, call(t, ctx, deletePod, args))
+	clock = clock.Add(DefaultTTL)
+	assert.True(t, call(t, ctx, deletePod, withToken(args, req.Token)).IsError)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWrapScale(t *testing.T) {
+	var calls int
+	scale := newTestTool(New(DefaultTTL), "scale_deployment", &calls)
+
+	call(t, context.Background(), scale, map[string]interface{}{"namespace": "shop", "name": "web", "replicas": float64(2)})
+	assert.Equal(t, 1, calls)
+
+	req := readRequest(t, call(t, context.Background(), scale, map[string]interface{}{"namespace": "shop", "name": "web", "replicas": float64(0)}))
+	assert.Equal(t, `Scale deployment "web" in namespace "shop" to 0 replicas, stopping all of its pods`, req.Summary)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWrapOtherTools(t *testing.T) {
+	var calls int
+	c := New(DefaultTTL)
+
+	getPod := newTestTool(c, "get_pod", &calls)
+	_, ok := getPod.Tool.InputSchema.Properties[TokenParam]
+	assert.False(t, ok)
+	call(t, context.Background(), getPod, map[string]interface{}{"namespace": "shop", "name": "web-0"})
+	assert.Equal(t, 1, calls)
+
+	// Calls the tool rejects anyway need no confirmation
+	call(t, context.Background(), newTestTool(c, "delete_pod", &calls), map[string]interface{}{"name": "web-0"})
+	assert.Equal(t, 2, calls)
+}
+
+func TestKubernetesImpact(t *testing.T) {
+	controller := true
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0", OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller},
+		}}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "proxy-1", OwnerReferences: []metav1.OwnerReference{
+			{Kind: "DaemonSet", Name: "proxy", Controller: &controller},
+		}}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "data"}},
+	)
+	c := New(DefaultTTL)
+	c.SetKubernetesImpact(func(context.Context) (kubernetes.Interface, error) { return client, nil })
+
+	var calls int
+	drain := c.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("drain_node", mcp.WithString("name", mcp.Required())),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText("drained"), nil
+		},
+	})
+	req := readRequest(t, call(t, context.Background(), drain, map[string]interface{}{"name": "node-1"}))
+	assert.Equal(t, []string{"1 pods will be evicted: shop/web-0"}, req.Impact)
+
+	req = readRequest(t, call(t, context.Background(), newTestTool(c, "delete_pod", &calls), map[string]interface{}{"namespace": "shop", "name": "web-0"}))
+	assert.Equal(t, []string{"The pod is managed by ReplicaSet web-7d9f, which will replace it"}, req.Impact)
+
+	deleteNamespace := c.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("delete_namespace", mcp.WithString("name", mcp.Required())),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText("deleted"), nil
+		},
+	})
+	req = readRequest(t, call(t, context.Background(), deleteNamespace, map[string]interface{}{"name": "shop"}))
+	assert.Equal(t, []string{
+		"The namespace holds 1 pods, 1 persistentvolumeclaims, which will be deleted",
+		"Data of the persistentvolumeclaims is lost unless their volumes are retained",
+	}, req.Impact)
+
+	// Impact that cannot be read is reported without blocking the confirmation
+	req = readRequest(t, call(t, context.Background(), newTestTool(c, "delete_deployment", &calls), map[string]interface{}{"namespace": "shop", "name": "missing"}))
+	require.Len(t, req.Impact, 1)
+	assert.Contains(t, req.Impact[0], "impact could not be determined")
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,189 @@
+package confirm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxImpactItems bounds the objects listed by name in an impact summary
+const maxImpactItems = 20
+
+// SetKubernetesImpact describes the impact of the destructive tools by reading the objects they
+// act on, such as the pods a drain would evict or the objects a namespace deletion removes
+func (c *Confirmer) SetKubernetesImpact(getClient toolsets.GetClientFn) {
+	c.SetImpact("delete_pod", podImpact(getClient))
+	c.SetImpact("delete_deployment", deploymentImpact(getClient))
+	c.SetImpact("scale_deployment", deploymentImpact(getClient))
+	c.SetImpact("delete_namespace", namespaceImpact(getClient))
+	c.SetImpact("drain_node", drainImpact(getClient))
+	c.SetImpact("hibernate_namespace", hibernateImpact(getClient))
+}
+
+func stringArg(args map[string]interface{}, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func podImpact(getClient toolsets.GetClientFn) ImpactFn {
+	return func(ctx context.Context, args map[string]interface{}) ([]string, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pod, err := client.CoreV1().Pods(stringArg(args, "namespace")).Get(ctx, stringArg(args, "name"), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil {
+			return []string{"The pod is not managed by a controller and will not be recreated"}, nil
+		}
+		return []string{fmt.Sprintf("The pod is managed by %s %s, which will replace it", owner.Kind, owner.Name)}, nil
+	}
+}
+
+func deploymentImpact(getClient toolsets.GetClientFn) ImpactFn {
+	return func(ctx context.Context, args map[string]interface{}) ([]string, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		deployment, err := client.AppsV1().Deployments(stringArg(args, "namespace")).Get(ctx, stringArg(args, "name"), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []string{fmt.Sprintf("The deployment runs %d replicas, %d of them ready, which will be terminated",
+			deployment.Status.Replicas, deployment.Status.ReadyReplicas)}, nil
+	}
+}
+
+func namespaceImpact(getClient toolsets.GetClientFn) ImpactFn {
+	return func(ctx context.Context, args map[string]interface{}) ([]string, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		namespace := stringArg(args, "name")
+		opts := metav1.ListOptions{}
+
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		deployments, err := client.AppsV1().Deployments(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		services, err := client.CoreV1().Services(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var counts []string
+		for _, c := range []struct {
+			kind string
+			n    int
+		}{
+			{"pods", len(pods.Items)},
+			{"deployments", len(deployments.Items)},
+			{"statefulsets", len(statefulSets.Items)},
+			{"services", len(services.Items)},
+			{"persistentvolumeclaims", len(claims.Items)},
+		} {
+			if c.n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", c.n, c.kind))
+			}
+		}
+		if len(counts) == 0 {
+			return []string{"The namespace holds no pods, deployments, statefulsets, services or persistentvolumeclaims"}, nil
+		}
+		impact := []string{fmt.Sprintf("The namespace holds %s, which will be deleted", strings.Join(counts, ", "))}
+		if len(claims.Items) > 0 {
+			impact = append(impact, "Data of the persistentvolumeclaims is lost unless their volumes are retained")
+		}
+		return impact, nil
+	}
+}
+
+func drainImpact(getClient toolsets.GetClientFn) ImpactFn {
+	return func(ctx context.Context, args map[string]interface{}) ([]string, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", stringArg(args, "name")).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// DaemonSet pods stay on the node, as drain_node skips them
+		var evicted []string
+		for _, pod := range pods.Items {
+			if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+				continue
+			}
+			evicted = append(evicted, pod.Namespace+"/"+pod.Name)
+		}
+		if len(evicted) == 0 {
+			return []string{"No pods will be evicted"}, nil
+		}
+		return []string{fmt.Sprintf("%d pods will be evicted: %s", len(evicted), truncate(evicted))}, nil
+	}
+}
+
+func hibernateImpact(getClient toolsets.GetClientFn) ImpactFn {
+	return func(ctx context.Context, args map[string]interface{}) ([]string, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		namespace := stringArg(args, "namespace")
+		deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var running []string
+		for _, d := range deployments.Items {
+			if d.Spec.Replicas == nil || *d.Spec.Replicas > 0 {
+				running = append(running, "deployment/"+d.Name)
+			}
+		}
+		for _, s := range statefulSets.Items {
+			if s.Spec.Replicas == nil || *s.Spec.Replicas > 0 {
+				running = append(running, "statefulset/"+s.Name)
+			}
+		}
+		if len(running) == 0 {
+			return []string{"No workloads are running"}, nil
+		}
+		return []string{fmt.Sprintf("%d workloads will be stopped: %s", len(running), truncate(running))}, nil
+	}
+}
+
+// truncate joins names, listing at most maxImpactItems of them
+func truncate(names []string) string {
+	if len(names) <= maxImpactItems {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxImpactItems], ", "), len(names)-maxImpactItems)
+}
@@ -0,0 +1,95 @@
+// Package health serves the liveness, readiness and version endpoints Kubernetes probes use to
+// manage the server's Deployment. They are served before client authentication, as kubelet probes
+// carry no credentials.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/discovery"
+)
+
+// Endpoint paths
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+	VersionPath   = "/version"
+)
+
+// DefaultCheckTimeout bounds each readiness check
+const DefaultCheckTimeout = 5 * time.Second
+
+// Check reports whether a dependency of the server is ready
+type Check func(ctx context.Context) error
+
+// Version is the build of the server reported by the version endpoint
+type Version struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// Handler serves the health endpoints
+type Handler struct {
+	version Version
+	ready   Check
+	timeout time.Duration
+}
+
+// NewHandler creates a handler reporting version and checking readiness with ready
+func NewHandler(version Version, ready Check) *Handler {
+	return &Handler{version: version, ready: ready, timeout: DefaultCheckTimeout}
+}
+
+// DiscoveryPing checks that the API server is reachable by requesting its version
+func DiscoveryPing(client discovery.DiscoveryInterface) Check {
+	return func(ctx context.Context) error {
+		var err error
+		if restClient := client.RESTClient(); restClient != nil {
+			_, err = restClient.Get().AbsPath("/version").Do(ctx).Raw()
+		} else {
+			_, err = client.ServerVersion()
+		}
+		if err != nil {
+			return fmt.Errorf("API server unreachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// Wrap serves the health endpoints, passing other requests to next
+func (h *Handler) Wrap(next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, h.serveLiveness)
+	mux.HandleFunc(ReadinessPath, h.serveReadiness)
+	mux.HandleFunc(VersionPath, h.serveVersion)
+	mux.Handle("/", next)
+	return mux
+}
+
+// serveLiveness reports the process is serving requests
+func (h *Handler) serveLiveness(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok"))
+}
+
+// serveReadiness reports whether the API server is reachable, so traffic is only routed to replicas that
+// can serve tool calls
+func (h *Handler) serveReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	if err := h.ready(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("ok"))
+}
+
+// serveVersion reports the build of the server
+func (h *Handler) serveVersion(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.version)
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandler(t *testing.T) {
+	var readyErr error
+	handler := NewHandler(Version{Version: "1.2.3", Commit: "abc", Date: "2025-05-01"}, func(context.Context) error {
+		return readyErr
+	}).Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		readyErr     error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "liveness",
+			path:         LivenessPath,
+			readyErr:     errors.New("down"),
+			expectedCode: http.StatusOK,
+			expectedBody: "ok",
+		},
+		{
+			name:         "ready",
+			path:         ReadinessPath,
+			expectedCode: http.StatusOK,
+			expectedBody: "ok",
+		},
+		{
+			name:         "not ready",
+			path:         ReadinessPath,
+			readyErr:     errors.New("API server unreachable: connection refused"),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "API server unreachable: connection refused\n",
+		},
+		{
+			name:         "version",
+			path:         VersionPath,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"version":"1.2.3","commit":"abc","date":"2025-05-01"}` + "\n",
+		},
+		{
+			name:         "other paths",
+			path:         "/mcp",
+			expectedCode: http.StatusTeapot,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			readyErr = tc.readyErr
+			w := serve(tc.path)
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Equal(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestDiscoveryPing(t *testing.T) {
+	client := fake.NewClientset()
+	require.NoError(t, DiscoveryPing(client.Discovery())(context.Background()))
+}
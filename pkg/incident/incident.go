@@ -0,0 +1,209 @@
+// Package incident implements incident mode for handing the server to an agent during an outage.
+// While an incident is active, write tools are locked down except an allow-list of remediation
+// actions, every tool call is reported to the client as a log notification, and recorded tool
+// calls are tagged with the incident ID. An incident lasts until the server restarts, so an agent
+// that is locked down cannot lift the lockdown.
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/log"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultAllowedTools are the write tools left enabled during an incident, covering the usual
+// mitigations of rolling back, restarting, scaling and isolating
+var DefaultAllowedTools = []string{
+	"rollout_undo",
+	"rollout_restart_deployment",
+	"scale_deployment",
+	"pause_deployment",
+	"resume_deployment",
+	"cordon_node",
+}
+
+// Actions of the incident_mode tool
+const (
+	ActionStart  = "start"
+	ActionStatus = "status"
+)
+
+// Status describes the incident mode
+type Status struct {
+	Active       bool       `json:"active"`
+	ID           string     `json:"incidentId,omitempty"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	AllowedTools []string   `json:"allowedTools"`
+}
+
+// Mode tracks the active incident
+type Mode struct {
+	allowed map[string]bool
+	now     func() time.Time
+
+	mu        sync.RWMutex
+	id        string
+	startedAt time.Time
+}
+
+// New creates an inactive incident mode allowing the given write tools during incidents
+func New(allowedTools []string) *Mode {
+	m := &Mode{allowed: make(map[string]bool, len(allowedTools)), now: time.Now}
+	for _, name := range allowedTools {
+		if name = strings.TrimSpace(name); name != "" {
+			m.allowed[name] = true
+		}
+	}
+	return m
+}
+
+// Start activates incident mode until the server restarts
+func (m *Mode) Start(id string) error {
+	if id == "" {
+		return fmt.Errorf("incident ID is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.id != "" {
+		return fmt.Errorf("incident %s is already active", m.id)
+	}
+	m.id, m.startedAt = id, m.now()
+	return nil
+}
+
+// ID returns the ID of the active incident, or an empty string
+func (m *Mode) ID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.id
+}
+
+// Status returns the current incident mode
+func (m *Mode) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := Status{Active: m.id != "", ID: m.id, AllowedTools: m.allowedTools()}
+	if status.Active {
+		startedAt := m.startedAt
+		status.StartedAt = &startedAt
+	}
+	return status
+}
+
+func (m *Mode) allowedTools() []string {
+	names := make([]string, 0, len(m.allowed))
+	for name := range m.allowed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Guard rejects calls of a write tool during an incident unless the tool is allowed
+func (m *Mode) Guard(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	if m.allowed[name] {
+		return tool
+	}
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if id := m.ID(); id != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("incident %s is active: %s is locked down, only %s may change the cluster",
+				id, name, strings.Join(m.allowedTools(), ", "))), nil
+		}
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// Notify reports every call of a tool during an incident to the calling client as a log
+// notification, so the humans watching the session see each action as it happens
+func (m *Mode) Notify(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := m.now()
+		result, err := next(ctx, request)
+
+		id := m.ID()
+		s := server.ServerFromContext(ctx)
+		if id == "" || s == nil {
+			return result, err
+		}
+		level, status := "notice", "ok"
+		if err != nil || (result != nil && result.IsError) {
+			level, status = "warning", "error"
+		}
+		// Notifications are best effort, a client that cannot receive them still gets the result
+		notifyErr := s.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+			"level":  level,
+			"logger": "incident",
+			"data": map[string]any{
+				"incidentId": id,
+				"tool":       name,
+				"status":     status,
+				"durationMs": m.now().Sub(start).Milliseconds(),
+			},
+		})
+		if notifyErr != nil {
+			log.FromContext(ctx).Debug().Err(notifyErr).Str("incidentId", id).Msg("Failed to send incident notification")
+		}
+		return result, err
+	}
+	return tool
+}
+
+// Tool creates a tool to start or inspect incident mode. It has no action to stop an incident, which
+// only a restart of the server ends, so an agent cannot lift a lockdown it is subject to.
+func (m *Mode) Tool() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("incident_mode",
+			mcp.WithDescription("Start or inspect incident mode. While an incident is active, write tools other than the allowed remediation actions are locked down, every tool call is reported as a log notification and recorded calls are tagged with the incident ID. An incident lasts until the server restarts"),
+			mcp.WithString("action",
+				mcp.Required(),
+				mcp.Description("start or status"),
+				mcp.Enum(ActionStart, ActionStatus),
+			),
+			mcp.WithString("incidentId",
+				mcp.Description("Incident ID, e.g. INC-1234 (required to start)"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			action, err := toolsets.RequiredParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			incidentID, err := toolsets.OptionalParam[string](request, "incidentId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			switch action {
+			case ActionStart:
+				err = m.Start(incidentID)
+			case ActionStatus:
+			default:
+				err = fmt.Errorf("invalid action %q: must be %s or %s", action, ActionStart, ActionStatus)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			r, err := json.Marshal(m.Status())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
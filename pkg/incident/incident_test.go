@@ -0,0 +1,143 @@
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// fakeSession is an initialized client session collecting its notifications
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s fakeSession) SessionID() string                                   { return "session" }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+func okTool(name string) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+}
+
+func TestGuard(t *testing.T) {
+	mode := New([]string{"rollout_undo", " scale_deployment "})
+	deletePod := mode.Guard(okTool("delete_pod"))
+	rollback := mode.Guard(okTool("rollout_undo"))
+
+	// Without an incident every tool runs
+	result, err := deletePod.Handler(context.Background(), createMCPRequest(nil))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	require.NoError(t, mode.Start("INC-42"))
+	result, err = deletePod.Handler(context.Background(), createMCPRequest(nil))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "incident INC-42 is active: delete_pod is locked down, only rollout_undo, scale_deployment may change the cluster", getTextResult(t, result).Text)
+
+	result, err = rollback.Handler(context.Background(), createMCPRequest(nil))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+}
+
+func TestNotify(t *testing.T) {
+	mode := New(DefaultAllowedTools)
+	srv := server.NewMCPServer("test", "1.0")
+	session := fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := srv.WithContext(context.Background(), session)
+	srv.AddTools(mode.Notify(okTool("list_pods")))
+	callListPods := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_pods"}}`)
+
+	srv.HandleMessage(ctx, callListPods)
+	assert.Empty(t, session.notifications)
+
+	require.NoError(t, mode.Start("INC-42"))
+	srv.HandleMessage(ctx, callListPods)
+
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	assert.Equal(t, "notifications/message", notification.Method)
+	params := notification.Params.AdditionalFields
+	assert.Equal(t, "notice", params["level"])
+	assert.Equal(t, "incident", params["logger"])
+	data := params["data"].(map[string]any)
+	assert.Equal(t, "INC-42", data["incidentId"])
+	assert.Equal(t, "list_pods", data["tool"])
+	assert.Equal(t, "ok", data["status"])
+}
+
+func TestIncidentModeTool(t *testing.T) {
+	mode := New([]string{"rollout_undo"})
+	tool, handler := mode.Tool()
+	assert.Equal(t, "incident_mode", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"action"})
+
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return result
+	}
+	status := func(result *mcp.CallToolResult) Status {
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var s Status
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &s))
+		return s
+	}
+
+	s := status(call(map[string]interface{}{"action": "status"}))
+	assert.False(t, s.Active)
+	assert.Equal(t, []string{"rollout_undo"}, s.AllowedTools)
+
+	result := call(map[string]interface{}{"action": "start"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "incident ID is required")
+
+	s = status(call(map[string]interface{}{"action": "start", "incidentId": "INC-42"}))
+	assert.True(t, s.Active)
+	assert.Equal(t, "INC-42", s.ID)
+	assert.NotNil(t, s.StartedAt)
+	assert.Equal(t, "INC-42", mode.ID())
+
+	result = call(map[string]interface{}{"action": "start", "incidentId": "INC-43"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "incident INC-42 is already active")
+
+	// An agent that is locked down cannot lift the lockdown
+	result = call(map[string]interface{}{"action": "stop"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, `invalid action "stop"`)
+	assert.Equal(t, "INC-42", mode.ID())
+
+	result = call(map[string]interface{}{"action": "resolve"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, `invalid action "resolve"`)
+}
@@ -0,0 +1,191 @@
+package informercache
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// cachedClientset is a clientset whose get and list calls of the cached resources are served from
+// the cache when made by read tools
+type cachedClientset struct {
+	kubernetes.Interface
+	cache *Cache
+}
+
+// WithCache wraps a clientset so the get and list calls read tools make for pods, services,
+// ConfigMaps, namespaces, nodes and deployments are served from shared informers run with the
+// clientset. Secrets are never cached.
+func WithCache(client kubernetes.Interface) kubernetes.Interface {
+	return &cachedClientset{Interface: client, cache: newCache(client)}
+}
+
+// Stop stops the informers of the clientset, once it has been replaced
+func (c *cachedClientset) Stop() {
+	c.cache.Stop()
+}
+
+func (c *cachedClientset) CoreV1() corev1client.CoreV1Interface {
+	return &cachedCoreV1{CoreV1Interface: c.Interface.CoreV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) AppsV1() appsv1client.AppsV1Interface {
+	return &cachedAppsV1{AppsV1Interface: c.Interface.AppsV1(), cache: c.cache}
+}
+
+type cachedCoreV1 struct {
+	corev1client.CoreV1Interface
+	cache *Cache
+}
+
+func (c *cachedCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return &cachedPods{PodInterface: c.CoreV1Interface.Pods(namespace), cache: c.cache, namespace: namespace}
+}
+
+func (c *cachedCoreV1) Services(namespace string) corev1client.ServiceInterface {
+	return &cachedServices{ServiceInterface: c.CoreV1Interface.Services(namespace), cache: c.cache, namespace: namespace}
+}
+
+func (c *cachedCoreV1) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	return &cachedConfigMaps{ConfigMapInterface: c.CoreV1Interface.ConfigMaps(namespace), cache: c.cache, namespace: namespace}
+}
+
+func (c *cachedCoreV1) Namespaces() corev1client.NamespaceInterface {
+	return &cachedNamespaces{NamespaceInterface: c.CoreV1Interface.Namespaces(), cache: c.cache}
+}
+
+func (c *cachedCoreV1) Nodes() corev1client.NodeInterface {
+	return &cachedNodes{NodeInterface: c.CoreV1Interface.Nodes(), cache: c.cache}
+}
+
+type cachedAppsV1 struct {
+	appsv1client.AppsV1Interface
+	cache *Cache
+}
+
+func (c *cachedAppsV1) Deployments(namespace string) appsv1client.DeploymentInterface {
+	return &cachedDeployments{DeploymentInterface: c.AppsV1Interface.Deployments(namespace), cache: c.cache, namespace: namespace}
+}
+
+type cachedPods struct {
+	corev1client.PodInterface
+	cache     *Cache
+	namespace string
+}
+
+func (p *cachedPods) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Pod, error) {
+	if pod, ok := get[corev1.Pod](ctx, p.cache, "pods", p.namespace, name, opts); ok {
+		return pod, nil
+	}
+	return p.PodInterface.Get(ctx, name, opts)
+}
+
+func (p *cachedPods) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	if items, listMeta, ok := list[corev1.Pod](ctx, p.cache, "pods", p.namespace, opts); ok {
+		return &corev1.PodList{ListMeta: listMeta, Items: items}, nil
+	}
+	return p.PodInterface.List(ctx, opts)
+}
+
+type cachedServices struct {
+	corev1client.ServiceInterface
+	cache     *Cache
+	namespace string
+}
+
+func (s *cachedServices) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Service, error) {
+	if service, ok := get[corev1.Service](ctx, s.cache, "services", s.namespace, name, opts); ok {
+		return service, nil
+	}
+	return s.ServiceInterface.Get(ctx, name, opts)
+}
+
+func (s *cachedServices) List(ctx context.Context, opts metav1.ListOptions) (*corev1.ServiceList, error) {
+	if items, listMeta, ok := list[corev1.Service](ctx, s.cache, "services", s.namespace, opts); ok {
+		return &corev1.ServiceList{ListMeta: listMeta, Items: items}, nil
+	}
+	return s.ServiceInterface.List(ctx, opts)
+}
+
+type cachedConfigMaps struct {
+	corev1client.ConfigMapInterface
+	cache     *Cache
+	namespace string
+}
+
+func (c *cachedConfigMaps) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+	if configMap, ok := get[corev1.ConfigMap](ctx, c.cache, "configmaps", c.namespace, name, opts); ok {
+		return configMap, nil
+	}
+	return c.ConfigMapInterface.Get(ctx, name, opts)
+}
+
+func (c *cachedConfigMaps) List(ctx context.Context, opts metav1.ListOptions) (*corev1.ConfigMapList, error) {
+	if items, listMeta, ok := list[corev1.ConfigMap](ctx, c.cache, "configmaps", c.namespace, opts); ok {
+		return &corev1.ConfigMapList{ListMeta: listMeta, Items: items}, nil
+	}
+	return c.ConfigMapInterface.List(ctx, opts)
+}
+
+type cachedNamespaces struct {
+	corev1client.NamespaceInterface
+	cache *Cache
+}
+
+func (n *cachedNamespaces) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Namespace, error) {
+	if namespace, ok := get[corev1.Namespace](ctx, n.cache, "namespaces", "", name, opts); ok {
+		return namespace, nil
+	}
+	return n.NamespaceInterface.Get(ctx, name, opts)
+}
+
+func (n *cachedNamespaces) List(ctx context.Context, opts metav1.ListOptions) (*corev1.NamespaceList, error) {
+	if items, listMeta, ok := list[corev1.Namespace](ctx, n.cache, "namespaces", "", opts); ok {
+		return &corev1.NamespaceList{ListMeta: listMeta, Items: items}, nil
+	}
+	return n.NamespaceInterface.List(ctx, opts)
+}
+
+type cachedNodes struct {
+	corev1client.NodeInterface
+	cache *Cache
+}
+
+func (n *cachedNodes) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Node, error) {
+	if node, ok := get[corev1.Node](ctx, n.cache, "nodes", "", name, opts); ok {
+		return node, nil
+	}
+	return n.NodeInterface.Get(ctx, name, opts)
+}
+
+func (n *cachedNodes) List(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error) {
+	if items, listMeta, ok := list[corev1.Node](ctx, n.cache, "nodes", "", opts); ok {
+		return &corev1.NodeList{ListMeta: listMeta, Items: items}, nil
+	}
+	return n.NodeInterface.List(ctx, opts)
+}
+
+type cachedDeployments struct {
+	appsv1client.DeploymentInterface
+	cache     *Cache
+	namespace string
+}
+
+func (d *cachedDeployments) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	if deployment, ok := get[appsv1.Deployment](ctx, d.cache, "deployments", d.namespace, name, opts); ok {
+		return deployment, nil
+	}
+	return d.DeploymentInterface.Get(ctx, name, opts)
+}
+
+func (d *cachedDeployments) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	if items, listMeta, ok := list[appsv1.Deployment](ctx, d.cache, "deployments", d.namespace, opts); ok {
+		return &appsv1.DeploymentList{ListMeta: listMeta, Items: items}, nil
+	}
+	return d.DeploymentInterface.List(ctx, opts)
+}
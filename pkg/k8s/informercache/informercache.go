@@ -0,0 +1,271 @@
+// Package informercache serves the get and list calls of read tools from shared informers, so
+// bursts of list calls from agents are answered from memory instead of the API server. Like a list
+// with resourceVersion=0, cached results may lag the cluster by a few seconds; write tools always
+// read from the API server.
+package informercache
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// continuePrefix marks the continue tokens of cached lists, which API server tokens never start with
+const continuePrefix = "cache."
+
+// newInformers create the informers of the cached resources
+var newInformers = map[string]func(informers.SharedInformerFactory) cache.SharedIndexInformer{
+	"pods": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Pods().Informer()
+	},
+	"services": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Services().Informer()
+	},
+	"configmaps": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().ConfigMaps().Informer()
+	},
+	"namespaces": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Namespaces().Informer()
+	},
+	"nodes": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Nodes().Informer()
+	},
+	"deployments": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().Deployments().Informer()
+	},
+}
+
+// Cache runs the shared informers of one client. An informer is started when a read tool first
+// gets or lists its resource, and serves calls once it has synced; until then, and for resources
+// the client may not list and watch, calls go to the API server.
+type Cache struct {
+	factory informers.SharedInformerFactory
+	stop    chan struct{}
+
+	mu        sync.Mutex
+	informers map[string]cache.SharedIndexInformer
+}
+
+// newCache creates a cache running its informers with client
+func newCache(client kubernetes.Interface) *Cache {
+	return &Cache{
+		factory:   informers.NewSharedInformerFactory(client, 0),
+		stop:      make(chan struct{}),
+		informers: make(map[string]cache.SharedIndexInformer),
+	}
+}
+
+// Stop stops the informers of the cache
+func (c *Cache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.stop:
+	default:
+		close(c.stop)
+	}
+}
+
+// informer returns the informer of a resource, starting it on first use
+func (c *Cache) informer(resource string) cache.SharedIndexInformer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if informer, ok := c.informers[resource]; ok {
+		return informer
+	}
+	informer := newInformers[resource](c.factory)
+	c.informers[resource] = informer
+	c.factory.Start(c.stop)
+	return informer
+}
+
+// synced returns the informer of a resource if it has synced, starting it on first use
+func (c *Cache) synced(resource string) (cache.SharedIndexInformer, bool) {
+	informer := c.informer(resource)
+	return informer, informer.HasSynced()
+}
+
+// served records the resources whose calls a read tool call got from the cache
+type served struct {
+	mu        sync.Mutex
+	resources []string
+}
+
+type servedKey struct{}
+
+func (s *served) add(resource string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.resources {
+		if r == resource {
+			return
+		}
+	}
+	s.resources = append(s.resources, resource)
+}
+
+// Wrap lets the get and list calls of a read tool be served from the cache of the client it uses,
+// and adds a note on their freshness to the results that were
+func Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		s := &served{}
+		result, err := next(context.WithValue(ctx, servedKey{}, s), request)
+		if err != nil || result == nil || result.IsError || len(s.resources) == 0 {
+			return result, err
+		}
+		sort.Strings(s.resources)
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+			"Note: %s were read from the server's informer cache, like a list with resourceVersion=0, and may lag the cluster by a few seconds.",
+			strings.Join(s.resources, " and "))))
+		return result, nil
+	}
+	return tool
+}
+
+// get returns an object from the cache, or false when the call must go to the API server: for
+// calls not made by read tools, objects not in the cache and reads of a specific resourceVersion
+func get[T any, PT interface {
+	*T
+	runtime.Object
+}](ctx context.Context, c *Cache, resource, namespace, name string, opts metav1.GetOptions) (PT, bool) {
+	s, ok := ctx.Value(servedKey{}).(*served)
+	if !ok || (opts.ResourceVersion != "" && opts.ResourceVersion != "0") {
+		return nil, false
+	}
+	informer, ok := c.synced(resource)
+	if !ok {
+		return nil, false
+	}
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	// Objects missing from the cache, such as objects outside a label selector scope or created
+	// a moment ago, are looked up on the API server
+	obj, exists, err := informer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil, false
+	}
+	s.add(resource)
+	return obj.(PT).DeepCopyObject().(PT), true
+}
+
+// list returns the objects of a list call from the cache, paginated with continue tokens of the
+// cache, or false when the call must go to the API server: for calls not made by read tools,
+// continue tokens of the API server, reads of a specific resourceVersion and field selectors on
+// fields the cache cannot match
+func list[T any, PT interface {
+	*T
+	runtime.Object
+}](ctx context.Context, c *Cache, resource, namespace string, opts metav1.ListOptions) ([]T, metav1.ListMeta, bool) {
+	s, ok := ctx.Value(servedKey{}).(*served)
+	if !ok || opts.Watch || (opts.ResourceVersion != "" && opts.ResourceVersion != "0") || opts.ResourceVersionMatch != "" {
+		return nil, metav1.ListMeta{}, false
+	}
+	after := ""
+	if opts.Continue != "" {
+		if !strings.HasPrefix(opts.Continue, continuePrefix) {
+			return nil, metav1.ListMeta{}, false
+		}
+		key, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(opts.Continue, continuePrefix))
+		if err != nil {
+			return nil, metav1.ListMeta{}, false
+		}
+		after = string(key)
+	}
+	labelSelector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, metav1.ListMeta{}, false
+	}
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, metav1.ListMeta{}, false
+	}
+	for _, requirement := range fieldSelector.Requirements() {
+		if !selectable(resource, requirement.Field) {
+			return nil, metav1.ListMeta{}, false
+		}
+	}
+	informer, ok := c.synced(resource)
+	if !ok {
+		return nil, metav1.ListMeta{}, false
+	}
+
+	objs := informer.GetStore().List()
+	if namespace != "" {
+		if objs, err = informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace); err != nil {
+			return nil, metav1.ListMeta{}, false
+		}
+	}
+	type keyed struct {
+		key string
+		obj PT
+	}
+	var matched []keyed
+	for _, obj := range objs {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(accessor.GetLabels())) || !fieldSelector.Matches(fieldSet(obj, accessor)) {
+			continue
+		}
+		key, _ := cache.MetaNamespaceKeyFunc(obj)
+		if key > after {
+			matched = append(matched, keyed{key: key, obj: obj.(PT)})
+		}
+	}
+	// The API server returns objects in the order of their keys, which continue tokens rely on
+	sort.Slice(matched, func(i, j int) bool { return matched[i].key < matched[j].key })
+
+	list := metav1.ListMeta{ResourceVersion: informer.LastSyncResourceVersion()}
+	if opts.Limit > 0 && int64(len(matched)) > opts.Limit {
+		remaining := int64(len(matched)) - opts.Limit
+		matched = matched[:opts.Limit]
+		list.Continue = continuePrefix + base64.RawURLEncoding.EncodeToString([]byte(matched[len(matched)-1].key))
+		list.RemainingItemCount = &remaining
+	}
+	items := make([]T, 0, len(matched))
+	for _, m := range matched {
+		items = append(items, *m.obj.DeepCopyObject().(PT))
+	}
+	s.add(resource)
+	return items, list, true
+}
+
+// selectable reports whether the cache can match a field selector on a field of a resource: the
+// metadata fields of every resource and the pod fields tools select on
+func selectable(resource, field string) bool {
+	switch field {
+	case "metadata.name", "metadata.namespace":
+		return true
+	case "spec.nodeName", "status.phase":
+		return resource == "pods"
+	}
+	return false
+}
+
+func fieldSet(obj interface{}, accessor metav1.Object) fields.Set {
+	set := fields.Set{"metadata.name": accessor.GetName(), "metadata.namespace": accessor.GetNamespace()}
+	if pod, ok := obj.(*corev1.Pod); ok {
+		set["spec.nodeName"] = pod.Spec.NodeName
+		set["status.phase"] = string(pod.Status.Phase)
+	}
+	return set
+}
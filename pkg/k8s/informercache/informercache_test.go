@@ -0,0 +1,220 @@
+package informercache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newClient(t *testing.T) (kubernetes.Interface, *fake.Clientset) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{NodeName: "worker-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{NodeName: "worker-2"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "shop", Labels: map[string]string{"app": "db"}}, Spec: corev1.PodSpec{NodeName: "worker-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	)
+	client := WithCache(clientset)
+	t.Cleanup(client.(*cachedClientset).cache.Stop)
+	return client, clientset
+}
+
+// readCtx returns the context of a read tool call and the resources it got from the cache
+func readCtx() (context.Context, *served) {
+	s := &served{}
+	return context.WithValue(context.Background(), servedKey{}, s), s
+}
+
+// synced lists a resource until its informer has synced
+func synced(t *testing.T, client kubernetes.Interface, resource string) {
+	client.(*cachedClientset).cache.informer(resource)
+	require.Eventually(t, func() bool {
+		_, ok := client.(*cachedClientset).cache.synced(resource)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func names(pods []corev1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
+
+func TestList(t *testing.T) {
+	client, _ := newClient(t)
+	synced(t, client, "pods")
+
+	tests := []struct {
+		name      string
+		namespace string
+		opts      metav1.ListOptions
+		expected  []string
+	}{
+		{
+			name:     "all namespaces",
+			expected: []string{"default/web-2", "shop/db-0", "shop/web-0", "shop/web-1"},
+		},
+		{
+			name:      "namespace",
+			namespace: "shop",
+			expected:  []string{"shop/db-0", "shop/web-0", "shop/web-1"},
+		},
+		{
+			name:      "label selector",
+			namespace: "shop",
+			opts:      metav1.ListOptions{LabelSelector: "app=web"},
+			expected:  []string{"shop/web-0", "shop/web-1"},
+		},
+		{
+			name:     "field selector",
+			opts:     metav1.ListOptions{FieldSelector: "spec.nodeName=worker-1"},
+			expected: []string{"shop/db-0", "shop/web-1"},
+		},
+		{
+			name:      "resourceVersion 0",
+			namespace: "default",
+			opts:      metav1.ListOptions{ResourceVersion: "0"},
+			expected:  []string{"default/web-2"},
+		},
+		{
+			name:      "no match",
+			namespace: "shop",
+			opts:      metav1.ListOptions{LabelSelector: "app=cache"},
+			expected:  []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, s := readCtx()
+			pods, err := client.CoreV1().Pods(tc.namespace).List(ctx, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, names(pods.Items))
+			assert.Equal(t, []string{"pods"}, s.resources)
+		})
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	client, _ := newClient(t)
+	synced(t, client, "pods")
+
+	ctx, _ := readCtx()
+	var pages [][]string
+	opts := metav1.ListOptions{Limit: 3}
+	for {
+		pods, err := client.CoreV1().Pods("").List(ctx, opts)
+		require.NoError(t, err)
+		pages = append(pages, names(pods.Items))
+		if pods.Continue == "" {
+			assert.Nil(t, pods.RemainingItemCount)
+			break
+		}
+		assert.Equal(t, int64(1), *pods.RemainingItemCount)
+		opts.Continue = pods.Continue
+	}
+	assert.Equal(t, [][]string{{"default/web-2", "shop/db-0", "shop/web-0"}, {"shop/web-1"}}, pages)
+}
+
+func TestFallback(t *testing.T) {
+	client, clientset := newClient(t)
+	synced(t, client, "pods")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opts metav1.ListOptions
+	}{
+		{name: "write tool", ctx: context.Background()},
+		{name: "continue token of the API server", opts: metav1.ListOptions{Continue: "eyJ2IjoibWV0YS5rOHMuaW8vdjEifQ"}},
+		{name: "specific resourceVersion", opts: metav1.ListOptions{ResourceVersion: "42"}},
+		{name: "resourceVersionMatch", opts: metav1.ListOptions{ResourceVersion: "0", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan}},
+		{name: "unsupported field selector", opts: metav1.ListOptions{FieldSelector: "spec.restartPolicy=Always"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, s := readCtx()
+			if tc.ctx != nil {
+				ctx = tc.ctx
+			}
+			calls := len(clientset.Actions())
+			_, _ = client.CoreV1().Pods("shop").List(ctx, tc.opts)
+			assert.Equal(t, calls+1, len(clientset.Actions()), "list must go to the API server")
+			assert.Empty(t, s.resources)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	client, clientset := newClient(t)
+	synced(t, client, "deployments")
+	calls := len(clientset.Actions())
+
+	ctx, s := readCtx()
+	deployment, err := client.AppsV1().Deployments("shop").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web", deployment.Name)
+	assert.Equal(t, []string{"deployments"}, s.resources)
+	assert.Equal(t, calls, len(clientset.Actions()))
+
+	// Changing a served object leaves the cache untouched
+	deployment.Labels = map[string]string{"changed": "true"}
+	deployment, err = client.AppsV1().Deployments("shop").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Labels)
+
+	// Objects missing from the cache are looked up on the API server
+	_, err = client.AppsV1().Deployments("shop").Get(ctx, "api", metav1.GetOptions{})
+	require.Error(t, err)
+	assert.Equal(t, calls+1, len(clientset.Actions()))
+
+	// Write tools always read from the API server
+	_, err = client.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, calls+2, len(clientset.Actions()))
+}
+
+func TestWrap(t *testing.T) {
+	client, _ := newClient(t)
+	synced(t, client, "pods")
+
+	listPods := func(namespace string) server.ServerTool {
+		return Wrap(server.ServerTool{
+			Tool: mcp.NewTool("list_pods"),
+			Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{ResourceVersion: "42"})
+				if err != nil {
+					return nil, err
+				}
+				if namespace == "" {
+					pods, err = client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+				}
+				return mcp.NewToolResultText(names(pods.Items)[0]), nil
+			},
+		})
+	}
+
+	// Results served from the cache note their freshness
+	result, err := listPods("").Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "pods were read from the server's informer cache")
+
+	// Results read from the API server do not
+	result, err = listPods("shop").Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Len(t, result.Content, 1)
+}
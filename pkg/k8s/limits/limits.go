@@ -0,0 +1,127 @@
+// Package limits restricts the tools of a resource type with settings from the config file: the
+// namespaces their calls may target and how often they may be called. Calls breaking a limit are
+// rejected without reaching the API server. It also lets agents bound how long a call may run.
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// namespaceParam is the tool parameter naming the namespace of a call
+const namespaceParam = "namespace"
+
+// Settings restrict the tools of a resource type
+type Settings struct {
+	// Namespaces are the only namespaces the tools may target, any namespace when empty
+	Namespaces []string `mapstructure:"namespaces"`
+	// RateLimit is the number of calls per minute shared by the tools, unlimited when 0. Up to
+	// RateLimit calls may be made at once after a quiet minute.
+	RateLimit int `mapstructure:"rate-limit"`
+}
+
+// Validate checks that the settings are valid
+func (s Settings) Validate() error {
+	if s.RateLimit < 0 {
+		return fmt.Errorf("rate-limit must not be negative")
+	}
+	for _, namespace := range s.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// RateLimited is the error returned, JSON encoded, for calls over the rate limit
+type RateLimited struct {
+	Error             string `json:"error"`
+	Tool              string `json:"tool"`
+	ResourceType      string `json:"resourceType"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+}
+
+// Limiter enforces the settings of a resource type on its tools
+type Limiter struct {
+	resourceType string
+	settings     Settings
+	allowed      map[string]bool
+	limiter      *rate.Limiter
+	now          func() time.Time
+}
+
+// New creates a Limiter enforcing settings on the tools of resourceType. The tools it wraps share
+// a single rate limit.
+func New(resourceType string, settings Settings) *Limiter {
+	l := &Limiter{
+		resourceType: resourceType,
+		settings:     settings,
+		allowed:      map[string]bool{},
+		now:          time.Now,
+	}
+	for _, namespace := range settings.Namespaces {
+		l.allowed[namespace] = true
+	}
+	if settings.RateLimit > 0 {
+		l.limiter = rate.NewLimiter(rate.Limit(float64(settings.RateLimit)/60), settings.RateLimit)
+	}
+	return l
+}
+
+// Wrap rejects calls of a tool targeting a namespace outside of the allowed namespaces, and calls
+// over the rate limit. Tools without a namespace parameter, such as those of cluster-scoped
+// resources, are only rate limited.
+func (l *Limiter) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	_, namespaced := tool.Tool.InputSchema.Properties[namespaceParam]
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if namespaced && len(l.allowed) > 0 {
+			namespace, _ := request.GetArguments()[namespaceParam].(string)
+			if !l.allowed[namespace] {
+				return mcp.NewToolResultError(fmt.Sprintf("the %s tools are limited to the namespaces %s; set namespace to one of them",
+					l.resourceType, strings.Join(l.settings.Namespaces, ", "))), nil
+			}
+		}
+
+		if rejection := l.reserve(name); rejection != nil {
+			r, err := json.Marshal(rejection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultError(string(r)), nil
+		}
+
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// reserve takes a call from the rate limit, returning the rejection when none is left
+func (l *Limiter) reserve(tool string) *RateLimited {
+	if l.limiter == nil {
+		return nil
+	}
+	now := l.now()
+	reservation := l.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	reservation.CancelAt(now)
+	return &RateLimited{
+		Error:             fmt.Sprintf("the %s tools are limited to %d calls per minute; retry later", l.resourceType, l.settings.RateLimit),
+		Tool:              tool,
+		ResourceType:      l.resourceType,
+		RetryAfterSeconds: int(math.Ceil(delay.Seconds())),
+	}
+}
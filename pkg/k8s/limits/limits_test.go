@@ -0,0 +1,97 @@
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTool(name string, opts ...mcp.ToolOption) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name, opts...),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+}
+
+func call(t *testing.T, tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	return result
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestWrapNamespaces(t *testing.T) {
+	limiter := New("pod", Settings{Namespaces: []string{"payments", "checkout"}})
+	getPod := limiter.Wrap(newTool("get_pod", mcp.WithString("namespace"), mcp.WithString("name")))
+	listNodes := limiter.Wrap(newTool("list_nodes"))
+
+	assert.False(t, call(t, getPod, map[string]interface{}{"namespace": "payments", "name": "api-0"}).IsError)
+
+	result := call(t, getPod, map[string]interface{}{"namespace": "kube-system", "name": "etcd-0"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "the pod tools are limited to the namespaces payments, checkout; set namespace to one of them", resultText(result))
+
+	// Calls relying on the default namespace must name an allowed one
+	assert.True(t, call(t, getPod, map[string]interface{}{"name": "api-0"}).IsError)
+
+	// Lists across all namespaces would leave the allowed ones
+	listPods := limiter.Wrap(newTool("list_pods", mcp.WithString("namespace"), mcp.WithBoolean("allNamespaces")))
+	assert.True(t, call(t, listPods, map[string]interface{}{"namespace": "*"}).IsError)
+	assert.True(t, call(t, listPods, map[string]interface{}{"allNamespaces": true}).IsError)
+
+	// Tools without a namespace parameter are not restricted
+	assert.False(t, call(t, listNodes, nil).IsError)
+}
+
+func TestWrapRateLimit(t *testing.T) {
+	limiter := New("deployment", Settings{RateLimit: 2})
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return clock }
+
+	getDeployment := limiter.Wrap(newTool("get_deployment"))
+	listDeployments := limiter.Wrap(newTool("list_deployments"))
+
+	assert.False(t, call(t, getDeployment, nil).IsError)
+	assert.False(t, call(t, listDeployments, nil).IsError)
+
+	// The tools of a resource type share the limit
+	result := call(t, getDeployment, nil)
+	require.True(t, result.IsError)
+	var rejection RateLimited
+	require.NoError(t, json.Unmarshal([]byte(resultText(result)), &rejection))
+	assert.Equal(t, RateLimited{
+		Error:             "the deployment tools are limited to 2 calls per minute; retry later",
+		Tool:              "get_deployment",
+		ResourceType:      "deployment",
+		RetryAfterSeconds: 30,
+	}, rejection)
+
+	// Rejected calls do not use up the limit
+	clock = clock.Add(30 * time.Second)
+	assert.False(t, call(t, listDeployments, nil).IsError)
+	assert.True(t, call(t, listDeployments, nil).IsError)
+}
+
+func TestWrapUnlimited(t *testing.T) {
+	tool := New("configmap", Settings{}).Wrap(newTool("get_configmap", mcp.WithString("namespace")))
+	for i := 0; i < 100; i++ {
+		require.False(t, call(t, tool, map[string]interface{}{"namespace": "default"}).IsError)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Settings{Namespaces: []string{"payments"}, RateLimit: 60}.Validate())
+	assert.ErrorContains(t, Settings{RateLimit: -1}.Validate(), "rate-limit must not be negative")
+	assert.ErrorContains(t, Settings{Namespaces: []string{"Payments"}}.Validate(), `invalid namespace "Payments"`)
+}
@@ -0,0 +1,53 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TimeoutParam is the tool parameter bounding how long a call may run
+const TimeoutParam = "timeoutSeconds"
+
+// MaxTimeoutSeconds is the longest timeout a call may set
+const MaxTimeoutSeconds = 3600
+
+// WithTimeoutParam adds the "timeoutSeconds" parameter to a tool and cancels the call once that
+// many seconds have passed, so a call against a slow API server fails instead of hanging. Tools
+// defining the parameter already, such as those waiting for a condition, keep their own meaning
+// of it and are returned unchanged.
+func WithTimeoutParam(tool server.ServerTool) server.ServerTool {
+	if _, ok := tool.Tool.InputSchema.Properties[TimeoutParam]; ok {
+		return tool
+	}
+	mcp.WithNumber(TimeoutParam,
+		mcp.Description(fmt.Sprintf("Seconds after which the call is cancelled (max %d, default no timeout)", MaxTimeoutSeconds)),
+	)(&tool.Tool)
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		seconds, ok := request.GetArguments()[TimeoutParam]
+		if !ok || seconds == nil {
+			return next(ctx, request)
+		}
+		timeoutSeconds, ok := seconds.(float64)
+		if !ok || timeoutSeconds <= 0 || timeoutSeconds > MaxTimeoutSeconds {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be a number between 1 and %d", TimeoutParam, MaxTimeoutSeconds)), nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds*float64(time.Second)))
+		defer cancel()
+		result, err := next(ctx, request)
+		// Report the timeout rather than the error of whichever request it cancelled
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && (err != nil || result == nil || result.IsError) {
+			return mcp.NewToolResultError(fmt.Sprintf("%s timed out after %gs; retry with a larger %s or a narrower request",
+				tool.Tool.Name, timeoutSeconds, TimeoutParam)), nil
+		}
+		return result, err
+	}
+	return tool
+}
@@ -0,0 +1,93 @@
+package limits
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowTool waits for its context or the given delay, like a call to a slow API server
+func slowTool(delay time.Duration) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_pods"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+				return mcp.NewToolResultText("ok"), nil
+			}
+		},
+	}
+}
+
+func TestWithTimeoutParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		args     map[string]interface{}
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "no timeout",
+			delay:    10 * time.Millisecond,
+			expected: "ok",
+		},
+		{
+			name:     "within the timeout",
+			delay:    10 * time.Millisecond,
+			args:     map[string]interface{}{"timeoutSeconds": float64(5)},
+			expected: "ok",
+		},
+		{
+			name:     "timed out",
+			delay:    time.Minute,
+			args:     map[string]interface{}{"timeoutSeconds": 0.05},
+			expected: "list_pods timed out after 0.05s; retry with a larger timeoutSeconds or a narrower request",
+			isError:  true,
+		},
+		{
+			name:     "too long",
+			args:     map[string]interface{}{"timeoutSeconds": float64(MaxTimeoutSeconds + 1)},
+			expected: "timeoutSeconds must be a number between 1 and 3600",
+			isError:  true,
+		},
+		{
+			name:     "not a number",
+			args:     map[string]interface{}{"timeoutSeconds": "10"},
+			expected: "timeoutSeconds must be a number between 1 and 3600",
+			isError:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := WithTimeoutParam(slowTool(tc.delay))
+			assert.Contains(t, tool.Tool.InputSchema.Properties, TimeoutParam)
+
+			result := call(t, tool, tc.args)
+			assert.Equal(t, tc.isError, result.IsError)
+			assert.Equal(t, tc.expected, result.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestWithTimeoutParamOwnParam(t *testing.T) {
+	tool := server.ServerTool{
+		Tool: mcp.NewTool("wait_for_condition", mcp.WithNumber(TimeoutParam, mcp.Description("Seconds to wait"))),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			_, hasDeadline := ctx.Deadline()
+			require.False(t, hasDeadline)
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+	wrapped := WithTimeoutParam(tool)
+	assert.Equal(t, "Seconds to wait", wrapped.Tool.InputSchema.Properties[TimeoutParam].(map[string]interface{})["description"])
+	result := call(t, wrapped, map[string]interface{}{"timeoutSeconds": 0.01})
+	assert.Equal(t, "ok", result.Content[0].(mcp.TextContent).Text)
+}
@@ -0,0 +1,207 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// lastAppliedAnnotation is the annotation kubectl apply stores the previous manifest in
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Change operations
+const (
+	ChangeAdd     = "add"
+	ChangeRemove  = "remove"
+	ChangeReplace = "replace"
+)
+
+// Change is a single field that differs between the live and the desired state of an object.
+// Paths index lists of named items, such as containers, by name: spec.containers[name=web].image
+type Change struct {
+	Path    string      `json:"path"`
+	Op      string      `json:"op"`
+	Live    interface{} `json:"live,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// Changes reports every field where desired differs from live, sorted by path. Both objects are
+// normalized first: the status stanza, metadata owned by the API server and the last applied
+// configuration are dropped, so only changes to the specification are reported.
+func Changes(live, desired *unstructured.Unstructured) []Change {
+	var changes []Change
+	changes = changesOf("", normalize(live), normalize(desired), changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Merge returns a copy of live with the fields of desired set on it. Maps are merged and other
+// values, lists included, are replaced. It approximates the result of applying desired when the
+// API server cannot compute it.
+func Merge(live, desired *unstructured.Unstructured) *unstructured.Unstructured {
+	merged := live.DeepCopy()
+	merged.Object = merge(merged.Object, desired.DeepCopy().Object)
+	return merged
+}
+
+func merge(dst, src map[string]interface{}) map[string]interface{} {
+	for key, value := range src {
+		srcMap, srcOK := value.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			dst[key] = merge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+// normalize returns the fields of an object that an apply can change
+func normalize(obj *unstructured.Unstructured) map[string]interface{} {
+	normalized := obj.DeepCopy().Object
+	delete(normalized, "status")
+	metadata, ok := normalized["metadata"].(map[string]interface{})
+	if !ok {
+		return normalized
+	}
+	for field := range ignoredMetadata {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		delete(annotations, lastAppliedAnnotation)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	return normalized
+}
+
+func changesOf(path string, live, desired interface{}, changes []Change) []Change {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range unionKeys(l, d) {
+			lv, inLive := l[key]
+			dv, inDesired := d[key]
+			field := joinPath(path, key)
+			switch {
+			case !inLive:
+				changes = append(changes, Change{Path: field, Op: ChangeAdd, Desired: dv})
+			case !inDesired:
+				changes = append(changes, Change{Path: field, Op: ChangeRemove, Live: lv})
+			default:
+				changes = changesOf(field, lv, dv, changes)
+			}
+		}
+		return changes
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			break
+		}
+		if liveNames, desiredNames := itemNames(l), itemNames(d); liveNames != nil && desiredNames != nil {
+			return namedListChanges(path, l, d, liveNames, desiredNames, changes)
+		}
+		for i := 0; i < len(l) || i < len(d); i++ {
+			item := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(l):
+				changes = append(changes, Change{Path: item, Op: ChangeAdd, Desired: d[i]})
+			case i >= len(d):
+				changes = append(changes, Change{Path: item, Op: ChangeRemove, Live: l[i]})
+			default:
+				changes = changesOf(item, l[i], d[i], changes)
+			}
+		}
+		return changes
+	default:
+		if scalarEqual(live, desired) {
+			return changes
+		}
+	}
+	return append(changes, Change{Path: path, Op: ChangeReplace, Live: live, Desired: desired})
+}
+
+// namedListChanges compares lists of named items, such as containers or ports, item by item
+func namedListChanges(path string, live, desired []interface{}, liveNames, desiredNames map[string]int, changes []Change) []Change {
+	names := make([]string, 0, len(liveNames)+len(desiredNames))
+	for name := range liveNames {
+		names = append(names, name)
+	}
+	for name := range desiredNames {
+		if _, ok := liveNames[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		item := fmt.Sprintf("%s[name=%s]", path, name)
+		li, inLive := liveNames[name]
+		di, inDesired := desiredNames[name]
+		switch {
+		case !inLive:
+			changes = append(changes, Change{Path: item, Op: ChangeAdd, Desired: desired[di]})
+		case !inDesired:
+			changes = append(changes, Change{Path: item, Op: ChangeRemove, Live: live[li]})
+		default:
+			changes = changesOf(item, live[li], desired[di], changes)
+		}
+	}
+	return changes
+}
+
+// itemNames indexes the items of a list by their name field, returning nil unless every item is
+// a map with a distinct name
+func itemNames(list []interface{}) map[string]int {
+	if len(list) == 0 {
+		return nil
+	}
+	names := make(map[string]int, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, ok := m["name"].(string)
+		if !ok {
+			return nil
+		}
+		if _, dup := names[name]; dup {
+			return nil
+		}
+		names[name] = i
+	}
+	return names
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		key = fmt.Sprintf("[%q]", key)
+		return path + key
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,268 @@
+// Package manifest decodes Kubernetes manifests and applies them through the dynamic client,
+// resolving kinds to resources with API discovery.
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// DefaultFieldManager is the field manager used for server-side apply when none is given
+const DefaultFieldManager = "k8s-mcp-server"
+
+// Decode splits a YAML or JSON manifest, single or multi-document, into unstructured objects.
+// Empty documents are skipped and List kinds are flattened into their items.
+func Decode(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objects []*unstructured.Unstructured
+	for i := 1; ; i++ {
+		raw := map[string]interface{}{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode document %d: %v", i, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode list in document %d: %v", i, err)
+			}
+			for j := range list.Items {
+				objects = append(objects, &list.Items[j])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	for i, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("object %d is missing apiVersion or kind", i+1)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("object %d (%s) is missing metadata.name", i+1, obj.GetKind())
+		}
+	}
+	return objects, nil
+}
+
+// Client resolves objects to their API resources and reads or writes them with the dynamic client
+type Client struct {
+	discovery discovery.DiscoveryInterface
+	dynamic   dynamic.Interface
+	mapper    meta.RESTMapper
+}
+
+// NewClient builds a Client, discovering the API resources served by the cluster
+func NewClient(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface) (*Client, error) {
+	mapper, err := newMapper(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		discovery: discoveryClient,
+		dynamic:   dynamicClient,
+		mapper:    mapper,
+	}, nil
+}
+
+func newMapper(discoveryClient discovery.DiscoveryInterface) (meta.RESTMapper, error) {
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+// restMapping resolves a kind to its resource. A cached discovery client may predate a CRD
+// installed since it was filled, so an unknown kind invalidates the cache and is resolved again.
+func (c *Client) restMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	cached, ok := c.discovery.(discovery.CachedDiscoveryInterface)
+	if !meta.IsNoMatchError(err) || !ok {
+		return mapping, err
+	}
+	cached.Invalidate()
+	mapper, refreshErr := newMapper(cached)
+	if refreshErr != nil {
+		return nil, err
+	}
+	c.mapper = mapper
+	return c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// ResourceFor returns the dynamic resource interface for an object. Namespaced objects
+// without a namespace are defaulted to defaultNamespace; cluster-scoped objects have
+// their namespace cleared.
+func (c *Client) ResourceFor(obj *unstructured.Unstructured, defaultNamespace string) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.restMapping(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %v", gvk, err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return c.dynamic.Resource(mapping.Resource), nil
+	}
+	if obj.GetNamespace() == "" {
+		if defaultNamespace == "" {
+			defaultNamespace = metav1.NamespaceDefault
+		}
+		obj.SetNamespace(defaultNamespace)
+	}
+	return c.dynamic.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// ApplyOptions configures a server-side apply
+type ApplyOptions struct {
+	FieldManager     string
+	Force            bool
+	DryRun           bool
+	DefaultNamespace string
+}
+
+// Apply server-side applies an object and returns the result reported by the API server
+func (c *Client) Apply(ctx context.Context, obj *unstructured.Unstructured, opts ApplyOptions) (*unstructured.Unstructured, error) {
+	resource, err := c.ResourceFor(obj, opts.DefaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	applyOptions := metav1.ApplyOptions{FieldManager: fieldManager, Force: opts.Force}
+	if opts.DryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	return resource.Apply(ctx, obj.GetName(), obj, applyOptions)
+}
+
+// Get fetches the live state of an object
+func (c *Client) Get(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string) (*unstructured.Unstructured, error) {
+	resource, err := c.ResourceFor(obj, defaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+}
+
+// Ref returns a human readable reference such as "apps/v1 Deployment shop/web"
+func Ref(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return fmt.Sprintf("%s %s %s", obj.GetAPIVersion(), obj.GetKind(), name)
+}
+
+// ignoredMetadata are metadata fields owned by the API server that never count as drift
+var ignoredMetadata = map[string]bool{
+	"creationTimestamp": true,
+	"generation":        true,
+	"managedFields":     true,
+	"resourceVersion":   true,
+	"selfLink":          true,
+	"uid":               true,
+}
+
+// Diff reports the field paths where the live object differs from the desired one.
+// Only fields present in desired are compared, so defaults and fields set by controllers
+// are not reported as drift. The status stanza is ignored.
+func Diff(desired, live *unstructured.Unstructured) []string {
+	var differences []string
+	for key, value := range desired.Object {
+		switch key {
+		case "status":
+			continue
+		case "metadata":
+			desiredMeta, _ := value.(map[string]interface{})
+			liveMeta, _ := live.Object["metadata"].(map[string]interface{})
+			for field, v := range desiredMeta {
+				if ignoredMetadata[field] {
+					continue
+				}
+				differences = compare("metadata."+field, v, liveMeta[field], differences)
+			}
+		default:
+			differences = compare(key, value, live.Object[key], differences)
+		}
+	}
+	sort.Strings(differences)
+	return differences
+}
+
+func compare(path string, desired, live interface{}, differences []string) []string {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return append(differences, path)
+		}
+		for key, value := range d {
+			differences = compare(path+"."+key, value, l[key], differences)
+		}
+		return differences
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return append(differences, path)
+		}
+		for i := range d {
+			differences = compare(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], differences)
+		}
+		return differences
+	default:
+		if !scalarEqual(desired, live) {
+			return append(differences, path)
+		}
+		return differences
+	}
+}
+
+// scalarEqual compares scalars, treating numbers of different Go types as equal when their values are
+func scalarEqual(a, b interface{}) bool {
+	af, aNum := toFloat(a)
+	bf, bNum := toFloat(b)
+	if aNum && bNum {
+		return af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
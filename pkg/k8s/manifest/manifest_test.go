@@ -0,0 +1,341 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	namespacesGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// Helper function to create a fake discovery client serving deployments and namespaces
+func newFakeDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment", Namespaced: true}},
+				},
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "namespaces", Kind: "Namespace", Namespaced: false}},
+				},
+			},
+		},
+	}
+}
+
+// Helper function to create a fake dynamic client whose server-side apply creates missing objects
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			deploymentsGVR: "DeploymentList",
+			namespacesGVR:  "NamespaceList",
+		}, objects...)
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patch.GetPatch(), &obj.Object); err != nil {
+			return true, nil, err
+		}
+		_, err := client.Tracker().Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if apierrors.IsNotFound(err) {
+			err = client.Tracker().Create(patch.GetResource(), obj, patch.GetNamespace())
+		} else if err == nil {
+			err = client.Tracker().Update(patch.GetResource(), obj, patch.GetNamespace())
+		}
+		return true, obj, err
+	})
+	return client
+}
+
+const testManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+---
+# comment only documents are skipped
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+`
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name           string
+		manifest       string
+		expectedKinds  []string
+		expectedErrMsg string
+	}{
+		{
+			name:          "multi-document yaml",
+			manifest:      testManifest,
+			expectedKinds: []string{"Namespace", "Deployment"},
+		},
+		{
+			name:          "json object",
+			manifest:      `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"}}`,
+			expectedKinds: []string{"ConfigMap"},
+		},
+		{
+			name:          "list kind is flattened",
+			manifest:      `{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"}},{"apiVersion":"v1","kind":"Secret","metadata":{"name":"b"}}]}`,
+			expectedKinds: []string{"ConfigMap", "Secret"},
+		},
+		{
+			name:           "missing kind",
+			manifest:       "apiVersion: v1\nmetadata:\n  name: x\n",
+			expectedErrMsg: "missing apiVersion or kind",
+		},
+		{
+			name:           "missing name",
+			manifest:       "apiVersion: v1\nkind: ConfigMap\n",
+			expectedErrMsg: "missing metadata.name",
+		},
+		{
+			name:           "invalid yaml",
+			manifest:       "apiVersion: v1\nkind: [",
+			expectedErrMsg: "failed to decode document 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			objects, err := Decode([]byte(tc.manifest))
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var kinds []string
+			for _, obj := range objects {
+				kinds = append(kinds, obj.GetKind())
+			}
+			assert.Equal(t, tc.expectedKinds, kinds)
+		})
+	}
+}
+
+func TestClientApply(t *testing.T) {
+	objects, err := Decode([]byte(testManifest))
+	require.NoError(t, err)
+
+	dynamicClient := newFakeDynamicClient()
+	client, err := NewClient(newFakeDiscovery(), dynamicClient)
+	require.NoError(t, err)
+
+	for _, obj := range objects {
+		_, err := client.Apply(context.Background(), obj, ApplyOptions{DefaultNamespace: "shop"})
+		require.NoError(t, err)
+	}
+
+	// Namespaced objects are defaulted, cluster-scoped objects are left without a namespace
+	assert.Equal(t, "", objects[0].GetNamespace())
+	assert.Equal(t, "shop", objects[1].GetNamespace())
+
+	live, err := dynamicClient.Resource(deploymentsGVR).Namespace("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web", live.GetName())
+
+	var applyActions int
+	for _, action := range dynamicClient.Actions() {
+		if patch, ok := action.(k8stesting.PatchAction); ok && patch.GetPatchType() == types.ApplyPatchType {
+			applyActions++
+		}
+	}
+	assert.Equal(t, 2, applyActions)
+
+	t.Run("unknown kind", func(t *testing.T) {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "w"},
+		}}
+		_, err := client.Apply(context.Background(), obj, ApplyOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve")
+	})
+}
+
+func TestClientRefreshesCachedDiscovery(t *testing.T) {
+	fakeDiscovery := newFakeDiscovery()
+	client, err := NewClient(memory.NewMemCacheClient(fakeDiscovery), newFakeDynamicClient())
+	require.NoError(t, err)
+
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "w"},
+	}}
+	_, err = client.ResourceFor(widget, "shop")
+	require.Error(t, err)
+
+	// The CRD is installed after the cache was filled
+	fakeDiscovery.Resources = append(fakeDiscovery.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget", Namespaced: true}},
+	})
+	_, err = client.ResourceFor(widget, "shop")
+	require.NoError(t, err)
+	assert.Equal(t, "shop", widget.GetNamespace())
+}
+
+func TestDiff(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":   "web",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "nginx:1.25"},
+					},
+				},
+			},
+		},
+	}}
+
+	t.Run("in sync with defaulted fields", func(t *testing.T) {
+		live := desired.DeepCopy()
+		live.SetResourceVersion("42")
+		live.SetUID("abc")
+		require.NoError(t, unstructured.SetNestedField(live.Object, "Always", "spec", "template", "spec", "restartPolicy"))
+		require.NoError(t, unstructured.SetNestedField(live.Object, float64(2), "spec", "replicas"))
+		assert.Empty(t, Diff(desired, live))
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		live := desired.DeepCopy()
+		require.NoError(t, unstructured.SetNestedField(live.Object, int64(5), "spec", "replicas"))
+		require.NoError(t, unstructured.SetNestedSlice(live.Object, []interface{}{
+			map[string]interface{}{"name": "web", "image": "nginx:1.26"},
+		}, "spec", "template", "spec", "containers"))
+		live.SetLabels(nil)
+
+		assert.Equal(t, []string{
+			"metadata.labels",
+			"spec.replicas",
+			"spec.template.spec.containers[0].image",
+		}, Diff(desired, live))
+	})
+}
+
+func TestChanges(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "42",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations":     map[string]interface{}{lastAppliedAnnotation: "{}"},
+			"labels":          map[string]interface{}{"app.kubernetes.io/name": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "sidecar", "image": "envoy:1.30"},
+						map[string]interface{}{"name": "web", "image": "nginx:1.25"},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"readyReplicas": int64(2)},
+	}}
+
+	t.Run("unchanged", func(t *testing.T) {
+		desired := live.DeepCopy()
+		desired.SetResourceVersion("43")
+		require.NoError(t, unstructured.SetNestedField(desired.Object, float64(2), "spec", "replicas"))
+		unstructured.RemoveNestedField(desired.Object, "status")
+		assert.Empty(t, Changes(live, desired))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		desired := live.DeepCopy()
+		require.NoError(t, unstructured.SetNestedField(desired.Object, int64(3), "spec", "replicas"))
+		desired.SetLabels(map[string]string{"app.kubernetes.io/name": "web"})
+		// Containers are matched by name, not by position
+		require.NoError(t, unstructured.SetNestedSlice(desired.Object, []interface{}{
+			map[string]interface{}{"name": "web", "image": "nginx:1.26"},
+			map[string]interface{}{"name": "metrics", "image": "exporter:2"},
+		}, "spec", "template", "spec", "containers"))
+
+		assert.Equal(t, []Change{
+			{Path: "metadata.labels.tier", Op: ChangeRemove, Live: "frontend"},
+			{Path: "spec.replicas", Op: ChangeReplace, Live: int64(2), Desired: int64(3)},
+			{Path: "spec.template.spec.containers[name=metrics]", Op: ChangeAdd, Desired: map[string]interface{}{"name": "metrics", "image": "exporter:2"}},
+			{Path: "spec.template.spec.containers[name=sidecar]", Op: ChangeRemove, Live: map[string]interface{}{"name": "sidecar", "image": "envoy:1.30"}},
+			{Path: "spec.template.spec.containers[name=web].image", Op: ChangeReplace, Live: "nginx:1.25", Desired: "nginx:1.26"},
+		}, Changes(live, desired))
+	})
+
+	t.Run("keys with dots", func(t *testing.T) {
+		desired := live.DeepCopy()
+		desired.SetLabels(map[string]string{"app.kubernetes.io/name": "shop", "tier": "frontend"})
+		assert.Equal(t, []Change{
+			{Path: `metadata.labels["app.kubernetes.io/name"]`, Op: ChangeReplace, Live: "web", Desired: "shop"},
+		}, Changes(live, desired))
+	})
+}
+
+func TestMerge(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "uid": "abc"},
+		"data":       map[string]interface{}{"mode": "staging", "region": "eu"},
+	}}
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings"},
+		"data":       map[string]interface{}{"mode": "production"},
+	}}
+
+	merged := Merge(live, desired)
+	assert.Equal(t, map[string]interface{}{"mode": "production", "region": "eu"}, merged.Object["data"])
+	assert.Equal(t, "abc", string(merged.GetUID()))
+	// The live object is left unchanged
+	assert.Equal(t, "staging", live.Object["data"].(map[string]interface{})["mode"])
+}
@@ -0,0 +1,737 @@
+// Package multicluster lets one server target several clusters. Each kubeconfig context gets its own
+// clients, created on first use and recreated when its credentials change, and every tool call
+// picks a cluster through the optional "cluster" parameter, falling back to the current context. With token passthrough, calls run with the bearer token of the client that
+// made them instead of the server's credentials, and with impersonation they run as the user and
+// groups named by the call.
+package multicluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// InClusterContext names the cluster the server runs in when it uses its service account
+const InClusterContext = "in-cluster"
+
+// ClusterParam is the tool parameter selecting the cluster of a call
+const ClusterParam = "cluster"
+
+// ContextHeader is the HTTP header selecting the cluster of the calls made through a request, which
+// the cluster parameter of a call overrides
+const ContextHeader = "X-Kubernetes-Context"
+
+// ImpersonateUserParam and ImpersonateGroupsParam are the tool parameters selecting the user and
+// groups a call impersonates
+const (
+	ImpersonateUserParam   = "impersonateUser"
+	ImpersonateGroupsParam = "impersonateGroups"
+)
+
+// identityTTL is how long the clients created for a passed-through token or an impersonated user are
+// kept after their last use
+const identityTTL = 15 * time.Minute
+
+// Context is a kubeconfig context the server can target
+type Context struct {
+	Name string
+	// ClusterName is the kubeconfig cluster entry of the context
+	ClusterName string
+	Server      string
+	User        string
+	Namespace   string
+	// Source is the kubeconfig file the context was read from
+	Source string
+	Config *rest.Config
+}
+
+// Cluster is a context together with the clients created for it
+type Cluster struct {
+	Context
+	Client  kubernetes.Interface
+	Dynamic dynamic.Interface
+}
+
+// Kubeconfig is the set of contexts loaded from kubeconfig files
+type Kubeconfig struct {
+	Contexts []Context
+	// Current is the context selected by the kubeconfig files, or the first context by name
+	Current string
+	// Invalid holds the errors of contexts that were skipped, such as contexts naming a missing cluster
+	Invalid []error
+}
+
+// Load reads the contexts of the kubeconfig files in kubeconfig, a list separated like $KUBECONFIG,
+// and of every file in dir. A context defined more than once keeps its first definition.
+func Load(kubeconfig, dir string) (*Kubeconfig, error) {
+	var files []string
+	for _, path := range filepath.SplitList(kubeconfig) {
+		if stat, err := os.Stat(path); err == nil && stat.Size() > 0 {
+			files = append(files, path)
+		}
+	}
+
+	result := &Kubeconfig{}
+	seen := map[string]bool{}
+	add := func(raw *clientcmdapi.Config, source string) {
+		names := make([]string, 0, len(raw.Contexts))
+		for name := range raw.Contexts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			config, err := clientcmd.NewNonInteractiveClientConfig(*raw, name, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+			if err != nil {
+				result.Invalid = append(result.Invalid, fmt.Errorf("invalid kubeconfig context %q in %s: %w", name, source, err))
+				continue
+			}
+			seen[name] = true
+			c := raw.Contexts[name]
+			result.Contexts = append(result.Contexts, Context{
+				Name:        name,
+				ClusterName: c.Cluster,
+				Server:      config.Host,
+				User:        c.AuthInfo,
+				Namespace:   c.Namespace,
+				Source:      source,
+				Config:      config,
+			})
+		}
+	}
+
+	if len(files) > 0 {
+		raw, err := (&clientcmd.ClientConfigLoadingRules{Precedence: files}).Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig %s: %w", kubeconfig, err)
+		}
+		add(raw, strings.Join(files, string(filepath.ListSeparator)))
+		result.Current = raw.CurrentContext
+	}
+
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubeconfig directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			raw, err := (&clientcmd.ClientConfigLoadingRules{ExplicitPath: path}).Load()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+			}
+			add(raw, path)
+		}
+	}
+
+	if !seen[result.Current] {
+		result.Current = ""
+		for _, c := range result.Contexts {
+			if result.Current == "" || c.Name < result.Current {
+				result.Current = c.Name
+			}
+		}
+	}
+	return result, nil
+}
+
+type clusterKey struct{}
+
+// WithCluster returns a context selecting the named cluster for the clients of a tool call
+func WithCluster(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, clusterKey{}, name)
+}
+
+// ClusterFromContext returns the cluster selected for a tool call, or "" for the current cluster
+func ClusterFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(clusterKey{}).(string)
+	return name
+}
+
+type tokenKey struct{}
+
+// WithToken returns a context whose tool calls authenticate with a bearer token
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext returns the bearer token passed through for a tool call, if any
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+// Impersonation is the user and groups a tool call acts as
+type Impersonation struct {
+	User   string
+	Groups []string
+}
+
+type impersonationKey struct{}
+
+// WithImpersonation returns a context whose tool calls impersonate a user and its groups
+func WithImpersonation(ctx context.Context, impersonation Impersonation) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, impersonation)
+}
+
+// ImpersonationFromContext returns the user and groups a tool call impersonates, if any
+func ImpersonationFromContext(ctx context.Context) Impersonation {
+	impersonation, _ := ctx.Value(impersonationKey{}).(Impersonation)
+	return impersonation
+}
+
+// BuildFunc creates the clients for a REST config
+type BuildFunc func(*rest.Config) (kubernetes.Interface, dynamic.Interface, error)
+
+// ReloadFunc loads the contexts again, for clusters whose files changed
+type ReloadFunc func() ([]Context, error)
+
+// RefreshInterval is how often the files of a cluster's context are checked for changes, at most
+// once per interval and only when a tool call uses the cluster
+const RefreshInterval = 10 * time.Second
+
+// Files returns the files the credentials of a context are read from: its kubeconfig files and the
+// certificate, key and token files it names, such as the service account token and CA of the
+// in-cluster context
+func (c Context) Files() []string {
+	var files []string
+	if c.Source != "" {
+		files = append(files, filepath.SplitList(c.Source)...)
+	}
+	if c.Config != nil {
+		for _, file := range []string{c.Config.CAFile, c.Config.CertFile, c.Config.KeyFile, c.Config.BearerTokenFile} {
+			if file != "" {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// fileVersion tells versions of a file apart by their size and modification time, zero for a
+// missing file. Stat follows symlinks, so the atomic updates of mounted Secrets are noticed.
+type fileVersion struct {
+	size    int64
+	modTime time.Time
+}
+
+func fileVersions(files []string) map[string]fileVersion {
+	versions := make(map[string]fileVersion, len(files))
+	for _, file := range files {
+		var version fileVersion
+		if stat, err := os.Stat(file); err == nil {
+			version = fileVersion{size: stat.Size(), modTime: stat.ModTime()}
+		}
+		versions[file] = version
+	}
+	return versions
+}
+
+// cluster is a context and its clients, created when a tool call first uses them and recreated
+// when the files of the context change
+type cluster struct {
+	mu       sync.Mutex
+	context  Context
+	clients  *Cluster
+	versions map[string]fileVersion
+	checked  time.Time
+}
+
+// Context returns the context of the cluster as last loaded
+func (c *cluster) Context() Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.context
+}
+
+// identity is a cluster accessed with a passed-through token or as an impersonated user
+type identity struct {
+	cluster  *Cluster
+	lastUsed time.Time
+}
+
+// Manager holds the clusters the server can target
+type Manager struct {
+	clusters map[string]*cluster
+	current  string
+
+	// create and reload are set when clusters are created on first use
+	create    BuildFunc
+	reload    ReloadFunc
+	onRefresh func(name string, err error)
+
+	// build is set when token passthrough or per-call impersonation is enabled
+	build         BuildFunc
+	passthrough   bool
+	impersonation bool
+	mu            sync.Mutex
+	identities    map[string]*identity
+	now           func() time.Time
+}
+
+// NewManager creates a manager for clusters whose clients are already created, with current used
+// by calls that select no cluster
+func NewManager(clusters []*Cluster, current string) (*Manager, error) {
+	m := &Manager{clusters: map[string]*cluster{}, current: current, identities: map[string]*identity{}, now: time.Now}
+	for _, c := range clusters {
+		m.clusters[c.Name] = &cluster{context: c.Context, clients: c}
+	}
+	if _, ok := m.clusters[current]; !ok {
+		return nil, fmt.Errorf("current cluster %q is not one of the loaded clusters", current)
+	}
+	return m, nil
+}
+
+// NewLazyManager creates a manager for the clusters of contexts, with current used by calls that
+// select no cluster. The clients of a cluster are created with create when a tool call first uses
+// it, so the server starts without contacting or authenticating to any cluster. When the files of
+// a context change, such as a kubeconfig rewritten with new credentials or a rotated service
+// account token, the next call reloads the contexts with reload and recreates the clients of that
+// cluster. Clusters added to the kubeconfig files need a restart.
+func NewLazyManager(contexts []Context, current string, create BuildFunc, reload ReloadFunc) (*Manager, error) {
+	m := &Manager{
+		clusters:   map[string]*cluster{},
+		current:    current,
+		create:     create,
+		reload:     reload,
+		identities: map[string]*identity{},
+		now:        time.Now,
+	}
+	for _, c := range contexts {
+		m.clusters[c.Name] = &cluster{context: c, versions: fileVersions(c.Files())}
+	}
+	if _, ok := m.clusters[current]; !ok {
+		return nil, fmt.Errorf("current cluster %q is not one of the loaded clusters", current)
+	}
+	return m, nil
+}
+
+// OnRefresh sets a function called when the context of a cluster was reloaded after its files
+// changed, with the error when it failed to load and the previous context and clients were kept
+func (m *Manager) OnRefresh(onRefresh func(name string, err error)) {
+	m.onRefresh = onRefresh
+}
+
+// Names returns the names of the clusters, sorted
+func (m *Manager) Names() []string {
+	names := make([]string, 0, len(m.clusters))
+	for name := range m.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurrentContext returns the context of the cluster used by calls that select no cluster
+func (m *Manager) CurrentContext() Context {
+	return m.clusters[m.current].Context()
+}
+
+// EnableTokenPassthrough makes tool calls authenticate with the bearer token of the client that
+// made them, creating their clients with build. Calls without a token are rejected.
+func (m *Manager) EnableTokenPassthrough(build BuildFunc) {
+	m.build = build
+	m.passthrough = true
+}
+
+// EnableImpersonation makes tool calls impersonate the user and groups selected by
+// WithImpersonationParams, creating their clients with build
+func (m *Manager) EnableImpersonation(build BuildFunc) {
+	m.build = build
+	m.impersonation = true
+}
+
+// selected returns the cluster a tool call selects
+func (m *Manager) selected(ctx context.Context) (*cluster, error) {
+	name := ClusterFromContext(ctx)
+	if name == "" {
+		name = m.current
+	}
+	c, ok := m.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q: must be one of %s", name, strings.Join(m.Names(), ", "))
+	}
+	return c, nil
+}
+
+// Cluster returns the cluster a tool call targets, with the clients of the caller's token when
+// token passthrough is enabled and of the impersonated user when the call selects one
+func (m *Manager) Cluster(ctx context.Context) (*Cluster, error) {
+	c, err := m.selected(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var token string
+	if m.passthrough {
+		token = TokenFromContext(ctx)
+		if token == "" {
+			return nil, fmt.Errorf("token passthrough is enabled but the request has no bearer token in its Authorization header")
+		}
+	}
+	var impersonation Impersonation
+	if m.impersonation {
+		impersonation = ImpersonationFromContext(ctx)
+	}
+	if token == "" && impersonation.User == "" {
+		return m.clients(c)
+	}
+
+	// Clients of other identities only need the context, so the server's own are not created
+	c.mu.Lock()
+	m.refresh(c)
+	clusterContext := c.context
+	c.mu.Unlock()
+	return m.identity(clusterContext, token, impersonation)
+}
+
+// ServerClient returns the typed client of the cluster a call selects with the server's own
+// credentials, ignoring token passthrough and impersonation, for work the server does itself such
+// as probing permissions
+func (m *Manager) ServerClient(ctx context.Context) (kubernetes.Interface, error) {
+	c, err := m.selected(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := m.clients(c)
+	if err != nil {
+		return nil, err
+	}
+	return clients.Client, nil
+}
+
+// clients returns the cluster with the server's own clients, creating them on first use
+func (m *Manager) clients(c *cluster) (*Cluster, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m.refresh(c)
+	if c.clients == nil {
+		client, dynamicClient, err := m.create(c.context.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create clients for cluster %q: %w", c.context.Name, err)
+		}
+		c.clients = &Cluster{Context: c.context, Client: client, Dynamic: dynamicClient}
+	}
+	return c.clients, nil
+}
+
+// refresh reloads the context of a cluster when its files changed since it was loaded, checking at
+// most once per RefreshInterval, and recreates the clients already created. When the context
+// fails to load, such as while a kubeconfig is being rewritten, or its clients fail to be created,
+// the previous ones are kept and the reload is tried again after the next interval. The cluster
+// must be locked.
+func (m *Manager) refresh(c *cluster) {
+	if m.reload == nil {
+		return
+	}
+	now := m.now()
+	if now.Sub(c.checked) < RefreshInterval {
+		return
+	}
+	c.checked = now
+	if maps.Equal(fileVersions(c.context.Files()), c.versions) {
+		return
+	}
+
+	name := c.context.Name
+	reloaded, err := m.reloadContext(name)
+	var clients *Cluster
+	if err == nil && c.clients != nil {
+		var client kubernetes.Interface
+		var dynamicClient dynamic.Interface
+		if client, dynamicClient, err = m.create(reloaded.Config); err == nil {
+			clients = &Cluster{Context: reloaded, Client: client, Dynamic: dynamicClient}
+		}
+	}
+	if err != nil {
+		m.notify(name, err)
+		return
+	}
+
+	// Clients running in the background, such as those serving reads from informers, are
+	// stopped once replaced
+	if c.clients != nil {
+		if stopper, ok := c.clients.Client.(interface{ Stop() }); ok {
+			stopper.Stop()
+		}
+	}
+	c.context = reloaded
+	c.versions = fileVersions(reloaded.Files())
+	c.clients = clients
+	m.forgetIdentities(name)
+	m.notify(name, nil)
+}
+
+// reloadContext loads the contexts again and returns the named one
+func (m *Manager) reloadContext(name string) (Context, error) {
+	contexts, err := m.reload()
+	if err != nil {
+		return Context{}, err
+	}
+	for _, c := range contexts {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Context{}, fmt.Errorf("context %q is no longer in the kubeconfig", name)
+}
+
+func (m *Manager) notify(name string, err error) {
+	if m.onRefresh != nil {
+		m.onRefresh(name, err)
+	}
+}
+
+// forgetIdentities drops the clients created for other identities on a cluster, so they are
+// created again from its reloaded context
+func (m *Manager) forgetIdentities(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.identities {
+		if strings.HasPrefix(key, name+"/") {
+			delete(m.identities, key)
+		}
+	}
+}
+
+// identity returns the cluster accessed with token and as the impersonated user, creating its
+// clients on first use. With a token the credentials of the server, including its --as user, are
+// dropped, while its TLS settings, client limits and transport wrappers, such as the default label
+// selector, are kept. An impersonated user replaces the --as user of the server.
+func (m *Manager) identity(c Context, token string, impersonation Impersonation) (*Cluster, error) {
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{token, impersonation.User}, impersonation.Groups...), "\x00")))
+	key := c.Name + "/" + hex.EncodeToString(sum[:])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	for k, id := range m.identities {
+		if now.Sub(id.lastUsed) > identityTTL {
+			delete(m.identities, k)
+		}
+	}
+	if id, ok := m.identities[key]; ok {
+		id.lastUsed = now
+		return id.cluster, nil
+	}
+
+	config := rest.CopyConfig(c.Config)
+	if token != "" {
+		config = rest.AnonymousClientConfig(c.Config)
+		// AnonymousClientConfig drops the transport wrappers, which scope and trace every request
+		config.WrapTransport = c.Config.WrapTransport
+		config.BearerToken = token
+	}
+	if impersonation.User != "" {
+		config.Impersonate = rest.ImpersonationConfig{UserName: impersonation.User, Groups: impersonation.Groups}
+	}
+	client, dynamicClient, err := m.build(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clients for the request's identity: %w", err)
+	}
+	id := &identity{
+		cluster:  &Cluster{Context: c, Client: client, Dynamic: dynamicClient},
+		lastUsed: now,
+	}
+	id.cluster.Config = config
+	m.identities[key] = id
+	return id.cluster, nil
+}
+
+// ContextFromRequest passes the bearer token of the Authorization header and the cluster named by
+// the X-Kubernetes-Context header of an HTTP request to the tool calls it carries. The token is only
+// used when token passthrough is enabled.
+func ContextFromRequest(ctx context.Context, r *http.Request) context.Context {
+	if name := r.Header.Get(ContextHeader); name != "" {
+		ctx = WithCluster(ctx, name)
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") && strings.TrimSpace(token) != "" {
+		ctx = WithToken(ctx, strings.TrimSpace(token))
+	}
+	return ctx
+}
+
+// GetClient returns the typed client of the cluster a tool call targets
+func (m *Manager) GetClient(ctx context.Context) (kubernetes.Interface, error) {
+	c, err := m.Cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client, nil
+}
+
+// GetDynamicClient returns the dynamic client of the cluster a tool call targets
+func (m *Manager) GetDynamicClient(ctx context.Context) (dynamic.Interface, error) {
+	c, err := m.Cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Dynamic, nil
+}
+
+// GetRESTConfig returns the REST config of the cluster a tool call targets
+func (m *Manager) GetRESTConfig(ctx context.Context) (*rest.Config, error) {
+	c, err := m.Cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Config, nil
+}
+
+// WithClusterParam adds the "cluster" parameter to a tool and selects that cluster for the call.
+// Unknown clusters are rejected before the tool runs.
+func (m *Manager) WithClusterParam(tool server.ServerTool) server.ServerTool {
+	mcp.WithString(ClusterParam,
+		mcp.Description(fmt.Sprintf("Kubernetes cluster (kubeconfig context) to target, see list_clusters (default: %s)", m.current)),
+		mcp.Enum(m.Names()...),
+	)(&tool.Tool)
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, err := toolsets.OptionalParam[string](request, ClusterParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if name != "" {
+			if _, ok := m.clusters[name]; !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown cluster %q: must be one of %s", name, strings.Join(m.Names(), ", "))), nil
+			}
+			ctx = WithCluster(ctx, name)
+		}
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// WithImpersonationParams adds the "impersonateUser" and "impersonateGroups" parameters to a tool
+// and runs the call as that user, so the API server applies the user's RBAC permissions
+func (m *Manager) WithImpersonationParams(tool server.ServerTool) server.ServerTool {
+	mcp.WithString(ImpersonateUserParam,
+		mcp.Description("User to impersonate for this call, so it runs with that user's permissions"),
+	)(&tool.Tool)
+	mcp.WithArray(ImpersonateGroupsParam,
+		mcp.Description("Groups to impersonate along with impersonateUser"),
+		mcp.Items(map[string]interface{}{"type": "string"}),
+	)(&tool.Tool)
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		user, err := toolsets.OptionalParam[string](request, ImpersonateUserParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		rawGroups, err := toolsets.OptionalParam[[]interface{}](request, ImpersonateGroupsParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		groups := make([]string, 0, len(rawGroups))
+		for _, group := range rawGroups {
+			s, ok := group.(string)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("%s must be strings, got %T", ImpersonateGroupsParam, group)), nil
+			}
+			groups = append(groups, s)
+		}
+		if user == "" && len(groups) > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("%s requires %s", ImpersonateGroupsParam, ImpersonateUserParam)), nil
+		}
+		if user != "" {
+			ctx = WithImpersonation(ctx, Impersonation{User: user, Groups: groups})
+		}
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// ClusterInfo describes a cluster in the list_clusters result
+type ClusterInfo struct {
+	Name      string `json:"name"`
+	Server    string `json:"server"`
+	User      string `json:"user,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Current   bool   `json:"current"`
+}
+
+// ListTool creates a tool listing the clusters the server can target
+func (m *Manager) ListTool() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_clusters",
+			mcp.WithDescription("List the Kubernetes clusters (kubeconfig contexts) this server can target. Pass a name as the cluster parameter of any tool to run it against that cluster"),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			clusters := []ClusterInfo{}
+			for _, name := range m.Names() {
+				c := m.clusters[name].Context()
+				clusters = append(clusters, ClusterInfo{
+					Name:      c.Name,
+					Server:    c.Server,
+					User:      c.User,
+					Namespace: c.Namespace,
+					Current:   c.Name == m.current,
+				})
+			}
+			return toolsets.NewToolResultJSON(clusters)
+		}
+}
+
+// ContextInfo describes the context a tool call runs against
+type ContextInfo struct {
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster,omitempty"`
+	Server    string `json:"server"`
+	User      string `json:"user,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Source    string `json:"source,omitempty"`
+	// Impersonating is the user the context's calls impersonate, if any
+	Impersonating string `json:"impersonating,omitempty"`
+	Current       bool   `json:"current"`
+}
+
+// CurrentContextTool creates a tool reporting the context, cluster, API server and user in use, and
+// the user impersonated
+func (m *Manager) CurrentContextTool() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_current_context",
+			mcp.WithDescription("Report the kubeconfig context in use: its name, cluster, API server, user and default namespace"),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			c, err := m.Cluster(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			info := ContextInfo{
+				Name:      c.Name,
+				Cluster:   c.ClusterName,
+				Server:    c.Server,
+				User:      c.User,
+				Namespace: c.Namespace,
+				Source:    c.Source,
+				Current:   c.Name == m.current,
+			}
+			if c.Config != nil {
+				info.Impersonating = c.Config.Impersonate.UserName
+			}
+			return toolsets.NewToolResultJSON(info)
+		}
+}
@@ -0,0 +1,630 @@
+package multicluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/scope"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+const stagingKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: alice
+    namespace: shop
+- name: dangling
+  context:
+    cluster: missing
+    user: alice
+users:
+- name: alice
+  user:
+    token: secret
+`
+
+const productionKubeconfig = `apiVersion: v1
+kind: Config
+current-context: production
+clusters:
+- name: production
+  cluster:
+    server: https://production.example.com
+contexts:
+- name: production
+  context:
+    cluster: production
+    user: bob
+- name: staging
+  context:
+    cluster: production
+    user: bob
+users:
+- name: bob
+  user:
+    token: secret
+`
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+	return request
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	kubeconfig := filepath.Join(root, "config")
+	writeFile(t, kubeconfig, stagingKubeconfig)
+	dir := filepath.Join(root, "clusters")
+	require.NoError(t, os.Mkdir(dir, 0700))
+	writeFile(t, filepath.Join(dir, "production.yaml"), productionKubeconfig)
+	writeFile(t, filepath.Join(dir, ".hidden"), "not a kubeconfig")
+
+	t.Run("kubeconfig and directory", func(t *testing.T) {
+		loaded, err := Load(kubeconfig, dir)
+		require.NoError(t, err)
+
+		require.Len(t, loaded.Contexts, 2)
+		assert.Equal(t, "staging", loaded.Current)
+
+		staging := loaded.Contexts[0]
+		assert.Equal(t, "staging", staging.Name)
+		// The context defined first wins over the directory's context of the same name
+		assert.Equal(t, "https://staging.example.com", staging.Server)
+		assert.Equal(t, "staging", staging.ClusterName)
+		assert.Equal(t, "alice", staging.User)
+		assert.Equal(t, "shop", staging.Namespace)
+		assert.Equal(t, "secret", staging.Config.BearerToken)
+
+		production := loaded.Contexts[1]
+		assert.Equal(t, "production", production.Name)
+		assert.Equal(t, filepath.Join(dir, "production.yaml"), production.Source)
+
+		require.Len(t, loaded.Invalid, 1)
+		assert.Contains(t, loaded.Invalid[0].Error(), `"dangling"`)
+	})
+
+	t.Run("directory only selects the first context", func(t *testing.T) {
+		loaded, err := Load("", dir)
+		require.NoError(t, err)
+		require.Len(t, loaded.Contexts, 2)
+		assert.Equal(t, "production", loaded.Current)
+	})
+
+	t.Run("missing kubeconfig", func(t *testing.T) {
+		loaded, err := Load(filepath.Join(root, "missing"), "")
+		require.NoError(t, err)
+		assert.Empty(t, loaded.Contexts)
+		assert.Empty(t, loaded.Current)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Load(kubeconfig, filepath.Join(root, "missing"))
+		assert.ErrorContains(t, err, "failed to read kubeconfig directory")
+	})
+}
+
+func newManager(t *testing.T) *Manager {
+	staging := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "staging-only"}})
+	production := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "production-only"}})
+	m, err := NewManager([]*Cluster{
+		{Context: Context{Name: "staging", Server: "https://staging.example.com", Config: &rest.Config{Host: "https://staging.example.com"}}, Client: staging},
+		{Context: Context{Name: "production", Server: "https://production.example.com", User: "bob"}, Client: production},
+	}, "staging")
+	require.NoError(t, err)
+	return m
+}
+
+func TestManager(t *testing.T) {
+	m := newManager(t)
+	assert.Equal(t, []string{"production", "staging"}, m.Names())
+	assert.Equal(t, "staging", m.CurrentContext().Name)
+
+	config, err := m.GetRESTConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com", config.Host)
+
+	client, err := m.GetClient(WithCluster(context.Background(), "production"))
+	require.NoError(t, err)
+	_, err = client.CoreV1().Namespaces().Get(context.Background(), "production-only", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	_, err = m.GetClient(WithCluster(context.Background(), "unknown"))
+	assert.ErrorContains(t, err, `unknown cluster "unknown": must be one of production, staging`)
+
+	_, err = NewManager(nil, "staging")
+	assert.Error(t, err)
+}
+
+func TestWithClusterParam(t *testing.T) {
+	m := newManager(t)
+	tool := m.WithClusterParam(server.ServerTool{
+		Tool: mcp.NewTool("list_namespaces"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := m.GetClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return mcp.NewToolResultText(namespaces.Items[0].Name), nil
+		},
+	})
+
+	require.Contains(t, tool.Tool.InputSchema.Properties, ClusterParam)
+	assert.NotContains(t, tool.Tool.InputSchema.Required, ClusterParam)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "current cluster by default",
+			requestArgs:  map[string]interface{}{},
+			expectedText: "staging-only",
+		},
+		{
+			name:         "selected cluster",
+			requestArgs:  map[string]interface{}{"cluster": "production"},
+			expectedText: "production-only",
+		},
+		{
+			name:           "unknown cluster",
+			requestArgs:    map[string]interface{}{"cluster": "dev"},
+			expectedErrMsg: `unknown cluster "dev"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, text)
+		})
+	}
+}
+
+func TestListTool(t *testing.T) {
+	tool, handler := newManager(t).ListTool()
+	assert.Equal(t, "list_clusters", tool.Name)
+
+	result, err := handler(context.Background(), createMCPRequest(nil))
+	require.NoError(t, err)
+
+	var clusters []ClusterInfo
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &clusters))
+	assert.Equal(t, []ClusterInfo{
+		{Name: "production", Server: "https://production.example.com", User: "bob"},
+		{Name: "staging", Server: "https://staging.example.com", Current: true},
+	}, clusters)
+}
+
+func TestCurrentContextTool(t *testing.T) {
+	m := newManager(t)
+	tool, handler := m.CurrentContextTool()
+	assert.Equal(t, "get_current_context", tool.Name)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected ContextInfo
+	}{
+		{
+			name:     "current context",
+			ctx:      context.Background(),
+			expected: ContextInfo{Name: "staging", Server: "https://staging.example.com", Current: true},
+		},
+		{
+			name:     "selected cluster",
+			ctx:      WithCluster(context.Background(), "production"),
+			expected: ContextInfo{Name: "production", Server: "https://production.example.com", User: "bob"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(tc.ctx, createMCPRequest(nil))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var info ContextInfo
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info))
+			assert.Equal(t, tc.expected, info)
+		})
+	}
+}
+
+func TestTokenPassthrough(t *testing.T) {
+	m := newManager(t)
+	m.CurrentContext().Config.BearerToken = "server-token"
+	m.CurrentContext().Config.TLSClientConfig.CAData = []byte("ca")
+
+	var built []*rest.Config
+	m.EnableTokenPassthrough(func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		built = append(built, config)
+		return fake.NewClientset(), nil, nil
+	})
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	_, err := m.GetClient(context.Background())
+	assert.ErrorContains(t, err, "no bearer token")
+
+	alice := WithToken(context.Background(), "alice-token")
+	first, err := m.GetClient(alice)
+	require.NoError(t, err)
+	require.Len(t, built, 1)
+	assert.Equal(t, "alice-token", built[0].BearerToken)
+	assert.Equal(t, []byte("ca"), built[0].CAData)
+	assert.Equal(t, "https://staging.example.com", built[0].Host)
+	// The server's own credentials are left untouched
+	assert.Equal(t, "server-token", m.CurrentContext().Config.BearerToken)
+
+	config, err := m.GetRESTConfig(alice)
+	require.NoError(t, err)
+	assert.Equal(t, "alice-token", config.BearerToken)
+
+	second, err := m.GetClient(alice)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Len(t, built, 1)
+
+	_, err = m.GetClient(WithToken(context.Background(), "bob-token"))
+	require.NoError(t, err)
+	assert.Len(t, built, 2)
+
+	// Clients unused for longer than the TTL are created again
+	now = now.Add(identityTTL + time.Minute)
+	_, err = m.GetClient(alice)
+	require.NoError(t, err)
+	assert.Len(t, built, 3)
+	assert.Len(t, m.identities, 1)
+}
+
+func TestTokenPassthroughLabelSelector(t *testing.T) {
+	var requests []*http.Request
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+	}))
+	defer apiServer.Close()
+
+	wrapper, err := scope.LabelSelector("tenant=a")
+	require.NoError(t, err)
+	config := &rest.Config{Host: apiServer.URL, BearerToken: "server-token"}
+	config.Wrap(wrapper)
+	m, err := NewManager([]*Cluster{
+		{Context: Context{Name: "staging", Server: apiServer.URL, Config: config}, Client: fake.NewClientset()},
+	}, "staging")
+	require.NoError(t, err)
+	m.EnableTokenPassthrough(func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		client, err := kubernetes.NewForConfig(config)
+		return client, nil, err
+	})
+
+	client, err := m.GetClient(WithToken(context.Background(), "alice-token"))
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("shop").List(context.Background(), metav1.ListOptions{LabelSelector: "app=web"})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Bearer alice-token", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "app=web,tenant=a", requests[0].URL.Query().Get("labelSelector"))
+}
+
+func TestContextFromRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		expectedToken   string
+		expectedCluster string
+	}{
+		{
+			name:          "bearer token",
+			headers:       map[string]string{"Authorization": "Bearer abc.def"},
+			expectedToken: "abc.def",
+		},
+		{
+			name:          "scheme is case insensitive",
+			headers:       map[string]string{"Authorization": "bearer abc"},
+			expectedToken: "abc",
+		},
+		{
+			name:    "basic credentials are ignored",
+			headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+		},
+		{
+			name:            "context header",
+			headers:         map[string]string{ContextHeader: "production"},
+			expectedCluster: "production",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp/message", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			ctx := ContextFromRequest(context.Background(), r)
+			assert.Equal(t, tc.expectedToken, TokenFromContext(ctx))
+			assert.Equal(t, tc.expectedCluster, ClusterFromContext(ctx))
+		})
+	}
+}
+
+func TestImpersonation(t *testing.T) {
+	m := newManager(t)
+	m.CurrentContext().Config.BearerToken = "server-token"
+
+	var built []*rest.Config
+	m.EnableImpersonation(func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		built = append(built, config)
+		return fake.NewClientset(), nil, nil
+	})
+
+	// Calls without an impersonated user use the server's clients
+	config, err := m.GetRESTConfig(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, m.CurrentContext().Config, config)
+	assert.Empty(t, built)
+
+	alice := WithImpersonation(context.Background(), Impersonation{User: "alice", Groups: []string{"dev"}})
+	config, err = m.GetRESTConfig(alice)
+	require.NoError(t, err)
+	require.Len(t, built, 1)
+	assert.Equal(t, rest.ImpersonationConfig{UserName: "alice", Groups: []string{"dev"}}, config.Impersonate)
+	// The server authenticates with its own credentials to impersonate the user
+	assert.Equal(t, "server-token", config.BearerToken)
+	assert.Empty(t, m.CurrentContext().Config.Impersonate.UserName)
+
+	_, err = m.GetClient(alice)
+	require.NoError(t, err)
+	assert.Len(t, built, 1)
+
+	_, err = m.GetClient(WithImpersonation(context.Background(), Impersonation{User: "alice", Groups: []string{"ops"}}))
+	require.NoError(t, err)
+	assert.Len(t, built, 2)
+}
+
+func TestWithImpersonationParams(t *testing.T) {
+	m := newManager(t)
+	m.EnableImpersonation(func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		return fake.NewClientset(), nil, nil
+	})
+	tool := m.WithImpersonationParams(server.ServerTool{
+		Tool: mcp.NewTool("whoami"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			config, err := m.GetRESTConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			impersonate := config.Impersonate
+			return mcp.NewToolResultText(impersonate.UserName + "/" + strings.Join(impersonate.Groups, ",")), nil
+		},
+	})
+
+	require.Contains(t, tool.Tool.InputSchema.Properties, ImpersonateUserParam)
+	require.Contains(t, tool.Tool.InputSchema.Properties, ImpersonateGroupsParam)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "server identity by default",
+			requestArgs:  map[string]interface{}{},
+			expectedText: "/",
+		},
+		{
+			name:         "impersonated user and groups",
+			requestArgs:  map[string]interface{}{"impersonateUser": "alice", "impersonateGroups": []interface{}{"dev", "qa"}},
+			expectedText: "alice/dev,qa",
+		},
+		{
+			name:           "groups without a user",
+			requestArgs:    map[string]interface{}{"impersonateGroups": []interface{}{"dev"}},
+			expectedErrMsg: "impersonateGroups requires impersonateUser",
+		},
+		{
+			name:           "non-string group",
+			requestArgs:    map[string]interface{}{"impersonateUser": "alice", "impersonateGroups": []interface{}{1}},
+			expectedErrMsg: "impersonateGroups must be strings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, text)
+		})
+	}
+}
+
+// stoppableClient records that its informers were stopped when its cluster's clients are replaced
+type stoppableClient struct {
+	*fake.Clientset
+	stopped bool
+}
+
+func (c *stoppableClient) Stop() {
+	c.stopped = true
+}
+
+func TestLazyManager(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "config")
+	write := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(kubeconfig, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(kubeconfig, modTime, modTime))
+	}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	write(stagingKubeconfig, start)
+
+	reload := func() ([]Context, error) {
+		loaded, err := Load(kubeconfig, "")
+		if err != nil {
+			return nil, err
+		}
+		return loaded.Contexts, nil
+	}
+	contexts, err := reload()
+	require.NoError(t, err)
+
+	var built []*stoppableClient
+	var hosts []string
+	m, err := NewLazyManager(contexts, "staging", func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		client := &stoppableClient{Clientset: fake.NewClientset()}
+		built = append(built, client)
+		hosts = append(hosts, config.Host)
+		return client, nil, nil
+	}, reload)
+	require.NoError(t, err)
+	var refreshed []error
+	m.OnRefresh(func(name string, err error) {
+		assert.Equal(t, "staging", name)
+		refreshed = append(refreshed, err)
+	})
+	now := start
+	m.now = func() time.Time { return now }
+
+	// Clients are created on first use, once
+	assert.Empty(t, built)
+	assert.Equal(t, "https://staging.example.com", m.CurrentContext().Server)
+	first, err := m.GetClient(context.Background())
+	require.NoError(t, err)
+	_, err = m.ServerClient(context.Background())
+	require.NoError(t, err)
+	require.Len(t, built, 1)
+
+	// Changes are noticed once the refresh interval has passed
+	write(strings.ReplaceAll(stagingKubeconfig, "staging.example.com", "staging-2.example.com"), start.Add(time.Minute))
+	client, err := m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, client)
+
+	now = now.Add(RefreshInterval)
+	client, err = m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, client)
+	assert.Equal(t, []string{"https://staging.example.com", "https://staging-2.example.com"}, hosts)
+	assert.True(t, built[0].stopped)
+	assert.Equal(t, "https://staging-2.example.com", m.CurrentContext().Server)
+	assert.Equal(t, []error{nil}, refreshed)
+
+	// A kubeconfig that fails to load keeps the previous clients until it is fixed
+	write("clusters: [", start.Add(2*time.Minute))
+	now = now.Add(RefreshInterval)
+	kept, err := m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, client, kept)
+	require.Len(t, refreshed, 2)
+	assert.Error(t, refreshed[1])
+
+	write(stagingKubeconfig, start.Add(3*time.Minute))
+	now = now.Add(RefreshInterval)
+	_, err = m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, built, 3)
+	assert.Equal(t, "https://staging.example.com", hosts[2])
+}
+
+func TestLazyManagerTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first"), 0o600))
+
+	// The in-cluster context reads its rotated service account token from a file
+	inCluster := func() ([]Context, error) {
+		return []Context{{Name: InClusterContext, Server: "https://10.0.0.1", Config: &rest.Config{Host: "https://10.0.0.1", BearerTokenFile: tokenFile}}}, nil
+	}
+	contexts, _ := inCluster()
+	var builds int
+	m, err := NewLazyManager(contexts, InClusterContext, func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		builds++
+		return fake.NewClientset(), nil, nil
+	}, inCluster)
+	require.NoError(t, err)
+	var built []*rest.Config
+	m.EnableImpersonation(func(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+		built = append(built, config)
+		return fake.NewClientset(), nil, nil
+	})
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	// Calls impersonating a user do not create the server's own clients
+	alice := WithImpersonation(context.Background(), Impersonation{User: "alice"})
+	_, err = m.GetClient(alice)
+	require.NoError(t, err)
+	assert.Equal(t, 0, builds)
+	_, err = m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, builds)
+
+	// A rotated token recreates the server's clients and those of other identities
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second token"), 0o600))
+	now = now.Add(RefreshInterval)
+	_, err = m.GetClient(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, builds)
+	_, err = m.GetClient(alice)
+	require.NoError(t, err)
+	assert.Len(t, built, 2)
+	assert.Len(t, m.identities, 1)
+}
@@ -0,0 +1,408 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/manifest"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Drift statuses reported for each object in a bundle
+const (
+	StatusInSync  = "in-sync"
+	StatusDrifted = "drifted"
+	StatusMissing = "missing"
+	StatusApplied = "applied"
+	StatusError   = "error"
+)
+
+// Bundle is a named set of manifests describing the desired state of part of the cluster
+type Bundle struct {
+	Name      string
+	Namespace string
+	Objects   []*unstructured.Unstructured
+	UpdatedAt time.Time
+}
+
+// Store keeps desired-state bundles in memory for the lifetime of the server
+type Store struct {
+	mu      sync.RWMutex
+	bundles map[string]*Bundle
+}
+
+// NewStore creates an empty bundle store
+func NewStore() *Store {
+	return &Store{bundles: map[string]*Bundle{}}
+}
+
+// Save adds or replaces a bundle
+func (s *Store) Save(b *Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bundles[b.Name] = b
+}
+
+// Get returns a copy of a bundle so callers can freely default namespaces on its objects
+func (s *Store) Get(name string) (*Bundle, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.bundles[name]
+	if !ok {
+		return nil, false
+	}
+	cp := *b
+	cp.Objects = make([]*unstructured.Unstructured, len(b.Objects))
+	for i, obj := range b.Objects {
+		cp.Objects[i] = obj.DeepCopy()
+	}
+	return &cp, true
+}
+
+// List returns all bundles sorted by name
+func (s *Store) List() []*Bundle {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bundles := make([]*Bundle, 0, len(s.bundles))
+	for _, b := range s.bundles {
+		bundles = append(bundles, b)
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].Name < bundles[j].Name })
+	return bundles
+}
+
+// Delete removes a bundle, reporting whether it existed
+func (s *Store) Delete(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.bundles[name]
+	delete(s.bundles, name)
+	return ok
+}
+
+// Summary describes a stored bundle
+type Summary struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace,omitempty"`
+	Objects   []string  `json:"objects"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ObjectStatus is the drift or reconcile outcome for one object of a bundle
+type ObjectStatus struct {
+	Object      string   `json:"object"`
+	Status      string   `json:"status"`
+	Differences []string `json:"differences,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// Report is the drift or reconcile outcome for a whole bundle
+type Report struct {
+	Bundle  string         `json:"bundle"`
+	InSync  bool           `json:"inSync"`
+	DryRun  bool           `json:"dryRun,omitempty"`
+	Objects []ObjectStatus `json:"objects"`
+}
+
+// Handler implements the K8sResourceHandler interface for desired-state bundles
+type Handler struct {
+	getClient        toolsets.GetClientFn
+	getDynamicClient toolsets.GetDynamicClientFn
+	t                translations.TranslationHelperFunc
+	store            *Store
+}
+
+// NewHandler creates a new bundle handler with an empty store
+func NewHandler(getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:        getClient,
+		getDynamicClient: getDynamicClient,
+		t:                t,
+		store:            NewStore(),
+	}
+}
+
+// RegisterTools registers all bundle tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	listTool, listHandler := h.List()
+	toolset.AddReadTool(listTool, listHandler)
+
+	driftTool, driftHandler := h.Drift()
+	toolset.AddReadTool(driftTool, driftHandler)
+
+	// Register write tools. Saving and deleting bundles changes the store every session shares,
+	// and with it the manifests reconcile_bundle applies for other clients.
+	saveTool, saveHandler := h.Save()
+	toolset.AddWriteTool(saveTool, saveHandler)
+
+	deleteTool, deleteHandler := h.Delete()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
+
+	reconcileTool, reconcileHandler := h.Reconcile()
+	toolset.AddWriteTool(reconcileTool, reconcileHandler)
+}
+
+// Save creates a tool to store a named desired-state bundle
+func (h *Handler) Save() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("save_bundle",
+			mcp.WithDescription(h.t("TOOL_SAVE_BUNDLE_DESCRIPTION", "Store a named desired-state bundle (a set of YAML/JSON manifests) on the server, replacing any bundle with the same name. Nothing is applied until reconcile_bundle is called")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Bundle name"),
+			),
+			mcp.WithString("manifests",
+				mcp.Required(),
+				mcp.Description("YAML or JSON manifests; multiple YAML documents are separated by ---"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace for namespaced objects that do not set one (default: default)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			manifests, err := toolsets.RequiredParam[string](request, "manifests")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			objects, err := manifest.Decode([]byte(manifests))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(objects) == 0 {
+				return mcp.NewToolResultError("manifests contain no objects"), nil
+			}
+
+			bundle := &Bundle{
+				Name:      name,
+				Namespace: namespace,
+				Objects:   objects,
+				UpdatedAt: time.Now().UTC(),
+			}
+			h.store.Save(bundle)
+
+			r, err := json.Marshal(summarize(bundle))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// List creates a tool to list stored bundles
+func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_bundles",
+			mcp.WithDescription(h.t("TOOL_LIST_BUNDLES_DESCRIPTION", "List the desired-state bundles stored on the server")),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			bundles := h.store.List()
+			summaries := make([]Summary, 0, len(bundles))
+			for _, b := range bundles {
+				summaries = append(summaries, summarize(b))
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Delete creates a tool to remove a stored bundle
+func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_bundle",
+			mcp.WithDescription(h.t("TOOL_DELETE_BUNDLE_DESCRIPTION", "Remove a stored desired-state bundle. Objects already applied to the cluster are left untouched")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Bundle name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !h.store.Delete(name) {
+				return mcp.NewToolResultError(fmt.Sprintf("bundle %s not found", name)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Bundle %s deleted", name)), nil
+		}
+}
+
+// Drift creates a tool to report how the cluster differs from a stored bundle
+func (h *Handler) Drift() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_bundle_drift",
+			mcp.WithDescription(h.t("TOOL_GET_BUNDLE_DRIFT_DESCRIPTION", "Compare a stored desired-state bundle with the live cluster and report missing objects and drifted fields. Only fields set in the bundle are compared")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Bundle name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			bundle, ok := h.store.Get(name)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("bundle %s not found", name)), nil
+			}
+
+			client, err := h.manifestClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			report := Report{Bundle: bundle.Name, InSync: true, Objects: make([]ObjectStatus, 0, len(bundle.Objects))}
+			for _, desired := range bundle.Objects {
+				status := ObjectStatus{Status: StatusInSync}
+				live, err := client.Get(ctx, desired, bundle.Namespace)
+				status.Object = manifest.Ref(desired)
+				switch {
+				case apierrors.IsNotFound(err):
+					status.Status = StatusMissing
+				case err != nil:
+					status.Status = StatusError
+					status.Error = err.Error()
+				default:
+					if status.Differences = manifest.Diff(desired, live); len(status.Differences) > 0 {
+						status.Status = StatusDrifted
+					}
+				}
+				if status.Status != StatusInSync {
+					report.InSync = false
+				}
+				report.Objects = append(report.Objects, status)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Reconcile creates a tool to re-apply a stored bundle with server-side apply
+func (h *Handler) Reconcile() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("reconcile_bundle",
+			mcp.WithDescription(h.t("TOOL_RECONCILE_BUNDLE_DESCRIPTION", "Re-apply every object of a stored desired-state bundle to the cluster using server-side apply")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Bundle name"),
+			),
+			mcp.WithString("fieldManager",
+				mcp.Description("Field manager recorded for the applied fields (default: k8s-mcp-server)"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Take ownership of fields managed by other field managers"),
+			),
+			mcp.WithBoolean("dryRun",
+				mcp.Description("Validate the apply on the server without persisting it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldManager, err := toolsets.OptionalParam[string](request, "fieldManager")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := toolsets.OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, err := toolsets.OptionalParam[bool](request, "dryRun")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			bundle, ok := h.store.Get(name)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("bundle %s not found", name)), nil
+			}
+
+			client, err := h.manifestClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			opts := manifest.ApplyOptions{
+				FieldManager:     fieldManager,
+				Force:            force,
+				DryRun:           dryRun,
+				DefaultNamespace: bundle.Namespace,
+			}
+			report := Report{Bundle: bundle.Name, InSync: true, DryRun: dryRun, Objects: make([]ObjectStatus, 0, len(bundle.Objects))}
+			for _, obj := range bundle.Objects {
+				status := ObjectStatus{Status: StatusApplied}
+				_, err := client.Apply(ctx, obj, opts)
+				status.Object = manifest.Ref(obj)
+				if err != nil {
+					status.Status = StatusError
+					status.Error = err.Error()
+					report.InSync = false
+				}
+				report.Objects = append(report.Objects, status)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// manifestClient builds a discovery-backed client for applying and reading arbitrary kinds
+func (h *Handler) manifestClient(ctx context.Context) (*manifest.Client, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	dynamicClient, err := h.getDynamicClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+	}
+	return manifest.NewClient(client.Discovery(), dynamicClient)
+}
+
+func summarize(b *Bundle) Summary {
+	objects := make([]string, 0, len(b.Objects))
+	for _, obj := range b.Objects {
+		objects = append(objects, manifest.Ref(obj))
+	}
+	return Summary{
+		Name:      b.Name,
+		Namespace: b.Namespace,
+		Objects:   objects,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
@@ -0,0 +1,272 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a fake dynamic client
+func stubGetDynamicClientFn(client dynamic.Interface) toolsets.GetDynamicClientFn {
+	return func(ctx context.Context) (dynamic.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// Helper function to create a fake clientset whose discovery serves config maps
+func newFakeClientset() *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+		},
+	}
+	return client
+}
+
+// Helper function to create a fake dynamic client whose server-side apply creates missing objects
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMapsGVR: "ConfigMapList"}, objects...)
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patch.GetPatch(), &obj.Object); err != nil {
+			return true, nil, err
+		}
+		_, err := client.Tracker().Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if apierrors.IsNotFound(err) {
+			err = client.Tracker().Create(patch.GetResource(), obj, patch.GetNamespace())
+		} else if err == nil {
+			err = client.Tracker().Update(patch.GetResource(), obj, patch.GetNamespace())
+		}
+		return true, obj, err
+	})
+	return client
+}
+
+func configMap(namespace, name string, data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"data":       data,
+	}}
+}
+
+const testManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  mode: production
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: flags
+data:
+  beta: "false"
+`
+
+func TestRegisterTools(t *testing.T) {
+	names := func(tools []server.ServerTool) []string {
+		var names []string
+		for _, tool := range tools {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(newFakeDynamicClient()), translations.NullTranslationHelper)
+
+	toolset := toolsets.NewToolset("test", "test", false)
+	handler.RegisterTools(toolset)
+	assert.ElementsMatch(t, []string{"list_bundles", "get_bundle_drift", "save_bundle", "delete_bundle", "reconcile_bundle"}, names(toolset.GetActiveTools()))
+
+	// Read-only clients cannot change the bundles other clients reconcile
+	toolset = toolsets.NewToolset("test", "test", true)
+	handler.RegisterTools(toolset)
+	assert.ElementsMatch(t, []string{"list_bundles", "get_bundle_drift"}, names(toolset.GetActiveTools()))
+}
+
+func TestSaveAndListBundles(t *testing.T) {
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(newFakeDynamicClient()), translations.NullTranslationHelper)
+	saveTool, saveFn := handler.Save()
+	listTool, listFn := handler.List()
+	deleteTool, deleteFn := handler.Delete()
+
+	assert.Equal(t, "save_bundle", saveTool.Name)
+	assert.ElementsMatch(t, saveTool.InputSchema.Required, []string{"name", "manifests"})
+	assert.Equal(t, "list_bundles", listTool.Name)
+	assert.Equal(t, "delete_bundle", deleteTool.Name)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful save",
+			requestArgs: map[string]interface{}{"name": "app", "manifests": testManifests, "namespace": "shop"},
+		},
+		{
+			name:           "invalid manifests",
+			requestArgs:    map[string]interface{}{"name": "broken", "manifests": "apiVersion: v1\nkind: ConfigMap\n"},
+			expectedErrMsg: "missing metadata.name",
+		},
+		{
+			name:           "empty manifests",
+			requestArgs:    map[string]interface{}{"name": "empty", "manifests": "---\n"},
+			expectedErrMsg: "manifests contain no objects",
+		},
+		{
+			name:           "missing required param: manifests",
+			requestArgs:    map[string]interface{}{"name": "app"},
+			expectedErrMsg: "missing required parameter: manifests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := saveFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var summary Summary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+			assert.Equal(t, "app", summary.Name)
+			assert.Equal(t, []string{"v1 ConfigMap settings", "v1 ConfigMap flags"}, summary.Objects)
+		})
+	}
+
+	result, err := listFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	var summaries []Summary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "shop", summaries[0].Namespace)
+
+	result, err = deleteFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app"}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	result, err = deleteFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "bundle app not found")
+}
+
+func TestBundleDriftAndReconcile(t *testing.T) {
+	dynamicClient := newFakeDynamicClient(configMap("shop", "settings", map[string]interface{}{"mode": "debug"}))
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+	_, saveFn := handler.Save()
+	driftTool, driftFn := handler.Drift()
+	reconcileTool, reconcileFn := handler.Reconcile()
+
+	assert.Equal(t, "get_bundle_drift", driftTool.Name)
+	assert.Equal(t, "reconcile_bundle", reconcileTool.Name)
+	assert.ElementsMatch(t, reconcileTool.InputSchema.Required, []string{"name"})
+
+	result, err := saveFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app", "manifests": testManifests, "namespace": "shop"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	drift := func() Report {
+		result, err := driftFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var report Report
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+		return report
+	}
+
+	report := drift()
+	assert.False(t, report.InSync)
+	require.Len(t, report.Objects, 2)
+	assert.Equal(t, ObjectStatus{Object: "v1 ConfigMap shop/settings", Status: StatusDrifted, Differences: []string{"data.mode"}}, report.Objects[0])
+	assert.Equal(t, ObjectStatus{Object: "v1 ConfigMap shop/flags", Status: StatusMissing}, report.Objects[1])
+
+	t.Run("dry run is reported", func(t *testing.T) {
+		result, err := reconcileFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app", "dryRun": true}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var reconciled Report
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reconciled))
+		assert.True(t, reconciled.DryRun)
+	})
+
+	result, err = reconcileFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "app"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var reconciled Report
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reconciled))
+	assert.True(t, reconciled.InSync)
+	for _, obj := range reconciled.Objects {
+		assert.Equal(t, StatusApplied, obj.Status)
+	}
+
+	report = drift()
+	assert.True(t, report.InSync)
+
+	result, err = driftFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "unknown"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "bundle unknown not found")
+}
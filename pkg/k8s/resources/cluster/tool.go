@@ -0,0 +1,936 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// Handler implements the K8sResourceHandler interface for cluster-wide inspection tools
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+	now       func() time.Time
+}
+
+// NewHandler creates a new cluster handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+		now:       time.Now,
+	}
+}
+
+// Addon is a detected cluster add-on and the version it runs
+type Addon struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Namespace string `json:"namespace,omitempty"`
+	Workload  string `json:"workload,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Image     string `json:"image,omitempty"`
+	Ready     string `json:"ready,omitempty"`
+}
+
+// addonRule describes how to recognise an add-on from its Deployment or DaemonSet
+type addonRule struct {
+	name     string
+	category string
+	labels   map[string]string
+	names    []string
+}
+
+var addonRules = []addonRule{
+	// CNI plugins
+	{name: "calico", category: "cni", labels: map[string]string{"k8s-app": "calico-node"}, names: []string{"calico-node"}},
+	{name: "cilium", category: "cni", labels: map[string]string{"k8s-app": "cilium"}, names: []string{"cilium"}},
+	{name: "flannel", category: "cni", labels: map[string]string{"app": "flannel"}, names: []string{"kube-flannel-ds"}},
+	{name: "weave-net", category: "cni", labels: map[string]string{"name": "weave-net"}, names: []string{"weave-net"}},
+	{name: "aws-vpc-cni", category: "cni", labels: map[string]string{"k8s-app": "aws-node"}, names: []string{"aws-node"}},
+	{name: "antrea", category: "cni", labels: map[string]string{"component": "antrea-agent"}, names: []string{"antrea-agent"}},
+	// Ingress controllers
+	{name: "ingress-nginx", category: "ingress-controller", labels: map[string]string{"app.kubernetes.io/name": "ingress-nginx"}, names: []string{"ingress-nginx-controller"}},
+	{name: "traefik", category: "ingress-controller", labels: map[string]string{"app.kubernetes.io/name": "traefik"}, names: []string{"traefik"}},
+	{name: "contour", category: "ingress-controller", labels: map[string]string{"app.kubernetes.io/name": "contour"}, names: []string{"contour"}},
+	{name: "haproxy-ingress", category: "ingress-controller", labels: map[string]string{"app.kubernetes.io/name": "haproxy-ingress"}, names: []string{"haproxy-ingress"}},
+	{name: "aws-load-balancer-controller", category: "ingress-controller", labels: map[string]string{"app.kubernetes.io/name": "aws-load-balancer-controller"}, names: []string{"aws-load-balancer-controller"}},
+	// Core and common platform add-ons
+	{name: "coredns", category: "dns", labels: map[string]string{"k8s-app": "kube-dns"}, names: []string{"coredns"}},
+	{name: "kube-proxy", category: "networking", labels: map[string]string{"k8s-app": "kube-proxy"}, names: []string{"kube-proxy"}},
+	{name: "metrics-server", category: "metrics", labels: map[string]string{"k8s-app": "metrics-server", "app.kubernetes.io/name": "metrics-server"}, names: []string{"metrics-server"}},
+	{name: "cert-manager", category: "certificates", labels: map[string]string{"app.kubernetes.io/name": "cert-manager"}, names: []string{"cert-manager"}},
+}
+
+// RegisterTools registers all cluster tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	addonsTool, addonsHandler := h.ListAddons()
+	toolset.AddReadTool(addonsTool, addonsHandler)
+
+	featureGatesTool, featureGatesHandler := h.GetFeatureGates()
+	toolset.AddReadTool(featureGatesTool, featureGatesHandler)
+
+	digestTool, digestHandler := h.Digest()
+	toolset.AddReadTool(digestTool, digestHandler)
+
+	overviewTool, overviewHandler := h.Overview()
+	toolset.AddReadTool(overviewTool, overviewHandler)
+}
+
+// ListAddons creates a tool that detects common cluster add-ons and reports their versions
+func (h *Handler) ListAddons() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_cluster_addons",
+			mcp.WithDescription(h.t("TOOL_LIST_CLUSTER_ADDONS_DESCRIPTION", "Detect common cluster add-ons (CNI, ingress controllers, CoreDNS, kube-proxy, metrics-server, cert-manager, CSI drivers) by well-known labels and names, and report their versions")),
+			mcp.WithString("category",
+				mcp.Description("Only report add-ons of this category (cni, ingress-controller, dns, networking, metrics, certificates, csi)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			category, err := toolsets.OptionalParam[string](request, "category")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployments, err := client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list deployments: %v", err)), nil
+			}
+			daemonSets, err := client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list daemonsets: %v", err)), nil
+			}
+			csiDrivers, err := client.StorageV1().CSIDrivers().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list CSI drivers: %v", err)), nil
+			}
+
+			addons := []Addon{}
+			for _, d := range deployments.Items {
+				ready := fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas)
+				if addon, ok := detectAddon("Deployment", d.ObjectMeta, d.Spec.Template.Spec, ready); ok {
+					addons = append(addons, addon)
+				}
+			}
+			for _, ds := range daemonSets.Items {
+				ready := fmt.Sprintf("%d/%d", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+				if addon, ok := detectAddon("DaemonSet", ds.ObjectMeta, ds.Spec.Template.Spec, ready); ok {
+					addons = append(addons, addon)
+				}
+			}
+			for _, driver := range csiDrivers.Items {
+				addons = append(addons, Addon{
+					Name:     driver.Name,
+					Category: "csi",
+					Version:  driver.Labels["app.kubernetes.io/version"],
+				})
+			}
+
+			if category != "" {
+				filtered := []Addon{}
+				for _, addon := range addons {
+					if addon.Category == category {
+						filtered = append(filtered, addon)
+					}
+				}
+				addons = filtered
+			}
+
+			sort.Slice(addons, func(i, j int) bool {
+				if addons[i].Category != addons[j].Category {
+					return addons[i].Category < addons[j].Category
+				}
+				return addons[i].Name < addons[j].Name
+			})
+
+			r, err := json.Marshal(addons)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// detectAddon matches a workload against the known add-on rules
+func detectAddon(kind string, meta metav1.ObjectMeta, spec corev1.PodSpec, ready string) (Addon, bool) {
+	for _, rule := range addonRules {
+		if !rule.matches(meta) {
+			continue
+		}
+		addon := Addon{
+			Name:      rule.name,
+			Category:  rule.category,
+			Namespace: meta.Namespace,
+			Workload:  kind + "/" + meta.Name,
+			Version:   meta.Labels["app.kubernetes.io/version"],
+			Ready:     ready,
+		}
+		if len(spec.Containers) > 0 {
+			addon.Image = spec.Containers[0].Image
+			if addon.Version == "" {
+				addon.Version = imageTag(addon.Image)
+			}
+		}
+		return addon, true
+	}
+	return Addon{}, false
+}
+
+func (r addonRule) matches(meta metav1.ObjectMeta) bool {
+	for key, value := range r.labels {
+		if meta.Labels[key] == value {
+			return true
+		}
+	}
+	for _, name := range r.names {
+		if meta.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// imageTag returns the tag of an image reference, ignoring any digest
+func imageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	return image[i+1:]
+}
+
+// FeatureGate is a feature gate reported by the API server
+type FeatureGate struct {
+	Name    string `json:"name"`
+	Stage   string `json:"stage,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Capability is a commonly asked-about feature and whether the cluster supports it
+type Capability struct {
+	Name      string `json:"name"`
+	Gate      string `json:"gate"`
+	Supported bool   `json:"supported"`
+	Source    string `json:"source"`
+}
+
+// FeatureDiscovery is the result of the feature gate discovery tool
+type FeatureDiscovery struct {
+	ServerVersion    string        `json:"serverVersion"`
+	FeatureGates     []FeatureGate `json:"featureGates,omitempty"`
+	AdmissionPlugins []string      `json:"admissionPlugins,omitempty"`
+	Capabilities     []Capability  `json:"capabilities"`
+	Notes            []string      `json:"notes,omitempty"`
+}
+
+// knownCapability maps a capability to its feature gate and the minor release where it became enabled by default
+type knownCapability struct {
+	name         string
+	gate         string
+	defaultMinor int
+}
+
+var knownCapabilities = []knownCapability{
+	{name: "ephemeralContainers", gate: "EphemeralContainers", defaultMinor: 23},
+	{name: "sidecarContainers", gate: "SidecarContainers", defaultMinor: 29},
+	{name: "validatingAdmissionPolicy", gate: "ValidatingAdmissionPolicy", defaultMinor: 30},
+	{name: "podSchedulingReadiness", gate: "PodSchedulingReadiness", defaultMinor: 27},
+	{name: "inPlacePodVerticalScaling", gate: "InPlacePodVerticalScaling", defaultMinor: 33},
+	{name: "userNamespaces", gate: "UserNamespacesSupport", defaultMinor: 33},
+}
+
+var (
+	featureEnabledMetric  = regexp.MustCompile(`^kubernetes_feature_enabled\{([^}]*)\}\s+(\S+)`)
+	admissionPluginMetric = regexp.MustCompile(`^apiserver_admission_plugin_admission_duration_seconds_count\{([^}]*)\}`)
+	metricLabel           = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// GetFeatureGates creates a tool that discovers API server feature gates and admission plugins
+func (h *Handler) GetFeatureGates() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_feature_gates",
+			mcp.WithDescription(h.t("TOOL_GET_FEATURE_GATES_DESCRIPTION", "Discover API server feature gates and admission plugins from its metrics endpoint where exposed, falling back to server version heuristics. Reports whether common capabilities such as ephemeral and sidecar containers are supported")),
+			mcp.WithBoolean("includeAllGates",
+				mcp.Description("Include every feature gate reported by the API server, not just the summarized capabilities"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			includeAllGates, err := toolsets.OptionalParam[bool](request, "includeAllGates")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			serverVersion, err := client.Discovery().ServerVersion()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get server version: %v", err)), nil
+			}
+
+			var gates []FeatureGate
+			var plugins []string
+			var notes []string
+			if restClient := client.Discovery().RESTClient(); restClient != nil {
+				metrics, err := restClient.Get().AbsPath("/metrics").DoRaw(ctx)
+				if err != nil {
+					notes = append(notes, fmt.Sprintf("API server metrics unavailable (%v); capabilities are inferred from the server version", err))
+				} else {
+					gates, plugins = ParseAPIServerMetrics(metrics)
+				}
+			} else {
+				notes = append(notes, "API server metrics unavailable; capabilities are inferred from the server version")
+			}
+
+			discovery := FeatureDiscovery{
+				ServerVersion:    serverVersion.GitVersion,
+				AdmissionPlugins: plugins,
+				Capabilities:     capabilities(gates, serverVersion),
+				Notes:            notes,
+			}
+			if includeAllGates {
+				discovery.FeatureGates = gates
+			}
+			if len(plugins) == 0 {
+				discovery.Notes = append(discovery.Notes, "enabled admission plugins are only listed once they have handled a request")
+			}
+
+			r, err := json.Marshal(discovery)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ParseAPIServerMetrics extracts feature gates and admission plugin names from API server metrics text
+func ParseAPIServerMetrics(metrics []byte) ([]FeatureGate, []string) {
+	gates := []FeatureGate{}
+	pluginSet := map[string]struct{}{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := featureEnabledMetric.FindStringSubmatch(line); m != nil {
+			labels := parseMetricLabels(m[1])
+			value, _ := strconv.ParseFloat(m[2], 64)
+			gates = append(gates, FeatureGate{Name: labels["name"], Stage: labels["stage"], Enabled: value == 1})
+			continue
+		}
+		if m := admissionPluginMetric.FindStringSubmatch(line); m != nil {
+			if name := parseMetricLabels(m[1])["name"]; name != "" {
+				pluginSet[name] = struct{}{}
+			}
+		}
+	}
+
+	plugins := make([]string, 0, len(pluginSet))
+	for name := range pluginSet {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	sort.Slice(gates, func(i, j int) bool { return gates[i].Name < gates[j].Name })
+	return gates, plugins
+}
+
+func parseMetricLabels(s string) map[string]string {
+	labels := map[string]string{}
+	for _, m := range metricLabel.FindAllStringSubmatch(s, -1) {
+		labels[m[1]] = m[2]
+	}
+	return labels
+}
+
+// capabilities resolves known capabilities from reported gates, falling back to the server version
+func capabilities(gates []FeatureGate, serverVersion *version.Info) []Capability {
+	reported := map[string]bool{}
+	for _, gate := range gates {
+		reported[gate.Name] = gate.Enabled
+	}
+	minor := minorVersion(serverVersion)
+
+	result := make([]Capability, 0, len(knownCapabilities))
+	for _, known := range knownCapabilities {
+		capability := Capability{Name: known.name, Gate: known.gate}
+		if enabled, ok := reported[known.gate]; ok {
+			capability.Supported = enabled
+			capability.Source = "metrics"
+		} else {
+			// Gates are removed from the metrics once they are locked to GA
+			capability.Supported = minor >= known.defaultMinor
+			capability.Source = "version"
+		}
+		result = append(result, capability)
+	}
+	return result
+}
+
+// minorVersion parses the minor version, tolerating provider suffixes such as "29+"
+func minorVersion(info *version.Info) int {
+	minor, err := strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	if err != nil {
+		return 0
+	}
+	return minor
+}
+
+// Rollout is a deployment revision rolled out during the digest window
+type Rollout struct {
+	Namespace  string    `json:"namespace"`
+	Deployment string    `json:"deployment"`
+	Revision   string    `json:"revision,omitempty"`
+	Images     []string  `json:"images"`
+	StartedAt  time.Time `json:"startedAt"`
+	Ready      string    `json:"ready"`
+}
+
+// NodeChange is a node added to or removed from the cluster
+type NodeChange struct {
+	Name   string    `json:"name"`
+	Change string    `json:"change"`
+	At     time.Time `json:"at"`
+}
+
+// EventHotspot is an object that received many warning events
+type EventHotspot struct {
+	Namespace string    `json:"namespace,omitempty"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Reasons   []string  `json:"reasons"`
+	Count     int32     `json:"count"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// CrashLoop is a container that started crash looping during the digest window
+type CrashLoop struct {
+	Namespace  string    `json:"namespace"`
+	Pod        string    `json:"pod"`
+	Container  string    `json:"container"`
+	Restarts   int32     `json:"restarts"`
+	LastReason string    `json:"lastReason,omitempty"`
+	Since      time.Time `json:"since"`
+}
+
+// ActivityDigest summarizes cluster activity over a time window
+type ActivityDigest struct {
+	Since         time.Time      `json:"since"`
+	Until         time.Time      `json:"until"`
+	Rollouts      []Rollout      `json:"rollouts"`
+	Nodes         []NodeChange   `json:"nodes"`
+	WarningEvents int32          `json:"warningEvents"`
+	Hotspots      []EventHotspot `json:"hotspots"`
+	CrashLoops    []CrashLoop    `json:"crashLoops"`
+	Notes         []string       `json:"notes,omitempty"`
+}
+
+// Node changes
+const (
+	NodeAdded   = "added"
+	NodeRemoved = "removed"
+)
+
+// nodeRemovalReasons are the event reasons the node lifecycle controller records when a node goes away
+var nodeRemovalReasons = map[string]bool{"RemovingNode": true, "DeletingNode": true}
+
+// Digest creates a tool that summarizes recent cluster activity
+func (h *Handler) Digest() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cluster_digest",
+			mcp.WithDescription(h.t("TOOL_CLUSTER_DIGEST_DESCRIPTION", "Summarize cluster activity over the last hours: deployments rolled out, nodes added or removed, objects with the most warning events, and containers that started crash looping. Suited to a daily standup summary")),
+			mcp.WithNumber("hours",
+				mcp.Description("Length of the window in hours (default 24)"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Limit rollouts, events and crash loops to a namespace (all namespaces if omitted)"),
+			),
+			mcp.WithNumber("topHotspots",
+				mcp.Description("Number of warning event hotspots to report (default 10)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			hours, err := toolsets.OptionalParam[float64](request, "hours")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if hours == 0 {
+				hours = 24
+			}
+			if hours < 0 {
+				return mcp.NewToolResultError("hours must be positive"), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			topHotspots, err := toolsets.OptionalParam[float64](request, "topHotspots")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if topHotspots <= 0 {
+				topHotspots = 10
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			until := h.now()
+			since := until.Add(-time.Duration(hours * float64(time.Hour)))
+
+			replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list replicasets: %v", err)), nil
+			}
+			nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
+			}
+			events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list events: %v", err)), nil
+			}
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+
+			digest := ActivityDigest{
+				Since:      since,
+				Until:      until,
+				Rollouts:   rollouts(replicaSets.Items, since),
+				Nodes:      nodeChanges(nodes.Items, events.Items, since),
+				CrashLoops: crashLoops(pods.Items, events.Items, since),
+			}
+			digest.WarningEvents, digest.Hotspots = hotspots(events.Items, since, int(topHotspots))
+			if hours > 1 {
+				digest.Notes = append(digest.Notes, "the API server keeps events for one hour by default, so hotspots and node removals may not cover the whole window")
+			}
+
+			r, err := json.Marshal(digest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// rollouts reports ReplicaSets created by a Deployment inside the window, each being a new revision.
+// Rollbacks to an existing ReplicaSet do not create one and are not reported.
+func rollouts(replicaSets []appsv1.ReplicaSet, since time.Time) []Rollout {
+	result := []Rollout{}
+	for _, rs := range replicaSets {
+		owner := metav1.GetControllerOf(&rs)
+		if owner == nil || owner.Kind != "Deployment" || rs.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		var images []string
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		desired := int32(1)
+		if rs.Spec.Replicas != nil {
+			desired = *rs.Spec.Replicas
+		}
+		result = append(result, Rollout{
+			Namespace:  rs.Namespace,
+			Deployment: owner.Name,
+			Revision:   rs.Annotations["deployment.kubernetes.io/revision"],
+			Images:     images,
+			StartedAt:  rs.CreationTimestamp.Time,
+			Ready:      fmt.Sprintf("%d/%d", rs.Status.ReadyReplicas, desired),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartedAt.Before(result[j].StartedAt) })
+	return result
+}
+
+// nodeChanges reports nodes created inside the window and nodes whose removal was recorded by an event
+func nodeChanges(nodes []corev1.Node, events []corev1.Event, since time.Time) []NodeChange {
+	result := []NodeChange{}
+	current := map[string]bool{}
+	for _, node := range nodes {
+		current[node.Name] = true
+		if !node.CreationTimestamp.Time.Before(since) {
+			result = append(result, NodeChange{Name: node.Name, Change: NodeAdded, At: node.CreationTimestamp.Time})
+		}
+	}
+	removed := map[string]time.Time{}
+	for _, event := range events {
+		if event.InvolvedObject.Kind != "Node" || !nodeRemovalReasons[event.Reason] || current[event.InvolvedObject.Name] {
+			continue
+		}
+		if at := eventTime(event); !at.Before(since) && at.After(removed[event.InvolvedObject.Name]) {
+			removed[event.InvolvedObject.Name] = at
+		}
+	}
+	for name, at := range removed {
+		result = append(result, NodeChange{Name: name, Change: NodeRemoved, At: at})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].At.Before(result[j].At) })
+	return result
+}
+
+// hotspots totals warning events seen inside the window and returns the objects that received the most
+func hotspots(events []corev1.Event, since time.Time, top int) (int32, []EventHotspot) {
+	var total int32
+	byObject := map[string]*EventHotspot{}
+	for _, event := range events {
+		at := eventTime(event)
+		if event.Type != corev1.EventTypeWarning || at.Before(since) {
+			continue
+		}
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		total += count
+
+		obj := event.InvolvedObject
+		key := obj.Namespace + "/" + obj.Kind + "/" + obj.Name
+		spot, ok := byObject[key]
+		if !ok {
+			spot = &EventHotspot{Namespace: obj.Namespace, Kind: obj.Kind, Name: obj.Name}
+			byObject[key] = spot
+		}
+		spot.Count += count
+		if !containsString(spot.Reasons, event.Reason) {
+			spot.Reasons = append(spot.Reasons, event.Reason)
+		}
+		if at.After(spot.LastSeen) {
+			spot.LastSeen = at
+		}
+	}
+
+	result := []EventHotspot{}
+	for _, spot := range byObject {
+		sort.Strings(spot.Reasons)
+		result = append(result, *spot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Namespace+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Name
+	})
+	if len(result) > top {
+		result = result[:top]
+	}
+	return total, result
+}
+
+// crashLoops reports containers in CrashLoopBackOff whose crash loop began inside the window. The onset is
+// the first BackOff event recorded for the pod, or the pod creation time when no such event is retained.
+func crashLoops(pods []corev1.Pod, events []corev1.Event, since time.Time) []CrashLoop {
+	backOffSince := map[string]time.Time{}
+	for _, event := range events {
+		if event.InvolvedObject.Kind != "Pod" || event.Reason != "BackOff" {
+			continue
+		}
+		key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		first := event.FirstTimestamp.Time
+		if first.IsZero() {
+			first = eventTime(event)
+		}
+		if existing, ok := backOffSince[key]; !ok || first.Before(existing) {
+			backOffSince[key] = first
+		}
+	}
+
+	result := []CrashLoop{}
+	for _, pod := range pods {
+		onset, ok := backOffSince[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			onset = pod.CreationTimestamp.Time
+		}
+		if onset.Before(since) {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+				continue
+			}
+			loop := CrashLoop{Namespace: pod.Namespace, Pod: pod.Name, Container: status.Name, Restarts: status.RestartCount, Since: onset}
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				loop.LastReason = terminated.Reason
+			}
+			result = append(result, loop)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Since.Before(result[j].Since) })
+	return result
+}
+
+// NodeHealth summarizes the readiness of the nodes
+type NodeHealth struct {
+	Total    int      `json:"total"`
+	Ready    int      `json:"ready"`
+	NotReady []string `json:"notReady,omitempty"`
+	Cordoned []string `json:"cordoned,omitempty"`
+	// Pressure lists nodes under resource pressure as node:condition
+	Pressure []string `json:"pressure,omitempty"`
+}
+
+// NamespacePods counts the pods of a namespace that are not running, by phase
+type NamespacePods struct {
+	Namespace  string         `json:"namespace"`
+	NotRunning int            `json:"notRunning"`
+	Phases     map[string]int `json:"phases"`
+}
+
+// PodHealth summarizes the pods that are not running. Succeeded pods have completed and are not counted.
+type PodHealth struct {
+	Total       int             `json:"total"`
+	NotRunning  int             `json:"notRunning"`
+	ByNamespace []NamespacePods `json:"byNamespace"`
+}
+
+// PendingClaim is a PersistentVolumeClaim that is not bound
+type PendingClaim struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// FailingDeployment is a deployment with unavailable replicas or a stalled rollout
+type FailingDeployment struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Ready     string `json:"ready"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Overview is a compact health summary of the cluster
+type Overview struct {
+	Nodes              NodeHealth          `json:"nodes"`
+	Pods               PodHealth           `json:"pods"`
+	PendingClaims      []PendingClaim      `json:"pendingClaims"`
+	FailingDeployments []FailingDeployment `json:"failingDeployments"`
+	Healthy            bool                `json:"healthy"`
+}
+
+// nodePressureConditions are the node conditions that are unhealthy when true
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// Overview creates a tool that summarizes the health of the cluster in one call
+func (h *Handler) Overview() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cluster_overview",
+			mcp.WithDescription(h.t("TOOL_CLUSTER_OVERVIEW_DESCRIPTION", "Summarize cluster health in one compact call to start triage: node readiness, the count of pods that are not running per namespace, pending PersistentVolumeClaims and deployments with unavailable replicas")),
+			mcp.WithString("namespace",
+				mcp.Description("Limit pods, claims and deployments to a namespace (all namespaces if omitted)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
+			}
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+			claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list persistentvolumeclaims: %v", err)), nil
+			}
+			deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list deployments: %v", err)), nil
+			}
+
+			overview := Overview{
+				Nodes:              nodeHealth(nodes.Items),
+				Pods:               podHealth(pods.Items),
+				PendingClaims:      pendingClaims(claims.Items),
+				FailingDeployments: failingDeployments(deployments.Items),
+			}
+			overview.Healthy = len(overview.Nodes.NotReady) == 0 && len(overview.Nodes.Pressure) == 0 &&
+				overview.Pods.NotRunning == 0 && len(overview.PendingClaims) == 0 && len(overview.FailingDeployments) == 0
+
+			r, err := json.Marshal(overview)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func nodeHealth(nodes []corev1.Node) NodeHealth {
+	health := NodeHealth{Total: len(nodes)}
+	for _, node := range nodes {
+		ready := false
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady {
+				ready = c.Status == corev1.ConditionTrue
+			}
+			for _, pressure := range nodePressureConditions {
+				if c.Type == pressure && c.Status == corev1.ConditionTrue {
+					health.Pressure = append(health.Pressure, node.Name+":"+string(c.Type))
+				}
+			}
+		}
+		if ready {
+			health.Ready++
+		} else {
+			health.NotReady = append(health.NotReady, node.Name)
+		}
+		if node.Spec.Unschedulable {
+			health.Cordoned = append(health.Cordoned, node.Name)
+		}
+	}
+	sort.Strings(health.NotReady)
+	sort.Strings(health.Cordoned)
+	sort.Strings(health.Pressure)
+	return health
+}
+
+func podHealth(pods []corev1.Pod) PodHealth {
+	health := PodHealth{Total: len(pods), ByNamespace: []NamespacePods{}}
+	byNamespace := map[string]*NamespacePods{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		phase := string(pod.Status.Phase)
+		if phase == "" {
+			phase = string(corev1.PodUnknown)
+		}
+		ns, ok := byNamespace[pod.Namespace]
+		if !ok {
+			ns = &NamespacePods{Namespace: pod.Namespace, Phases: map[string]int{}}
+			byNamespace[pod.Namespace] = ns
+		}
+		ns.NotRunning++
+		ns.Phases[phase]++
+		health.NotRunning++
+	}
+	for _, ns := range byNamespace {
+		health.ByNamespace = append(health.ByNamespace, *ns)
+	}
+	sort.Slice(health.ByNamespace, func(i, j int) bool {
+		if health.ByNamespace[i].NotRunning != health.ByNamespace[j].NotRunning {
+			return health.ByNamespace[i].NotRunning > health.ByNamespace[j].NotRunning
+		}
+		return health.ByNamespace[i].Namespace < health.ByNamespace[j].Namespace
+	})
+	return health
+}
+
+func pendingClaims(claims []corev1.PersistentVolumeClaim) []PendingClaim {
+	result := []PendingClaim{}
+	for _, claim := range claims {
+		if claim.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		pending := PendingClaim{Namespace: claim.Namespace, Name: claim.Name}
+		if claim.Spec.StorageClassName != nil {
+			pending.StorageClass = *claim.Spec.StorageClassName
+		}
+		result = append(result, pending)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Name
+	})
+	return result
+}
+
+// failingDeployments reports deployments with fewer available replicas than desired, or whose rollout
+// has stopped progressing
+func failingDeployments(deployments []appsv1.Deployment) []FailingDeployment {
+	result := []FailingDeployment{}
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		var reason string
+		for _, c := range d.Status.Conditions {
+			if (c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse) ||
+				(c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue) {
+				reason = c.Reason
+			}
+		}
+		if d.Status.AvailableReplicas >= desired && reason == "" {
+			continue
+		}
+		result = append(result, FailingDeployment{
+			Namespace: d.Namespace,
+			Name:      d.Name,
+			Ready:     fmt.Sprintf("%d/%d", d.Status.AvailableReplicas, desired),
+			Reason:    reason,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Name
+	})
+	return result
+}
+
+// eventTime returns when an event was last observed, falling back through the fields older and newer clients set
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
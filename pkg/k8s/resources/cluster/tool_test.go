@@ -0,0 +1,436 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func podSpec(image string) corev1.PodSpec {
+	return corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}}
+}
+
+func TestListAddons(t *testing.T) {
+	calico := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "calico-node", Namespace: "kube-system", Labels: map[string]string{"k8s-app": "calico-node"}},
+		Spec:       appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("docker.io/calico/node:v3.27.0")}},
+		Status:     appsv1.DaemonSetStatus{NumberReady: 3, DesiredNumberScheduled: 3},
+	}
+	nginx := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress-nginx-controller",
+			Namespace: "ingress-nginx",
+			Labels:    map[string]string{"app.kubernetes.io/name": "ingress-nginx", "app.kubernetes.io/version": "1.9.4"},
+		},
+		Spec:   appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("registry.k8s.io/ingress-nginx/controller:v1.9.4@sha256:abc")}},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, Replicas: 2},
+	}
+	metricsServer := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-server", Namespace: "kube-system"},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("registry.k8s.io/metrics-server/metrics-server:v0.7.0")}},
+	}
+	unrelated := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("nginx:1.25")}},
+	}
+	driver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "ebs.csi.aws.com"}}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(calico, nginx, metricsServer, unrelated, driver)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListAddons()
+
+	assert.Equal(t, "list_cluster_addons", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	t.Run("all add-ons", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var addons []Addon
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &addons))
+
+		byName := map[string]Addon{}
+		for _, addon := range addons {
+			byName[addon.Name] = addon
+		}
+		assert.Len(t, byName, 4)
+		assert.Equal(t, "v3.27.0", byName["calico"].Version)
+		assert.Equal(t, "3/3", byName["calico"].Ready)
+		assert.Equal(t, "1.9.4", byName["ingress-nginx"].Version)
+		assert.Equal(t, "Deployment/ingress-nginx-controller", byName["ingress-nginx"].Workload)
+		assert.Equal(t, "v0.7.0", byName["metrics-server"].Version)
+		assert.Equal(t, "csi", byName["ebs.csi.aws.com"].Category)
+		assert.NotContains(t, byName, "web")
+	})
+
+	t.Run("filter by category", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"category": "cni"}))
+		require.NoError(t, err)
+
+		var addons []Addon
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &addons))
+		require.Len(t, addons, 1)
+		assert.Equal(t, "calico", addons[0].Name)
+	})
+}
+
+func TestImageTag(t *testing.T) {
+	assert.Equal(t, "v1.2.3", imageTag("registry.example.com:5000/team/app:v1.2.3"))
+	assert.Equal(t, "", imageTag("registry.example.com:5000/team/app"))
+	assert.Equal(t, "1.0", imageTag("app:1.0@sha256:deadbeef"))
+}
+
+const testMetrics = `# HELP kubernetes_feature_enabled [BETA] This metric records the data about the stage and enablement of a k8s feature.
+# TYPE kubernetes_feature_enabled gauge
+kubernetes_feature_enabled{name="SidecarContainers",stage="BETA"} 1
+kubernetes_feature_enabled{name="InPlacePodVerticalScaling",stage="ALPHA"} 0
+apiserver_admission_plugin_admission_duration_seconds_count{name="NamespaceLifecycle",operation="CREATE",rejected="false",type="validate"} 12
+apiserver_admission_plugin_admission_duration_seconds_count{name="NamespaceLifecycle",operation="UPDATE",rejected="false",type="validate"} 3
+apiserver_admission_plugin_admission_duration_seconds_count{name="MutatingAdmissionWebhook",operation="CREATE",rejected="false",type="admit"} 5
+`
+
+func TestParseAPIServerMetrics(t *testing.T) {
+	gates, plugins := ParseAPIServerMetrics([]byte(testMetrics))
+
+	assert.Equal(t, []FeatureGate{
+		{Name: "InPlacePodVerticalScaling", Stage: "ALPHA", Enabled: false},
+		{Name: "SidecarContainers", Stage: "BETA", Enabled: true},
+	}, gates)
+	assert.Equal(t, []string{"MutatingAdmissionWebhook", "NamespaceLifecycle"}, plugins)
+}
+
+func TestCapabilities(t *testing.T) {
+	gates := []FeatureGate{{Name: "SidecarContainers", Enabled: false}}
+	result := capabilities(gates, &version.Info{Major: "1", Minor: "30+"})
+
+	byName := map[string]Capability{}
+	for _, c := range result {
+		byName[c.Name] = c
+	}
+	// Reported gates take precedence over version heuristics
+	assert.False(t, byName["sidecarContainers"].Supported)
+	assert.Equal(t, "metrics", byName["sidecarContainers"].Source)
+	assert.True(t, byName["ephemeralContainers"].Supported)
+	assert.True(t, byName["validatingAdmissionPolicy"].Supported)
+	assert.False(t, byName["inPlacePodVerticalScaling"].Supported)
+	assert.Equal(t, "version", byName["inPlacePodVerticalScaling"].Source)
+}
+
+func TestGetFeatureGates(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{Major: "1", Minor: "28", GitVersion: "v1.28.5"}
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.GetFeatureGates()
+
+	assert.Equal(t, "get_feature_gates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var discovery FeatureDiscovery
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &discovery))
+	assert.Equal(t, "v1.28.5", discovery.ServerVersion)
+	assert.NotEmpty(t, discovery.Notes, "falls back to version heuristics without metrics")
+
+	byName := map[string]Capability{}
+	for _, c := range discovery.Capabilities {
+		byName[c.Name] = c
+	}
+	assert.True(t, byName["ephemeralContainers"].Supported)
+	assert.False(t, byName["sidecarContainers"].Supported)
+}
+
+func TestDigest(t *testing.T) {
+	now := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+	controller := true
+	ownedBy := func(name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: name, Controller: &controller}}
+	}
+	replicas := int32(2)
+
+	client := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "web-2", Namespace: "shop", CreationTimestamp: ago(3 * time.Hour), OwnerReferences: ownedBy("web"),
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": "2"},
+			},
+			Spec:   appsv1.ReplicaSetSpec{Replicas: &replicas, Template: corev1.PodTemplateSpec{Spec: podSpec("web:1.1")}},
+			Status: appsv1.ReplicaSetStatus{ReadyReplicas: 1},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop", CreationTimestamp: ago(72 * time.Hour), OwnerReferences: ownedBy("web")},
+			Spec:       appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("web:1.0")}},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-new", CreationTimestamp: ago(2 * time.Hour)}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-old", CreationTimestamp: ago(400 * time.Hour)}},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "removed", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-gone"},
+			Reason:         "RemovingNode",
+			Type:           corev1.EventTypeNormal,
+			LastTimestamp:  ago(time.Hour),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "probe", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "api-0"},
+			Reason:         "Unhealthy",
+			Type:           corev1.EventTypeWarning,
+			Count:          12,
+			LastTimestamp:  ago(10 * time.Minute),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "backoff", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "api-0"},
+			Reason:         "BackOff",
+			Type:           corev1.EventTypeWarning,
+			Count:          5,
+			FirstTimestamp: ago(5 * time.Hour),
+			LastTimestamp:  ago(5 * time.Minute),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "pull", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "worker-0"},
+			Reason:         "Failed",
+			Type:           corev1.EventTypeWarning,
+			Count:          3,
+			LastTimestamp:  ago(30 * time.Minute),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "stale", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "worker-0"},
+			Reason:         "Failed",
+			Type:           corev1.EventTypeWarning,
+			Count:          50,
+			LastTimestamp:  ago(48 * time.Hour),
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "shop", CreationTimestamp: ago(100 * time.Hour)},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "api",
+				RestartCount:         7,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+			}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy-0", Namespace: "shop", CreationTimestamp: ago(100 * time.Hour)},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "legacy",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	handler.now = func() time.Time { return now }
+	tool, handlerFn := handler.Digest()
+
+	assert.Equal(t, "cluster_digest", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var digest ActivityDigest
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+	assert.Equal(t, now.Add(-24*time.Hour), digest.Since.UTC())
+
+	require.Len(t, digest.Rollouts, 1)
+	rollout := digest.Rollouts[0]
+	rollout.StartedAt = rollout.StartedAt.UTC()
+	assert.Equal(t, Rollout{Namespace: "shop", Deployment: "web", Revision: "2", Images: []string{"web:1.1"}, StartedAt: now.Add(-3 * time.Hour), Ready: "1/2"}, rollout)
+
+	require.Len(t, digest.Nodes, 2)
+	assert.Equal(t, "node-new", digest.Nodes[0].Name)
+	assert.Equal(t, NodeAdded, digest.Nodes[0].Change)
+	assert.Equal(t, "node-gone", digest.Nodes[1].Name)
+	assert.Equal(t, NodeRemoved, digest.Nodes[1].Change)
+
+	assert.Equal(t, int32(20), digest.WarningEvents)
+	require.Len(t, digest.Hotspots, 2)
+	assert.Equal(t, "api-0", digest.Hotspots[0].Name)
+	assert.Equal(t, int32(17), digest.Hotspots[0].Count)
+	assert.Equal(t, []string{"BackOff", "Unhealthy"}, digest.Hotspots[0].Reasons)
+
+	require.Len(t, digest.CrashLoops, 1)
+	assert.Equal(t, "api", digest.CrashLoops[0].Container)
+	assert.Equal(t, "Error", digest.CrashLoops[0].LastReason)
+	assert.Equal(t, int32(7), digest.CrashLoops[0].Restarts)
+	assert.NotEmpty(t, digest.Notes)
+
+	t.Run("top hotspots and short window", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"hours": float64(1), "topHotspots": float64(1)}))
+		require.NoError(t, err)
+		var digest ActivityDigest
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+		assert.Empty(t, digest.Rollouts)
+		assert.Len(t, digest.Hotspots, 1)
+		assert.Empty(t, digest.CrashLoops)
+		assert.Empty(t, digest.Notes)
+	})
+
+	t.Run("negative hours", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"hours": float64(-2)}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "hours must be positive")
+	})
+}
+
+func TestOverview(t *testing.T) {
+	replicas := int32(3)
+	storageClass := "fast"
+	client := fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+			}},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job-0", Namespace: "batch"}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: "batch"}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "shop"},
+			Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "shop"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				}},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 3},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.Overview()
+	assert.Equal(t, "cluster_overview", tool.Name)
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]interface{}
+		expectedNotRunning  int
+		expectedNamespaces  []string
+		expectedClaims      int
+		expectedDeployments int
+	}{
+		{
+			name:                "all namespaces",
+			requestArgs:         map[string]interface{}{},
+			expectedNotRunning:  3,
+			expectedNamespaces:  []string{"shop", "batch"},
+			expectedClaims:      1,
+			expectedDeployments: 1,
+		},
+		{
+			name:                "single namespace",
+			requestArgs:         map[string]interface{}{"namespace": "batch"},
+			expectedNotRunning:  1,
+			expectedNamespaces:  []string{"batch"},
+			expectedClaims:      0,
+			expectedDeployments: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var overview Overview
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &overview))
+			assert.False(t, overview.Healthy)
+
+			assert.Equal(t, 2, overview.Nodes.Total)
+			assert.Equal(t, 1, overview.Nodes.Ready)
+			assert.Equal(t, []string{"node-b"}, overview.Nodes.NotReady)
+			assert.Equal(t, []string{"node-b"}, overview.Nodes.Cordoned)
+			assert.Equal(t, []string{"node-a:DiskPressure"}, overview.Nodes.Pressure)
+
+			assert.Equal(t, tc.expectedNotRunning, overview.Pods.NotRunning)
+			var namespaces []string
+			for _, ns := range overview.Pods.ByNamespace {
+				namespaces = append(namespaces, ns.Namespace)
+			}
+			assert.Equal(t, tc.expectedNamespaces, namespaces)
+
+			assert.Len(t, overview.PendingClaims, tc.expectedClaims)
+			require.Len(t, overview.FailingDeployments, tc.expectedDeployments)
+			if tc.expectedDeployments > 0 {
+				assert.Equal(t, FailingDeployment{Namespace: "shop", Name: "web", Ready: "1/3", Reason: "ProgressDeadlineExceeded"}, overview.FailingDeployments[0])
+				assert.Equal(t, PendingClaim{Namespace: "shop", Name: "data", StorageClass: "fast"}, overview.PendingClaims[0])
+			}
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // Handler implements the K8sResourceHandler interface for ConfigMap resources
@@ -34,6 +36,19 @@ func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
+
+	// Register write tools
+	createTool, createHandler := h.Create()
+	toolset.AddWriteTool(createTool, createHandler)
+
+	updateTool, updateHandler := h.Update()
+	toolset.AddWriteTool(updateTool, updateHandler)
+
+	patchTool, patchHandler := h.PatchData()
+	toolset.AddWriteTool(patchTool, patchHandler)
+
+	deleteTool, deleteHandler := h.Delete()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
 }
 
 // Get creates a tool to get details of a specific configmap
@@ -81,20 +96,18 @@ func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 // List creates a tool to list configmaps in a namespace
 func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_configmaps",
-			mcp.WithDescription(h.t("TOOL_LIST_CONFIGMAPS_DESCRIPTION", "List configmaps in a namespace")),
-			mcp.WithString("namespace",
-				mcp.Required(),
-				mcp.Description("Kubernetes namespace"),
-			),
+			mcp.WithDescription(h.t("TOOL_LIST_CONFIGMAPS_DESCRIPTION", "List configmaps in a namespace or across all namespaces")),
+			toolsets.WithListNamespace(),
 			mcp.WithString("fieldSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their fields"),
 			),
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			namespace, err := toolsets.ListNamespace(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -118,13 +131,71 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			configmaps, err := client.CoreV1().ConfigMaps(namespace).List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list configmaps: %v", err)), nil
 			}
 
-			r, err := json.Marshal(configmaps)
+			return toolsets.NewListPageResult(configmaps.Items, configmaps.ListMeta)
+		}
+}
+
+// Create creates a tool to create a configmap
+func (h *Handler) Create() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_configmap",
+			mcp.WithDescription(h.t("TOOL_CREATE_CONFIGMAP_DESCRIPTION", "Create a configmap from key/value data")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("ConfigMap name"),
+			),
+			mcp.WithObject("data",
+				mcp.Description("Key/value data; values must be strings"),
+			),
+			mcp.WithObject("labels",
+				mcp.Description("Labels applied to the configmap"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			data, err := toolsets.StringMapParam(request, "data")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := toolsets.StringMapParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			configmap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+				Data:       data,
+			}
+			created, err := client.CoreV1().ConfigMaps(namespace).Create(ctx, configmap, metav1.CreateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create configmap: %v", err)), nil
+			}
+
+			r, err := json.Marshal(created)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -132,3 +203,180 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// Update creates a tool to replace the data of a configmap
+func (h *Handler) Update() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_configmap",
+			mcp.WithDescription(h.t("TOOL_UPDATE_CONFIGMAP_DESCRIPTION", "Replace all data of an existing configmap. Keys not present in data are removed; use patch_configmap_data to change individual keys")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("ConfigMap name"),
+			),
+			mcp.WithObject("data",
+				mcp.Required(),
+				mcp.Description("New key/value data; values must be strings"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["data"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: data"), nil
+			}
+			data, err := toolsets.StringMapParam(request, "data")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			configmap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get configmap: %v", err)), nil
+			}
+
+			// The resource version read above makes the update fail on concurrent changes
+			configmap.Data = data
+			updated, err := client.CoreV1().ConfigMaps(namespace).Update(ctx, configmap, metav1.UpdateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update configmap: %v", err)), nil
+			}
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PatchData creates a tool to set or remove individual keys of a configmap
+func (h *Handler) PatchData() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("patch_configmap_data",
+			mcp.WithDescription(h.t("TOOL_PATCH_CONFIGMAP_DATA_DESCRIPTION", "Set or remove individual keys of a configmap, leaving other keys untouched")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("ConfigMap name"),
+			),
+			mcp.WithObject("set",
+				mcp.Description("Keys to add or overwrite; values must be strings"),
+			),
+			mcp.WithArray("remove",
+				mcp.Description("Keys to remove"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			set, err := toolsets.StringMapParam(request, "set")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			remove, err := toolsets.OptionalParam[[]interface{}](request, "remove")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(set) == 0 && len(remove) == 0 {
+				return mcp.NewToolResultError("at least one of set or remove must be provided"), nil
+			}
+
+			// A JSON merge patch sets keys to their new value and deletes keys set to null
+			changes := make(map[string]interface{}, len(set)+len(remove))
+			for _, k := range remove {
+				key, ok := k.(string)
+				if !ok {
+					return mcp.NewToolResultError("remove must be a list of strings"), nil
+				}
+				if _, ok := set[key]; ok {
+					return mcp.NewToolResultError(fmt.Sprintf("key %s cannot be both set and removed", key)), nil
+				}
+				changes[key] = nil
+			}
+			for k, v := range set {
+				changes[k] = v
+			}
+			patch, err := json.Marshal(map[string]interface{}{"data": changes})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal patch: %w", err)
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			patched, err := client.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to patch configmap: %v", err)), nil
+			}
+
+			r, err := json.Marshal(patched)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Delete creates a tool to delete a configmap
+func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_configmap",
+			mcp.WithDescription(h.t("TOOL_DELETE_CONFIGMAP_DESCRIPTION", "Delete a configmap")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("ConfigMap name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			err = client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete configmap: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("ConfigMap %s in namespace %s deleted", name, namespace)), nil
+		}
+}
@@ -34,13 +34,7 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -202,7 +196,8 @@ func TestListConfigMaps(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "namespace")
 	assert.Contains(t, tool.InputSchema.Properties, "fieldSelector")
 	assert.Contains(t, tool.InputSchema.Properties, "labelSelector")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace"})
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
 
 	tests := []struct {
 		name                  string
@@ -314,3 +309,223 @@ func TestListConfigMaps(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateConfigMap(t *testing.T) {
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"}}
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(existing)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.Create()
+
+	assert.Equal(t, "create_configmap", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedData   map[string]string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful create",
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "settings",
+				"data":      map[string]interface{}{"mode": "production"},
+				"labels":    map[string]interface{}{"app": "shop"},
+			},
+			expectedData: map[string]string{"mode": "production"},
+		},
+		{
+			name:           "already exists",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "existing"},
+			expectedErrMsg: "failed to create configmap",
+		},
+		{
+			name: "non-string value",
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "settings",
+				"data":      map[string]interface{}{"replicas": float64(3)},
+			},
+			expectedErrMsg: "data.replicas must be a string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var created corev1.ConfigMap
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &created))
+			assert.Equal(t, tc.expectedData, created.Data)
+			assert.Equal(t, "shop", created.Labels["app"])
+		})
+	}
+}
+
+func TestUpdateConfigMap(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		Data:       map[string]string{"mode": "debug", "region": "eu"},
+	}
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(existing)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.Update()
+
+	assert.Equal(t, "update_configmap", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name", "data"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{
+		"namespace": "default",
+		"name":      "settings",
+		"data":      map[string]interface{}{"mode": "production"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var updated corev1.ConfigMap
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &updated))
+	assert.Equal(t, map[string]string{"mode": "production"}, updated.Data)
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "name": "settings"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "missing required parameter: data")
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{
+		"namespace": "default",
+		"name":      "missing",
+		"data":      map[string]interface{}{},
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "failed to get configmap")
+}
+
+func TestPatchConfigMapData(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		Data:       map[string]string{"mode": "debug", "region": "eu", "legacy": "true"},
+	}
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(existing)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.PatchData()
+
+	assert.Equal(t, "patch_configmap_data", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedData   map[string]string
+		expectedErrMsg string
+	}{
+		{
+			name: "set and remove keys",
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "settings",
+				"set":       map[string]interface{}{"mode": "production", "tier": "gold"},
+				"remove":    []interface{}{"legacy"},
+			},
+			expectedData: map[string]string{"mode": "production", "region": "eu", "tier": "gold"},
+		},
+		{
+			name:           "nothing to change",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "settings"},
+			expectedErrMsg: "at least one of set or remove must be provided",
+		},
+		{
+			name: "conflicting keys",
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "settings",
+				"set":       map[string]interface{}{"mode": "production"},
+				"remove":    []interface{}{"mode"},
+			},
+			expectedErrMsg: "key mode cannot be both set and removed",
+		},
+		{
+			name: "configmap not found",
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "missing",
+				"remove":    []interface{}{"mode"},
+			},
+			expectedErrMsg: "failed to patch configmap",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var patched corev1.ConfigMap
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &patched))
+			assert.Equal(t, tc.expectedData, patched.Data)
+		})
+	}
+}
+
+func TestDeleteConfigMap(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "delete configmap",
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web"},
+		},
+		{
+			name:           "configmap not found",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to delete configmap",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{"namespace": "default"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Delete()
+			assert.Equal(t, "delete_configmap", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "ConfigMap web in namespace default deleted", getTextResult(t, result).Text)
+			_, err = client.CoreV1().ConfigMaps("default").Get(context.Background(), "web", metav1.GetOptions{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package cronjob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	// Embed the timezone database so CronJob time zones resolve in minimal container images
+	_ "time/tzdata"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultRunDuration is assumed for jobs that have never completed and set no active deadline
+const defaultRunDuration = time.Minute
+
+// Handler implements the K8sResourceHandler interface for CronJob resources
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+	now       func() time.Time
+}
+
+// NewHandler creates a new CronJob resource handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+		now:       time.Now,
+	}
+}
+
+// Analysis is the schedule analysis of a single CronJob
+type Analysis struct {
+	Namespace         string   `json:"namespace"`
+	Name              string   `json:"name"`
+	Schedule          string   `json:"schedule"`
+	TimeZone          string   `json:"timeZone"`
+	Suspended         bool     `json:"suspended,omitempty"`
+	ConcurrencyPolicy string   `json:"concurrencyPolicy"`
+	Heavy             bool     `json:"heavy,omitempty"`
+	ActiveJobs        int      `json:"activeJobs"`
+	EstimatedDuration string   `json:"estimatedDuration"`
+	NextRuns          []string `json:"nextRuns"`
+	Issues            []string `json:"issues,omitempty"`
+}
+
+// Overlap is a pair of heavy CronJobs whose runs overlap within the analysed horizon
+type Overlap struct {
+	First        string `json:"first"`
+	Second       string `json:"second"`
+	Count        int    `json:"count"`
+	FirstOverlap string `json:"firstOverlap"`
+}
+
+// Report is the result of a CronJob schedule analysis
+type Report struct {
+	TimeZone string     `json:"timeZone"`
+	CronJobs []Analysis `json:"cronJobs"`
+	Overlaps []Overlap  `json:"overlaps"`
+}
+
+// RegisterTools registers all CronJob resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	analyzeTool, analyzeHandler := h.Analyze()
+	toolset.AddReadTool(analyzeTool, analyzeHandler)
+}
+
+// Analyze creates a tool to analyse CronJob schedules for overlaps and concurrency issues
+func (h *Handler) Analyze() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_cronjobs",
+			mcp.WithDescription(h.t("TOOL_ANALYZE_CRONJOBS_DESCRIPTION", "Parse CronJob schedules, report their next run times, flag concurrencyPolicy and deadline issues, and detect heavy jobs whose runs overlap")),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (all namespaces if omitted)"),
+			),
+			mcp.WithString("timeZone",
+				mcp.Description("IANA time zone used by the cluster for CronJobs without spec.timeZone and for reported times (default: UTC)"),
+			),
+			mcp.WithNumber("nextRuns",
+				mcp.Description("Number of upcoming runs to list per CronJob (default: 5)"),
+			),
+			mcp.WithNumber("horizonHours",
+				mcp.Description("How far ahead to look for overlapping runs (default: 24)"),
+			),
+			mcp.WithString("heavyCPU",
+				mcp.Description("Total CPU request from which a job counts as heavy (default: 1)"),
+			),
+			mcp.WithString("heavyMemory",
+				mcp.Description("Total memory request from which a job counts as heavy (default: 1Gi)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeZone, err := toolsets.OptionalParam[string](request, "timeZone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			nextRuns, err := toolsets.OptionalParam[float64](request, "nextRuns")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			horizonHours, err := toolsets.OptionalParam[float64](request, "horizonHours")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			heavyCPU, err := quantityParam(request, "heavyCPU", "1")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			heavyMemory, err := quantityParam(request, "heavyMemory", "1Gi")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if timeZone == "" {
+				timeZone = "UTC"
+			}
+			location, err := time.LoadLocation(timeZone)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid timeZone %q: %v", timeZone, err)), nil
+			}
+			if nextRuns == 0 {
+				nextRuns = 5
+			}
+			if horizonHours == 0 {
+				horizonHours = 24
+			}
+			if nextRuns < 0 || horizonHours < 0 {
+				return mcp.NewToolResultError("nextRuns and horizonHours must be positive"), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list cronjobs: %v", err)), nil
+			}
+			jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list jobs: %v", err)), nil
+			}
+
+			// Longest observed run per CronJob, from the jobs it still keeps in its history
+			observed := map[string]time.Duration{}
+			for _, job := range jobs.Items {
+				ref := metav1.GetControllerOf(&job)
+				if ref == nil || ref.Kind != "CronJob" || job.Status.StartTime == nil || job.Status.CompletionTime == nil {
+					continue
+				}
+				key := job.Namespace + "/" + ref.Name
+				if d := job.Status.CompletionTime.Sub(job.Status.StartTime.Time); d > observed[key] {
+					observed[key] = d
+				}
+			}
+
+			now := h.now().In(location)
+			horizon := now.Add(time.Duration(horizonHours * float64(time.Hour)))
+			report := Report{TimeZone: timeZone, CronJobs: make([]Analysis, 0, len(cronJobs.Items)), Overlaps: []Overlap{}}
+			var windows []runWindows
+			for _, cj := range cronJobs.Items {
+				analysis, schedule, duration := analyze(cj, location, observed[cj.Namespace+"/"+cj.Name], now)
+				analysis.Heavy = isHeavy(cj.Spec.JobTemplate.Spec.Template.Spec, heavyCPU, heavyMemory)
+				if schedule != nil {
+					for t, i := schedule.Next(now), 0; i < int(nextRuns); t, i = schedule.Next(t), i+1 {
+						analysis.NextRuns = append(analysis.NextRuns, t.In(location).Format(time.RFC3339))
+					}
+					if analysis.Heavy && !analysis.Suspended {
+						windows = append(windows, runWindows{name: cj.Namespace + "/" + cj.Name, starts: runsUntil(schedule, now, horizon), duration: duration})
+					}
+				}
+				report.CronJobs = append(report.CronJobs, analysis)
+			}
+			report.Overlaps = overlaps(windows, location)
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// analyze parses a CronJob's schedule and flags configuration issues. It returns the parsed
+// schedule, nil when invalid, and the run duration assumed for overlap detection.
+func analyze(cj batchv1.CronJob, location *time.Location, observed time.Duration, now time.Time) (Analysis, cron.Schedule, time.Duration) {
+	analysis := Analysis{
+		Namespace:         cj.Namespace,
+		Name:              cj.Name,
+		Schedule:          cj.Spec.Schedule,
+		TimeZone:          location.String(),
+		Suspended:         cj.Spec.Suspend != nil && *cj.Spec.Suspend,
+		ConcurrencyPolicy: string(cj.Spec.ConcurrencyPolicy),
+		ActiveJobs:        len(cj.Status.Active),
+		NextRuns:          []string{},
+	}
+	if analysis.ConcurrencyPolicy == "" {
+		analysis.ConcurrencyPolicy = string(batchv1.AllowConcurrent)
+	}
+
+	scheduleLocation := location
+	if cj.Spec.TimeZone != nil {
+		analysis.TimeZone = *cj.Spec.TimeZone
+		loc, err := time.LoadLocation(*cj.Spec.TimeZone)
+		if err != nil {
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("unknown timeZone %q", *cj.Spec.TimeZone))
+			return analysis, nil, 0
+		}
+		scheduleLocation = loc
+	} else {
+		analysis.Issues = append(analysis.Issues, "no spec.timeZone: runs follow the kube-controller-manager's local time zone")
+	}
+
+	parsed, err := cron.ParseStandard(cj.Spec.Schedule)
+	if err != nil {
+		analysis.Issues = append(analysis.Issues, fmt.Sprintf("invalid schedule: %v", err))
+		return analysis, nil, 0
+	}
+	schedule := inLocation{schedule: parsed, location: scheduleLocation}
+
+	duration := observed
+	switch {
+	case duration > 0:
+		analysis.EstimatedDuration = duration.String() + " (longest observed run)"
+	case cj.Spec.JobTemplate.Spec.ActiveDeadlineSeconds != nil:
+		duration = time.Duration(*cj.Spec.JobTemplate.Spec.ActiveDeadlineSeconds) * time.Second
+		analysis.EstimatedDuration = duration.String() + " (activeDeadlineSeconds)"
+	default:
+		duration = defaultRunDuration
+		analysis.EstimatedDuration = duration.String() + " (assumed, no completed runs)"
+	}
+
+	// The shortest gap between consecutive runs over the next day decides whether runs can collide
+	interval := shortestInterval(schedule, now)
+	if analysis.Suspended {
+		analysis.Issues = append(analysis.Issues, "suspended: no new runs are scheduled")
+	}
+	if observed > interval {
+		switch cj.Spec.ConcurrencyPolicy {
+		case batchv1.ForbidConcurrent:
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("runs take up to %s but are scheduled every %s: with concurrencyPolicy Forbid, runs will be skipped", observed, interval))
+		case batchv1.ReplaceConcurrent:
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("runs take up to %s but are scheduled every %s: with concurrencyPolicy Replace, runs are killed before completing", observed, interval))
+		default:
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("runs take up to %s but are scheduled every %s: with concurrencyPolicy Allow, runs pile up", observed, interval))
+		}
+	}
+	if analysis.ActiveJobs > 1 && analysis.ConcurrencyPolicy == string(batchv1.AllowConcurrent) {
+		analysis.Issues = append(analysis.Issues, fmt.Sprintf("%d runs are active at the same time", analysis.ActiveJobs))
+	}
+	if cj.Spec.StartingDeadlineSeconds != nil && *cj.Spec.StartingDeadlineSeconds < 10 {
+		analysis.Issues = append(analysis.Issues, "startingDeadlineSeconds below 10: the controller may miss runs")
+	}
+	return analysis, schedule, duration
+}
+
+// inLocation evaluates a schedule in a fixed time zone, as the CronJob controller does
+type inLocation struct {
+	schedule cron.Schedule
+	location *time.Location
+}
+
+func (s inLocation) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.location))
+}
+
+func shortestInterval(schedule cron.Schedule, from time.Time) time.Duration {
+	shortest := time.Duration(0)
+	prev := schedule.Next(from)
+	end := from.Add(24 * time.Hour)
+	for i := 0; i < 1440 && !prev.IsZero(); i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+		if next.After(end) {
+			break
+		}
+		prev = next
+	}
+	return shortest
+}
+
+type runWindows struct {
+	name     string
+	starts   []time.Time
+	duration time.Duration
+}
+
+func runsUntil(schedule cron.Schedule, from, until time.Time) []time.Time {
+	var starts []time.Time
+	for t := schedule.Next(from); !t.IsZero() && !t.After(until) && len(starts) < 1440; t = schedule.Next(t) {
+		starts = append(starts, t)
+	}
+	return starts
+}
+
+// overlaps reports pairs of CronJobs whose run windows intersect
+func overlaps(windows []runWindows, location *time.Location) []Overlap {
+	sort.Slice(windows, func(i, j int) bool { return windows[i].name < windows[j].name })
+	result := []Overlap{}
+	for i := range windows {
+		for j := i + 1; j < len(windows); j++ {
+			a, b := windows[i], windows[j]
+			overlap := Overlap{First: a.name, Second: b.name}
+			var first time.Time
+			for _, sa := range a.starts {
+				for _, sb := range b.starts {
+					if sa.Before(sb.Add(b.duration)) && sb.Before(sa.Add(a.duration)) {
+						overlap.Count++
+						start := sa
+						if sb.After(sa) {
+							start = sb
+						}
+						if first.IsZero() || start.Before(first) {
+							first = start
+						}
+					}
+				}
+			}
+			if overlap.Count > 0 {
+				overlap.FirstOverlap = first.In(location).Format(time.RFC3339)
+				result = append(result, overlap)
+			}
+		}
+	}
+	return result
+}
+
+// isHeavy reports whether a pod template requests at least the given CPU or memory in total
+func isHeavy(spec corev1.PodSpec, cpu, memory resource.Quantity) bool {
+	totalCPU := resource.Quantity{}
+	totalMemory := resource.Quantity{}
+	for _, c := range spec.Containers {
+		if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+			totalCPU.Add(q)
+		}
+		if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+			totalMemory.Add(q)
+		}
+	}
+	return totalCPU.Cmp(cpu) >= 0 || totalMemory.Cmp(memory) >= 0
+}
+
+func quantityParam(request mcp.CallToolRequest, p, defaultValue string) (resource.Quantity, error) {
+	value, err := toolsets.OptionalParam[string](request, p)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	if value == "" {
+		value = defaultValue
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("invalid %s %q: %v", p, value, err)
+	}
+	return q, nil
+}
@@ -0,0 +1,165 @@
+package cronjob
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func stringPtr(s string) *string { return &s }
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func cronJob(name, schedule string, timeZone *string, requests corev1.ResourceList) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+		Spec: batchv1.CronJobSpec{
+			Schedule: schedule,
+			TimeZone: timeZone,
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: requests}}},
+			}}}},
+		},
+	}
+}
+
+func completedJob(cronJobName string, start time.Time, duration time.Duration) *batchv1.Job {
+	isController := true
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cronJobName + "-" + start.Format("1504"),
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: cronJobName, Controller: &isController}},
+		},
+		Status: batchv1.JobStatus{
+			StartTime:      &metav1.Time{Time: start},
+			CompletionTime: &metav1.Time{Time: start.Add(duration)},
+		},
+	}
+}
+
+func TestAnalyzeCronJobs(t *testing.T) {
+	now := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	utc := stringPtr("UTC")
+
+	reindex := cronJob("reindex", "30 2 * * *", utc, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")})
+	reindex.Spec.JobTemplate.Spec.ActiveDeadlineSeconds = int64Ptr(600)
+	report := cronJob("report", "*/5 * * * *", nil, nil)
+	report.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+
+	objects := []runtime.Object{
+		cronJob("backup", "0 2 * * *", utc, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}),
+		reindex,
+		report,
+		cronJob("broken", "61 * * * *", utc, nil),
+		cronJob("berlin", "0 9 * * *", stringPtr("Europe/Berlin"), nil),
+		completedJob("backup", now.Add(-22*time.Hour), 90*time.Minute),
+		completedJob("report", now.Add(-time.Hour), 10*time.Minute),
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(objects...)), translations.NullTranslationHelper)
+	handler.now = func() time.Time { return now }
+	tool, handlerFn := handler.Analyze()
+
+	assert.Equal(t, "analyze_cronjobs", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"nextRuns": float64(2)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned Report
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	analyses := map[string]Analysis{}
+	for _, a := range returned.CronJobs {
+		analyses[a.Name] = a
+	}
+	require.Len(t, analyses, 5)
+
+	assert.True(t, analyses["backup"].Heavy)
+	assert.Equal(t, []string{"2025-01-06T02:00:00Z", "2025-01-07T02:00:00Z"}, analyses["backup"].NextRuns)
+	assert.Contains(t, analyses["backup"].EstimatedDuration, "longest observed run")
+	assert.Empty(t, analyses["backup"].Issues)
+
+	assert.Equal(t, []string{"2025-01-06T08:00:00Z", "2025-01-07T08:00:00Z"}, analyses["berlin"].NextRuns)
+	assert.Contains(t, analyses["broken"].Issues[0], "invalid schedule")
+	assert.Empty(t, analyses["broken"].NextRuns)
+
+	assert.Equal(t, "Forbid", analyses["report"].ConcurrencyPolicy)
+	require.Len(t, analyses["report"].Issues, 2)
+	assert.Contains(t, analyses["report"].Issues[0], "no spec.timeZone")
+	assert.Contains(t, analyses["report"].Issues[1], "runs will be skipped")
+
+	assert.Equal(t, []Overlap{
+		{First: "shop/backup", Second: "shop/reindex", Count: 1, FirstOverlap: "2025-01-06T02:30:00Z"},
+	}, returned.Overlaps)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:           "invalid time zone",
+			requestArgs:    map[string]interface{}{"timeZone": "Mars/Olympus"},
+			expectedErrMsg: "invalid timeZone",
+		},
+		{
+			name:           "invalid heavy threshold",
+			requestArgs:    map[string]interface{}{"heavyCPU": "lots"},
+			expectedErrMsg: "invalid heavyCPU",
+		},
+		{
+			name:           "negative horizon",
+			requestArgs:    map[string]interface{}{"horizonHours": float64(-1)},
+			expectedErrMsg: "must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+		})
+	}
+}
@@ -4,14 +4,58 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
+// Annotations maintained by the deployment controller and kubectl
+const (
+	// RestartedAtAnnotation is the pod template annotation kubectl sets to trigger a rollout restart
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// RevisionAnnotation holds the rollout revision of a ReplicaSet
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// ChangeCauseAnnotation records why a revision was created
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// RolloutStatus is the non-blocking equivalent of kubectl rollout status
+type RolloutStatus struct {
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	Revision          int64  `json:"revision,omitempty"`
+	Done              bool   `json:"done"`
+	Failed            bool   `json:"failed,omitempty"`
+	Message           string `json:"message"`
+	Replicas          int32  `json:"replicas"`
+	UpdatedReplicas   int32  `json:"updatedReplicas"`
+	ReadyReplicas     int32  `json:"readyReplicas"`
+	AvailableReplicas int32  `json:"availableReplicas"`
+}
+
+// Revision is a single entry of a deployment's rollout history
+type Revision struct {
+	Revision    int64       `json:"revision"`
+	ReplicaSet  string      `json:"replicaSet"`
+	ChangeCause string      `json:"changeCause,omitempty"`
+	Images      []string    `json:"images"`
+	Replicas    int32       `json:"replicas"`
+	Current     bool        `json:"current,omitempty"`
+	CreatedAt   metav1.Time `json:"createdAt"`
+}
+
 // Handler implements the K8sResourceHandler interface for Deployment resources
 type Handler struct {
 	getClient toolsets.GetClientFn
@@ -35,9 +79,46 @@ func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
 
+	statusTool, statusHandler := h.RolloutStatus()
+	toolset.AddReadTool(statusTool, statusHandler)
+
+	historyTool, historyHandler := h.RolloutHistory()
+	toolset.AddReadTool(historyTool, historyHandler)
+
 	// Register write tools
 	scaleTool, scaleHandler := h.Scale()
 	toolset.AddWriteTool(scaleTool, scaleHandler)
+
+	createTool, createHandler := h.Create()
+	toolset.AddWriteTool(createTool, createHandler)
+
+	restartTool, restartHandler := h.RolloutRestart()
+	toolset.AddWriteTool(restartTool, restartHandler)
+
+	undoTool, undoHandler := h.RolloutUndo()
+	toolset.AddWriteTool(undoTool, undoHandler)
+
+	pauseTool, pauseHandler := h.Pause()
+	toolset.AddWriteTool(pauseTool, pauseHandler)
+
+	resumeTool, resumeHandler := h.Resume()
+	toolset.AddWriteTool(resumeTool, resumeHandler)
+
+	deleteTool, deleteHandler := h.Delete()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
+}
+
+// RegisterResources exposes deployments as MCP resources, read with get_deployment and
+// list_deployments
+func (h *Handler) RegisterResources(resourceSet *toolsets.ResourceSet) {
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/deployments/{name}", "deployment",
+		mcp.WithTemplateDescription("A deployment of a namespace"),
+		mcp.WithTemplateMIMEType("application/json"),
+	), "get_deployment")
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/deployments", "deployments",
+		mcp.WithTemplateDescription("Summaries of the deployments of a namespace"),
+		mcp.WithTemplateMIMEType("application/json"),
+	), "list_deployments")
 }
 
 // Get creates a tool to get details of a specific deployment
@@ -85,20 +166,22 @@ func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 // List creates a tool to list deployments in a namespace
 func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_deployments",
-			mcp.WithDescription(h.t("TOOL_LIST_DEPLOYMENTS_DESCRIPTION", "List deployments in a namespace")),
-			mcp.WithString("namespace",
-				mcp.Required(),
-				mcp.Description("Kubernetes namespace"),
-			),
+			mcp.WithDescription(h.t("TOOL_LIST_DEPLOYMENTS_DESCRIPTION", "List deployments in a namespace or across all namespaces")),
+			toolsets.WithListNamespace(),
 			mcp.WithString("fieldSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their fields"),
 			),
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			mcp.WithBoolean("notReady",
+				mcp.Description("Only return deployments with fewer ready replicas than desired"),
+			),
+			toolsets.WithSortBy(deploymentSortKeys, ", readyReplicas fewest first"),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			namespace, err := toolsets.ListNamespace(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -113,6 +196,11 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			notReady, err := toolsets.OptionalParam[bool](request, "notReady")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := h.getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
@@ -122,21 +210,42 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			deployments, err := client.AppsV1().Deployments(namespace).List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list deployments: %v", err)), nil
 			}
 
-			r, err := json.Marshal(deployments)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			items := deployments.Items[:0]
+			for _, deployment := range deployments.Items {
+				if !notReady || deployment.Status.ReadyReplicas < desiredReplicas(&deployment) {
+					items = append(items, deployment)
+				}
+			}
+			if err := toolsets.SortItems(request, items, deploymentSortKeys); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return toolsets.NewListPageResult(items, deployments.ListMeta)
 		}
 }
 
+// deploymentSortKeys are the sort keys of list_deployments besides name and age
+var deploymentSortKeys = toolsets.SortKeys[appsv1.Deployment]{
+	"readyReplicas": func(a, b *appsv1.Deployment) bool { return a.Status.ReadyReplicas < b.Status.ReadyReplicas },
+}
+
+// desiredReplicas returns the replicas a deployment asks for, 1 when unset
+func desiredReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
 // Scale creates a tool to scale a deployment
 func (h *Handler) Scale() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("scale_deployment",
@@ -201,3 +310,595 @@ func (h *Handler) Scale() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// RolloutRestart creates a tool to restart all pods of a deployment with a rolling update
+func (h *Handler) RolloutRestart() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rollout_restart_deployment",
+			mcp.WithDescription(h.t("TOOL_ROLLOUT_RESTART_DEPLOYMENT_DESCRIPTION", "Restart all pods of a deployment with a rolling update, like kubectl rollout restart. Useful after changing a configmap or secret the pods read at startup")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+			if deployment.Spec.Paused {
+				return mcp.NewToolResultError(fmt.Sprintf("deployment %s is paused; resume it before restarting", name)), nil
+			}
+
+			patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+				RestartedAtAnnotation, time.Now().Format(time.RFC3339))
+			restarted, err := client.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to restart deployment: %v", err)), nil
+			}
+
+			r, err := json.Marshal(restarted)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Pause creates a tool to pause a deployment rollout
+func (h *Handler) Pause() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.setPaused("pause_deployment",
+		h.t("TOOL_PAUSE_DEPLOYMENT_DESCRIPTION", "Pause a deployment, like kubectl rollout pause. Changes to the pod template no longer trigger a rollout and an in-progress rollout is halted until the deployment is resumed"),
+		true)
+}
+
+// Resume creates a tool to resume a paused deployment rollout
+func (h *Handler) Resume() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.setPaused("resume_deployment",
+		h.t("TOOL_RESUME_DEPLOYMENT_DESCRIPTION", "Resume a paused deployment, like kubectl rollout resume. Pod template changes made while paused are rolled out"),
+		false)
+}
+
+// setPaused builds the pause and resume tools, which only differ in the value they set spec.paused to
+func (h *Handler) setPaused(name, description string, paused bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+			if deployment.Spec.Paused == paused {
+				state := "resumed"
+				if paused {
+					state = "paused"
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("deployment %s is already %s", name, state)), nil
+			}
+
+			patch := fmt.Sprintf(`{"spec":{"paused":%t}}`, paused)
+			updated, err := client.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update deployment: %v", err)), nil
+			}
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RolloutStatus creates a tool to report the progress of a deployment rollout without blocking
+func (h *Handler) RolloutStatus() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rollout_status",
+			mcp.WithDescription(h.t("TOOL_ROLLOUT_STATUS_DESCRIPTION", "Report the progress of a deployment rollout, like kubectl rollout status but without waiting")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+
+			r, err := json.Marshal(rolloutStatus(deployment))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RolloutHistory creates a tool to list the revisions of a deployment
+func (h *Handler) RolloutHistory() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rollout_history",
+			mcp.WithDescription(h.t("TOOL_ROLLOUT_HISTORY_DESCRIPTION", "List the rollout revisions of a deployment from its ReplicaSets, with change-cause annotations and images")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+			replicaSets, err := ownedReplicaSets(ctx, client, deployment)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			current := revisionOf(deployment.Annotations)
+			history := make([]Revision, 0, len(replicaSets))
+			for _, rs := range replicaSets {
+				revision := Revision{
+					Revision:    revisionOf(rs.Annotations),
+					ReplicaSet:  rs.Name,
+					ChangeCause: rs.Annotations[ChangeCauseAnnotation],
+					Images:      make([]string, 0, len(rs.Spec.Template.Spec.Containers)),
+					Replicas:    rs.Status.Replicas,
+					CreatedAt:   rs.CreationTimestamp,
+				}
+				revision.Current = revision.Revision == current
+				for _, c := range rs.Spec.Template.Spec.Containers {
+					revision.Images = append(revision.Images, c.Image)
+				}
+				history = append(history, revision)
+			}
+
+			r, err := json.Marshal(history)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RolloutUndo creates a tool to roll a deployment back to a previous revision
+func (h *Handler) RolloutUndo() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rollout_undo",
+			mcp.WithDescription(h.t("TOOL_ROLLOUT_UNDO_DESCRIPTION", "Roll a deployment back to a previous revision by restoring that revision's pod template, like kubectl rollout undo")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+			mcp.WithNumber("toRevision",
+				mcp.Description("Revision to roll back to (default: the previous revision)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toRevisionFloat, err := toolsets.OptionalParam[float64](request, "toRevision")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toRevision := int64(toRevisionFloat)
+			if float64(toRevision) != toRevisionFloat || toRevision < 0 {
+				return mcp.NewToolResultError("toRevision must be a positive integer"), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+			if deployment.Spec.Paused {
+				return mcp.NewToolResultError(fmt.Sprintf("deployment %s is paused; resume it before rolling back", name)), nil
+			}
+			replicaSets, err := ownedReplicaSets(ctx, client, deployment)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Without an explicit revision, roll back to the newest revision before the current one
+			current := revisionOf(deployment.Annotations)
+			var target *appsv1.ReplicaSet
+			for i := range replicaSets {
+				revision := revisionOf(replicaSets[i].Annotations)
+				if (toRevision == 0 && revision < current) || (toRevision != 0 && revision == toRevision) {
+					target = &replicaSets[i]
+				}
+			}
+			if target == nil {
+				if toRevision == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("deployment %s has no previous revision to roll back to", name)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("revision %d of deployment %s not found", toRevision, name)), nil
+			}
+
+			template := target.Spec.Template.DeepCopy()
+			delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+			if apiequality.Semantic.DeepEqual(*template, deployment.Spec.Template) {
+				return mcp.NewToolResultText(fmt.Sprintf("Deployment %s already matches revision %d; rollback skipped", name, revisionOf(target.Annotations))), nil
+			}
+
+			deployment.Spec.Template = *template
+			if cause, ok := target.Annotations[ChangeCauseAnnotation]; ok {
+				if deployment.Annotations == nil {
+					deployment.Annotations = map[string]string{}
+				}
+				deployment.Annotations[ChangeCauseAnnotation] = cause
+			}
+			rolledBack, err := client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to roll back deployment: %v", err)), nil
+			}
+
+			r, err := json.Marshal(rolledBack)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Delete creates a tool to delete a deployment
+func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_deployment",
+			mcp.WithDescription(h.t("TOOL_DELETE_DEPLOYMENT_DESCRIPTION", "Delete a deployment along with its replicasets and pods")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			err = client.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete deployment: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Deployment %s in namespace %s deleted", name, namespace)), nil
+		}
+}
+
+// rolloutStatus mirrors the checks kubectl rollout status performs on each poll
+func rolloutStatus(d *appsv1.Deployment) RolloutStatus {
+	status := RolloutStatus{
+		Name:              d.Name,
+		Namespace:         d.Namespace,
+		Revision:          revisionOf(d.Annotations),
+		Replicas:          d.Status.Replicas,
+		UpdatedReplicas:   d.Status.UpdatedReplicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+	}
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+
+	if d.Generation > d.Status.ObservedGeneration {
+		status.Message = "waiting for the deployment spec update to be observed"
+		return status
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			status.Failed = true
+			status.Message = fmt.Sprintf("deployment %s exceeded its progress deadline", d.Name)
+			return status
+		}
+	}
+	switch {
+	case d.Status.UpdatedReplicas < desired:
+		status.Message = fmt.Sprintf("%d out of %d new replicas have been updated", d.Status.UpdatedReplicas, desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("deployment %s successfully rolled out", d.Name)
+	}
+	return status
+}
+
+// ownedReplicaSets returns the ReplicaSets controlled by a deployment, oldest revision first
+func ownedReplicaSets(ctx context.Context, client kubernetes.Interface, d *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %v", err)
+	}
+	list, err := client.AppsV1().ReplicaSets(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %v", err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == d.UID {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool { return revisionOf(owned[i].Annotations) < revisionOf(owned[j].Annotations) })
+	return owned, nil
+}
+
+func revisionOf(annotations map[string]string) int64 {
+	revision, _ := strconv.ParseInt(annotations[RevisionAnnotation], 10, 64)
+	return revision
+}
+
+// Create creates a tool to create a deployment from a manifest or simplified parameters
+func (h *Handler) Create() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment",
+			mcp.WithDescription(h.t("TOOL_CREATE_DEPLOYMENT_DESCRIPTION", "Create a deployment from either a JSON/YAML manifest or simplified parameters (name, image, replicas, labels, ports)")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("manifest",
+				mcp.Description("Deployment manifest as JSON or YAML. Mutually exclusive with the simplified parameters"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Deployment name (simplified mode)"),
+			),
+			mcp.WithString("image",
+				mcp.Description("Container image (simplified mode)"),
+			),
+			mcp.WithNumber("replicas",
+				mcp.Description("Number of replicas (simplified mode, default: 1)"),
+			),
+			mcp.WithObject("labels",
+				mcp.Description("Labels applied to the deployment, its selector and pod template (simplified mode, default: app=<name>)"),
+			),
+			mcp.WithArray("ports",
+				mcp.Description("Container ports to expose (simplified mode)"),
+				mcp.Items(map[string]interface{}{"type": "number"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			manifest, err := toolsets.OptionalParam[string](request, "manifest")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var deployment *appsv1.Deployment
+			if manifest != "" {
+				for _, p := range []string{"name", "image", "replicas", "labels", "ports"} {
+					if _, ok := request.GetArguments()[p]; ok {
+						return mcp.NewToolResultError(fmt.Sprintf("parameter %s cannot be combined with manifest", p)), nil
+					}
+				}
+				deployment, err = deploymentFromManifest(manifest, namespace)
+			} else {
+				deployment, err = deploymentFromParams(request, namespace)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			createdDeployment, err := client.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create deployment: %v", err)), nil
+			}
+
+			r, err := json.Marshal(createdDeployment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// deploymentFromManifest decodes a JSON or YAML deployment manifest
+func deploymentFromManifest(manifest string, namespace string) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{}
+	if err := yaml.UnmarshalStrict([]byte(manifest), deployment); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	if deployment.Kind != "" && deployment.Kind != "Deployment" {
+		return nil, fmt.Errorf("manifest kind must be Deployment, got %s", deployment.Kind)
+	}
+	if deployment.Name == "" {
+		return nil, fmt.Errorf("manifest is missing metadata.name")
+	}
+	if deployment.Namespace != "" && deployment.Namespace != namespace {
+		return nil, fmt.Errorf("manifest namespace %s does not match namespace %s", deployment.Namespace, namespace)
+	}
+	deployment.Namespace = namespace
+	return deployment, nil
+}
+
+// deploymentFromParams builds a single-container deployment from the simplified parameters
+func deploymentFromParams(request mcp.CallToolRequest, namespace string) (*appsv1.Deployment, error) {
+	name, err := toolsets.RequiredParam[string](request, "name")
+	if err != nil {
+		return nil, fmt.Errorf("%v (or provide a manifest)", err)
+	}
+	image, err := toolsets.RequiredParam[string](request, "image")
+	if err != nil {
+		return nil, fmt.Errorf("%v (or provide a manifest)", err)
+	}
+	replicasFloat, err := toolsets.OptionalParam[float64](request, "replicas")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := request.GetArguments()["replicas"]; !ok {
+		replicasFloat = 1
+	}
+	replicas := int32(replicasFloat)
+	if float64(replicas) != replicasFloat || replicas < 0 {
+		return nil, fmt.Errorf("replicas must be a non-negative integer")
+	}
+
+	rawLabels, err := toolsets.OptionalParam[map[string]interface{}](request, "labels")
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{"app": name}
+	if len(rawLabels) > 0 {
+		labels = make(map[string]string, len(rawLabels))
+		for k, v := range rawLabels {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("label %s must be a string", k)
+			}
+			labels[k] = s
+		}
+	}
+
+	rawPorts, err := toolsets.OptionalParam[[]interface{}](request, "ports")
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]corev1.ContainerPort, 0, len(rawPorts))
+	for _, p := range rawPorts {
+		port, ok := p.(float64)
+		if !ok || port != float64(int32(port)) || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("ports must be integers between 1 and 65535")
+		}
+		ports = append(ports, corev1.ContainerPort{ContainerPort: int32(port), Protocol: corev1.ProtocolTCP})
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: image,
+							Ports: ports,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
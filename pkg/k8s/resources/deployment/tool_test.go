@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,13 +37,7 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -233,7 +229,8 @@ func TestListDeployments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "namespace")
 	assert.Contains(t, tool.InputSchema.Properties, "fieldSelector")
 	assert.Contains(t, tool.InputSchema.Properties, "labelSelector")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace"})
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
 
 	tests := []struct {
 		name                   string
@@ -340,6 +337,41 @@ func TestListDeployments(t *testing.T) {
 	}
 }
 
+func TestListDeploymentsNotReady(t *testing.T) {
+	newDeployment := func(name string, replicas *int32, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		newDeployment("api", int32Ptr(3), 3),
+		newDeployment("worker", int32Ptr(4), 1),
+		newDeployment("cron", nil, 0),
+		newDeployment("paused", int32Ptr(0), 0),
+	)
+	_, handlerFn := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper).List()
+
+	list := func(args map[string]interface{}) []string {
+		args["namespace"] = "default"
+		result, err := handlerFn(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var deployments appsv1.DeploymentList
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deployments))
+		var names []string
+		for _, d := range deployments.Items {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+
+	// Deployments without replicas want one
+	assert.Equal(t, []string{"cron", "worker"}, list(map[string]interface{}{"notReady": true, "sortBy": "name"}))
+	assert.Equal(t, []string{"cron", "paused", "worker", "api"}, list(map[string]interface{}{"sortBy": "readyReplicas"}))
+}
+
 func TestScaleDeployment(t *testing.T) {
 	// Create test deployment
 	replicas := int32(3)
@@ -511,3 +543,587 @@ func TestScaleDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDeployment(t *testing.T) {
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), translations.NullTranslationHelper)
+	tool, _ := handler.Create()
+
+	assert.Equal(t, "create_deployment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "manifest")
+	assert.Contains(t, tool.InputSchema.Properties, "image")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace"})
+
+	yamlManifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+`
+
+	tests := []struct {
+		name             string
+		client           kubernetes.Interface
+		requestArgs      map[string]interface{}
+		expectedReplicas int32
+		expectedImage    string
+		expectedLabels   map[string]string
+		expectedPorts    []int32
+		expectedErrMsg   string
+	}{
+		{
+			name:   "from yaml manifest",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"manifest":  yamlManifest,
+			},
+			expectedReplicas: 2,
+			expectedImage:    "nginx:1.25",
+			expectedLabels:   map[string]string{"app": "web"},
+		},
+		{
+			name:   "from json manifest",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"manifest":  `{"metadata":{"name":"web"},"spec":{"selector":{"matchLabels":{"app":"web"}},"template":{"metadata":{"labels":{"app":"web"}},"spec":{"containers":[{"name":"web","image":"nginx:1.25"}]}}}}`,
+			},
+			expectedImage:  "nginx:1.25",
+			expectedLabels: map[string]string{"app": "web"},
+		},
+		{
+			name:   "from simplified parameters",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "api",
+				"image":     "ghcr.io/example/api:v1",
+				"replicas":  float64(3),
+				"labels":    map[string]interface{}{"app": "api", "tier": "backend"},
+				"ports":     []interface{}{float64(8080), float64(9090)},
+			},
+			expectedReplicas: 3,
+			expectedImage:    "ghcr.io/example/api:v1",
+			expectedLabels:   map[string]string{"app": "api", "tier": "backend"},
+			expectedPorts:    []int32{8080, 9090},
+		},
+		{
+			name:   "simplified parameters default replicas and labels",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "api",
+				"image":     "ghcr.io/example/api:v1",
+			},
+			expectedReplicas: 1,
+			expectedImage:    "ghcr.io/example/api:v1",
+			expectedLabels:   map[string]string{"app": "api"},
+		},
+		{
+			name:   "manifest with wrong kind",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"manifest":  "kind: StatefulSet\nmetadata:\n  name: db\n",
+			},
+			expectedErrMsg: "manifest kind must be Deployment",
+		},
+		{
+			name:   "manifest namespace mismatch",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"manifest":  "kind: Deployment\nmetadata:\n  name: web\n  namespace: prod\n",
+			},
+			expectedErrMsg: "does not match namespace",
+		},
+		{
+			name:   "manifest combined with simplified parameters",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"manifest":  yamlManifest,
+				"image":     "nginx:1.26",
+			},
+			expectedErrMsg: "cannot be combined with manifest",
+		},
+		{
+			name:   "missing image",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "api",
+			},
+			expectedErrMsg: "missing required parameter: image",
+		},
+		{
+			name:   "invalid port",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "api",
+				"image":     "ghcr.io/example/api:v1",
+				"ports":     []interface{}{float64(70000)},
+			},
+			expectedErrMsg: "ports must be integers",
+		},
+		{
+			name: "deployment already exists",
+			client: fake.NewSimpleClientset(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			}),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "api",
+				"image":     "ghcr.io/example/api:v1",
+			},
+			expectedErrMsg: "failed to create deployment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.Create()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var returned appsv1.Deployment
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "default", returned.Namespace)
+			if tc.expectedReplicas != 0 {
+				assert.Equal(t, tc.expectedReplicas, *returned.Spec.Replicas)
+			}
+			assert.Equal(t, tc.expectedImage, returned.Spec.Template.Spec.Containers[0].Image)
+			assert.Equal(t, tc.expectedLabels, returned.Spec.Selector.MatchLabels)
+
+			var ports []int32
+			for _, p := range returned.Spec.Template.Spec.Containers[0].Ports {
+				ports = append(ports, p.ContainerPort)
+			}
+			assert.Equal(t, tc.expectedPorts, ports)
+
+			// Verify the deployment exists in the cluster
+			created, err := tc.client.AppsV1().Deployments("default").Get(context.Background(), returned.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, returned.Name, created.Name)
+		})
+	}
+}
+
+func TestRolloutRestartDeployment(t *testing.T) {
+	web := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "shop"}},
+		}},
+	}
+	paused := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Paused: true},
+	}
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(web, paused)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.RolloutRestart()
+
+	assert.Equal(t, "rollout_restart_deployment", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful restart",
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web"},
+		},
+		{
+			name:           "paused deployment",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "paused"},
+			expectedErrMsg: "deployment paused is paused",
+		},
+		{
+			name:           "deployment not found",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to get deployment",
+		},
+		{
+			name:           "missing required param: name",
+			requestArgs:    map[string]interface{}{"namespace": "default"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var restarted appsv1.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &restarted))
+			annotations := restarted.Spec.Template.Annotations
+			assert.Equal(t, "shop", annotations["team"])
+			_, err = time.Parse(time.RFC3339, annotations[RestartedAtAnnotation])
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPauseAndResumeDeployment(t *testing.T) {
+	web := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	client := fake.NewSimpleClientset(web)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	pauseTool, pauseFn := handler.Pause()
+	resumeTool, resumeFn := handler.Resume()
+
+	assert.Equal(t, "pause_deployment", pauseTool.Name)
+	assert.ElementsMatch(t, pauseTool.InputSchema.Required, []string{"namespace", "name"})
+	assert.Equal(t, "resume_deployment", resumeTool.Name)
+	assert.ElementsMatch(t, resumeTool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		handlerFn      server.ToolHandlerFunc
+		requestArgs    map[string]interface{}
+		expectedPaused bool
+		expectedErrMsg string
+	}{
+		{
+			name:           "resume running deployment",
+			handlerFn:      resumeFn,
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedErrMsg: "deployment web is already resumed",
+		},
+		{
+			name:           "pause deployment",
+			handlerFn:      pauseFn,
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedPaused: true,
+		},
+		{
+			name:           "pause paused deployment",
+			handlerFn:      pauseFn,
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedErrMsg: "deployment web is already paused",
+		},
+		{
+			name:           "resume deployment",
+			handlerFn:      resumeFn,
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedPaused: false,
+		},
+		{
+			name:           "deployment not found",
+			handlerFn:      pauseFn,
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to get deployment",
+		},
+		{
+			name:           "missing required param: namespace",
+			handlerFn:      resumeFn,
+			requestArgs:    map[string]interface{}{"name": "web"},
+			expectedErrMsg: "missing required parameter: namespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var updated appsv1.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &updated))
+			assert.Equal(t, tc.expectedPaused, updated.Spec.Paused)
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func rolloutFixture() (*appsv1.Deployment, []*appsv1.ReplicaSet) {
+	labels := map[string]string{"app": "web"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", UID: "web-uid", Generation: 3,
+			Annotations: map[string]string{RevisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(2),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.27"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2},
+	}
+
+	isController := true
+	replicaSet := func(revision, image, cause string) *appsv1.ReplicaSet {
+		rsLabels := map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "hash" + revision}
+		annotations := map[string]string{RevisionAnnotation: revision}
+		if cause != "" {
+			annotations[ChangeCauseAnnotation] = cause
+		}
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "web-" + revision, Namespace: "default", Labels: rsLabels, Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "web-uid", Controller: &isController}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: rsLabels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+			}},
+		}
+	}
+	return deployment, []*appsv1.ReplicaSet{
+		replicaSet("3", "nginx:1.27", "upgrade to 1.27"),
+		replicaSet("1", "nginx:1.25", ""),
+		replicaSet("2", "nginx:1.26", "upgrade to 1.26"),
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		mutate          func(d *appsv1.Deployment)
+		expectedDone    bool
+		expectedFailed  bool
+		expectedMessage string
+	}{
+		{
+			name:            "rolled out",
+			mutate:          func(d *appsv1.Deployment) {},
+			expectedDone:    true,
+			expectedMessage: "successfully rolled out",
+		},
+		{
+			name:            "spec not observed",
+			mutate:          func(d *appsv1.Deployment) { d.Generation = 4 },
+			expectedMessage: "waiting for the deployment spec update",
+		},
+		{
+			name:            "updating replicas",
+			mutate:          func(d *appsv1.Deployment) { d.Status.UpdatedReplicas = 1 },
+			expectedMessage: "1 out of 2 new replicas have been updated",
+		},
+		{
+			name:            "old replicas terminating",
+			mutate:          func(d *appsv1.Deployment) { d.Status.Replicas = 3 },
+			expectedMessage: "1 old replicas are pending termination",
+		},
+		{
+			name:            "waiting for availability",
+			mutate:          func(d *appsv1.Deployment) { d.Status.AvailableReplicas = 1 },
+			expectedMessage: "1 of 2 updated replicas are available",
+		},
+		{
+			name: "progress deadline exceeded",
+			mutate: func(d *appsv1.Deployment) {
+				d.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"}}
+			},
+			expectedFailed:  true,
+			expectedMessage: "exceeded its progress deadline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment, _ := rolloutFixture()
+			tc.mutate(deployment)
+			handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(deployment)), translations.NullTranslationHelper)
+			tool, handlerFn := handler.RolloutStatus()
+			assert.Equal(t, "rollout_status", tool.Name)
+
+			result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "name": "web"}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var status RolloutStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedDone, status.Done)
+			assert.Equal(t, tc.expectedFailed, status.Failed)
+			assert.Contains(t, status.Message, tc.expectedMessage)
+			assert.Equal(t, int64(3), status.Revision)
+		})
+	}
+}
+
+func TestRolloutHistory(t *testing.T) {
+	deployment, replicaSets := rolloutFixture()
+	orphan := replicaSets[1].DeepCopy()
+	orphan.Name = "someone-else"
+	orphan.OwnerReferences = nil
+	client := fake.NewSimpleClientset(deployment, replicaSets[0], replicaSets[1], replicaSets[2], orphan)
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.RolloutHistory()
+	assert.Equal(t, "rollout_history", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "name": "web"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var history []Revision
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &history))
+	require.Len(t, history, 3)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, []string{"nginx:1.26"}, history[1].Images)
+	assert.Equal(t, "upgrade to 1.26", history[1].ChangeCause)
+	assert.True(t, history[2].Current)
+	assert.False(t, history[1].Current)
+}
+
+func TestRolloutUndo(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedImage  string
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:          "previous revision",
+			requestArgs:   map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedImage: "nginx:1.26",
+		},
+		{
+			name:          "explicit revision",
+			requestArgs:   map[string]interface{}{"namespace": "default", "name": "web", "toRevision": float64(1)},
+			expectedImage: "nginx:1.25",
+		},
+		{
+			name:         "current revision",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "toRevision": float64(3)},
+			expectedText: "already matches revision 3",
+		},
+		{
+			name:           "unknown revision",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "toRevision": float64(7)},
+			expectedErrMsg: "revision 7 of deployment web not found",
+		},
+		{
+			name:           "invalid revision",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "toRevision": float64(1.5)},
+			expectedErrMsg: "toRevision must be a positive integer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment, replicaSets := rolloutFixture()
+			client := fake.NewSimpleClientset(deployment, replicaSets[0], replicaSets[1], replicaSets[2])
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.RolloutUndo()
+			assert.Equal(t, "rollout_undo", tool.Name)
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			if tc.expectedText != "" {
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+				return
+			}
+
+			var rolledBack appsv1.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &rolledBack))
+			assert.Equal(t, tc.expectedImage, rolledBack.Spec.Template.Spec.Containers[0].Image)
+			assert.NotContains(t, rolledBack.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		})
+	}
+}
+
+func TestDeleteDeployment(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "delete deployment",
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web"},
+		},
+		{
+			name:           "deployment not found",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to delete deployment",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{"namespace": "default"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Delete()
+			assert.Equal(t, "delete_deployment", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Deployment web in namespace default deleted", getTextResult(t, result).Text)
+			_, err = client.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,1150 @@
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/deployment"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/pod"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Severities of findings, from most to least severe
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// maxDiagnosisEvents is the number of most recent warning events included in a diagnosis
+const maxDiagnosisEvents = 10
+
+// Outcomes of a service check
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+// maxDiagnosedPods is the number of unhealthy pods detailed in a workload diagnosis
+const maxDiagnosedPods = 5
+
+// Handler implements the K8sResourceHandler interface for the troubleshooting analyzers
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new diagnostics handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// RegisterTools registers all diagnostics tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	podTool, podHandler := h.DiagnosePod()
+	toolset.AddReadTool(podTool, podHandler)
+
+	deploymentTool, deploymentHandler := h.DiagnoseDeployment()
+	toolset.AddReadTool(deploymentTool, deploymentHandler)
+
+	serviceTool, serviceHandler := h.CheckService()
+	toolset.AddReadTool(serviceTool, serviceHandler)
+}
+
+// Finding is a problem, or a notable fact, found while diagnosing a resource
+type Finding struct {
+	Severity string `json:"severity"`
+	// Reason is a short machine-readable cause such as CrashLoopBackOff or Unschedulable
+	Reason    string `json:"reason"`
+	Container string `json:"container,omitempty"`
+	// Pods lists the pods sharing a finding of a workload diagnosis
+	Pods       []string `json:"pods,omitempty"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// NodeCondition is a condition of the node running a pod that is not in its healthy state
+type NodeCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// PodDiagnosis explains the state of a pod
+type PodDiagnosis struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Node      string `json:"node,omitempty"`
+	// Healthy is set when no critical or warning findings were made
+	Healthy        bool                 `json:"healthy"`
+	Findings       []Finding            `json:"findings"`
+	Restarts       int32                `json:"restarts"`
+	NodeConditions []NodeCondition      `json:"nodeConditions,omitempty"`
+	Events         []pod.EventSummary   `json:"events,omitempty"`
+	Containers     []ContainerDiagnosis `json:"containers"`
+}
+
+// ContainerDiagnosis is the state of a container as seen by the diagnosis
+type ContainerDiagnosis struct {
+	Name         string `json:"name"`
+	Init         bool   `json:"init,omitempty"`
+	Image        string `json:"image"`
+	State        string `json:"state"`
+	Reason       string `json:"reason,omitempty"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+	// LastTermination describes the previous instance of a restarted container
+	LastTermination string `json:"lastTermination,omitempty"`
+}
+
+// DiagnosePod creates a tool to explain why a pod is not healthy
+func (h *Handler) DiagnosePod() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("diagnose_pod",
+			mcp.WithDescription(h.t("TOOL_DIAGNOSE_POD_DESCRIPTION", "Diagnose a pod: correlate its status, container states, recent restarts, warning events and the conditions of its node into a list of findings such as CrashLoopBackOff with the exit reason, ImagePullBackOff details, OOMKilled containers and unschedulable reasons, each with a suggested next step")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			p, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+
+			selector := fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": name}.AsSelector().String()
+			events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list events: %v", err)), nil
+			}
+
+			// The node is optional context; a pod whose node is gone is diagnosed without it
+			var node *corev1.Node
+			if p.Spec.NodeName != "" {
+				n, err := client.CoreV1().Nodes().Get(ctx, p.Spec.NodeName, metav1.GetOptions{})
+				switch {
+				case err == nil:
+					node = n
+				case !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err):
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get node: %v", err)), nil
+				}
+			}
+
+			return toolsets.NewToolResultJSON(diagnosePod(p, podEvents(p, events.Items), node))
+		}
+}
+
+// diagnosePod analyzes a pod together with its events, newest first, and its node, which is nil
+// when the pod is not scheduled or the node cannot be read
+func diagnosePod(p *corev1.Pod, events []corev1.Event, node *corev1.Node) PodDiagnosis {
+	d := PodDiagnosis{
+		Namespace:  p.Namespace,
+		Name:       p.Name,
+		Phase:      string(p.Status.Phase),
+		Node:       p.Spec.NodeName,
+		Findings:   []Finding{},
+		Containers: []ContainerDiagnosis{},
+	}
+
+	if p.DeletionTimestamp != nil {
+		d.Findings = append(d.Findings, Finding{
+			Severity:   SeverityWarning,
+			Reason:     "Terminating",
+			Message:    fmt.Sprintf("pod is being deleted since %s", p.DeletionTimestamp.UTC().Format(time.RFC3339)),
+			Suggestion: "If it stays terminating, check finalizers and whether the node is reachable",
+		})
+	}
+	if p.Status.Phase == corev1.PodFailed && p.Status.Reason != "" {
+		f := Finding{Severity: SeverityCritical, Reason: p.Status.Reason, Message: p.Status.Message}
+		if p.Status.Reason == "Evicted" {
+			f.Suggestion = "The kubelet evicted the pod under node resource pressure; check the node conditions and the pod's requests"
+		}
+		d.Findings = append(d.Findings, f)
+	}
+	d.Findings = append(d.Findings, schedulingFindings(p, events)...)
+
+	specs := map[string]corev1.Container{}
+	for _, c := range p.Spec.InitContainers {
+		specs[c.Name] = c
+	}
+	for _, c := range p.Spec.Containers {
+		specs[c.Name] = c
+	}
+	for _, s := range p.Status.InitContainerStatuses {
+		d.Containers = append(d.Containers, diagnoseContainer(s, true))
+		d.Findings = append(d.Findings, containerFindings(s, specs[s.Name], true)...)
+		d.Restarts += s.RestartCount
+	}
+	for _, s := range p.Status.ContainerStatuses {
+		d.Containers = append(d.Containers, diagnoseContainer(s, false))
+		d.Findings = append(d.Findings, containerFindings(s, specs[s.Name], false)...)
+		d.Restarts += s.RestartCount
+	}
+	d.Findings = append(d.Findings, eventFindings(events)...)
+
+	if node != nil {
+		conditions, findings := nodeFindings(node)
+		d.NodeConditions = conditions
+		d.Findings = append(d.Findings, findings...)
+	}
+
+	d.Events = warningEvents(events)
+
+	sortFindings(d.Findings)
+	d.Healthy = healthy(d.Findings)
+	return d
+}
+
+// schedulingFindings explains why a pod is not scheduled
+func schedulingFindings(p *corev1.Pod, events []corev1.Event) []Finding {
+	if p.Spec.NodeName != "" {
+		return nil
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type != corev1.PodScheduled || c.Status != corev1.ConditionFalse {
+			continue
+		}
+		message := c.Message
+		// The scheduler event usually carries the most recent attempt
+		for _, e := range events {
+			if e.Reason == "FailedScheduling" {
+				message = e.Message
+				break
+			}
+		}
+		return []Finding{{
+			Severity:   SeverityCritical,
+			Reason:     "Unschedulable",
+			Message:    message,
+			Suggestion: schedulingSuggestion(message),
+		}}
+	}
+	return nil
+}
+
+// schedulingSuggestion maps the scheduler's explanation to the constraint to relax
+func schedulingSuggestion(message string) string {
+	switch {
+	case strings.Contains(message, "Insufficient"):
+		return "No node has enough allocatable resources for the pod's requests; lower the requests or add capacity"
+	case strings.Contains(message, "didn't match Pod's node affinity/selector") || strings.Contains(message, "node(s) didn't match node selector"):
+		return "No node matches the pod's nodeSelector or node affinity; check the node labels"
+	case strings.Contains(message, "untolerated taint") || strings.Contains(message, "had taint"):
+		return "The nodes have taints the pod does not tolerate; add a toleration or schedule on other nodes"
+	case strings.Contains(message, "unbound immediate PersistentVolumeClaims") || strings.Contains(message, "volume node affinity conflict"):
+		return "A PersistentVolumeClaim is not bound or its volume is pinned to another zone; check the claim and the storage class"
+	case strings.Contains(message, "didn't match pod anti-affinity rules") || strings.Contains(message, "didn't match pod affinity rules"):
+		return "Pod affinity or anti-affinity rules cannot be satisfied by the current pods and nodes"
+	case strings.Contains(message, "didn't match pod topology spread constraints"):
+		return "Topology spread constraints cannot be satisfied; check maxSkew and whenUnsatisfiable"
+	}
+	return "Check the pod's requests, nodeSelector, affinity, tolerations and volumes against the available nodes"
+}
+
+// diagnoseContainer summarizes the status of a container
+func diagnoseContainer(s corev1.ContainerStatus, init bool) ContainerDiagnosis {
+	c := ContainerDiagnosis{
+		Name:         s.Name,
+		Init:         init,
+		Image:        s.Image,
+		Ready:        s.Ready,
+		RestartCount: s.RestartCount,
+	}
+	switch {
+	case s.State.Running != nil:
+		c.State = "running"
+	case s.State.Waiting != nil:
+		c.State = "waiting"
+		c.Reason = s.State.Waiting.Reason
+	case s.State.Terminated != nil:
+		c.State = "terminated"
+		c.Reason = s.State.Terminated.Reason
+	default:
+		c.State = "pending"
+	}
+	if t := s.LastTerminationState.Terminated; t != nil {
+		c.LastTermination = describeTermination(t)
+	}
+	return c
+}
+
+// containerFindings explains the problems of a container
+func containerFindings(s corev1.ContainerStatus, spec corev1.Container, init bool) []Finding {
+	var findings []Finding
+	last := s.LastTerminationState.Terminated
+
+	if w := s.State.Waiting; w != nil {
+		switch w.Reason {
+		case "CrashLoopBackOff":
+			message := fmt.Sprintf("container keeps crashing and has restarted %d times", s.RestartCount)
+			suggestion := "Check the logs of the previous instance with get_pod_logs and previous=true"
+			if last != nil {
+				message += "; last exit: " + describeTermination(last)
+				suggestion = exitCodeSuggestion(last.ExitCode, last.Reason)
+			}
+			findings = append(findings, Finding{Severity: SeverityCritical, Reason: w.Reason, Container: s.Name, Message: message, Suggestion: suggestion})
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ErrImageNeverPull":
+			findings = append(findings, Finding{
+				Severity:   SeverityCritical,
+				Reason:     w.Reason,
+				Container:  s.Name,
+				Message:    fmt.Sprintf("image %s cannot be pulled: %s", spec.Image, w.Message),
+				Suggestion: imagePullSuggestion(w.Message),
+			})
+		case "CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+			findings = append(findings, Finding{
+				Severity:   SeverityCritical,
+				Reason:     w.Reason,
+				Container:  s.Name,
+				Message:    w.Message,
+				Suggestion: "The container could not be created; check that the ConfigMaps, Secrets and keys it references exist",
+			})
+		}
+	}
+
+	// An OOM kill is reported whether the container is still down or already restarted
+	for _, t := range []*corev1.ContainerStateTerminated{s.State.Terminated, last} {
+		if t == nil || t.Reason != "OOMKilled" {
+			continue
+		}
+		message := fmt.Sprintf("container was killed for exceeding its memory at %s", t.FinishedAt.UTC().Format(time.RFC3339))
+		if limit, ok := spec.Resources.Limits[corev1.ResourceMemory]; ok {
+			message += fmt.Sprintf("; memory limit is %s", limit.String())
+		}
+		findings = append(findings, Finding{
+			Severity:   SeverityCritical,
+			Reason:     "OOMKilled",
+			Container:  s.Name,
+			Message:    message,
+			Suggestion: "Raise the memory limit or reduce the container's memory use; top_pods shows the current usage",
+		})
+		break
+	}
+
+	if t := s.State.Terminated; t != nil && t.ExitCode != 0 && t.Reason != "OOMKilled" && !init {
+		findings = append(findings, Finding{
+			Severity:   SeverityCritical,
+			Reason:     "Terminated",
+			Container:  s.Name,
+			Message:    "container exited: " + describeTermination(t),
+			Suggestion: exitCodeSuggestion(t.ExitCode, t.Reason),
+		})
+	}
+	if t := s.State.Terminated; t != nil && t.ExitCode != 0 && init {
+		findings = append(findings, Finding{
+			Severity:   SeverityCritical,
+			Reason:     "InitContainerFailed",
+			Container:  s.Name,
+			Message:    "init container failed: " + describeTermination(t),
+			Suggestion: "The app containers only start once every init container succeeds; check the init container's logs",
+		})
+	}
+
+	if s.State.Running != nil && !s.Ready && !init {
+		findings = append(findings, Finding{
+			Severity:   SeverityWarning,
+			Reason:     "NotReady",
+			Container:  s.Name,
+			Message:    "container is running but not ready, so it receives no Service traffic",
+			Suggestion: "Check the readiness probe; Unhealthy events show why it fails",
+		})
+	}
+
+	if s.State.Running != nil && s.RestartCount > 0 && last != nil && last.Reason != "OOMKilled" {
+		findings = append(findings, Finding{
+			Severity:  SeverityWarning,
+			Reason:    "Restarted",
+			Container: s.Name,
+			Message:   fmt.Sprintf("container has restarted %d times; last exit: %s", s.RestartCount, describeTermination(last)),
+		})
+	}
+	return findings
+}
+
+// describeTermination describes how a container instance ended
+func describeTermination(t *corev1.ContainerStateTerminated) string {
+	parts := []string{fmt.Sprintf("exit code %d", t.ExitCode)}
+	if t.Reason != "" {
+		parts = append(parts, "reason "+t.Reason)
+	}
+	if t.Signal != 0 {
+		parts = append(parts, fmt.Sprintf("signal %d", t.Signal))
+	}
+	if !t.FinishedAt.IsZero() {
+		parts = append(parts, "at "+t.FinishedAt.UTC().Format(time.RFC3339))
+	}
+	description := strings.Join(parts, ", ")
+	if message := strings.TrimSpace(t.Message); message != "" {
+		description += ": " + message
+	}
+	return description
+}
+
+// exitCodeSuggestion interprets the exit code of a crashed container
+func exitCodeSuggestion(exitCode int32, reason string) string {
+	switch {
+	case reason == "OOMKilled":
+		return "Raise the memory limit or reduce the container's memory use"
+	case exitCode == 126:
+		return "The command is not executable; check the image entrypoint and file permissions"
+	case exitCode == 127:
+		return "The command was not found in the image; check the container's command and args"
+	case exitCode == 137:
+		return "The process was killed with SIGKILL, usually by a failed liveness probe or the OOM killer; check the liveness probe and memory use"
+	case exitCode == 143:
+		return "The process was stopped with SIGTERM; check whether a liveness probe or the application itself stops it"
+	}
+	return "The application exited with an error; check the logs of the previous instance with get_pod_logs and previous=true"
+}
+
+// imagePullSuggestion maps the container runtime's pull error to its likely cause
+func imagePullSuggestion(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "not found") || strings.Contains(lower, "manifest unknown"):
+		return "The image or tag does not exist; check the image name and tag"
+	case strings.Contains(lower, "unauthorized") || strings.Contains(lower, "denied") || strings.Contains(lower, "authentication required"):
+		return "The registry refused the credentials; check the pod's imagePullSecrets and its service account"
+	case strings.Contains(lower, "timeout") || strings.Contains(lower, "no such host") || strings.Contains(lower, "connection refused"):
+		return "The registry cannot be reached from the node; check DNS, proxies and network policies"
+	case strings.Contains(lower, "toomanyrequests") || strings.Contains(lower, "rate limit"):
+		return "The registry is rate limiting pulls; authenticate or use a mirror"
+	}
+	return "Check the image reference, the registry credentials and whether the node can reach the registry"
+}
+
+// eventFindings reports problems that only show up in events
+func eventFindings(events []corev1.Event) []Finding {
+	var findings []Finding
+	seen := map[string]bool{}
+	for _, e := range events {
+		if e.Type != corev1.EventTypeWarning || seen[e.Reason] {
+			continue
+		}
+		var f Finding
+		switch e.Reason {
+		case "FailedMount", "FailedAttachVolume":
+			f = Finding{Severity: SeverityCritical, Suggestion: "Check that the volumes' PersistentVolumeClaims, ConfigMaps and Secrets exist and that the volume can attach to the node"}
+		case "Unhealthy":
+			f = Finding{Severity: SeverityWarning, Suggestion: "A probe is failing; check the probe's path, port and timeouts against the application"}
+		case "FailedCreatePodSandBox", "FailedKillPod":
+			f = Finding{Severity: SeverityCritical, Suggestion: "The container runtime or network plugin failed on the node; check the node and its CNI pods"}
+		default:
+			continue
+		}
+		seen[e.Reason] = true
+		f.Reason = e.Reason
+		f.Message = e.Message
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// nodeFindings reports the conditions of a node that may affect its pods
+func nodeFindings(node *corev1.Node) ([]NodeCondition, []Finding) {
+	var conditions []NodeCondition
+	var findings []Finding
+	for _, c := range node.Status.Conditions {
+		unhealthy := (c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue) ||
+			(c.Type != corev1.NodeReady && c.Status == corev1.ConditionTrue)
+		if !unhealthy {
+			continue
+		}
+		conditions = append(conditions, NodeCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+		findings = append(findings, Finding{
+			Severity:   SeverityWarning,
+			Reason:     "Node" + string(c.Type),
+			Message:    fmt.Sprintf("node %s has condition %s=%s: %s", node.Name, c.Type, c.Status, c.Message),
+			Suggestion: "The node is unhealthy, which can evict or slow down its pods; check the node with get_node",
+		})
+	}
+	if node.Spec.Unschedulable {
+		findings = append(findings, Finding{
+			Severity: SeverityInfo,
+			Reason:   "NodeCordoned",
+			Message:  fmt.Sprintf("node %s is cordoned; replacement pods will be scheduled elsewhere", node.Name),
+		})
+	}
+	return conditions, findings
+}
+
+// DeploymentDiagnosis explains why a deployment is not fully available
+type DeploymentDiagnosis struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Healthy is set when every replica is available and no critical or warning findings were made
+	Healthy     bool                `json:"healthy"`
+	Paused      bool                `json:"paused,omitempty"`
+	Replicas    ReplicaSummary      `json:"replicas"`
+	Findings    []Finding           `json:"findings"`
+	ReplicaSets []ReplicaSetSummary `json:"replicaSets"`
+	// Pods details the first unhealthy pods, most severe first
+	Pods   []PodDiagnosis     `json:"pods,omitempty"`
+	Events []pod.EventSummary `json:"events,omitempty"`
+}
+
+// ReplicaSummary counts the replicas of a workload
+type ReplicaSummary struct {
+	Desired     int32 `json:"desired"`
+	Updated     int32 `json:"updated"`
+	Ready       int32 `json:"ready"`
+	Available   int32 `json:"available"`
+	Unavailable int32 `json:"unavailable"`
+}
+
+// ReplicaSetSummary is a ReplicaSet of a deployment
+type ReplicaSetSummary struct {
+	Name     string `json:"name"`
+	Revision int64  `json:"revision"`
+	Current  bool   `json:"current,omitempty"`
+	Desired  int32  `json:"desired"`
+	Ready    int32  `json:"ready"`
+}
+
+// DiagnoseDeployment creates a tool to explain why a deployment is not fully available
+func (h *Handler) DiagnoseDeployment() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("diagnose_deployment",
+			mcp.WithDescription(h.t("TOOL_DIAGNOSE_DEPLOYMENT_DESCRIPTION", "Diagnose a deployment that is not fully available: correlate its rollout conditions, ReplicaSets, pods and events into findings such as failed or crash looping pods, nodeSelectors no node satisfies, exhausted resource quotas and pods rejected by admission webhooks")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Deployment name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			d, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get deployment: %v", err)), nil
+			}
+			selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid deployment selector: %v", err)), nil
+			}
+
+			replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list replicasets: %v", err)), nil
+			}
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+			// Events of the deployment, its ReplicaSets and its pods are all in its namespace
+			events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list events: %v", err)), nil
+			}
+
+			// Nodes and quotas are optional context that a namespaced user may not be allowed to read
+			var nodes []corev1.Node
+			nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			switch {
+			case err == nil:
+				nodes = nodeList.Items
+			case !apierrors.IsForbidden(err):
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
+			}
+			var quotas []corev1.ResourceQuota
+			quotaList, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+			switch {
+			case err == nil:
+				quotas = quotaList.Items
+			case !apierrors.IsForbidden(err):
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list resource quotas: %v", err)), nil
+			}
+
+			return toolsets.NewToolResultJSON(diagnoseDeployment(d, replicaSets.Items, pods.Items, events.Items, nodes, quotas))
+		}
+}
+
+// diagnoseDeployment analyzes a deployment together with the ReplicaSets and pods matching its
+// selector, the events of its namespace, the nodes and the resource quotas of its namespace
+func diagnoseDeployment(d *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, pods []corev1.Pod, events []corev1.Event, nodes []corev1.Node, quotas []corev1.ResourceQuota) DeploymentDiagnosis {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	diagnosis := DeploymentDiagnosis{
+		Namespace: d.Namespace,
+		Name:      d.Name,
+		Paused:    d.Spec.Paused,
+		Replicas: ReplicaSummary{
+			Desired:     desired,
+			Updated:     d.Status.UpdatedReplicas,
+			Ready:       d.Status.ReadyReplicas,
+			Available:   d.Status.AvailableReplicas,
+			Unavailable: d.Status.UnavailableReplicas,
+		},
+		Findings:    []Finding{},
+		ReplicaSets: []ReplicaSetSummary{},
+	}
+	available := d.Status.AvailableReplicas >= desired && d.Status.UpdatedReplicas >= desired
+
+	// Owned ReplicaSets, oldest revision first
+	owned := map[types.UID]bool{}
+	current := revisionOf(d.Annotations)
+	for _, rs := range replicaSets {
+		if !metav1.IsControlledBy(&rs, d) {
+			continue
+		}
+		owned[rs.UID] = true
+		summary := ReplicaSetSummary{
+			Name:     rs.Name,
+			Revision: revisionOf(rs.Annotations),
+			Ready:    rs.Status.ReadyReplicas,
+		}
+		summary.Current = summary.Revision == current
+		if rs.Spec.Replicas != nil {
+			summary.Desired = *rs.Spec.Replicas
+		}
+		diagnosis.ReplicaSets = append(diagnosis.ReplicaSets, summary)
+	}
+	sort.Slice(diagnosis.ReplicaSets, func(i, j int) bool {
+		return diagnosis.ReplicaSets[i].Revision < diagnosis.ReplicaSets[j].Revision
+	})
+
+	if !available {
+		diagnosis.Findings = append(diagnosis.Findings, Finding{
+			Severity: SeverityWarning,
+			Reason:   "ReplicasUnavailable",
+			Message:  fmt.Sprintf("%d of %d replicas available, %d updated, %d ready", d.Status.AvailableReplicas, desired, d.Status.UpdatedReplicas, d.Status.ReadyReplicas),
+		})
+	}
+	if d.Spec.Paused {
+		diagnosis.Findings = append(diagnosis.Findings, Finding{
+			Severity:   SeverityInfo,
+			Reason:     "Paused",
+			Message:    "rollouts of the deployment are paused",
+			Suggestion: "Resume the deployment to roll out template changes",
+		})
+	}
+	for _, c := range d.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse:
+			diagnosis.Findings = append(diagnosis.Findings, Finding{
+				Severity:   SeverityCritical,
+				Reason:     c.Reason,
+				Message:    c.Message,
+				Suggestion: "The rollout stopped making progress; the pod findings below explain why the new pods do not become available",
+			})
+		case c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue:
+			diagnosis.Findings = append(diagnosis.Findings, creationFinding(c.Reason, c.Message))
+		}
+	}
+
+	// The controllers report pod creation failures as events on the ReplicaSets and the deployment
+	var workloadEvents []corev1.Event
+	for _, e := range events {
+		switch {
+		case e.InvolvedObject.Kind == "Deployment" && e.InvolvedObject.Name == d.Name,
+			e.InvolvedObject.Kind == "ReplicaSet" && owned[e.InvolvedObject.UID]:
+			workloadEvents = append(workloadEvents, e)
+		}
+	}
+	sort.SliceStable(workloadEvents, func(i, j int) bool {
+		return eventTime(workloadEvents[i]).After(eventTime(workloadEvents[j]))
+	})
+	for _, e := range workloadEvents {
+		if e.Type == corev1.EventTypeWarning && e.Reason == "FailedCreate" {
+			diagnosis.Findings = append(diagnosis.Findings, creationFinding(e.Reason, e.Message))
+		}
+	}
+	diagnosis.Events = warningEvents(workloadEvents)
+
+	diagnosis.Findings = append(diagnosis.Findings, nodeSelectorFindings(d.Spec.Template.Spec.NodeSelector, nodes)...)
+	diagnosis.Findings = append(diagnosis.Findings, quotaFindings(quotas, available)...)
+
+	nodesByName := map[string]*corev1.Node{}
+	for i := range nodes {
+		nodesByName[nodes[i].Name] = &nodes[i]
+	}
+	var unhealthy []PodDiagnosis
+	for i := range pods {
+		p := &pods[i]
+		if ref := metav1.GetControllerOf(p); ref == nil || !owned[ref.UID] {
+			continue
+		}
+		pd := diagnosePod(p, podEvents(p, events), nodesByName[p.Spec.NodeName])
+		if !pd.Healthy {
+			unhealthy = append(unhealthy, pd)
+		}
+	}
+	diagnosis.Findings = append(diagnosis.Findings, aggregatePodFindings(unhealthy)...)
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return severityRank[unhealthy[i].Findings[0].Severity] < severityRank[unhealthy[j].Findings[0].Severity]
+	})
+	if len(unhealthy) > maxDiagnosedPods {
+		unhealthy = unhealthy[:maxDiagnosedPods]
+	}
+	diagnosis.Pods = unhealthy
+
+	sortFindings(diagnosis.Findings)
+	diagnosis.Healthy = available && healthy(diagnosis.Findings)
+	return diagnosis
+}
+
+// creationFinding explains why a controller could not create pods
+func creationFinding(reason, message string) Finding {
+	f := Finding{Severity: SeverityCritical, Reason: reason, Message: message}
+	switch {
+	case strings.Contains(message, "exceeded quota"):
+		f.Reason = "QuotaExceeded"
+		f.Suggestion = "A ResourceQuota of the namespace is exhausted; raise the quota or lower the pods' requests"
+	case strings.Contains(message, "admission webhook"):
+		f.Reason = "WebhookRejected"
+		f.Suggestion = "An admission webhook rejected the pods; its message says which policy they violate"
+	case strings.Contains(message, "violates PodSecurity"):
+		f.Reason = "PodSecurityRejected"
+		f.Suggestion = "The pod template violates the namespace's Pod Security level; adjust its securityContext"
+	case strings.Contains(message, "forbidden"):
+		f.Suggestion = "The API server refused to create the pods; check LimitRanges, quotas and admission policies"
+	}
+	return f
+}
+
+// nodeSelectorFindings reports a nodeSelector that no schedulable node satisfies
+func nodeSelectorFindings(nodeSelector map[string]string, nodes []corev1.Node) []Finding {
+	if len(nodeSelector) == 0 || len(nodes) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(nodeSelector)
+	matching, schedulable := 0, 0
+	for _, n := range nodes {
+		if !selector.Matches(labels.Set(n.Labels)) {
+			continue
+		}
+		matching++
+		if !n.Spec.Unschedulable {
+			schedulable++
+		}
+	}
+	switch {
+	case matching == 0:
+		return []Finding{{
+			Severity:   SeverityCritical,
+			Reason:     "NodeSelectorUnsatisfiable",
+			Message:    fmt.Sprintf("no node has the labels %s required by the pod template's nodeSelector", selector.String()),
+			Suggestion: "Fix the nodeSelector or label the nodes the pods should run on",
+		}}
+	case schedulable == 0:
+		return []Finding{{
+			Severity:   SeverityCritical,
+			Reason:     "NodeSelectorUnsatisfiable",
+			Message:    fmt.Sprintf("all %d nodes matching the nodeSelector %s are cordoned", matching, selector.String()),
+			Suggestion: "Uncordon a matching node or relax the nodeSelector",
+		}}
+	}
+	return nil
+}
+
+// quotaFindings reports resource quotas whose usage has reached their limit. An exhausted quota
+// only blocks scaling of a deployment that is otherwise available, so it is reported as info then.
+func quotaFindings(quotas []corev1.ResourceQuota, available bool) []Finding {
+	severity := SeverityWarning
+	if available {
+		severity = SeverityInfo
+	}
+	var findings []Finding
+	for _, q := range quotas {
+		names := make([]string, 0, len(q.Status.Hard))
+		for name := range q.Status.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hard := q.Status.Hard[corev1.ResourceName(name)]
+			used, ok := q.Status.Used[corev1.ResourceName(name)]
+			if !ok || used.Cmp(hard) < 0 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Severity:   severity,
+				Reason:     "QuotaExhausted",
+				Message:    fmt.Sprintf("resourcequota %s: %s used %s of %s", q.Name, name, used.String(), hard.String()),
+				Suggestion: "New pods that need this resource are rejected until the quota is raised or usage drops",
+			})
+		}
+	}
+	return findings
+}
+
+// aggregatePodFindings merges the findings of pods, keeping one finding per reason and container
+// with the pods it applies to
+func aggregatePodFindings(pods []PodDiagnosis) []Finding {
+	var findings []Finding
+	index := map[string]int{}
+	for _, p := range pods {
+		for _, f := range p.Findings {
+			if f.Severity == SeverityInfo {
+				continue
+			}
+			key := f.Reason + "/" + f.Container
+			if i, ok := index[key]; ok {
+				findings[i].Pods = append(findings[i].Pods, p.Name)
+				continue
+			}
+			index[key] = len(findings)
+			f.Pods = []string{p.Name}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// warningEvents summarizes the most recent warning events of a list ordered newest first
+func warningEvents(events []corev1.Event) []pod.EventSummary {
+	var summaries []pod.EventSummary
+	for _, e := range events {
+		if e.Type != corev1.EventTypeWarning {
+			continue
+		}
+		summaries = append(summaries, pod.EventSummary{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Message:  e.Message,
+			Count:    e.Count,
+			LastSeen: eventTime(e),
+		})
+		if len(summaries) == maxDiagnosisEvents {
+			break
+		}
+	}
+	return summaries
+}
+
+// revisionOf returns the rollout revision recorded in the annotations of a deployment or ReplicaSet
+func revisionOf(annotations map[string]string) int64 {
+	revision, _ := strconv.ParseInt(annotations[deployment.RevisionAnnotation], 10, 64)
+	return revision
+}
+
+// ServiceCheck is the checklist of a service's connectivity
+type ServiceCheck struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	ClusterIP string `json:"clusterIP,omitempty"`
+	// Passed is set when no check failed
+	Passed bool    `json:"passed"`
+	Checks []Check `json:"checks"`
+}
+
+// Check is one step of the service debugging runbook
+type Check struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// CheckService creates a tool to run the service debugging runbook against a service
+func (h *Handler) CheckService() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_service_connectivity",
+			mcp.WithDescription(h.t("TOOL_CHECK_SERVICE_CONNECTIVITY_DESCRIPTION", "Check why a service may not route traffic: verify that its selector matches pods, the pods are ready, its EndpointSlices have ready endpoints and its targetPorts map to container ports. Returns a pass/fail checklist")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Service name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			svc, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get service: %v", err)), nil
+			}
+
+			var pods []corev1.Pod
+			if len(svc.Spec.Selector) > 0 {
+				list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String()})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+				}
+				pods = list.Items
+			}
+			slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: labels.Set{discoveryv1.LabelServiceName: name}.String(),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list endpointslices: %v", err)), nil
+			}
+
+			return toolsets.NewToolResultJSON(checkService(svc, pods, slices.Items))
+		}
+}
+
+// checkService runs the checks against a service, the pods matching its selector and its EndpointSlices
+func checkService(svc *corev1.Service, pods []corev1.Pod, slices []discoveryv1.EndpointSlice) ServiceCheck {
+	result := ServiceCheck{
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+		Checks:    []Check{},
+	}
+	add := func(name, status, message string) {
+		result.Checks = append(result.Checks, Check{Name: name, Status: status, Message: message})
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		add("selector", CheckSkip, fmt.Sprintf("ExternalName service resolves to %s through DNS and has no endpoints", svc.Spec.ExternalName))
+		result.Passed = true
+		return result
+	}
+
+	// Services without a selector have their endpoints managed by hand, so only the endpoints are checked
+	selector := labels.SelectorFromSet(svc.Spec.Selector).String()
+	switch {
+	case len(svc.Spec.Selector) == 0:
+		add("selector", CheckSkip, "service has no selector; its endpoints are managed manually or by another controller")
+	case len(pods) == 0:
+		add("selector", CheckFail, fmt.Sprintf("no pods match the selector %s; compare it with the pod template labels", selector))
+	default:
+		add("selector", CheckPass, fmt.Sprintf("%d pods match the selector %s", len(pods), selector))
+	}
+
+	if len(pods) > 0 {
+		var notReady []string
+		for _, p := range pods {
+			if !podReady(p) {
+				notReady = append(notReady, p.Name)
+			}
+		}
+		switch {
+		case len(notReady) == len(pods):
+			add("podsReady", CheckFail, fmt.Sprintf("none of the %d pods is ready: %s; diagnose_pod explains why", len(pods), strings.Join(notReady, ", ")))
+		case len(notReady) > 0:
+			add("podsReady", CheckWarn, fmt.Sprintf("%d of %d pods are not ready and receive no traffic: %s", len(notReady), len(pods), strings.Join(notReady, ", ")))
+		default:
+			add("podsReady", CheckPass, fmt.Sprintf("all %d pods are ready", len(pods)))
+		}
+	}
+
+	ready, notReady := 0, 0
+	for _, slice := range slices {
+		for _, e := range slice.Endpoints {
+			if e.Conditions.Ready == nil || *e.Conditions.Ready {
+				ready += len(e.Addresses)
+			} else {
+				notReady += len(e.Addresses)
+			}
+		}
+	}
+	switch {
+	case ready == 0 && notReady == 0:
+		add("endpoints", CheckFail, "the service has no endpoints, so connections to it are refused or time out")
+	case ready == 0:
+		add("endpoints", CheckFail, fmt.Sprintf("the service has no ready endpoints (%d not ready)", notReady))
+	case notReady > 0:
+		add("endpoints", CheckWarn, fmt.Sprintf("%d ready and %d not ready endpoints", ready, notReady))
+	default:
+		add("endpoints", CheckPass, fmt.Sprintf("%d ready endpoints", ready))
+	}
+
+	if len(pods) > 0 {
+		for _, port := range svc.Spec.Ports {
+			status, message := checkTargetPort(port, pods)
+			add("targetPort "+servicePortName(port), status, message)
+		}
+	}
+
+	result.Passed = true
+	for _, c := range result.Checks {
+		if c.Status == CheckFail {
+			result.Passed = false
+		}
+	}
+	return result
+}
+
+// checkTargetPort verifies that a service port's targetPort resolves to a container port of every pod.
+// A named targetPort must be declared, while a numeric one works when the process listens on it even
+// if no container declares it, which is only a warning.
+func checkTargetPort(port corev1.ServicePort, pods []corev1.Pod) (string, string) {
+	target := port.TargetPort
+	if target.IntVal == 0 && target.StrVal == "" {
+		target = intstr.FromInt32(port.Port)
+	}
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+
+	var missing []string
+	for _, p := range pods {
+		if !declaresPort(p, target, protocol) {
+			missing = append(missing, p.Name)
+		}
+	}
+	mapping := fmt.Sprintf("%d/%s -> %s", port.Port, protocol, target.String())
+	switch {
+	case len(missing) == 0:
+		return CheckPass, fmt.Sprintf("%s is a container port of all %d pods", mapping, len(pods))
+	case target.Type == intstr.String:
+		return CheckFail, fmt.Sprintf("%s: no container declares a port named %q in %s", mapping, target.StrVal, strings.Join(missing, ", "))
+	default:
+		return CheckWarn, fmt.Sprintf("%s: no container declares port %d in %s; traffic still arrives if the process listens on it", mapping, target.IntVal, strings.Join(missing, ", "))
+	}
+}
+
+func declaresPort(p corev1.Pod, target intstr.IntOrString, protocol corev1.Protocol) bool {
+	for _, c := range p.Spec.Containers {
+		for _, cp := range c.Ports {
+			cpProtocol := cp.Protocol
+			if cpProtocol == "" {
+				cpProtocol = corev1.ProtocolTCP
+			}
+			if cpProtocol != protocol {
+				continue
+			}
+			if (target.Type == intstr.String && cp.Name == target.StrVal) || (target.Type == intstr.Int && cp.ContainerPort == target.IntVal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func servicePortName(port corev1.ServicePort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	return strconv.Itoa(int(port.Port))
+}
+
+func podReady(p corev1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podEvents returns the events of a pod, newest first. Events of an earlier pod with the same name
+// are left out.
+func podEvents(p *corev1.Pod, events []corev1.Event) []corev1.Event {
+	var result []corev1.Event
+	for _, e := range events {
+		if e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != p.Name || (e.InvolvedObject.UID != "" && e.InvolvedObject.UID != p.UID) {
+			continue
+		}
+		result = append(result, e)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return eventTime(result[i]).After(eventTime(result[j]))
+	})
+	return result
+}
+
+// eventTime returns when an event was last seen
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// severityRank orders severities from most to least severe
+var severityRank = map[string]int{SeverityCritical: 0, SeverityWarning: 1, SeverityInfo: 2}
+
+// sortFindings orders findings from most to least severe, keeping their order otherwise
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] < severityRank[findings[j].Severity]
+	})
+}
+
+// healthy reports whether none of the findings is a problem
+func healthy(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity != SeverityInfo {
+			return false
+		}
+	}
+	return true
+}
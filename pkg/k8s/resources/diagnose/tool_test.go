@@ -0,0 +1,654 @@
+package diagnose
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func findingReasons(findings []Finding) []string {
+	reasons := []string{}
+	for _, f := range findings {
+		reasons = append(reasons, f.Reason)
+	}
+	return reasons
+}
+
+func TestDiagnosePod(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	healthyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "default", UID: "healthy-uid"},
+		Spec: corev1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []corev1.Container{{Name: "app", Image: "nginx:1.27"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				Image: "nginx:1.27",
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	crashingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "crashing", Namespace: "default", UID: "crashing-uid"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "example/app:1.0",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				Image:        "example/app:1.0",
+				RestartCount: 7,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off 5m0s restarting failed container",
+				}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   137,
+					Reason:     "OOMKilled",
+					FinishedAt: finished,
+				}},
+			}},
+		},
+	}
+	pullingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pulling", Namespace: "default", UID: "pulling-uid"},
+		Spec: corev1.PodSpec{
+			NodeName:   "node-2",
+			Containers: []corev1.Container{{Name: "app", Image: "example/missing:latest"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				Image: "example/missing:latest",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "ImagePullBackOff",
+					Message: `Back-off pulling image "example/missing:latest": manifest unknown`,
+				}},
+			}},
+		},
+	}
+	pendingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default", UID: "pending-uid"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "nginx:1.27"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient cpu.",
+			}},
+		},
+	}
+	node1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+		}},
+	}
+	node2 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Message: "kubelet has disk pressure"},
+		}},
+	}
+	schedulingEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "pending.1", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pending", UID: "pending-uid"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedScheduling",
+		Message:        "0/3 nodes are available: 1 Insufficient cpu, 2 node(s) had untolerated taint.",
+		LastTimestamp:  finished,
+	}
+	staleEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "pending.2", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pending", UID: "old-uid"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedMount",
+		Message:        "MountVolume.SetUp failed",
+		LastTimestamp:  finished,
+	}
+
+	objects := []runtime.Object{healthyPod, crashingPod, pullingPod, pendingPod, node1, node2, schedulingEvent, staleEvent}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedErrMsg   string
+		expectedHealthy  bool
+		expectedReasons  []string
+		expectedContains []string
+		expectedEvents   int
+		expectedNodeConf int
+	}{
+		{
+			name:            "healthy pod has no findings",
+			requestArgs:     map[string]interface{}{"namespace": "default", "name": "healthy"},
+			expectedHealthy: true,
+			expectedReasons: []string{},
+		},
+		{
+			name:             "crash loop reports the exit and the OOM kill",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "crashing"},
+			expectedReasons:  []string{"CrashLoopBackOff", "OOMKilled"},
+			expectedContains: []string{"restarted 7 times", "exit code 137, reason OOMKilled", "memory limit is 128Mi"},
+		},
+		{
+			name:             "image pull failure and unhealthy node",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "pulling"},
+			expectedReasons:  []string{"ImagePullBackOff", "NodeDiskPressure", "NodeCordoned"},
+			expectedContains: []string{"example/missing:latest", "The image or tag does not exist"},
+			expectedNodeConf: 1,
+		},
+		{
+			name:             "unschedulable pod uses the scheduler event",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "pending"},
+			expectedReasons:  []string{"Unschedulable"},
+			expectedContains: []string{"untolerated taint", "enough allocatable resources"},
+			expectedEvents:   1,
+		},
+		{
+			name:           "missing pod",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to get pod",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{"namespace": "default"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientset(objects...)
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, toolHandler := handler.DiagnosePod()
+
+			result, err := toolHandler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			for _, s := range tc.expectedContains {
+				assert.Contains(t, textContent.Text, s)
+			}
+
+			var diagnosis PodDiagnosis
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &diagnosis))
+			assert.Equal(t, tc.requestArgs["name"], diagnosis.Name)
+			assert.Equal(t, tc.expectedHealthy, diagnosis.Healthy)
+			assert.Equal(t, tc.expectedReasons, findingReasons(diagnosis.Findings))
+			assert.Len(t, diagnosis.Events, tc.expectedEvents)
+			assert.Len(t, diagnosis.NodeConditions, tc.expectedNodeConf)
+		})
+	}
+}
+
+func TestContainerFindings(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          corev1.ContainerStatus
+		init            bool
+		expectedReasons []string
+	}{
+		{
+			name: "config error",
+			status: corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "CreateContainerConfigError",
+				Message: `secret "db" not found`,
+			}}},
+			expectedReasons: []string{"CreateContainerConfigError"},
+		},
+		{
+			name: "running but not ready after restarts",
+			status: corev1.ContainerStatus{
+				Name:                 "app",
+				RestartCount:         2,
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+			},
+			expectedReasons: []string{"NotReady", "Restarted"},
+		},
+		{
+			name: "failed init container",
+			status: corev1.ContainerStatus{Name: "migrate", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 127,
+				Reason:   "Error",
+			}}},
+			init:            true,
+			expectedReasons: []string{"InitContainerFailed"},
+		},
+		{
+			name: "completed container",
+			status: corev1.ContainerStatus{Name: "job", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 0,
+				Reason:   "Completed",
+			}}},
+			expectedReasons: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := containerFindings(tc.status, corev1.Container{Name: tc.status.Name}, tc.init)
+			assert.Equal(t, tc.expectedReasons, findingReasons(findings))
+		})
+	}
+}
+
+func TestExitCodeSuggestion(t *testing.T) {
+	assert.Contains(t, exitCodeSuggestion(127, "Error"), "not found")
+	assert.Contains(t, exitCodeSuggestion(126, "Error"), "not executable")
+	assert.Contains(t, exitCodeSuggestion(137, "Error"), "SIGKILL")
+	assert.Contains(t, exitCodeSuggestion(143, "Error"), "SIGTERM")
+	assert.Contains(t, exitCodeSuggestion(137, "OOMKilled"), "memory limit")
+	assert.Contains(t, exitCodeSuggestion(1, "Error"), "previous=true")
+}
+
+func TestDiagnoseDeployment(t *testing.T) {
+	controller := true
+	replicas := int32(3)
+
+	newDeployment := func(name string, nodeSelector map[string]string, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				UID:         types.UID(name + "-uid"),
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": "2"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+					Spec: corev1.PodSpec{
+						NodeSelector: nodeSelector,
+						Containers:   []corev1.Container{{Name: "app", Image: "example/app:2.0"}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          3,
+				UpdatedReplicas:   3,
+				ReadyReplicas:     available,
+				AvailableReplicas: available,
+			},
+		}
+	}
+	newReplicaSet := func(d *appsv1.Deployment, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        d.Name + "-" + revision,
+				Namespace:   "default",
+				UID:         types.UID(d.Name + "-rs-" + revision),
+				Labels:      map[string]string{"app": d.Name},
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": revision},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       d.Name,
+					UID:        d.UID,
+					Controller: &controller,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Replicas: &replicas},
+		}
+	}
+	newPod := func(name string, rs *appsv1.ReplicaSet, status corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name + "-uid"),
+				Labels:    rs.Labels,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       rs.Name,
+					UID:        rs.UID,
+					Controller: &controller,
+				}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []corev1.Container{{Name: "app", Image: "example/app:2.0"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+	running := corev1.ContainerStatus{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	crashing := corev1.ContainerStatus{
+		Name:         "app",
+		RestartCount: 4,
+		State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 1,
+			Reason:   "Error",
+		}},
+	}
+
+	healthyDeployment := newDeployment("web", nil, 3)
+	healthyRS := newReplicaSet(healthyDeployment, "2")
+
+	crashingDeployment := newDeployment("api", nil, 1)
+	crashingRS := newReplicaSet(crashingDeployment, "2")
+
+	rejectedDeployment := newDeployment("batch", map[string]string{"pool": "gpu"}, 0)
+	rejectedDeployment.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "batch-2" has timed out progressing.`,
+	}}
+	rejectedRS := newReplicaSet(rejectedDeployment, "2")
+	webhookEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "batch-2.1", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: rejectedRS.Name, UID: rejectedRS.UID},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        `Error creating: admission webhook "validate.policy.example.com" denied the request: image tag is not pinned`,
+	}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "default"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10"), corev1.ResourceRequestsCPU: resource.MustParse("4")},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10"), corev1.ResourceRequestsCPU: resource.MustParse("2")},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "general"}},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+	}
+
+	objects := []runtime.Object{
+		healthyDeployment, healthyRS,
+		newPod("web-a", healthyRS, running), newPod("web-b", healthyRS, running), newPod("web-c", healthyRS, running),
+		crashingDeployment, crashingRS,
+		newPod("api-a", crashingRS, running), newPod("api-b", crashingRS, crashing), newPod("api-c", crashingRS, crashing),
+		rejectedDeployment, rejectedRS, webhookEvent,
+		quota, node,
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedErrMsg   string
+		expectedHealthy  bool
+		expectedReasons  []string
+		expectedPods     []string
+		expectedContains []string
+	}{
+		{
+			name:            "available deployment",
+			requestArgs:     map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedHealthy: true,
+			expectedReasons: []string{"QuotaExhausted"},
+		},
+		{
+			name:             "crash looping pods are aggregated",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "api"},
+			expectedReasons:  []string{"CrashLoopBackOff", "ReplicasUnavailable", "QuotaExhausted"},
+			expectedPods:     []string{"api-b", "api-c"},
+			expectedContains: []string{"1 of 3 replicas available"},
+		},
+		{
+			name:             "webhook rejection and unsatisfiable nodeSelector",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "batch"},
+			expectedReasons:  []string{"ProgressDeadlineExceeded", "WebhookRejected", "NodeSelectorUnsatisfiable", "ReplicasUnavailable", "QuotaExhausted"},
+			expectedContains: []string{"image tag is not pinned", "pool=gpu", "pods used 10 of 10"},
+		},
+		{
+			name:           "missing deployment",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to get deployment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientset(objects...)
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, toolHandler := handler.DiagnoseDeployment()
+
+			result, err := toolHandler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			for _, s := range tc.expectedContains {
+				assert.Contains(t, textContent.Text, s)
+			}
+
+			var diagnosis DeploymentDiagnosis
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &diagnosis))
+			assert.Equal(t, tc.expectedHealthy, diagnosis.Healthy)
+			assert.Equal(t, tc.expectedReasons, findingReasons(diagnosis.Findings))
+			require.Len(t, diagnosis.ReplicaSets, 1)
+			assert.True(t, diagnosis.ReplicaSets[0].Current)
+			if tc.expectedPods != nil {
+				assert.Equal(t, tc.expectedPods, diagnosis.Findings[0].Pods)
+				assert.Len(t, diagnosis.Pods, len(tc.expectedPods))
+			}
+		})
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	ready := true
+	notReady := false
+
+	newPod := func(name string, isReady bool, ports ...corev1.ContainerPort) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if isReady {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Ports: ports}}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	newService := func(name string, selector map[string]string, ports ...corev1.ServicePort) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.10", Selector: selector, Ports: ports},
+		}
+	}
+	newSlice := func(service string, conditions ...*bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: service + "-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: service}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		}
+		for _, c := range conditions {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{Addresses: []string{"10.1.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: c}})
+		}
+		return slice
+	}
+
+	httpPort := corev1.ContainerPort{Name: "http", ContainerPort: 8080}
+	objects := []runtime.Object{
+		newPod("web-a", true, httpPort),
+		newPod("web-b", false, httpPort),
+		newService("web", map[string]string{"app": "web"}, corev1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}),
+		newSlice("web", &ready, &notReady),
+		newService("wrong-port", map[string]string{"app": "web"},
+			corev1.ServicePort{Name: "named", Port: 80, TargetPort: intstr.FromString("metrics")},
+			corev1.ServicePort{Port: 9000, TargetPort: intstr.FromInt32(9000)},
+		),
+		newSlice("wrong-port", &ready),
+		newService("orphan", map[string]string{"app": "missing"}, corev1.ServicePort{Port: 80}),
+		newService("manual", nil, corev1.ServicePort{Port: 5432}),
+		newSlice("manual", nil),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedErrMsg   string
+		expectedPassed   bool
+		expectedStatuses map[string]string
+	}{
+		{
+			name:           "named target port with one pod not ready",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedPassed: true,
+			expectedStatuses: map[string]string{
+				"selector":        CheckPass,
+				"podsReady":       CheckWarn,
+				"endpoints":       CheckWarn,
+				"targetPort http": CheckPass,
+			},
+		},
+		{
+			name:           "unresolvable named port and undeclared numeric port",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "wrong-port"},
+			expectedPassed: false,
+			expectedStatuses: map[string]string{
+				"endpoints":        CheckPass,
+				"targetPort named": CheckFail,
+				"targetPort 9000":  CheckWarn,
+			},
+		},
+		{
+			name:           "selector matches nothing",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "orphan"},
+			expectedPassed: false,
+			expectedStatuses: map[string]string{
+				"selector":  CheckFail,
+				"endpoints": CheckFail,
+			},
+		},
+		{
+			name:           "service without selector",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "manual"},
+			expectedPassed: true,
+			expectedStatuses: map[string]string{
+				"selector":  CheckSkip,
+				"endpoints": CheckPass,
+			},
+		},
+		{
+			name:             "external name service",
+			requestArgs:      map[string]interface{}{"namespace": "default", "name": "external"},
+			expectedPassed:   true,
+			expectedStatuses: map[string]string{"selector": CheckSkip},
+		},
+		{
+			name:           "missing service",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to get service",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientset(objects...)
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, toolHandler := handler.CheckService()
+
+			result, err := toolHandler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var check ServiceCheck
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &check))
+			assert.Equal(t, tc.expectedPassed, check.Passed)
+
+			statuses := map[string]string{}
+			for _, c := range check.Checks {
+				statuses[c.Name] = c.Status
+			}
+			for name, status := range tc.expectedStatuses {
+				assert.Equal(t, status, statuses[name], name)
+			}
+		})
+	}
+}
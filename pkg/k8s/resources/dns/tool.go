@@ -0,0 +1,404 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultCoreDNSNamespace = "kube-system"
+	defaultCoreDNSConfigMap = "coredns"
+	defaultCoreDNSSelector  = "k8s-app=kube-dns"
+	defaultTestImage        = "busybox:1.36"
+	defaultTestNamespace    = "default"
+	defaultTestTimeout      = 60
+)
+
+// Handler implements the K8sResourceHandler interface for cluster DNS inspection
+type Handler struct {
+	getClient    toolsets.GetClientFn
+	t            translations.TranslationHelperFunc
+	pollInterval time.Duration
+}
+
+// NewHandler creates a new DNS handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:    getClient,
+		t:            t,
+		pollInterval: 2 * time.Second,
+	}
+}
+
+// Forward is a forward (or legacy proxy) directive within a server block
+type Forward struct {
+	From string   `json:"from"`
+	To   []string `json:"to"`
+}
+
+// ServerBlock is a single server block of a Corefile
+type ServerBlock struct {
+	Zones   []string `json:"zones"`
+	Plugins []string `json:"plugins"`
+	Forward *Forward `json:"forward,omitempty"`
+}
+
+// StubDomain is a non-root zone forwarded to dedicated nameservers
+type StubDomain struct {
+	Zone        string   `json:"zone"`
+	Nameservers []string `json:"nameservers"`
+}
+
+// CoreDNSPod is the status of a single CoreDNS replica
+type CoreDNSPod struct {
+	Name     string `json:"name"`
+	Node     string `json:"node"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+}
+
+// CoreDNSSummary summarizes the CoreDNS configuration and replicas
+type CoreDNSSummary struct {
+	ConfigMap          string        `json:"configMap"`
+	ServerBlocks       []ServerBlock `json:"serverBlocks"`
+	StubDomains        []StubDomain  `json:"stubDomains"`
+	UpstreamForwarders []string      `json:"upstreamForwarders"`
+	Pods               []CoreDNSPod  `json:"pods"`
+	Corefile           string        `json:"corefile,omitempty"`
+}
+
+// ResolutionTestResult is the outcome of a DNS lookup run from a short-lived debug pod
+type ResolutionTestResult struct {
+	Hostname  string          `json:"hostname"`
+	Namespace string          `json:"namespace"`
+	Pod       string          `json:"pod"`
+	Phase     string          `json:"phase"`
+	Succeeded bool            `json:"succeeded"`
+	Output    string          `json:"output"`
+	CoreDNS   *CoreDNSSummary `json:"coreDNS,omitempty"`
+}
+
+// RegisterTools registers all DNS tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	inspectTool, inspectHandler := h.InspectCoreDNS()
+	toolset.AddReadTool(inspectTool, inspectHandler)
+
+	// Register write tools
+	testTool, testHandler := h.TestResolution()
+	toolset.AddWriteTool(testTool, testHandler)
+}
+
+// InspectCoreDNS creates a tool that summarizes the CoreDNS Corefile and replica health
+func (h *Handler) InspectCoreDNS() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("inspect_coredns",
+			mcp.WithDescription(h.t("TOOL_INSPECT_COREDNS_DESCRIPTION", "Fetch the CoreDNS Corefile and summarize its server blocks, stub domains, upstream forwarders and replica health")),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace CoreDNS runs in (default: kube-system)"),
+			),
+			mcp.WithString("configMap",
+				mcp.Description("Name of the ConfigMap holding the Corefile (default: coredns)"),
+			),
+			mcp.WithBoolean("includeCorefile",
+				mcp.Description("Include the raw Corefile in the response"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, configMap, err := coreDNSLocation(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			includeCorefile, err := toolsets.OptionalParam[bool](request, "includeCorefile")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			summary, err := inspectCoreDNS(ctx, client, namespace, configMap)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !includeCorefile {
+				summary.Corefile = ""
+			}
+
+			r, err := json.Marshal(summary)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// TestResolution creates a tool that resolves a hostname from a short-lived debug pod
+func (h *Handler) TestResolution() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("test_dns_resolution",
+			mcp.WithDescription(h.t("TOOL_TEST_DNS_RESOLUTION_DESCRIPTION", "Run nslookup for a hostname from a short-lived debug pod and return its output together with the CoreDNS configuration summary. The pod is deleted afterwards")),
+			mcp.WithString("hostname",
+				mcp.Required(),
+				mcp.Description("Hostname to resolve, e.g. kubernetes.default.svc.cluster.local"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace to run the debug pod in (default: default)"),
+			),
+			mcp.WithString("image",
+				mcp.Description("Image providing nslookup (default: busybox:1.36)"),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description("How long to wait for the lookup to complete (default: 60)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			hostname, err := toolsets.RequiredParam[string](request, "hostname")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if namespace == "" {
+				namespace = defaultTestNamespace
+			}
+			image, err := toolsets.OptionalParam[string](request, "image")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if image == "" {
+				image = defaultTestImage
+			}
+			timeoutSeconds, err := toolsets.OptionalParam[float64](request, "timeoutSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds <= 0 {
+				timeoutSeconds = defaultTestTimeout
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dns-test-" + utilrand.String(5),
+					Namespace: namespace,
+					Labels:    map[string]string{"app.kubernetes.io/managed-by": "k8s-mcp-server"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "nslookup",
+							Image:   image,
+							Command: []string{"nslookup", hostname},
+						},
+					},
+				},
+			}
+
+			pod, err = client.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create debug pod: %v", err)), nil
+			}
+			defer func() {
+				// Clean up even if the request context was cancelled
+				_ = client.CoreV1().Pods(namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+			}()
+
+			phase := pod.Status.Phase
+			err = wait.PollUntilContextTimeout(ctx, h.pollInterval, time.Duration(timeoutSeconds)*time.Second, true,
+				func(ctx context.Context) (bool, error) {
+					current, err := client.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+					if err != nil {
+						return false, err
+					}
+					phase = current.Status.Phase
+					return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+				})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("debug pod %s did not complete (phase %s): %v", pod.Name, phase, err)), nil
+			}
+
+			logs, err := client.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get debug pod logs: %v", err)), nil
+			}
+
+			result := ResolutionTestResult{
+				Hostname:  hostname,
+				Namespace: namespace,
+				Pod:       pod.Name,
+				Phase:     string(phase),
+				Succeeded: phase == corev1.PodSucceeded,
+				Output:    string(logs),
+			}
+
+			// The CoreDNS summary is best effort; the lookup result is still useful without it
+			if summary, err := inspectCoreDNS(ctx, client, defaultCoreDNSNamespace, defaultCoreDNSConfigMap); err == nil {
+				summary.Corefile = ""
+				result.CoreDNS = summary
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func coreDNSLocation(request mcp.CallToolRequest) (string, string, error) {
+	namespace, err := toolsets.OptionalParam[string](request, "namespace")
+	if err != nil {
+		return "", "", err
+	}
+	if namespace == "" {
+		namespace = defaultCoreDNSNamespace
+	}
+	configMap, err := toolsets.OptionalParam[string](request, "configMap")
+	if err != nil {
+		return "", "", err
+	}
+	if configMap == "" {
+		configMap = defaultCoreDNSConfigMap
+	}
+	return namespace, configMap, nil
+}
+
+func inspectCoreDNS(ctx context.Context, client kubernetes.Interface, namespace, configMap string) (*CoreDNSSummary, error) {
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, configMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CoreDNS config map: %v", err)
+	}
+	corefile, ok := cm.Data["Corefile"]
+	if !ok {
+		return nil, fmt.Errorf("config map %s/%s has no Corefile key", namespace, configMap)
+	}
+
+	summary := &CoreDNSSummary{
+		ConfigMap:          namespace + "/" + configMap,
+		ServerBlocks:       ParseCorefile(corefile),
+		StubDomains:        []StubDomain{},
+		UpstreamForwarders: []string{},
+		Pods:               []CoreDNSPod{},
+		Corefile:           corefile,
+	}
+	for _, block := range summary.ServerBlocks {
+		if block.Forward == nil {
+			continue
+		}
+		for _, zone := range block.Zones {
+			if zone == "." {
+				summary.UpstreamForwarders = append(summary.UpstreamForwarders, block.Forward.To...)
+				continue
+			}
+			summary.StubDomains = append(summary.StubDomains, StubDomain{Zone: zone, Nameservers: block.Forward.To})
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: defaultCoreDNSSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CoreDNS pods: %v", err)
+	}
+	for _, pod := range pods.Items {
+		p := CoreDNSPod{Name: pod.Name, Node: pod.Spec.NodeName, Phase: string(pod.Status.Phase)}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				p.Ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			p.Restarts += cs.RestartCount
+		}
+		summary.Pods = append(summary.Pods, p)
+	}
+
+	return summary, nil
+}
+
+// ParseCorefile extracts the server blocks, their plugins and forward targets from a Corefile.
+// It understands the subset of the Caddyfile syntax used by CoreDNS configurations in practice.
+func ParseCorefile(corefile string) []ServerBlock {
+	blocks := []ServerBlock{}
+	var current *ServerBlock
+	depth := 0
+
+	for _, line := range strings.Split(corefile, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		opens := strings.Count(line, "{")
+		closes := strings.Count(line, "}")
+
+		switch {
+		case depth == 0 && opens > 0:
+			block := ServerBlock{Plugins: []string{}}
+			for _, f := range fields {
+				if f == "{" {
+					break
+				}
+				block.Zones = append(block.Zones, normalizeZone(strings.TrimSuffix(f, "{")))
+			}
+			blocks = append(blocks, block)
+			current = &blocks[len(blocks)-1]
+		case depth == 1 && current != nil && fields[0] != "}":
+			plugin := fields[0]
+			current.Plugins = append(current.Plugins, plugin)
+			if (plugin == "forward" || plugin == "proxy") && len(fields) > 2 {
+				fwd := &Forward{From: normalizeZone(fields[1])}
+				for _, to := range fields[2:] {
+					if to == "{" {
+						break
+					}
+					fwd.To = append(fwd.To, to)
+				}
+				current.Forward = fwd
+			}
+		}
+
+		depth += opens - closes
+		if depth <= 0 {
+			depth = 0
+			current = nil
+		}
+	}
+
+	return blocks
+}
+
+// normalizeZone strips the scheme and port from a Corefile zone key
+func normalizeZone(zone string) string {
+	zone = strings.TrimPrefix(zone, "dns://")
+	if i := strings.LastIndex(zone, ":"); i > 0 {
+		zone = zone[:i]
+	}
+	return zone
+}
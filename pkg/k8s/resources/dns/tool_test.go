@@ -0,0 +1,199 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+const testCorefile = `.:53 {
+    errors
+    health {
+       lameduck 5s
+    }
+    ready
+    kubernetes cluster.local in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+    }
+    forward . /etc/resolv.conf {
+       max_concurrent 1000
+    }
+    cache 30
+}
+corp.example.com:53 {
+    errors
+    cache 30
+    forward . 10.0.0.10 10.0.0.11
+}
+`
+
+func coreDNSObjects() []runtime.Object {
+	return []runtime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+			Data:       map[string]string{"Corefile": testCorefile},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns-abc", Namespace: "kube-system", Labels: map[string]string{"k8s-app": "kube-dns"}},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "coredns", RestartCount: 2}},
+			},
+		},
+	}
+}
+
+func TestParseCorefile(t *testing.T) {
+	blocks := ParseCorefile(testCorefile)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, []string{"."}, blocks[0].Zones)
+	assert.Equal(t, []string{"errors", "health", "ready", "kubernetes", "forward", "cache"}, blocks[0].Plugins)
+	require.NotNil(t, blocks[0].Forward)
+	assert.Equal(t, []string{"/etc/resolv.conf"}, blocks[0].Forward.To)
+
+	assert.Equal(t, []string{"corp.example.com"}, blocks[1].Zones)
+	require.NotNil(t, blocks[1].Forward)
+	assert.Equal(t, []string{"10.0.0.10", "10.0.0.11"}, blocks[1].Forward.To)
+}
+
+func TestInspectCoreDNS(t *testing.T) {
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(coreDNSObjects()...)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.InspectCoreDNS()
+
+	assert.Equal(t, "inspect_coredns", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectCorefile bool
+		expectedErrMsg string
+	}{
+		{
+			name:        "default location",
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name:           "include corefile",
+			requestArgs:    map[string]interface{}{"includeCorefile": true},
+			expectCorefile: true,
+		},
+		{
+			name:           "config map not found",
+			requestArgs:    map[string]interface{}{"configMap": "missing"},
+			expectedErrMsg: "failed to get CoreDNS config map",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var summary CoreDNSSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+
+			assert.Equal(t, "kube-system/coredns", summary.ConfigMap)
+			assert.Equal(t, []string{"/etc/resolv.conf"}, summary.UpstreamForwarders)
+			require.Len(t, summary.StubDomains, 1)
+			assert.Equal(t, "corp.example.com", summary.StubDomains[0].Zone)
+			require.Len(t, summary.Pods, 1)
+			assert.True(t, summary.Pods[0].Ready)
+			assert.Equal(t, int32(2), summary.Pods[0].Restarts)
+			assert.Equal(t, tc.expectCorefile, summary.Corefile != "")
+		})
+	}
+}
+
+func TestTestResolution(t *testing.T) {
+	client := fake.NewSimpleClientset(coreDNSObjects()...)
+	// Pretend the debug pod completes as soon as it is created
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Status.Phase = corev1.PodSucceeded
+		return false, nil, nil
+	})
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	handler.pollInterval = 10 * time.Millisecond
+	tool, handlerFn := handler.TestResolution()
+
+	assert.Equal(t, "test_dns_resolution", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"hostname"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{
+		"hostname": "kubernetes.default.svc.cluster.local",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var returned ResolutionTestResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.True(t, returned.Succeeded)
+	assert.Equal(t, "default", returned.Namespace)
+	assert.NotEmpty(t, returned.Output)
+	require.NotNil(t, returned.CoreDNS)
+	assert.Len(t, returned.CoreDNS.StubDomains, 1)
+
+	// The debug pod is cleaned up afterwards
+	pods, err := client.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+
+	t.Run("missing hostname", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "missing required parameter: hostname")
+	})
+}
@@ -0,0 +1,228 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// GatewayGVR is the Gateway API Gateway resource
+	GatewayGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+	// HTTPRouteGVR is the Gateway API HTTPRoute resource
+	HTTPRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+)
+
+// Handler implements the K8sResourceHandler interface for IngressClass and Gateway API resources
+type Handler struct {
+	getClient        toolsets.GetClientFn
+	getDynamicClient toolsets.GetDynamicClientFn
+	t                translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new gateway resource handler
+func NewHandler(getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:        getClient,
+		getDynamicClient: getDynamicClient,
+		t:                t,
+	}
+}
+
+// RegisterTools registers all gateway resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	getIngressClassTool, getIngressClassHandler := h.GetIngressClass()
+	toolset.AddReadTool(getIngressClassTool, getIngressClassHandler)
+
+	listIngressClassesTool, listIngressClassesHandler := h.ListIngressClasses()
+	toolset.AddReadTool(listIngressClassesTool, listIngressClassesHandler)
+
+	getGatewayTool, getGatewayHandler := h.GetGateway()
+	toolset.AddReadTool(getGatewayTool, getGatewayHandler)
+
+	listGatewaysTool, listGatewaysHandler := h.ListGateways()
+	toolset.AddReadTool(listGatewaysTool, listGatewaysHandler)
+
+	getHTTPRouteTool, getHTTPRouteHandler := h.GetHTTPRoute()
+	toolset.AddReadTool(getHTTPRouteTool, getHTTPRouteHandler)
+
+	listHTTPRoutesTool, listHTTPRoutesHandler := h.ListHTTPRoutes()
+	toolset.AddReadTool(listHTTPRoutesTool, listHTTPRoutesHandler)
+}
+
+// GetIngressClass creates a tool to get details of a specific ingress class
+func (h *Handler) GetIngressClass() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ingressclass",
+			mcp.WithDescription(h.t("TOOL_GET_INGRESSCLASS_DESCRIPTION", "Get details of a specific ingress class")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("IngressClass name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			ingressClass, err := client.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get ingress class: %v", err)), nil
+			}
+
+			r, err := json.Marshal(ingressClass)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListIngressClasses creates a tool to list all ingress classes
+func (h *Handler) ListIngressClasses() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_ingressclasses",
+			mcp.WithDescription(h.t("TOOL_LIST_INGRESSCLASSES_DESCRIPTION", "List all ingress classes in the cluster")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{LabelSelector: labelSelector}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			ingressClasses, err := client.NetworkingV1().IngressClasses().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list ingress classes: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(ingressClasses.Items, ingressClasses.ListMeta)
+		}
+}
+
+// GetGateway creates a tool to get details of a specific Gateway
+func (h *Handler) GetGateway() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.getTool("get_gateway", "TOOL_GET_GATEWAY_DESCRIPTION", "Get details of a specific Gateway API Gateway", "Gateway name", GatewayGVR)
+}
+
+// ListGateways creates a tool to list Gateways
+func (h *Handler) ListGateways() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.listTool("list_gateways", "TOOL_LIST_GATEWAYS_DESCRIPTION", "List Gateway API Gateways in a namespace, or in all namespaces", GatewayGVR)
+}
+
+// GetHTTPRoute creates a tool to get details of a specific HTTPRoute
+func (h *Handler) GetHTTPRoute() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.getTool("get_httproute", "TOOL_GET_HTTPROUTE_DESCRIPTION", "Get details of a specific Gateway API HTTPRoute", "HTTPRoute name", HTTPRouteGVR)
+}
+
+// ListHTTPRoutes creates a tool to list HTTPRoutes
+func (h *Handler) ListHTTPRoutes() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.listTool("list_httproutes", "TOOL_LIST_HTTPROUTES_DESCRIPTION", "List Gateway API HTTPRoutes in a namespace, or in all namespaces", HTTPRouteGVR)
+}
+
+// getTool builds a get tool for a namespaced Gateway API resource
+func (h *Handler) getTool(name, descriptionKey, description, nameDescription string, gvr schema.GroupVersionResource) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(h.t(descriptionKey, description)),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description(nameDescription),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			obj, err := client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get %s: %v", gvr.Resource, err)), nil
+			}
+
+			r, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listTool builds a list tool for a namespaced Gateway API resource
+func (h *Handler) listTool(name, descriptionKey, description string, gvr schema.GroupVersionResource) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(h.t(descriptionKey, description)),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (all namespaces if omitted)"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			list, err := client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("%s resources not found; are the Gateway API CRDs installed?", gvr.Resource)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list %s: %v", gvr.Resource, err)), nil
+			}
+
+			return toolsets.NewToolResultJSON(list)
+		}
+}
@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a fake dynamic client
+func stubGetDynamicClientFn(client dynamic.Interface) toolsets.GetDynamicClientFn {
+	return func(ctx context.Context) (dynamic.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			GatewayGVR:   "GatewayList",
+			HTTPRouteGVR: "HTTPRouteList",
+		}, objects...)
+}
+
+func gatewayObject(kind, namespace, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec":       map[string]interface{}{},
+	}}
+}
+
+func TestGetIngressClass(t *testing.T) {
+	ingressClass := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(ingressClass)), stubGetDynamicClientFn(newFakeDynamicClient()), translations.NullTranslationHelper)
+	tool, handlerFn := handler.GetIngressClass()
+
+	assert.Equal(t, "get_ingressclass", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "nginx"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned networkingv1.IngressClass
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "k8s.io/ingress-nginx", returned.Spec.Controller)
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "traefik"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "failed to get ingress class")
+}
+
+func TestListIngressClasses(t *testing.T) {
+	nginx := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}}
+	traefik := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "traefik"}}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(nginx, traefik)), stubGetDynamicClientFn(newFakeDynamicClient()), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListIngressClasses()
+
+	assert.Equal(t, "list_ingressclasses", tool.Name)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned networkingv1.IngressClassList
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Len(t, returned.Items, 2)
+}
+
+func TestGetGateway(t *testing.T) {
+	// The fake tracker would guess "gatewaies" from the kind, so create through the client
+	client := newFakeDynamicClient()
+	_, err := client.Resource(GatewayGVR).Namespace("infra").Create(context.Background(), gatewayObject("Gateway", "infra", "public"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), stubGetDynamicClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.GetGateway()
+
+	assert.Equal(t, "get_gateway", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful gateway fetch",
+			requestArgs: map[string]interface{}{"namespace": "infra", "name": "public"},
+		},
+		{
+			name:           "gateway not found",
+			requestArgs:    map[string]interface{}{"namespace": "infra", "name": "internal"},
+			expectedErrMsg: "failed to get gateways",
+		},
+		{
+			name:           "missing required param: namespace",
+			requestArgs:    map[string]interface{}{"name": "public"},
+			expectedErrMsg: "missing required parameter: namespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned unstructured.Unstructured
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned.Object))
+			assert.Equal(t, "Gateway", returned.GetKind())
+			assert.Equal(t, "public", returned.GetName())
+		})
+	}
+}
+
+func TestListHTTPRoutes(t *testing.T) {
+	client := newFakeDynamicClient(
+		gatewayObject("HTTPRoute", "shop", "checkout"),
+		gatewayObject("HTTPRoute", "blog", "posts"),
+	)
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), stubGetDynamicClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListHTTPRoutes()
+
+	assert.Equal(t, "list_httproutes", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]interface{}
+		expectedCount int
+	}{
+		{
+			name:          "all namespaces",
+			requestArgs:   map[string]interface{}{},
+			expectedCount: 2,
+		},
+		{
+			name:          "single namespace",
+			requestArgs:   map[string]interface{}{"namespace": "shop"},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned struct {
+				Items []map[string]interface{} `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Len(t, returned.Items, tc.expectedCount)
+		})
+	}
+
+	t.Run("gateway api not installed", func(t *testing.T) {
+		client := newFakeDynamicClient()
+		client.PrependReactor("list", "httproutes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(HTTPRouteGVR.GroupResource(), "")
+		})
+		_, handlerFn := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), stubGetDynamicClientFn(client), translations.NullTranslationHelper).ListHTTPRoutes()
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Gateway API CRDs installed")
+	})
+}
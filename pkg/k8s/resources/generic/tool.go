@@ -0,0 +1,1204 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/manifest"
+	"github.com/briankscheong/k8s-mcp-server/pkg/log"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// patchTypes maps the patchType parameter to the corresponding API patch type
+var patchTypes = map[string]types.PatchType{
+	"strategic": types.StrategicMergePatchType,
+	"merge":     types.MergePatchType,
+	"json":      types.JSONPatchType,
+}
+
+// propagationPolicies maps the propagationPolicy parameter to the corresponding API value
+var propagationPolicies = map[string]metav1.DeletionPropagation{
+	"Foreground": metav1.DeletePropagationForeground,
+	"Background": metav1.DeletePropagationBackground,
+	"Orphan":     metav1.DeletePropagationOrphan,
+}
+
+// Limits for watch_resource
+const (
+	defaultWatchSeconds = 60
+	maxWatchSeconds     = 600
+	defaultWatchEvents  = 100
+	maxWatchEvents      = 1000
+)
+
+// Limits for wait_for_condition
+const (
+	defaultWaitSeconds = 60
+	maxWaitSeconds     = 600
+)
+
+// Conditions wait_for_condition accepts besides the condition types and phases of objects
+const (
+	ConditionDeleted = "Deleted"
+	ConditionFailed  = "Failed"
+)
+
+// Reasons a watch stopped
+const (
+	StoppedByEnd       = "ended"
+	StoppedByDuration  = "duration"
+	StoppedByMaxEvents = "maxEvents"
+)
+
+// Handler implements the K8sResourceHandler interface for tools that work on any resource kind
+type Handler struct {
+	getClient        toolsets.GetClientFn
+	getDynamicClient toolsets.GetDynamicClientFn
+	t                translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new generic resource handler
+func NewHandler(getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:        getClient,
+		getDynamicClient: getDynamicClient,
+		t:                t,
+	}
+}
+
+// ApplyResult is the outcome of applying a single object from a manifest
+type ApplyResult struct {
+	Object          string `json:"object"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// Diff actions
+const (
+	DiffCreate    = "create"
+	DiffUpdate    = "update"
+	DiffUnchanged = "unchanged"
+)
+
+// DiffResult is what applying a single object from a manifest would change
+type DiffResult struct {
+	Object  string            `json:"object"`
+	Action  string            `json:"action,omitempty"`
+	Changes []manifest.Change `json:"changes,omitempty"`
+	Warning string            `json:"warning,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// WatchEvent is a change of an object seen while watching a resource
+type WatchEvent struct {
+	Type            string `json:"type,omitempty"`
+	Kind            string `json:"kind,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Phase and Conditions are the status.phase and status.conditions of the object, if it has them
+	Phase      string            `json:"phase,omitempty"`
+	Conditions map[string]string `json:"conditions,omitempty"`
+}
+
+// WatchResult is the outcome of watching a resource
+type WatchResult struct {
+	Resource string         `json:"resource"`
+	Events   []WatchEvent   `json:"events"`
+	Counts   map[string]int `json:"counts"`
+	// Notifications is the number of events pushed to the client while watching
+	Notifications int    `json:"notifications"`
+	StoppedBy     string `json:"stoppedBy"`
+}
+
+// WaitResult is the outcome of waiting for an object to meet a condition
+type WaitResult struct {
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Condition string `json:"condition"`
+	Met       bool   `json:"met"`
+	// Error says why the condition cannot be met any more, such as a failed job
+	Error         string  `json:"error,omitempty"`
+	WaitedSeconds float64 `json:"waitedSeconds"`
+	// Events is the number of watch events of the object seen while waiting
+	Events int `json:"events"`
+	// Status is the last seen state of the object, omitted while it does not exist
+	Status *WatchEvent `json:"status,omitempty"`
+}
+
+// RegisterTools registers all generic resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	diffTool, diffHandler := h.DiffManifest()
+	toolset.AddReadTool(diffTool, diffHandler)
+
+	watchTool, watchHandler := h.WatchResource()
+	toolset.AddReadTool(watchTool, watchHandler)
+
+	waitTool, waitHandler := h.WaitForCondition()
+	toolset.AddReadTool(waitTool, waitHandler)
+
+	// Register write tools
+	applyTool, applyHandler := h.ApplyManifest()
+	toolset.AddWriteTool(applyTool, applyHandler)
+
+	patchTool, patchHandler := h.PatchResource()
+	toolset.AddWriteTool(patchTool, patchHandler)
+
+	deleteTool, deleteHandler := h.DeleteResource()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
+
+	labelTool, labelHandler := h.LabelResource()
+	toolset.AddWriteTool(labelTool, labelHandler)
+
+	annotateTool, annotateHandler := h.AnnotateResource()
+	toolset.AddWriteTool(annotateTool, annotateHandler)
+}
+
+// ApplyManifest creates a tool to server-side apply arbitrary manifests
+func (h *Handler) ApplyManifest() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_manifest",
+			mcp.WithDescription(h.t("TOOL_APPLY_MANIFEST_DESCRIPTION", "Apply YAML or JSON manifests of any resource kind using server-side apply. Multiple YAML documents and List kinds are supported")),
+			mcp.WithString("manifest",
+				mcp.Required(),
+				mcp.Description("YAML or JSON manifests; multiple YAML documents are separated by ---"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace for namespaced objects that do not set one (default: default)"),
+			),
+			mcp.WithString("fieldManager",
+				mcp.Description("Field manager recorded for the applied fields (default: k8s-mcp-server)"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Take ownership of fields managed by other field managers"),
+			),
+			mcp.WithBoolean("dryRun",
+				mcp.Description("Validate the apply on the server without persisting it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, err := toolsets.RequiredParam[string](request, "manifest")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldManager, err := toolsets.OptionalParam[string](request, "fieldManager")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := toolsets.OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, err := toolsets.OptionalParam[bool](request, "dryRun")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			objects, err := manifest.Decode([]byte(data))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(objects) == 0 {
+				return mcp.NewToolResultError("manifest contains no objects"), nil
+			}
+
+			client, err := h.manifestClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			opts := manifest.ApplyOptions{
+				FieldManager:     fieldManager,
+				Force:            force,
+				DryRun:           dryRun,
+				DefaultNamespace: namespace,
+			}
+			results := make([]ApplyResult, 0, len(objects))
+			failed := 0
+			for _, obj := range objects {
+				applied, err := client.Apply(ctx, obj, opts)
+				result := ApplyResult{Object: manifest.Ref(obj)}
+				if err != nil {
+					result.Error = err.Error()
+					failed++
+				} else {
+					result.ResourceVersion = applied.GetResourceVersion()
+				}
+				results = append(results, result)
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			if failed == len(results) {
+				return mcp.NewToolResultError(string(r)), nil
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DiffManifest creates a tool comparing manifests against the live objects they describe
+func (h *Handler) DiffManifest() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("diff_manifest",
+			mcp.WithDescription(h.t("TOOL_DIFF_MANIFEST_DESCRIPTION", "Show what applying YAML or JSON manifests would change: each object is compared against its live state, ignoring status and server-managed metadata")),
+			mcp.WithString("manifest",
+				mcp.Required(),
+				mcp.Description("YAML or JSON manifests; multiple YAML documents are separated by ---"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace for namespaced objects that do not set one (default: default)"),
+			),
+			mcp.WithString("fieldManager",
+				mcp.Description("Field manager the apply would use (default: k8s-mcp-server)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, err := toolsets.RequiredParam[string](request, "manifest")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldManager, err := toolsets.OptionalParam[string](request, "fieldManager")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			objects, err := manifest.Decode([]byte(data))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(objects) == 0 {
+				return mcp.NewToolResultError("manifest contains no objects"), nil
+			}
+
+			client, err := h.manifestClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			opts := manifest.ApplyOptions{
+				FieldManager:     fieldManager,
+				DryRun:           true,
+				DefaultNamespace: namespace,
+			}
+			results := make([]DiffResult, 0, len(objects))
+			failed := 0
+			for _, obj := range objects {
+				result := diffObject(ctx, client, obj, opts)
+				if result.Error != "" {
+					failed++
+				}
+				results = append(results, result)
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			if failed == len(results) {
+				return mcp.NewToolResultError(string(r)), nil
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// diffObject compares an object against its live state. The desired state is the result of a
+// server-side dry-run apply, so defaults and fields owned by other managers are accounted for;
+// when the dry run fails, the fields of the manifest are set on the live object instead.
+func diffObject(ctx context.Context, client *manifest.Client, obj *unstructured.Unstructured, opts manifest.ApplyOptions) DiffResult {
+	live, err := client.Get(ctx, obj, opts.DefaultNamespace)
+	result := DiffResult{Object: manifest.Ref(obj)}
+	if apierrors.IsNotFound(err) {
+		result.Action = DiffCreate
+		return result
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	desired, err := client.Apply(ctx, obj, opts)
+	if err != nil {
+		result.Warning = fmt.Sprintf("dry-run apply failed, so changes are computed from the manifest alone: %v", err)
+		desired = manifest.Merge(live, obj)
+	}
+	result.Changes = manifest.Changes(live, desired)
+	result.Action = DiffUnchanged
+	if len(result.Changes) > 0 {
+		result.Action = DiffUpdate
+	}
+	return result
+}
+
+// PatchResource creates a tool to patch any resource with a strategic merge, JSON merge or JSON patch
+func (h *Handler) PatchResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("patch_resource",
+			mcp.WithDescription(h.t("TOOL_PATCH_RESOURCE_DESCRIPTION", "Patch a single resource of any kind, e.g. to change one environment variable or annotation")),
+			mcp.WithString("group",
+				mcp.Description("API group of the resource (empty for the core group)"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("API version of the resource, e.g. v1"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, e.g. deployments"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace of the resource (omit for cluster-scoped resources)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the resource"),
+			),
+			mcp.WithString("patchType",
+				mcp.Description("Patch type: strategic (built-in kinds only), merge or json (default: merge)"),
+				mcp.Enum("strategic", "merge", "json"),
+			),
+			mcp.WithString("patch",
+				mcp.Required(),
+				mcp.Description("Patch body as JSON; a JSON array of operations for json patches"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			version, err := toolsets.RequiredParam[string](request, "version")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patchTypeName, err := toolsets.OptionalParam[string](request, "patchType")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patch, err := toolsets.RequiredParam[string](request, "patch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if patchTypeName == "" {
+				patchTypeName = "merge"
+			}
+			patchType, ok := patchTypes[patchTypeName]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported patchType %q: must be strategic, merge or json", patchTypeName)), nil
+			}
+			if !json.Valid([]byte(patch)) {
+				return mcp.NewToolResultError("patch must be valid JSON"), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+			patched, err := client.Resource(gvr).Namespace(namespace).Patch(ctx, name, patchType, []byte(patch), metav1.PatchOptions{FieldManager: manifest.DefaultFieldManager})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to patch %s %s: %v", resource, name, err)), nil
+			}
+
+			r, err := json.Marshal(patched)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteResource creates a tool to delete a single resource of any kind
+func (h *Handler) DeleteResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_resource",
+			mcp.WithDescription(h.t("TOOL_DELETE_RESOURCE_DESCRIPTION", "Delete a single resource of any kind, optionally controlling how dependents are garbage collected")),
+			mcp.WithString("group",
+				mcp.Description("API group of the resource (empty for the core group)"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("API version of the resource, e.g. v1"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, e.g. deployments"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace of the resource (omit for cluster-scoped resources)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the resource"),
+			),
+			mcp.WithString("propagationPolicy",
+				mcp.Description("How dependents are deleted: Foreground, Background or Orphan (default: the resource's own default)"),
+				mcp.Enum("Foreground", "Background", "Orphan"),
+			),
+			mcp.WithNumber("gracePeriodSeconds",
+				mcp.Description("Seconds to wait before the object is deleted; 0 deletes immediately"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			version, err := toolsets.RequiredParam[string](request, "version")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			propagation, err := toolsets.OptionalParam[string](request, "propagationPolicy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			options := metav1.DeleteOptions{}
+			if propagation != "" {
+				policy, ok := propagationPolicies[propagation]
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("unsupported propagationPolicy %q: must be Foreground, Background or Orphan", propagation)), nil
+				}
+				options.PropagationPolicy = &policy
+			}
+			// Zero is a meaningful grace period, so only presence decides whether it is set
+			if _, ok := request.GetArguments()["gracePeriodSeconds"]; ok {
+				gracePeriod, err := toolsets.OptionalParam[float64](request, "gracePeriodSeconds")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if gracePeriod < 0 {
+					return mcp.NewToolResultError("gracePeriodSeconds must not be negative"), nil
+				}
+				seconds := int64(gracePeriod)
+				options.GracePeriodSeconds = &seconds
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+			if err := client.Resource(gvr).Namespace(namespace).Delete(ctx, name, options); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete %s %s: %v", resource, name, err)), nil
+			}
+
+			if namespace == "" {
+				return mcp.NewToolResultText(fmt.Sprintf("%s %s deleted", gvr.GroupResource(), name)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("%s %s in namespace %s deleted", gvr.GroupResource(), name, namespace)), nil
+		}
+}
+
+// WatchResource creates a tool to watch the objects of a resource for a bounded time
+func (h *Handler) WatchResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("watch_resource",
+			mcp.WithDescription(h.t("TOOL_WATCH_RESOURCE_DESCRIPTION", "Watch the objects of any resource kind, like kubectl get --watch, until a duration or event limit is reached. ADDED, MODIFIED and DELETED events are pushed to the client as log notifications (and progress notifications when a progress token is given) while the call runs; a summary of the events is returned at the end")),
+			mcp.WithString("group",
+				mcp.Description("API group of the resource (empty for the core group)"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("API version of the resource, e.g. v1"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, e.g. deployments"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace to watch (omit for cluster-scoped resources or to watch all namespaces)"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Only watch the object with this name"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Only watch objects matching this label selector, e.g. app=web"),
+			),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Only watch objects matching this field selector, e.g. status.phase=Pending"),
+			),
+			mcp.WithString("resourceVersion",
+				mcp.Description("Only report changes after this resource version, e.g. of a previous list; existing objects are first reported as ADDED if not set"),
+			),
+			mcp.WithNumber("durationSeconds",
+				mcp.Description(fmt.Sprintf("Seconds to watch for (default %d, max %d)", defaultWatchSeconds, maxWatchSeconds)),
+			),
+			mcp.WithNumber("maxEvents",
+				mcp.Description(fmt.Sprintf("Stop after this many events (default %d, max %d)", defaultWatchEvents, maxWatchEvents)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			version, err := toolsets.RequiredParam[string](request, "version")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resourceVersion, err := toolsets.OptionalParam[string](request, "resourceVersion")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			durationSeconds, err := toolsets.OptionalParam[float64](request, "durationSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if durationSeconds == 0 {
+				durationSeconds = defaultWatchSeconds
+			}
+			if durationSeconds < 0 || durationSeconds > maxWatchSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("durationSeconds must be between 1 and %d", maxWatchSeconds)), nil
+			}
+			maxEvents, err := toolsets.OptionalParam[float64](request, "maxEvents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxEvents == 0 {
+				maxEvents = defaultWatchEvents
+			}
+			if maxEvents < 0 || maxEvents > maxWatchEvents {
+				return mcp.NewToolResultError(fmt.Sprintf("maxEvents must be between 1 and %d", maxWatchEvents)), nil
+			}
+
+			if name != "" {
+				nameSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+				if fieldSelector == "" {
+					fieldSelector = nameSelector
+				} else {
+					fieldSelector += "," + nameSelector
+				}
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			watchCtx, cancel := context.WithTimeout(ctx, time.Duration(durationSeconds*float64(time.Second)))
+			defer cancel()
+			// The API server ends the watch as well, rounded up to whole seconds
+			timeoutSeconds := int64(math.Ceil(durationSeconds))
+			gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+			watcher, err := client.Resource(gvr).Namespace(namespace).Watch(watchCtx, metav1.ListOptions{
+				LabelSelector:   labelSelector,
+				FieldSelector:   fieldSelector,
+				ResourceVersion: resourceVersion,
+				TimeoutSeconds:  &timeoutSeconds,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to watch %s: %v", resource, err)), nil
+			}
+			defer watcher.Stop()
+
+			result := WatchResult{Resource: gvr.GroupResource().String(), Events: []WatchEvent{}, Counts: map[string]int{}}
+			notifier := newEventNotifier(ctx, request, "watch_resource", int(maxEvents))
+			result.StoppedBy, err = collectEvents(watchCtx, watcher, int(maxEvents), func(event WatchEvent) {
+				result.Events = append(result.Events, event)
+				result.Counts[event.Type]++
+				notifier.notify(event, len(result.Events))
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to watch %s: %v", resource, err)), nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Notifications = notifier.sent
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// collectEvents hands the object events of a watch to add until the watch ends, the context is
+// done or maxEvents were added. Bookmarks are skipped and error events end the watch with their
+// error. It returns why the watch stopped.
+func collectEvents(ctx context.Context, watcher watch.Interface, maxEvents int, add func(event WatchEvent)) (string, error) {
+	added := 0
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				if ctx.Err() != nil {
+					return StoppedByDuration, nil
+				}
+				return StoppedByEnd, nil
+			}
+			switch event.Type {
+			case watch.Bookmark:
+				continue
+			case watch.Error:
+				return "", apierrors.FromObject(event.Object)
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			add(newWatchEvent(event.Type, obj))
+			added++
+			if added >= maxEvents {
+				return StoppedByMaxEvents, nil
+			}
+		case <-ctx.Done():
+			return StoppedByDuration, nil
+		}
+	}
+}
+
+// WaitForCondition creates a tool that blocks until an object meets a condition
+func (h *Handler) WaitForCondition() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_condition",
+			mcp.WithDescription(h.t("TOOL_WAIT_FOR_CONDITION_DESCRIPTION", "Wait until an object of any resource kind meets a condition, like kubectl wait: a deployment Available, a pod Ready, a job Complete or a PVC Bound. Returns as soon as the condition is met, fails early when it no longer can be, such as for a Failed job, and pushes every change of the object to the client as notifications while waiting")),
+			mcp.WithString("group",
+				mcp.Description("API group of the resource (empty for the core group)"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("API version of the resource, e.g. v1"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, e.g. deployments"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace of the resource (omit for cluster-scoped resources)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the resource; it may not exist yet"),
+			),
+			mcp.WithString("condition",
+				mcp.Required(),
+				mcp.Description("Condition type that must be True, such as Available, Ready or Complete, a status phase such as Bound or Succeeded, or Deleted to wait for the object to be gone"),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait before giving up (default %d, max %d)", defaultWaitSeconds, maxWaitSeconds)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			version, err := toolsets.RequiredParam[string](request, "version")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			condition, err := toolsets.RequiredParam[string](request, "condition")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeoutSeconds, err := toolsets.OptionalParam[float64](request, "timeoutSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds == 0 {
+				timeoutSeconds = defaultWaitSeconds
+			}
+			if timeoutSeconds < 0 || timeoutSeconds > maxWaitSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("timeoutSeconds must be between 1 and %d", maxWaitSeconds)), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+			resourceClient := client.Resource(gvr).Namespace(namespace)
+			result := WaitResult{Resource: gvr.GroupResource().String(), Namespace: namespace, Name: name, Condition: condition}
+			notifier := newEventNotifier(ctx, request, "wait_for_condition", 0)
+			onEvent := func(event WatchEvent) {
+				result.Events++
+				result.Status = nil
+				if event.Type != string(watch.Deleted) {
+					result.Status = &event
+				}
+				notifier.notify(event, result.Events)
+			}
+
+			start := time.Now()
+			waitCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds*float64(time.Second)))
+			defer cancel()
+			// Each round reads the object and watches it from there, until the watch ends or expires
+			for !result.Met && waitCtx.Err() == nil {
+				obj, err := resourceClient.Get(waitCtx, name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					obj, err = nil, nil
+				}
+				if err != nil {
+					if waitCtx.Err() != nil {
+						break
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get %s %s: %v", resource, name, err)), nil
+				}
+				resourceVersion := ""
+				result.Status = nil
+				if obj != nil {
+					resourceVersion = obj.GetResourceVersion()
+					event := newWatchEvent("", obj)
+					result.Status = &event
+				}
+				if result.Met, err = evaluateCondition(obj, condition); err != nil || result.Met {
+					if err != nil {
+						result.Error = err.Error()
+					}
+					break
+				}
+
+				watcher, err := resourceClient.Watch(waitCtx, metav1.ListOptions{
+					FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+					ResourceVersion: resourceVersion,
+				})
+				if err != nil {
+					if waitCtx.Err() != nil {
+						break
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to watch %s %s: %v", resource, name, err)), nil
+				}
+				result.Met, err = watchUntil(waitCtx, watcher, condition, onEvent)
+				if err != nil && !apierrors.IsResourceExpired(err) && !apierrors.IsGone(err) {
+					result.Error = err.Error()
+					break
+				}
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.WaitedSeconds = math.Round(time.Since(start).Seconds()*10) / 10
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			if !result.Met {
+				if result.Error == "" {
+					result.Error = fmt.Sprintf("timed out after %gs waiting for %s %s to be %s", timeoutSeconds, resource, name, condition)
+					if r, err = json.Marshal(result); err != nil {
+						return nil, fmt.Errorf("failed to marshal response: %w", err)
+					}
+				}
+				return mcp.NewToolResultError(string(r)), nil
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// watchUntil hands the events of a watch of a single object to onEvent until the object meets the
+// condition, the watch ends or the context is done. Error events end the watch with their error,
+// as do objects that can no longer meet the condition.
+func watchUntil(ctx context.Context, watcher watch.Interface, condition string, onEvent func(event WatchEvent)) (bool, error) {
+	defer watcher.Stop()
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			switch event.Type {
+			case watch.Bookmark:
+				continue
+			case watch.Error:
+				return false, apierrors.FromObject(event.Object)
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			onEvent(newWatchEvent(event.Type, obj))
+			if event.Type == watch.Deleted {
+				obj = nil
+			}
+			if met, err := evaluateCondition(obj, condition); met || err != nil {
+				return met, err
+			}
+		case <-ctx.Done():
+			return false, nil
+		}
+	}
+}
+
+// evaluateCondition reports whether an object, nil while it does not exist, meets a condition: a
+// type of its status.conditions with status True, a status.phase, or Deleted. Status observed for
+// an older generation of the object does not count. Objects that failed, by a Failed condition or
+// phase, can no longer meet other conditions, which is reported as an error.
+func evaluateCondition(obj *unstructured.Unstructured, condition string) (bool, error) {
+	if strings.EqualFold(condition, ConditionDeleted) {
+		return obj == nil, nil
+	}
+	if obj == nil {
+		return false, nil
+	}
+	observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observed < obj.GetGeneration() {
+		return false, nil
+	}
+
+	status := newWatchEvent("", obj)
+	for conditionType, conditionStatus := range status.Conditions {
+		if strings.EqualFold(conditionType, condition) && conditionStatus == string(metav1.ConditionTrue) {
+			return true, nil
+		}
+	}
+	if strings.EqualFold(status.Phase, condition) {
+		return true, nil
+	}
+	if !strings.EqualFold(condition, ConditionFailed) &&
+		(status.Conditions[ConditionFailed] == string(metav1.ConditionTrue) || status.Phase == ConditionFailed) {
+		return false, fmt.Errorf("%s %s failed and cannot become %s", status.Kind, status.Name, condition)
+	}
+	return false, nil
+}
+
+// newWatchEvent describes an event of an object by its identity and status
+func newWatchEvent(eventType watch.EventType, obj *unstructured.Unstructured) WatchEvent {
+	event := WatchEvent{
+		Type:            string(eventType),
+		Kind:            obj.GetKind(),
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+	event.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		status, _ := condition["status"].(string)
+		if conditionType == "" {
+			continue
+		}
+		if event.Conditions == nil {
+			event.Conditions = map[string]string{}
+		}
+		event.Conditions[conditionType] = status
+	}
+	return event
+}
+
+// eventNotifier pushes watch events to the client of a tool call as log notifications, and as
+// progress notifications when the call carries a progress token. Notifications are best effort;
+// the events are always part of the tool result as well.
+type eventNotifier struct {
+	ctx    context.Context
+	server *server.MCPServer
+	token  mcp.ProgressToken
+	logger string
+	// total is the number of events the progress is reported against, unknown when 0
+	total int
+	sent  int
+}
+
+func newEventNotifier(ctx context.Context, request mcp.CallToolRequest, logger string, total int) *eventNotifier {
+	n := &eventNotifier{
+		ctx:    ctx,
+		server: server.ServerFromContext(ctx),
+		logger: logger,
+		total:  total,
+	}
+	if request.Params.Meta != nil {
+		n.token = request.Params.Meta.ProgressToken
+	}
+	return n
+}
+
+func (n *eventNotifier) notify(event WatchEvent, count int) {
+	if n.server == nil {
+		return
+	}
+	err := n.server.SendNotificationToClient(n.ctx, "notifications/message", map[string]any{
+		"level":  "info",
+		"logger": n.logger,
+		"data":   event,
+	})
+	if err != nil {
+		log.FromContext(n.ctx).Debug().Err(err).Str("object", event.Name).Msg("Failed to send watch event notification")
+		return
+	}
+	n.sent++
+	if n.token != nil {
+		progress := map[string]any{
+			"progressToken": n.token,
+			"progress":      count,
+			"message":       fmt.Sprintf("%s %s", event.Type, event.Name),
+		}
+		if n.total > 0 {
+			progress["total"] = n.total
+		}
+		_ = n.server.SendNotificationToClient(n.ctx, "notifications/progress", progress)
+	}
+}
+
+// MetadataResult is the labels and annotations of an object after label_resource or annotate_resource
+type MetadataResult struct {
+	Resource    string            `json:"resource"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// LabelResource creates a tool to add, update or remove labels on a resource of any kind
+func (h *Handler) LabelResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.patchMetadata("label_resource",
+		h.t("TOOL_LABEL_RESOURCE_DESCRIPTION", "Add, update or remove labels on a single resource of any kind, like kubectl label --overwrite"),
+		"labels", validateLabel)
+}
+
+// AnnotateResource creates a tool to add, update or remove annotations on a resource of any kind
+func (h *Handler) AnnotateResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.patchMetadata("annotate_resource",
+		h.t("TOOL_ANNOTATE_RESOURCE_DESCRIPTION", "Add, update or remove annotations on a single resource of any kind, like kubectl annotate --overwrite"),
+		"annotations", validateAnnotation)
+}
+
+// patchMetadata creates a tool that changes the labels or annotations of a resource with a JSON merge patch
+func (h *Handler) patchMetadata(name, description, field string, validate func(key, value string) error) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithString("group",
+				mcp.Description("API group of the resource (empty for the core group)"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("API version of the resource, e.g. v1"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, e.g. deployments"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace of the resource (omit for cluster-scoped resources)"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the resource"),
+			),
+			mcp.WithObject("set",
+				mcp.Description(fmt.Sprintf("%s to add or update; values must be strings", field)),
+			),
+			mcp.WithArray("remove",
+				mcp.Description(fmt.Sprintf("Keys of %s to remove", field)),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			version, err := toolsets.RequiredParam[string](request, "version")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			set, err := toolsets.OptionalParam[map[string]interface{}](request, "set")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			remove, err := toolsets.OptionalParam[[]interface{}](request, "remove")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(set) == 0 && len(remove) == 0 {
+				return mcp.NewToolResultError("at least one of set or remove is required"), nil
+			}
+
+			// A null value in a merge patch removes the key
+			changes := map[string]interface{}{}
+			for key, value := range set {
+				s, ok := value.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("set.%s must be a string", key)), nil
+				}
+				if err := validate(key, s); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				changes[key] = s
+			}
+			for _, value := range remove {
+				key, ok := value.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("remove must contain strings, got %T", value)), nil
+				}
+				if _, ok := changes[key]; ok {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is both set and removed", key)), nil
+				}
+				changes[key] = nil
+			}
+			patch, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{field: changes},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal patch: %w", err)
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+			patched, err := client.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: manifest.DefaultFieldManager})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to patch %s %s: %v", resource, name, err)), nil
+			}
+
+			result := MetadataResult{
+				Resource:    gvr.GroupResource().String(),
+				Namespace:   patched.GetNamespace(),
+				Name:        patched.GetName(),
+				Labels:      patched.GetLabels(),
+				Annotations: patched.GetAnnotations(),
+			}
+			if result.Labels == nil {
+				result.Labels = map[string]string{}
+			}
+			if result.Annotations == nil {
+				result.Annotations = map[string]string{}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// validateLabel checks a label with the rules the API server applies
+func validateLabel(key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateAnnotation checks an annotation key, annotation values may be any string
+func validateAnnotation(key, _ string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// manifestClient builds a discovery-backed client for reading and writing arbitrary kinds
+func (h *Handler) manifestClient(ctx context.Context) (*manifest.Client, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+	dynamicClient, err := h.getDynamicClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+	}
+	return manifest.NewClient(client.Discovery(), dynamicClient)
+}
@@ -0,0 +1,823 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/manifest"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a fake dynamic client
+func stubGetDynamicClientFn(client dynamic.Interface) toolsets.GetDynamicClientFn {
+	return func(ctx context.Context) (dynamic.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// fakeSession is a client session collecting the notifications sent to it
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s fakeSession) SessionID() string                                   { return "session" }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+// Helper function to create a fake clientset whose discovery serves config maps and namespaces
+func newFakeClientset() *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "namespaces", Kind: "Namespace", Namespaced: false},
+			},
+		},
+	}
+	return client
+}
+
+// Helper function to create a fake dynamic client whose server-side apply creates missing objects
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			configMapsGVR: "ConfigMapList",
+			namespacesGVR: "NamespaceList",
+		}, objects...)
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patch.GetPatch(), &obj.Object); err != nil {
+			return true, nil, err
+		}
+		_, err := client.Tracker().Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if apierrors.IsNotFound(err) {
+			err = client.Tracker().Create(patch.GetResource(), obj, patch.GetNamespace())
+		} else if err == nil {
+			err = client.Tracker().Update(patch.GetResource(), obj, patch.GetNamespace())
+		}
+		return true, obj, err
+	})
+	return client
+}
+
+func configMap(namespace, name string, data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"data":       data,
+	}}
+}
+
+func TestApplyManifest(t *testing.T) {
+	dynamicClient := newFakeDynamicClient()
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ApplyManifest()
+
+	assert.Equal(t, "apply_manifest", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"manifest"})
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]interface{}
+		expectedObjects []string
+		expectedErrMsg  string
+	}{
+		{
+			name: "multi-document manifest",
+			requestArgs: map[string]interface{}{
+				"manifest":  "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  mode: production\n",
+				"namespace": "shop",
+			},
+			expectedObjects: []string{"v1 Namespace shop", "v1 ConfigMap shop/settings"},
+		},
+		{
+			name: "json manifest defaults namespace",
+			requestArgs: map[string]interface{}{
+				"manifest": `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"flags"}}`,
+			},
+			expectedObjects: []string{"v1 ConfigMap default/flags"},
+		},
+		{
+			name: "unknown kind",
+			requestArgs: map[string]interface{}{
+				"manifest": "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n",
+			},
+			expectedErrMsg: "failed to resolve",
+		},
+		{
+			name:           "invalid manifest",
+			requestArgs:    map[string]interface{}{"manifest": "kind: ConfigMap\n"},
+			expectedErrMsg: "missing apiVersion or kind",
+		},
+		{
+			name:           "missing required param: manifest",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: manifest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var results []ApplyResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &results))
+			var objects []string
+			for _, r := range results {
+				assert.Empty(t, r.Error)
+				objects = append(objects, r.Object)
+			}
+			assert.Equal(t, tc.expectedObjects, objects)
+		})
+	}
+
+	live, err := dynamicClient.Resource(configMapsGVR).Namespace("shop").Get(context.Background(), "settings", metav1.GetOptions{})
+	require.NoError(t, err)
+	mode, _, _ := unstructured.NestedString(live.Object, "data", "mode")
+	assert.Equal(t, "production", mode)
+}
+
+func TestPatchResource(t *testing.T) {
+	settings := configMap("shop", "settings", map[string]interface{}{"mode": "debug", "region": "eu"})
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(newFakeDynamicClient(settings)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.PatchResource()
+
+	assert.Equal(t, "patch_resource", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"version", "resource", "name", "patch"})
+
+	base := func(args map[string]interface{}) map[string]interface{} {
+		request := map[string]interface{}{"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "settings"}
+		for k, v := range args {
+			request[k] = v
+		}
+		return request
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedData   map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:         "merge patch by default",
+			requestArgs:  base(map[string]interface{}{"patch": `{"data":{"mode":"production"}}`}),
+			expectedData: map[string]interface{}{"mode": "production", "region": "eu"},
+		},
+		{
+			name:         "json patch",
+			requestArgs:  base(map[string]interface{}{"patchType": "json", "patch": `[{"op":"remove","path":"/data/region"}]`}),
+			expectedData: map[string]interface{}{"mode": "production"},
+		},
+		{
+			name:           "unsupported patch type",
+			requestArgs:    base(map[string]interface{}{"patchType": "apply", "patch": `{}`}),
+			expectedErrMsg: "unsupported patchType",
+		},
+		{
+			name:           "invalid patch body",
+			requestArgs:    base(map[string]interface{}{"patch": `{"data":`}),
+			expectedErrMsg: "patch must be valid JSON",
+		},
+		{
+			name:           "resource not found",
+			requestArgs:    base(map[string]interface{}{"name": "missing", "patch": `{"data":{}}`}),
+			expectedErrMsg: "failed to patch configmaps missing",
+		},
+		{
+			name:           "missing required param: patch",
+			requestArgs:    base(nil),
+			expectedErrMsg: "missing required parameter: patch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var patched unstructured.Unstructured
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &patched.Object))
+			data, _, _ := unstructured.NestedMap(patched.Object, "data")
+			assert.Equal(t, tc.expectedData, data)
+		})
+	}
+}
+
+func TestDeleteResource(t *testing.T) {
+	objects := []runtime.Object{
+		configMap("shop", "settings", nil),
+		configMap("shop", "flags", nil),
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": "scratch"},
+		}},
+	}
+	dynamicClient := newFakeDynamicClient(objects...)
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+	tool, handlerFn := handler.DeleteResource()
+
+	assert.Equal(t, "delete_resource", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"version", "resource", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "namespaced resource with options",
+			requestArgs: map[string]interface{}{
+				"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "settings",
+				"propagationPolicy": "Foreground", "gracePeriodSeconds": float64(0),
+			},
+			expectedText: "configmaps settings in namespace shop deleted",
+		},
+		{
+			name:         "cluster-scoped resource",
+			requestArgs:  map[string]interface{}{"version": "v1", "resource": "namespaces", "name": "scratch"},
+			expectedText: "namespaces scratch deleted",
+		},
+		{
+			name:           "not found",
+			requestArgs:    map[string]interface{}{"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "settings"},
+			expectedErrMsg: "failed to delete configmaps settings",
+		},
+		{
+			name: "unsupported propagation policy",
+			requestArgs: map[string]interface{}{
+				"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "flags", "propagationPolicy": "Cascade",
+			},
+			expectedErrMsg: "unsupported propagationPolicy",
+		},
+		{
+			name: "negative grace period",
+			requestArgs: map[string]interface{}{
+				"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "flags", "gracePeriodSeconds": float64(-1),
+			},
+			expectedErrMsg: "gracePeriodSeconds must not be negative",
+		},
+		{
+			name:           "missing required param: resource",
+			requestArgs:    map[string]interface{}{"version": "v1", "name": "flags"},
+			expectedErrMsg: "missing required parameter: resource",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+
+	_, err := dynamicClient.Resource(configMapsGVR).Namespace("shop").Get(context.Background(), "flags", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestLabelAndAnnotateResource(t *testing.T) {
+	settings := configMap("shop", "settings", map[string]interface{}{"mode": "debug"})
+	settings.SetLabels(map[string]string{"app": "shop", "tier": "backend"})
+	settings.SetAnnotations(map[string]string{"owner": "payments"})
+
+	base := func(args map[string]interface{}) map[string]interface{} {
+		request := map[string]interface{}{"version": "v1", "resource": "configmaps", "namespace": "shop", "name": "settings"}
+		for k, v := range args {
+			request[k] = v
+		}
+		return request
+	}
+
+	tests := []struct {
+		name           string
+		annotate       bool
+		requestArgs    map[string]interface{}
+		expectedResult MetadataResult
+		expectedErrMsg string
+	}{
+		{
+			name:        "add update and remove labels",
+			requestArgs: base(map[string]interface{}{"set": map[string]interface{}{"tier": "frontend", "team": "payments"}, "remove": []interface{}{"app", "absent"}}),
+			expectedResult: MetadataResult{
+				Resource: "configmaps", Namespace: "shop", Name: "settings",
+				Labels:      map[string]string{"tier": "frontend", "team": "payments"},
+				Annotations: map[string]string{"owner": "payments"},
+			},
+		},
+		{
+			name:        "annotations accept any value",
+			annotate:    true,
+			requestArgs: base(map[string]interface{}{"set": map[string]interface{}{"example.com/note": "drained for maintenance, see INC-42"}, "remove": []interface{}{"owner"}}),
+			expectedResult: MetadataResult{
+				Resource: "configmaps", Namespace: "shop", Name: "settings",
+				Labels:      map[string]string{"app": "shop", "tier": "backend"},
+				Annotations: map[string]string{"example.com/note": "drained for maintenance, see INC-42"},
+			},
+		},
+		{
+			name:           "invalid label value",
+			requestArgs:    base(map[string]interface{}{"set": map[string]interface{}{"note": "not a label value"}}),
+			expectedErrMsg: `invalid label value "not a label value"`,
+		},
+		{
+			name:           "invalid annotation key",
+			annotate:       true,
+			requestArgs:    base(map[string]interface{}{"set": map[string]interface{}{"bad key": "x"}}),
+			expectedErrMsg: `invalid annotation key "bad key"`,
+		},
+		{
+			name:           "non-string value",
+			requestArgs:    base(map[string]interface{}{"set": map[string]interface{}{"replicas": float64(3)}}),
+			expectedErrMsg: "set.replicas must be a string",
+		},
+		{
+			name:           "key both set and removed",
+			requestArgs:    base(map[string]interface{}{"set": map[string]interface{}{"tier": "frontend"}, "remove": []interface{}{"tier"}}),
+			expectedErrMsg: "tier is both set and removed",
+		},
+		{
+			name:           "no changes",
+			requestArgs:    base(nil),
+			expectedErrMsg: "at least one of set or remove is required",
+		},
+		{
+			name:           "resource not found",
+			requestArgs:    base(map[string]interface{}{"name": "missing", "remove": []interface{}{"app"}}),
+			expectedErrMsg: "failed to patch configmaps missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(newFakeDynamicClient(settings.DeepCopy())), translations.NullTranslationHelper)
+			tool, handlerFn := handler.LabelResource()
+			if tc.annotate {
+				tool, handlerFn = handler.AnnotateResource()
+				assert.Equal(t, "annotate_resource", tool.Name)
+			} else {
+				assert.Equal(t, "label_resource", tool.Name)
+			}
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"version", "resource", "name"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var metadata MetadataResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &metadata))
+			assert.Equal(t, tc.expectedResult, metadata)
+		})
+	}
+}
+
+func TestDiffManifest(t *testing.T) {
+	live := configMap("shop", "settings", map[string]interface{}{"mode": "staging", "region": "eu"})
+	live.SetResourceVersion("7")
+	dynamicClient := newFakeDynamicClient(live)
+	// Dry-run applies return the object the apply would produce without storing it
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patch.GetPatch(), &obj.Object); err != nil {
+			return true, nil, err
+		}
+		current, err := dynamicClient.Tracker().Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		return true, manifest.Merge(current.(*unstructured.Unstructured), obj), nil
+	})
+	handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+	tool, handlerFn := handler.DiffManifest()
+
+	assert.Equal(t, "diff_manifest", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"manifest"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expected       []DiffResult
+		expectedErrMsg string
+	}{
+		{
+			name: "changed and new objects",
+			requestArgs: map[string]interface{}{
+				"manifest":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  mode: production\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: flags\n",
+				"namespace": "shop",
+			},
+			expected: []DiffResult{
+				{
+					Object: "v1 ConfigMap shop/settings",
+					Action: DiffUpdate,
+					Changes: []manifest.Change{
+						{Path: "data.mode", Op: manifest.ChangeReplace, Live: "staging", Desired: "production"},
+					},
+				},
+				{Object: "v1 ConfigMap shop/flags", Action: DiffCreate},
+			},
+		},
+		{
+			name: "unchanged object",
+			requestArgs: map[string]interface{}{
+				"manifest": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: shop\ndata:\n  mode: staging\n",
+			},
+			expected: []DiffResult{{Object: "v1 ConfigMap shop/settings", Action: DiffUnchanged}},
+		},
+		{
+			name: "unknown kind",
+			requestArgs: map[string]interface{}{
+				"manifest": "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n",
+			},
+			expectedErrMsg: "failed to resolve",
+		},
+		{
+			name:           "missing required param: manifest",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: manifest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var results []DiffResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &results))
+			assert.Equal(t, tc.expected, results)
+		})
+	}
+
+	// Nothing was written
+	current, err := dynamicClient.Resource(configMapsGVR).Namespace("shop").Get(context.Background(), "settings", metav1.GetOptions{})
+	require.NoError(t, err)
+	mode, _, _ := unstructured.NestedString(current.Object, "data", "mode")
+	assert.Equal(t, "staging", mode)
+}
+
+// Helper function to create a fake dynamic client serving a fake watch, recording its restrictions
+func newWatchingDynamicClient(watcher watch.Interface, restrictions *k8stesting.WatchRestrictions) *dynamicfake.FakeDynamicClient {
+	client := newFakeDynamicClient()
+	client.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		*restrictions = action.(k8stesting.WatchAction).GetWatchRestrictions()
+		return true, watcher, nil
+	})
+	return client
+}
+
+func TestWatchResource(t *testing.T) {
+	pod := func(name, phase, ready string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "shop", "resourceVersion": "7"},
+			"status": map[string]interface{}{
+				"phase":      phase,
+				"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": ready}},
+			},
+		}}
+	}
+
+	t.Run("pushes events as notifications", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(4, false)
+		watcher.Add(pod("web-0", "Pending", "False"))
+		watcher.Action(watch.Bookmark, pod("", "", ""))
+		watcher.Modify(pod("web-0", "Running", "True"))
+		var restrictions k8stesting.WatchRestrictions
+		dynamicClient := newWatchingDynamicClient(watcher, &restrictions)
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+		tool, handlerFn := handler.WatchResource()
+		assert.Equal(t, "watch_resource", tool.Name)
+		assert.ElementsMatch(t, tool.InputSchema.Required, []string{"version", "resource"})
+
+		srv := server.NewMCPServer("test", "1.0")
+		session := fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+		ctx := srv.WithContext(context.Background(), session)
+		srv.AddTool(tool, handlerFn)
+
+		response := srv.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"watch_resource","arguments":{"version":"v1","resource":"pods","namespace":"shop","name":"web-0","labelSelector":"app=web","maxEvents":2},"_meta":{"progressToken":"watch-1"}}}`))
+		callResult := response.(mcp.JSONRPCResponse).Result.(*mcp.CallToolResult)
+		require.False(t, callResult.IsError, getTextResult(t, callResult).Text)
+		var result WatchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, callResult).Text), &result))
+		assert.Equal(t, "pods", result.Resource)
+		assert.Equal(t, StoppedByMaxEvents, result.StoppedBy)
+		assert.Equal(t, map[string]int{"ADDED": 1, "MODIFIED": 1}, result.Counts)
+		assert.Equal(t, 2, result.Notifications)
+		assert.Equal(t, []WatchEvent{
+			{Type: "ADDED", Kind: "Pod", Namespace: "shop", Name: "web-0", ResourceVersion: "7", Phase: "Pending", Conditions: map[string]string{"Ready": "False"}},
+			{Type: "MODIFIED", Kind: "Pod", Namespace: "shop", Name: "web-0", ResourceVersion: "7", Phase: "Running", Conditions: map[string]string{"Ready": "True"}},
+		}, result.Events)
+		assert.Equal(t, "app=web", restrictions.Labels.String())
+		assert.Equal(t, "metadata.name=web-0", restrictions.Fields.String())
+
+		require.Len(t, session.notifications, 4)
+		message := <-session.notifications
+		assert.Equal(t, "notifications/message", message.Method)
+		assert.Equal(t, "watch_resource", message.Params.AdditionalFields["logger"])
+		progress := <-session.notifications
+		assert.Equal(t, "notifications/progress", progress.Method)
+		assert.Equal(t, "watch-1", progress.Params.AdditionalFields["progressToken"])
+		assert.Equal(t, 1, progress.Params.AdditionalFields["progress"])
+		assert.Equal(t, 2, progress.Params.AdditionalFields["total"])
+	})
+
+	t.Run("stops after the duration", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Delete(pod("web-0", "Running", "True"))
+		var restrictions k8stesting.WatchRestrictions
+		dynamicClient := newWatchingDynamicClient(watcher, &restrictions)
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+		_, handlerFn := handler.WatchResource()
+
+		start := time.Now()
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{
+			"version": "v1", "resource": "pods", "durationSeconds": 0.05,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		var watched WatchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watched))
+		assert.Equal(t, StoppedByDuration, watched.StoppedBy)
+		assert.Equal(t, map[string]int{"DELETED": 1}, watched.Counts)
+		assert.Equal(t, 0, watched.Notifications)
+	})
+
+	t.Run("ends with the watch", func(t *testing.T) {
+		watcher := watch.NewFake()
+		go watcher.Stop()
+		var restrictions k8stesting.WatchRestrictions
+		dynamicClient := newWatchingDynamicClient(watcher, &restrictions)
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+		_, handlerFn := handler.WatchResource()
+
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"version": "v1", "resource": "pods"}))
+		require.NoError(t, err)
+		var watched WatchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watched))
+		assert.Equal(t, StoppedByEnd, watched.StoppedBy)
+		assert.Empty(t, watched.Events)
+	})
+
+	t.Run("error events fail the watch", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Error(&apierrors.NewResourceExpired("too old resource version: 1 (7)").ErrStatus)
+		var restrictions k8stesting.WatchRestrictions
+		dynamicClient := newWatchingDynamicClient(watcher, &restrictions)
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(dynamicClient), translations.NullTranslationHelper)
+		_, handlerFn := handler.WatchResource()
+
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"version": "v1", "resource": "pods", "resourceVersion": "1"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "too old resource version")
+		assert.Equal(t, "1", restrictions.ResourceVersion)
+	})
+
+	for _, args := range []map[string]interface{}{
+		{"version": "v1", "resource": "pods", "maxEvents": float64(maxWatchEvents + 1)},
+		{"version": "v1", "resource": "pods", "durationSeconds": float64(-1)},
+	} {
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(newFakeDynamicClient()), translations.NullTranslationHelper)
+		_, handlerFn := handler.WatchResource()
+		result, err := handlerFn(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "must be between 1 and")
+	}
+}
+
+func TestWaitForCondition(t *testing.T) {
+	deploymentsGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	deployment := func(generation, observedGeneration int64, available string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "shop", "generation": generation},
+			"status": map[string]interface{}{
+				"observedGeneration": observedGeneration,
+				"conditions":         []interface{}{map[string]interface{}{"type": "Available", "status": available}},
+			},
+		}}
+	}
+	newClient := func(watcher watch.Interface, objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{deploymentsGVR: "DeploymentList"}, objects...)
+		client.PrependWatchReactor("*", k8stesting.DefaultWatchReactor(watcher, nil))
+		return client
+	}
+	wait := func(t *testing.T, client dynamic.Interface, args map[string]interface{}) (*mcp.CallToolResult, WaitResult) {
+		handler := NewHandler(stubGetClientFn(newFakeClientset()), stubGetDynamicClientFn(client), translations.NullTranslationHelper)
+		_, handlerFn := handler.WaitForCondition()
+		request := map[string]interface{}{"group": "apps", "version": "v1", "resource": "deployments", "namespace": "shop", "name": "web"}
+		for key, value := range args {
+			request[key] = value
+		}
+		result, err := handlerFn(context.Background(), createMCPRequest(request))
+		require.NoError(t, err)
+		var waited WaitResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &waited), getTextResult(t, result).Text)
+		return result, waited
+	}
+
+	t.Run("already met", func(t *testing.T) {
+		result, waited := wait(t, newClient(watch.NewFake(), deployment(2, 2, "True")), map[string]interface{}{"condition": "available"})
+		assert.False(t, result.IsError)
+		assert.True(t, waited.Met)
+		assert.Equal(t, 0, waited.Events)
+		assert.Equal(t, map[string]string{"Available": "True"}, waited.Status.Conditions)
+	})
+
+	t.Run("met by a later event", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(2, false)
+		// Status of the previous generation is ignored
+		watcher.Modify(deployment(3, 2, "True"))
+		watcher.Modify(deployment(3, 3, "True"))
+		result, waited := wait(t, newClient(watcher, deployment(3, 2, "False")), map[string]interface{}{"condition": "Available"})
+		assert.False(t, result.IsError)
+		assert.True(t, waited.Met)
+		assert.Equal(t, 2, waited.Events)
+		assert.Equal(t, "MODIFIED", waited.Status.Type)
+	})
+
+	t.Run("waits for deletion", func(t *testing.T) {
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Delete(deployment(1, 1, "True"))
+		result, waited := wait(t, newClient(watcher, deployment(1, 1, "True")), map[string]interface{}{"condition": ConditionDeleted})
+		assert.False(t, result.IsError)
+		assert.True(t, waited.Met)
+		assert.Nil(t, waited.Status)
+	})
+
+	t.Run("fails when the object failed", func(t *testing.T) {
+		job := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "batch/v1",
+			"kind":       "Job",
+			"metadata":   map[string]interface{}{"name": "migrate", "namespace": "shop"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Failed", "status": "True"}},
+			},
+		}}
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Modify(job)
+		result, waited := wait(t, newClient(watcher), map[string]interface{}{
+			"group": "batch", "resource": "jobs", "name": "migrate", "condition": "Complete",
+		})
+		assert.True(t, result.IsError)
+		assert.False(t, waited.Met)
+		assert.Equal(t, "Job migrate failed and cannot become Complete", waited.Error)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		result, waited := wait(t, newClient(watch.NewFake(), deployment(1, 1, "False")), map[string]interface{}{
+			"condition": "Available", "timeoutSeconds": 0.05,
+		})
+		assert.True(t, result.IsError)
+		assert.False(t, waited.Met)
+		assert.Equal(t, "timed out after 0.05s waiting for deployments web to be Available", waited.Error)
+	})
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	pvc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "PersistentVolumeClaim",
+		"metadata": map[string]interface{}{"name": "data"},
+		"status":   map[string]interface{}{"phase": "Bound"},
+	}}
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": "web-0"},
+		"status": map[string]interface{}{
+			"phase":      "Failed",
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}},
+		},
+	}}
+
+	tests := []struct {
+		name           string
+		obj            *unstructured.Unstructured
+		condition      string
+		expected       bool
+		expectedErrMsg string
+	}{
+		{name: "phase", obj: pvc, condition: "Bound", expected: true},
+		{name: "other phase", obj: pvc, condition: "Pending"},
+		{name: "missing object", condition: "Bound"},
+		{name: "deleted", condition: ConditionDeleted, expected: true},
+		{name: "not deleted", obj: pvc, condition: ConditionDeleted},
+		{name: "failed phase", obj: pod, condition: "Ready", expectedErrMsg: "Pod web-0 failed and cannot become Ready"},
+		{name: "waiting for failure", obj: pod, condition: "failed", expected: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			met, err := evaluateCondition(tc.obj, tc.condition)
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, met)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/scanner"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Handler implements the K8sResourceHandler interface for container images used by workloads
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+	scanner   scanner.Scanner
+}
+
+// NewHandler creates a new image handler. The scanner is optional; when nil, only the
+// image inventory tool is registered.
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc, imageScanner scanner.Scanner) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+		scanner:   imageScanner,
+	}
+}
+
+// RegisterTools registers all image tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	listTool, listHandler := h.List()
+	toolset.AddReadTool(listTool, listHandler)
+
+	if h.scanner != nil {
+		scanTool, scanHandler := h.Scan()
+		toolset.AddReadTool(scanTool, scanHandler)
+	}
+}
+
+// WorkloadRef identifies the workload that owns a pod
+type WorkloadRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ImageUsage describes a container image and the workloads running it
+type ImageUsage struct {
+	Image     string        `json:"image"`
+	Pods      int           `json:"pods"`
+	Workloads []WorkloadRef `json:"workloads"`
+}
+
+// WorkloadVulnerabilities is a workload annotated with the vulnerability counts of its images
+type WorkloadVulnerabilities struct {
+	WorkloadRef
+	CriticalCVEs int      `json:"criticalCVEs"`
+	HighCVEs     int      `json:"highCVEs"`
+	Images       []string `json:"images"`
+}
+
+// ImageVulnerabilities is the scanner result for a single image
+type ImageVulnerabilities struct {
+	scanner.Report
+	Error string `json:"error,omitempty"`
+}
+
+// ScanResult is the result of the scan_images tool
+type ScanResult struct {
+	Images    []ImageVulnerabilities    `json:"images"`
+	Workloads []WorkloadVulnerabilities `json:"workloads"`
+}
+
+// List creates a tool to list the container images running in the cluster
+func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_images",
+			mcp.WithDescription(h.t("TOOL_LIST_IMAGES_DESCRIPTION", "List container images running in a namespace (or all namespaces) together with the workloads that use them")),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (defaults to all namespaces)"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the pods inspected by their labels"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			images, err := collectImages(ctx, client, namespace, labelSelector)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list images: %v", err)), nil
+			}
+
+			r, err := json.Marshal(images)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Scan creates a tool to enrich the image inventory with vulnerability data from the configured scanner
+func (h *Handler) Scan() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("scan_images",
+			mcp.WithDescription(h.t("TOOL_SCAN_IMAGES_DESCRIPTION", "Query the configured vulnerability scanner for images running in a namespace (or all namespaces) and report critical CVE counts per workload")),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (defaults to all namespaces)"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the pods inspected by their labels"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			images, err := collectImages(ctx, client, namespace, labelSelector)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list images: %v", err)), nil
+			}
+
+			r, err := json.Marshal(h.scanImages(ctx, images))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// scanImages queries the scanner for every image and aggregates the counts per workload.
+// Scanner failures are reported per image instead of failing the whole scan.
+func (h *Handler) scanImages(ctx context.Context, images []ImageUsage) ScanResult {
+	result := ScanResult{
+		Images:    make([]ImageVulnerabilities, 0, len(images)),
+		Workloads: []WorkloadVulnerabilities{},
+	}
+	workloads := make(map[WorkloadRef]*WorkloadVulnerabilities)
+
+	for _, usage := range images {
+		entry := ImageVulnerabilities{Report: scanner.Report{Image: usage.Image}}
+		report, err := h.scanner.Scan(ctx, usage.Image)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Report = *report
+		}
+		result.Images = append(result.Images, entry)
+
+		for _, ref := range usage.Workloads {
+			w, ok := workloads[ref]
+			if !ok {
+				w = &WorkloadVulnerabilities{WorkloadRef: ref}
+				workloads[ref] = w
+			}
+			w.Images = append(w.Images, usage.Image)
+			w.CriticalCVEs += entry.Critical
+			w.HighCVEs += entry.High
+		}
+	}
+
+	for _, w := range workloads {
+		result.Workloads = append(result.Workloads, *w)
+	}
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.CriticalCVEs != b.CriticalCVEs {
+			return a.CriticalCVEs > b.CriticalCVEs
+		}
+		return workloadKey(a.WorkloadRef) < workloadKey(b.WorkloadRef)
+	})
+
+	return result
+}
+
+// collectImages builds the image inventory from the pods matching the namespace and selector
+func collectImages(ctx context.Context, client kubernetes.Interface, namespace string, labelSelector string) ([]ImageUsage, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*ImageUsage)
+	seenWorkloads := make(map[string]map[WorkloadRef]bool)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ref := OwningWorkload(pod)
+
+		for _, image := range podImages(pod) {
+			usage, ok := usages[image]
+			if !ok {
+				usage = &ImageUsage{Image: image, Workloads: []WorkloadRef{}}
+				usages[image] = usage
+				seenWorkloads[image] = make(map[WorkloadRef]bool)
+			}
+			usage.Pods++
+			if !seenWorkloads[image][ref] {
+				seenWorkloads[image][ref] = true
+				usage.Workloads = append(usage.Workloads, ref)
+			}
+		}
+	}
+
+	images := make([]ImageUsage, 0, len(usages))
+	for _, usage := range usages {
+		images = append(images, *usage)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Image < images[j].Image })
+
+	return images, nil
+}
+
+// podImages returns the unique images of the pod's init and regular containers
+func podImages(pod *corev1.Pod) []string {
+	var images []string
+	seen := make(map[string]bool)
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		if c.Image != "" && !seen[c.Image] {
+			seen[c.Image] = true
+			images = append(images, c.Image)
+		}
+	}
+	return images
+}
+
+// OwningWorkload resolves the top-level workload of a pod from its controller reference.
+// ReplicaSets created by a Deployment are resolved to the Deployment using the
+// pod-template-hash label, so no additional API calls are needed.
+func OwningWorkload(pod *corev1.Pod) WorkloadRef {
+	ref := WorkloadRef{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ref
+	}
+	ref.Kind, ref.Name = owner.Kind, owner.Name
+
+	if owner.Kind == "ReplicaSet" {
+		if hash, ok := pod.Labels["pod-template-hash"]; ok && strings.HasSuffix(owner.Name, "-"+hash) {
+			ref.Kind = "Deployment"
+			ref.Name = strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return ref
+}
+
+func workloadKey(ref WorkloadRef) string {
+	return ref.Namespace + "/" + ref.Kind + "/" + ref.Name
+}
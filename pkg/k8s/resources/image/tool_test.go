@@ -0,0 +1,200 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/scanner"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// stubScanner returns canned reports keyed by image
+type stubScanner map[string]*scanner.Report
+
+func (s stubScanner) Scan(_ context.Context, image string) (*scanner.Report, error) {
+	if r, ok := s[image]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("no report for %s", image)
+}
+
+func testPods() []*corev1.Pod {
+	controller := true
+	return []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-7d4b9c-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web", "pod-template-hash": "7d4b9c"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: &controller},
+				},
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36"}},
+				Containers:     []corev1.Container{{Name: "web", Image: "nginx:1.25"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-7d4b9c-fghij",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web", "pod-template-hash": "7d4b9c"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: &controller},
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "web", Image: "nginx:1.25"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db-0",
+				Namespace: "data",
+				Labels:    map[string]string{"app": "db"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "StatefulSet", Name: "db", Controller: &controller},
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "db", Image: "postgres:16"}},
+			},
+		},
+	}
+}
+
+func newFakeClient() kubernetes.Interface {
+	client := fake.NewSimpleClientset()
+	for _, pod := range testPods() {
+		_, _ = client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+	}
+	return client
+}
+
+func TestRegisterTools(t *testing.T) {
+	toolset := toolsets.NewToolset("test", "test", true)
+	NewHandler(stubGetClientFn(newFakeClient()), translations.NullTranslationHelper, nil).RegisterTools(toolset)
+	assert.Len(t, toolset.GetActiveTools(), 1)
+
+	toolset = toolsets.NewToolset("test", "test", true)
+	NewHandler(stubGetClientFn(newFakeClient()), translations.NullTranslationHelper, stubScanner{}).RegisterTools(toolset)
+	assert.Len(t, toolset.GetActiveTools(), 2)
+}
+
+func TestListImages(t *testing.T) {
+	handler := NewHandler(stubGetClientFn(newFakeClient()), translations.NullTranslationHelper, nil)
+	tool, handlerFn := handler.List()
+
+	assert.Equal(t, "list_images", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "namespace")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedImages map[string]int
+	}{
+		{
+			name:           "all namespaces",
+			requestArgs:    map[string]interface{}{},
+			expectedImages: map[string]int{"busybox:1.36": 1, "nginx:1.25": 2, "postgres:16": 1},
+		},
+		{
+			name:           "single namespace",
+			requestArgs:    map[string]interface{}{"namespace": "data"},
+			expectedImages: map[string]int{"postgres:16": 1},
+		},
+		{
+			name:           "with label selector",
+			requestArgs:    map[string]interface{}{"labelSelector": "app=web"},
+			expectedImages: map[string]int{"busybox:1.36": 1, "nginx:1.25": 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+
+			var images []ImageUsage
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &images)
+			require.NoError(t, err)
+
+			got := make(map[string]int)
+			for _, image := range images {
+				got[image.Image] = image.Pods
+			}
+			assert.Equal(t, tc.expectedImages, got)
+		})
+	}
+}
+
+func TestScanImages(t *testing.T) {
+	stub := stubScanner{
+		"nginx:1.25":  {Image: "nginx:1.25", Critical: 2, High: 1},
+		"postgres:16": {Image: "postgres:16", Critical: 1},
+	}
+	handler := NewHandler(stubGetClientFn(newFakeClient()), translations.NullTranslationHelper, stub)
+	tool, handlerFn := handler.Scan()
+
+	assert.Equal(t, "scan_images", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var scan ScanResult
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &scan)
+	require.NoError(t, err)
+
+	// Scanner errors are reported per image
+	require.Len(t, scan.Images, 3)
+	assert.Equal(t, "busybox:1.36", scan.Images[0].Image)
+	assert.Contains(t, scan.Images[0].Error, "no report")
+
+	// Workloads are sorted by critical CVE count and deployments resolved from replicasets
+	require.Len(t, scan.Workloads, 2)
+	assert.Equal(t, WorkloadRef{Kind: "Deployment", Namespace: "default", Name: "web"}, scan.Workloads[0].WorkloadRef)
+	assert.Equal(t, 2, scan.Workloads[0].CriticalCVEs)
+	assert.ElementsMatch(t, []string{"busybox:1.36", "nginx:1.25"}, scan.Workloads[0].Images)
+	assert.Equal(t, WorkloadRef{Kind: "StatefulSet", Namespace: "data", Name: "db"}, scan.Workloads[1].WorkloadRef)
+	assert.Equal(t, 1, scan.Workloads[1].CriticalCVEs)
+}
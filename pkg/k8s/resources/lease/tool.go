@@ -0,0 +1,190 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Handler implements the K8sResourceHandler interface for Lease resources
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+	now       func() time.Time
+}
+
+// NewHandler creates a new Lease resource handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+		now:       time.Now,
+	}
+}
+
+// Summary is a compact view of a Lease focused on who holds it and whether it is still being renewed
+type Summary struct {
+	Name                 string       `json:"name"`
+	Namespace            string       `json:"namespace"`
+	HolderIdentity       string       `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32        `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *metav1.Time `json:"acquireTime,omitempty"`
+	RenewTime            *metav1.Time `json:"renewTime,omitempty"`
+	SecondsSinceRenew    *int64       `json:"secondsSinceRenew,omitempty"`
+	Expired              bool         `json:"expired"`
+	LeaseTransitions     int32        `json:"leaseTransitions"`
+}
+
+// RegisterTools registers all Lease resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	getTool, getHandler := h.Get()
+	toolset.AddReadTool(getTool, getHandler)
+
+	listTool, listHandler := h.List()
+	toolset.AddReadTool(listTool, listHandler)
+}
+
+// Get creates a tool to get details of a specific lease
+func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_lease",
+			mcp.WithDescription(h.t("TOOL_GET_LEASE_DESCRIPTION", "Get details of a specific lease")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Lease name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			lease, err := client.CoordinationV1().Leases(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get lease: %v", err)), nil
+			}
+
+			r, err := json.Marshal(lease)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// List creates a tool to list leases in a namespace
+func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_leases",
+			mcp.WithDescription(h.t("TOOL_LIST_LEASES_DESCRIPTION", "List leases in a namespace or across all namespaces with their holder, last renew time and whether they have expired. Controller leader-election leases usually live in kube-system, node heartbeats in kube-node-lease")),
+			toolsets.WithListNamespace(),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			mcp.WithBoolean("onlyExpired",
+				mcp.Description("Only return leases that have not been renewed within their lease duration"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.ListNamespace(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			onlyExpired, err := toolsets.OptionalParam[bool](request, "onlyExpired")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			leases, err := client.CoordinationV1().Leases(namespace).List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list leases: %v", err)), nil
+			}
+
+			summaries := make([]Summary, 0, len(leases.Items))
+			for _, lease := range leases.Items {
+				summary := h.summarize(lease)
+				if onlyExpired && !summary.Expired {
+					continue
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return toolsets.NewListPageResult(summaries, leases.ListMeta)
+		}
+}
+
+func (h *Handler) summarize(lease coordinationv1.Lease) Summary {
+	summary := Summary{
+		Name:      lease.Name,
+		Namespace: lease.Namespace,
+	}
+	if lease.Spec.HolderIdentity != nil {
+		summary.HolderIdentity = *lease.Spec.HolderIdentity
+	}
+	if lease.Spec.LeaseDurationSeconds != nil {
+		summary.LeaseDurationSeconds = *lease.Spec.LeaseDurationSeconds
+	}
+	if lease.Spec.LeaseTransitions != nil {
+		summary.LeaseTransitions = *lease.Spec.LeaseTransitions
+	}
+	if lease.Spec.AcquireTime != nil {
+		summary.AcquireTime = &metav1.Time{Time: lease.Spec.AcquireTime.Time}
+	}
+	if lease.Spec.RenewTime != nil {
+		summary.RenewTime = &metav1.Time{Time: lease.Spec.RenewTime.Time}
+		since := int64(h.now().Sub(lease.Spec.RenewTime.Time).Seconds())
+		summary.SecondsSinceRenew = &since
+		summary.Expired = summary.LeaseDurationSeconds > 0 && since > int64(summary.LeaseDurationSeconds)
+	}
+	return summary
+}
@@ -0,0 +1,170 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func testLease(name, holder string, renewedAgo time.Duration) *coordinationv1.Lease {
+	duration := int32(15)
+	transitions := int32(3)
+	renew := metav1.NewMicroTime(testNow.Add(-renewedAgo))
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			LeaseTransitions:     &transitions,
+			RenewTime:            &renew,
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	lease := testLease("kube-scheduler", "master-1_abc", 2*time.Second)
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), translations.NullTranslationHelper)
+	tool, _ := handler.Get()
+
+	assert.Equal(t, "get_lease", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		client         kubernetes.Interface
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful lease fetch",
+			client:      fake.NewSimpleClientset(lease),
+			requestArgs: map[string]interface{}{"namespace": "kube-system", "name": "kube-scheduler"},
+		},
+		{
+			name:           "lease not found",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{"namespace": "kube-system", "name": "kube-scheduler"},
+			expectedErrMsg: "failed to get lease",
+		},
+		{
+			name:           "missing required param: name",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{"namespace": "kube-system"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.Get()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			var returned coordinationv1.Lease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "master-1_abc", *returned.Spec.HolderIdentity)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	healthy := testLease("kube-scheduler", "master-1_abc", 2*time.Second)
+	stale := testLease("kube-controller-manager", "master-2_def", 5*time.Minute)
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(healthy, stale)), translations.NullTranslationHelper)
+	handler.now = func() time.Time { return testNow }
+	tool, handlerFn := handler.List()
+
+	assert.Equal(t, "list_leases", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]interface{}
+		expectedNames []string
+	}{
+		{
+			name:          "all leases",
+			requestArgs:   map[string]interface{}{"namespace": "kube-system"},
+			expectedNames: []string{"kube-scheduler", "kube-controller-manager"},
+		},
+		{
+			name:          "only expired",
+			requestArgs:   map[string]interface{}{"namespace": "kube-system", "onlyExpired": true},
+			expectedNames: []string{"kube-controller-manager"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var page struct {
+				Items []Summary `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+
+			var names []string
+			for _, s := range page.Items {
+				names = append(names, s.Name)
+				if s.Name == "kube-controller-manager" {
+					assert.True(t, s.Expired)
+					assert.Equal(t, int64(300), *s.SecondsSinceRenew)
+					assert.Equal(t, "master-2_def", s.HolderIdentity)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
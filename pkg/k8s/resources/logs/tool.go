@@ -0,0 +1,954 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/pod"
+	"github.com/briankscheong/k8s-mcp-server/pkg/log"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+// Limits for get_pod_logs
+const (
+	defaultLogLimitBytes = 64 * 1024
+	maxLogLimitBytes     = 1024 * 1024
+)
+
+// Parts of the logs kept when get_pod_logs truncates them
+const (
+	TruncateHead = "head"
+	TruncateTail = "tail"
+)
+
+// Limits for stream_pod_logs
+const (
+	defaultStreamSeconds    = 30
+	maxStreamSeconds        = 300
+	defaultStreamLimitBytes = 64 * 1024
+	maxStreamLimitBytes     = 1024 * 1024
+)
+
+// Limits for get_logs_by_selector
+const (
+	defaultSelectorTailLines   = 100
+	defaultSelectorMaxBytes    = 256 * 1024
+	maxSelectorMaxBytes        = 1024 * 1024
+	defaultSelectorConcurrency = 5
+	maxSelectorConcurrency     = 20
+)
+
+// Reasons a log stream stopped
+const (
+	StoppedByEnd        = "ended"
+	StoppedByDuration   = "duration"
+	StoppedByLimitBytes = "limitBytes"
+)
+
+// Handler implements the K8sResourceHandler interface for container logs
+type Handler struct {
+	getClient     toolsets.GetClientFn
+	t             translations.TranslationHelperFunc
+	flushInterval time.Duration
+}
+
+// NewHandler creates a new container logs handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:     getClient,
+		t:             t,
+		flushInterval: time.Second,
+	}
+}
+
+// RegisterTools registers all log tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	getTool, getHandler := h.Get()
+	toolset.AddReadTool(getTool, getHandler)
+
+	streamTool, streamHandler := h.Stream()
+	toolset.AddReadTool(streamTool, streamHandler)
+
+	selectorTool, selectorHandler := h.GetBySelector()
+	toolset.AddReadTool(selectorTool, selectorHandler)
+}
+
+// StreamResult is the outcome of following the logs of a container
+type StreamResult struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Logs      string `json:"logs"`
+	Bytes     int    `json:"bytes"`
+	// Notifications is the number of log chunks pushed to the client while streaming
+	Notifications int    `json:"notifications"`
+	StoppedBy     string `json:"stoppedBy"`
+}
+
+// Get creates a tool to get a snapshot of the logs of a pod container
+func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pod_logs",
+			mcp.WithDescription(h.t("TOOL_GET_POD_LOGS_DESCRIPTION", "Get logs from a pod. Without a container, pods with several containers return the logs of every init, app and ephemeral container, one section per container")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Container name, including init and ephemeral containers (defaults to all containers)"),
+			),
+			mcp.WithNumber("tailLines",
+				mcp.Description("Number of lines to retrieve from the end of the logs"),
+			),
+			mcp.WithBoolean("previous",
+				mcp.Description("Get the logs of the previous, terminated instance of the container"),
+			),
+			mcp.WithNumber("limitBytes",
+				mcp.Description(fmt.Sprintf("Maximum bytes of logs returned (default %d, max %d)", defaultLogLimitBytes, maxLogLimitBytes)),
+			),
+			mcp.WithString("truncate",
+				mcp.Description("Which part of the logs to keep when they exceed limitBytes: the oldest lines (head) or the most recent ones (tail, the default)"),
+				mcp.Enum(TruncateHead, TruncateTail),
+			),
+			withLogFilter(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, err := tailLinesParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			previous, err := toolsets.OptionalParam[bool](request, "previous")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limitBytes, err := toolsets.OptionalParam[float64](request, "limitBytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limitBytes == 0 {
+				limitBytes = defaultLogLimitBytes
+			}
+			if limitBytes < 0 || limitBytes > maxLogLimitBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("limitBytes must be between 1 and %d", maxLogLimitBytes)), nil
+			}
+			truncate, err := toolsets.OptionalParam[string](request, "truncate")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch truncate {
+			case "":
+				truncate = TruncateTail
+			case TruncateHead, TruncateTail:
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid truncate %q: must be %s or %s", truncate, TruncateHead, TruncateTail)), nil
+			}
+			filter, err := logFilterParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			p, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			// Pods with a single container return its logs as they are; without a container,
+			// pods with more containers return a section per container
+			containers := podContainers(p)
+			if container != "" {
+				if _, err := logContainer(p, container); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				containers = []podContainer{{name: container}}
+			}
+
+			read := func(c podContainer, limit int) (*truncatedLogs, error) {
+				options := &corev1.PodLogOptions{
+					Container: c.name,
+					TailLines: tailLines,
+					Previous:  previous,
+				}
+				filter.apply(options)
+				logs := &truncatedLogs{limit: limit, head: truncate == TruncateHead}
+				return logs, readLogs(ctx, client.CoreV1().Pods(namespace).GetLogs(name, options), filter, logs)
+			}
+
+			if len(containers) == 1 {
+				logs, err := read(containers[0], int(limitBytes))
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if logs.total == 0 && filter.match != nil && logs.read > 0 {
+					return mcp.NewToolResultText(fmt.Sprintf("no log lines match %q", filter.pattern)), nil
+				}
+				return mcp.NewToolResultText(logs.String()), nil
+			}
+
+			// The byte limit is shared evenly between the containers
+			limit := max(int(limitBytes)/len(containers), 1)
+			sections := make([]string, 0, len(containers))
+			for _, c := range containers {
+				header := fmt.Sprintf("==> %s%s <==\n", c.name, c.kindSuffix())
+				logs, err := read(c, limit)
+				switch {
+				case err != nil:
+					sections = append(sections, header+err.Error()+"\n")
+				case logs.total == 0 && filter.match != nil && logs.read > 0:
+					sections = append(sections, header+fmt.Sprintf("no log lines match %q\n", filter.pattern))
+				case logs.total == 0:
+					sections = append(sections, header+"no logs\n")
+				default:
+					sections = append(sections, header+strings.TrimSuffix(logs.String(), "\n")+"\n")
+				}
+			}
+			return mcp.NewToolResultText(strings.Join(sections, "\n")), nil
+		}
+}
+
+// Stream creates a tool to follow the logs of a pod container, pushing new lines to the client
+// as they arrive
+func (h *Handler) Stream() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("stream_pod_logs",
+			mcp.WithDescription(h.t("TOOL_STREAM_POD_LOGS_DESCRIPTION", "Follow the logs of a pod container, like kubectl logs -f, until a duration or byte limit is reached. New lines are pushed to the client as log notifications (and progress notifications when a progress token is given) while the call runs; the collected logs are returned at the end")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Container name (defaults to the kubectl.kubernetes.io/default-container annotation or the first container)"),
+			),
+			mcp.WithNumber("tailLines",
+				mcp.Description("Number of existing lines to start from; only new lines are streamed if not set"),
+			),
+			mcp.WithNumber("durationSeconds",
+				mcp.Description(fmt.Sprintf("Seconds to follow the logs for (default %d, max %d)", defaultStreamSeconds, maxStreamSeconds)),
+			),
+			mcp.WithNumber("limitBytes",
+				mcp.Description(fmt.Sprintf("Stop after this many bytes of logs (default %d, max %d)", defaultStreamLimitBytes, maxStreamLimitBytes)),
+			),
+			withLogFilter(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, err := tailLinesParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if tailLines == nil {
+				tailLines = ptr.To[int64](0)
+			}
+			durationSeconds, err := toolsets.OptionalParam[float64](request, "durationSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if durationSeconds == 0 {
+				durationSeconds = defaultStreamSeconds
+			}
+			if durationSeconds < 0 || durationSeconds > maxStreamSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("durationSeconds must be between 1 and %d", maxStreamSeconds)), nil
+			}
+			limitBytes, err := toolsets.OptionalParam[float64](request, "limitBytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limitBytes == 0 {
+				limitBytes = defaultStreamLimitBytes
+			}
+			if limitBytes < 0 || limitBytes > maxStreamLimitBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("limitBytes must be between 1 and %d", maxStreamLimitBytes)), nil
+			}
+			filter, err := logFilterParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			p, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			container, err = logContainer(p, container)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			streamCtx, cancel := context.WithTimeout(ctx, time.Duration(durationSeconds*float64(time.Second)))
+			defer cancel()
+			options := &corev1.PodLogOptions{
+				Container: container,
+				Follow:    true,
+				TailLines: tailLines,
+			}
+			filter.apply(options)
+			stream, err := client.CoreV1().Pods(namespace).GetLogs(name, options).Stream(streamCtx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to stream pod logs: %v", err)), nil
+			}
+			defer stream.Close()
+
+			notifier := newChunkNotifier(ctx, request, name, container)
+			result := StreamResult{Pod: name, Container: container}
+			var logs strings.Builder
+			result.StoppedBy, err = follow(streamCtx, stream, int(limitBytes), h.flushInterval, filter.match, func(chunk string) {
+				logs.WriteString(chunk)
+				notifier.notify(chunk, logs.Len())
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to stream pod logs: %v", err)), nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Logs = logs.String()
+			result.Bytes = logs.Len()
+			result.Notifications = notifier.sent
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetBySelector creates a tool to get the logs of all pods matching a label selector
+func (h *Handler) GetBySelector() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_logs_by_selector",
+			mcp.WithDescription(h.t("TOOL_GET_LOGS_BY_SELECTOR_DESCRIPTION", "Get the recent logs of all pods matching a label selector, such as the pods of a deployment, with each line prefixed by [pod/container]. Logs are fetched concurrently and the combined output is capped at maxBytes")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Required(),
+				mcp.Description("Label selector of the pods, e.g. app=web"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Only get the logs of this container; pods without it are skipped (defaults to the default container of each pod)"),
+			),
+			mcp.WithBoolean("allContainers",
+				mcp.Description("Get the logs of every app container of each pod"),
+			),
+			mcp.WithNumber("tailLines",
+				mcp.Description(fmt.Sprintf("Number of lines to retrieve from the end of each container's logs (default %d)", defaultSelectorTailLines)),
+			),
+			mcp.WithNumber("maxBytes",
+				mcp.Description(fmt.Sprintf("Maximum bytes of combined output (default %d, max %d)", defaultSelectorMaxBytes, maxSelectorMaxBytes)),
+			),
+			mcp.WithNumber("concurrency",
+				mcp.Description(fmt.Sprintf("Number of containers whose logs are fetched at the same time (default %d, max %d)", defaultSelectorConcurrency, maxSelectorConcurrency)),
+			),
+			withLogFilter(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelSelector, err := toolsets.RequiredParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allContainers, err := toolsets.OptionalParam[bool](request, "allContainers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if container != "" && allContainers {
+				return mcp.NewToolResultError("container and allContainers cannot be used together"), nil
+			}
+			tailLines, err := tailLinesParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if tailLines == nil {
+				tailLines = ptr.To[int64](defaultSelectorTailLines)
+			}
+			maxBytes, err := toolsets.OptionalParam[float64](request, "maxBytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxBytes == 0 {
+				maxBytes = defaultSelectorMaxBytes
+			}
+			if maxBytes < 0 || maxBytes > maxSelectorMaxBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("maxBytes must be between 1 and %d", maxSelectorMaxBytes)), nil
+			}
+			concurrency, err := toolsets.OptionalParam[float64](request, "concurrency")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if concurrency == 0 {
+				concurrency = defaultSelectorConcurrency
+			}
+			if concurrency < 1 || concurrency > maxSelectorConcurrency {
+				return mcp.NewToolResultError(fmt.Sprintf("concurrency must be between 1 and %d", maxSelectorConcurrency)), nil
+			}
+			filter, err := logFilterParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+			if len(pods.Items) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no pods match %s in namespace %s", labelSelector, namespace)), nil
+			}
+			sort.Slice(pods.Items, func(i, j int) bool {
+				return pods.Items[i].Name < pods.Items[j].Name
+			})
+
+			var targets []logTarget
+			for i := range pods.Items {
+				p := &pods.Items[i]
+				switch {
+				case allContainers:
+					for _, c := range p.Spec.Containers {
+						targets = append(targets, logTarget{pod: p.Name, container: c.Name})
+					}
+				case container != "":
+					if hasContainer(p, container) {
+						targets = append(targets, logTarget{pod: p.Name, container: container})
+					}
+				default:
+					if c, err := logContainer(p, ""); err == nil {
+						targets = append(targets, logTarget{pod: p.Name, container: c})
+					}
+				}
+			}
+			if len(targets) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("none of the %d pods matching %s have a container %s", len(pods.Items), labelSelector, container)), nil
+			}
+
+			// No single container can contribute more than the whole budget
+			fetchLogs(ctx, targets, int(concurrency), func(t *logTarget) {
+				options := &corev1.PodLogOptions{
+					Container:  t.container,
+					TailLines:  tailLines,
+					LimitBytes: ptr.To(int64(maxBytes)),
+				}
+				filter.apply(options)
+				logs, err := client.CoreV1().Pods(namespace).GetLogs(t.pod, options).DoRaw(ctx)
+				t.logs, t.err = []byte(filter.filter(string(logs))), err
+			})
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			return mcp.NewToolResultText(prefixLogs(targets, int(maxBytes))), nil
+		}
+}
+
+// logTarget is a container whose logs are fetched, together with the outcome
+type logTarget struct {
+	pod       string
+	container string
+	logs      []byte
+	err       error
+}
+
+// fetchLogs runs fetch for every target using a pool of workers
+func fetchLogs(ctx context.Context, targets []logTarget, workers int, fetch func(t *logTarget)) {
+	jobs := make(chan *logTarget)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(targets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range jobs {
+				fetch(t)
+			}
+		}()
+	}
+	for i := range targets {
+		select {
+		case jobs <- &targets[i]:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// prefixLogs combines the logs of the targets, in order, prefixing each line with its pod and
+// container. Output stops at maxBytes, with a note saying how much was left out.
+func prefixLogs(targets []logTarget, maxBytes int) string {
+	var b strings.Builder
+	omitted := 0
+	for _, t := range targets {
+		prefix := fmt.Sprintf("[%s/%s] ", t.pod, t.container)
+		var lines []string
+		if t.err != nil {
+			lines = []string{fmt.Sprintf("failed to get logs: %v", t.err)}
+		} else if len(t.logs) > 0 {
+			lines = strings.Split(strings.TrimSuffix(string(t.logs), "\n"), "\n")
+		}
+		for _, line := range lines {
+			line = prefix + line + "\n"
+			if omitted > 0 || b.Len()+len(line) > maxBytes {
+				omitted += len(line)
+				continue
+			}
+			b.WriteString(line)
+		}
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&b, "[truncated: %d more bytes of logs were left out to stay within maxBytes %d]\n", omitted, maxBytes)
+	}
+	return b.String()
+}
+
+// readLogs reads the logs returned by a log request line by line, adding the lines accepted by the
+// filter to logs, so only the part that is returned is held in memory
+func readLogs(ctx context.Context, request *rest.Request, filter logFilter, logs *truncatedLogs) error {
+	stream, err := request.Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get pod logs: %w", err)
+	}
+	defer stream.Close()
+
+	r := bufio.NewReader(stream)
+	for {
+		line, err := r.ReadString('\n')
+		logs.read += len(line)
+		if line != "" && (filter.match == nil || filter.match(strings.TrimRight(line, "\r\n"))) {
+			logs.add(line)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read pod logs: %w", err)
+		}
+	}
+}
+
+// truncatedLogs keeps the first (head) or last lines of logs fitting in limit bytes. Lines are
+// kept whole unless a single line exceeds the limit.
+type truncatedLogs struct {
+	limit int
+	head  bool
+
+	lines []string
+	size  int
+	// total and totalLines count everything added, including the lines that were not kept
+	total      int
+	totalLines int
+	// read counts the bytes read before filtering
+	read int
+}
+
+func (l *truncatedLogs) add(line string) {
+	l.total += len(line)
+	l.totalLines++
+	if l.head {
+		if l.size+len(line) <= l.limit {
+			l.lines = append(l.lines, line)
+			l.size += len(line)
+		} else if len(l.lines) == 0 {
+			l.lines = append(l.lines, line[:l.limit])
+			l.size = l.limit
+		}
+		return
+	}
+	l.lines = append(l.lines, line)
+	l.size += len(line)
+	for l.size > l.limit && len(l.lines) > 1 {
+		l.size -= len(l.lines[0])
+		l.lines = l.lines[1:]
+	}
+	if l.size > l.limit {
+		l.lines[0] = l.lines[0][l.size-l.limit:]
+		l.size = l.limit
+	}
+}
+
+// String returns the kept lines, with a note saying how much was omitted when the logs were
+// truncated
+func (l *truncatedLogs) String() string {
+	logs := strings.Join(l.lines, "")
+	omitted := l.total - l.size
+	if omitted == 0 {
+		return logs
+	}
+	omittedLines := l.totalLines - len(l.lines)
+	if l.head {
+		return fmt.Sprintf("%s\n[truncated: %d later bytes (%d lines) omitted after the first %d bytes; use truncate=tail, sinceTime or grep to see more]\n", strings.TrimSuffix(logs, "\n"), omitted, omittedLines, l.size)
+	}
+	return fmt.Sprintf("[truncated: %d earlier bytes (%d lines) omitted before the last %d bytes; use truncate=head, tailLines or grep to see more]\n%s", omitted, omittedLines, l.size, logs)
+}
+
+// follow reads lines from a log stream and hands the lines accepted by match, or all lines if
+// match is nil, to flush in chunks, at most once per flush interval, until the stream ends, the
+// context is done or limit bytes were accepted. It returns why the stream stopped.
+func follow(ctx context.Context, stream io.Reader, limit int, interval time.Duration, match func(line string) bool, flush func(chunk string)) (string, error) {
+	lines := make(chan string)
+	errc := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(stream)
+		for {
+			line, err := r.ReadString('\n')
+			if line != "" {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pending strings.Builder
+	read := 0
+	flushPending := func() {
+		if pending.Len() > 0 {
+			flush(pending.String())
+			pending.Reset()
+		}
+	}
+	for {
+		select {
+		case line := <-lines:
+			if match != nil && !match(strings.TrimRight(line, "\r\n")) {
+				continue
+			}
+			if read+len(line) >= limit {
+				pending.WriteString(line[:limit-read])
+				flushPending()
+				return StoppedByLimitBytes, nil
+			}
+			read += len(line)
+			pending.WriteString(line)
+		case <-ticker.C:
+			flushPending()
+		case err := <-errc:
+			// Lines are handed over unbuffered, so all of them were received before the error
+			flushPending()
+			switch {
+			case ctx.Err() != nil:
+				return StoppedByDuration, nil
+			case errors.Is(err, io.EOF):
+				return StoppedByEnd, nil
+			default:
+				return "", err
+			}
+		case <-ctx.Done():
+			flushPending()
+			return StoppedByDuration, nil
+		}
+	}
+}
+
+// chunkNotifier pushes log chunks to the client of a tool call as log notifications, and as
+// progress notifications when the call carries a progress token. Notifications are best effort;
+// the chunks are always part of the tool result as well.
+type chunkNotifier struct {
+	ctx       context.Context
+	server    *server.MCPServer
+	token     mcp.ProgressToken
+	pod       string
+	container string
+	sent      int
+}
+
+func newChunkNotifier(ctx context.Context, request mcp.CallToolRequest, pod, container string) *chunkNotifier {
+	n := &chunkNotifier{
+		ctx:       ctx,
+		server:    server.ServerFromContext(ctx),
+		pod:       pod,
+		container: container,
+	}
+	if request.Params.Meta != nil {
+		n.token = request.Params.Meta.ProgressToken
+	}
+	return n
+}
+
+func (n *chunkNotifier) notify(chunk string, total int) {
+	if n.server == nil {
+		return
+	}
+	err := n.server.SendNotificationToClient(n.ctx, "notifications/message", map[string]any{
+		"level":  "info",
+		"logger": "stream_pod_logs",
+		"data": map[string]any{
+			"pod":       n.pod,
+			"container": n.container,
+			"logs":      chunk,
+		},
+	})
+	if err != nil {
+		log.FromContext(n.ctx).Debug().Err(err).Str("pod", n.pod).Msg("Failed to send log chunk notification")
+		return
+	}
+	n.sent++
+	if n.token != nil {
+		_ = n.server.SendNotificationToClient(n.ctx, "notifications/progress", map[string]any{
+			"progressToken": n.token,
+			"progress":      total,
+			"message":       fmt.Sprintf("%d bytes of logs from %s/%s", total, n.pod, n.container),
+		})
+	}
+}
+
+// logFilter narrows down the logs returned by the log tools. The time and timestamp options are
+// passed to the API server; match is applied to each line before it is returned.
+type logFilter struct {
+	sinceSeconds *int64
+	sinceTime    *metav1.Time
+	timestamps   bool
+	pattern      string
+	// match reports whether a line is kept; it is nil when every line is kept
+	match func(line string) bool
+}
+
+// withLogFilter adds the parameters read by logFilterParams to a log tool
+func withLogFilter() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		for _, option := range []mcp.ToolOption{
+			mcp.WithNumber("sinceSeconds",
+				mcp.Description("Only return logs newer than this many seconds"),
+			),
+			mcp.WithString("sinceTime",
+				mcp.Description("Only return logs after this RFC3339 time, e.g. 2025-01-02T15:04:05Z"),
+			),
+			mcp.WithBoolean("timestamps",
+				mcp.Description("Prefix each line with its RFC3339 timestamp"),
+			),
+			mcp.WithString("grep",
+				mcp.Description("Only return lines containing this text, filtered on the server before the logs are returned"),
+			),
+			mcp.WithBoolean("regex",
+				mcp.Description("Treat grep as a Go regular expression, e.g. (?i)error|timeout"),
+			),
+		} {
+			option(tool)
+		}
+	}
+}
+
+// logFilterParams reads the parameters added by withLogFilter
+func logFilterParams(request mcp.CallToolRequest) (logFilter, error) {
+	var filter logFilter
+	sinceSeconds, err := toolsets.OptionalParam[float64](request, "sinceSeconds")
+	if err != nil {
+		return filter, err
+	}
+	if sinceSeconds < 0 {
+		return filter, fmt.Errorf("sinceSeconds must not be negative")
+	}
+	sinceTime, err := toolsets.OptionalParam[string](request, "sinceTime")
+	if err != nil {
+		return filter, err
+	}
+	if sinceSeconds > 0 && sinceTime != "" {
+		return filter, fmt.Errorf("sinceSeconds and sinceTime cannot be used together")
+	}
+	if sinceSeconds > 0 {
+		filter.sinceSeconds = ptr.To(int64(sinceSeconds))
+	}
+	if sinceTime != "" {
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return filter, fmt.Errorf("invalid sinceTime %q: must be an RFC3339 time", sinceTime)
+		}
+		filter.sinceTime = &metav1.Time{Time: t}
+	}
+	if filter.timestamps, err = toolsets.OptionalParam[bool](request, "timestamps"); err != nil {
+		return filter, err
+	}
+
+	grep, err := toolsets.OptionalParam[string](request, "grep")
+	if err != nil {
+		return filter, err
+	}
+	regex, err := toolsets.OptionalParam[bool](request, "regex")
+	if err != nil {
+		return filter, err
+	}
+	filter.pattern = grep
+	switch {
+	case grep == "":
+	case regex:
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return filter, fmt.Errorf("invalid grep regular expression: %v", err)
+		}
+		filter.match = re.MatchString
+	default:
+		filter.match = func(line string) bool {
+			return strings.Contains(line, grep)
+		}
+	}
+	return filter, nil
+}
+
+// apply sets the options of the filter handled by the API server
+func (f logFilter) apply(options *corev1.PodLogOptions) {
+	options.SinceSeconds = f.sinceSeconds
+	options.SinceTime = f.sinceTime
+	options.Timestamps = f.timestamps
+}
+
+// filter returns the lines of logs accepted by the filter
+func (f logFilter) filter(logs string) string {
+	if f.match == nil || logs == "" {
+		return logs
+	}
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		if line != "" && f.match(strings.TrimRight(line, "\r\n")) {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+// tailLinesParam reads the optional tailLines parameter
+func tailLinesParam(request mcp.CallToolRequest) (*int64, error) {
+	if _, ok := request.GetArguments()["tailLines"]; !ok {
+		return nil, nil
+	}
+	tailLines, err := toolsets.OptionalParam[float64](request, "tailLines")
+	if err != nil {
+		return nil, err
+	}
+	if tailLines < 0 {
+		return nil, fmt.Errorf("tailLines must not be negative")
+	}
+	return ptr.To(int64(tailLines)), nil
+}
+
+// hasContainer reports whether a pod has an init, app or ephemeral container with the given name
+func hasContainer(p *corev1.Pod, container string) bool {
+	_, err := logContainer(p, container)
+	return err == nil
+}
+
+// podContainer is a container of a pod that can have logs
+type podContainer struct {
+	name string
+	kind string
+}
+
+// Kinds of pod containers
+const (
+	containerKindInit      = "init"
+	containerKindApp       = ""
+	containerKindEphemeral = "ephemeral"
+)
+
+// kindSuffix returns the kind of init and ephemeral containers as shown in section headers
+func (c podContainer) kindSuffix() string {
+	if c.kind == containerKindApp {
+		return ""
+	}
+	return " (" + c.kind + ")"
+}
+
+// podContainers lists the init, app and ephemeral containers of a pod, in that order
+func podContainers(p *corev1.Pod) []podContainer {
+	var containers []podContainer
+	for _, c := range p.Spec.InitContainers {
+		containers = append(containers, podContainer{name: c.Name, kind: containerKindInit})
+	}
+	for _, c := range p.Spec.Containers {
+		containers = append(containers, podContainer{name: c.Name, kind: containerKindApp})
+	}
+	for _, c := range p.Spec.EphemeralContainers {
+		containers = append(containers, podContainer{name: c.Name, kind: containerKindEphemeral})
+	}
+	return containers
+}
+
+// logContainer resolves the container to read logs from as kubectl logs does, validating that it
+// exists in the pod
+func logContainer(p *corev1.Pod, container string) (string, error) {
+	if container == "" {
+		container = p.Annotations[pod.DefaultContainerAnnotation]
+	}
+	if container == "" && len(p.Spec.Containers) > 0 {
+		container = p.Spec.Containers[0].Name
+	}
+
+	var names []string
+	for _, c := range podContainers(p) {
+		if c.name == container {
+			return container, nil
+		}
+		names = append(names, c.name)
+	}
+	return "", fmt.Errorf("container %s not found in pod %s; available containers: %s", container, p.Name, strings.Join(names, ", "))
+}
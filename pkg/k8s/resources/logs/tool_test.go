@@ -0,0 +1,496 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// fakeSession is a client session collecting the notifications sent to it
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s fakeSession) SessionID() string                                   { return "session" }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Annotations: map[string]string{"kubectl.kubernetes.io/default-container": "app"},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers:     []corev1.Container{{Name: "proxy"}, {Name: "app"}},
+		},
+	}
+}
+
+func TestGetPodLogs(t *testing.T) {
+	web := testPod()
+	web.Spec.EphemeralContainers = []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger"}}}
+	worker := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+	}
+	client := fake.NewSimpleClientset(web, worker)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFunc := handler.Get()
+	assert.Equal(t, "get_pod_logs", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedLogs   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "every container",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "tailLines": float64(10)},
+			expectedLogs: "==> migrate (init) <==\nfake logs\n\n==> proxy <==\nfake logs\n\n==> app <==\nfake logs\n\n==> debugger (ephemeral) <==\nfake logs\n",
+		},
+		{
+			name:         "single container pod",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "worker"},
+			expectedLogs: "fake logs",
+		},
+		{
+			name:         "every container with the limit shared",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "limitBytes": float64(8), "grep": "logs"},
+			expectedLogs: "==> migrate (init) <==\n[truncated: 7 earlier bytes (0 lines) omitted before the last 2 bytes; use truncate=head, tailLines or grep to see more]\ngs\n\n==> proxy <==\n[truncated: 7 earlier bytes (0 lines) omitted before the last 2 bytes; use truncate=head, tailLines or grep to see more]\ngs\n\n==> app <==\n[truncated: 7 earlier bytes (0 lines) omitted before the last 2 bytes; use truncate=head, tailLines or grep to see more]\ngs\n\n==> debugger (ephemeral) <==\n[truncated: 7 earlier bytes (0 lines) omitted before the last 2 bytes; use truncate=head, tailLines or grep to see more]\ngs\n",
+		},
+		{
+			name:         "every container without matching lines",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "container": "", "grep": "error"},
+			expectedLogs: "==> migrate (init) <==\nno log lines match \"error\"\n\n==> proxy <==\nno log lines match \"error\"\n\n==> app <==\nno log lines match \"error\"\n\n==> debugger (ephemeral) <==\nno log lines match \"error\"\n",
+		},
+		{
+			name:         "named container",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "container": "app"},
+			expectedLogs: "fake logs",
+		},
+		{
+			name:         "init container",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "container": "migrate", "previous": true},
+			expectedLogs: "fake logs",
+		},
+		{
+			name:           "unknown container",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "container": "db"},
+			expectedErrMsg: "container db not found in pod web; available containers: migrate, proxy, app, debugger",
+		},
+		{
+			name:         "matching substring",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "worker", "grep": "logs", "sinceSeconds": float64(60), "timestamps": true},
+			expectedLogs: "fake logs",
+		},
+		{
+			name:         "matching regular expression",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "worker", "grep": "(?i)^FAKE", "regex": true, "sinceTime": "2025-01-02T15:04:05Z"},
+			expectedLogs: "fake logs",
+		},
+		{
+			name:         "no matching lines",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "worker", "grep": "error"},
+			expectedLogs: `no log lines match "error"`,
+		},
+		{
+			name:         "truncated to the most recent bytes",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "worker", "limitBytes": float64(4)},
+			expectedLogs: "[truncated: 5 earlier bytes (0 lines) omitted before the last 4 bytes; use truncate=head, tailLines or grep to see more]\nlogs",
+		},
+		{
+			name:           "invalid truncate",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "truncate": "middle"},
+			expectedErrMsg: `invalid truncate "middle": must be head or tail`,
+		},
+		{
+			name:           "limit too large",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "limitBytes": float64(2 * 1024 * 1024)},
+			expectedErrMsg: "limitBytes must be between 1 and 1048576",
+		},
+		{
+			name:           "invalid regular expression",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "grep": "(", "regex": true},
+			expectedErrMsg: "invalid grep regular expression",
+		},
+		{
+			name:           "since seconds and since time",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "sinceSeconds": float64(60), "sinceTime": "2025-01-02T15:04:05Z"},
+			expectedErrMsg: "sinceSeconds and sinceTime cannot be used together",
+		},
+		{
+			name:           "invalid since time",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "sinceTime": "yesterday"},
+			expectedErrMsg: `invalid sinceTime "yesterday": must be an RFC3339 time`,
+		},
+		{
+			name:           "negative tail lines",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "tailLines": float64(-1)},
+			expectedErrMsg: "tailLines must not be negative",
+		},
+		{
+			name:           "missing pod",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "api"},
+			expectedErrMsg: "failed to get pod",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Equal(t, tc.expectedLogs, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	client := fake.NewSimpleClientset(testPod())
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	handler.flushInterval = time.Millisecond
+	tool, handlerFunc := handler.Stream()
+	assert.Equal(t, "stream_pod_logs", tool.Name)
+
+	t.Run("pushes chunks as notifications", func(t *testing.T) {
+		srv := server.NewMCPServer("test", "1.0")
+		session := fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+		ctx := srv.WithContext(context.Background(), session)
+		srv.AddTool(tool, handlerFunc)
+
+		response := srv.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"stream_pod_logs","arguments":{"namespace":"default","name":"web"},"_meta":{"progressToken":"logs-1"}}}`))
+		callResult := response.(mcp.JSONRPCResponse).Result.(*mcp.CallToolResult)
+		require.False(t, callResult.IsError, getTextResult(t, callResult).Text)
+		var result StreamResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, callResult).Text), &result))
+		assert.Equal(t, "app", result.Container)
+		assert.Equal(t, "fake logs", result.Logs)
+		assert.Equal(t, StoppedByEnd, result.StoppedBy)
+		assert.Equal(t, 1, result.Notifications)
+
+		require.Len(t, session.notifications, 2)
+		message := <-session.notifications
+		assert.Equal(t, "notifications/message", message.Method)
+		assert.Equal(t, "stream_pod_logs", message.Params.AdditionalFields["logger"])
+		data := message.Params.AdditionalFields["data"].(map[string]any)
+		assert.Equal(t, "fake logs", data["logs"])
+		progress := <-session.notifications
+		assert.Equal(t, "notifications/progress", progress.Method)
+		assert.Equal(t, "logs-1", progress.Params.AdditionalFields["progressToken"])
+		assert.Equal(t, 9, progress.Params.AdditionalFields["progress"])
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedLogs   string
+		expectedStop   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "without a client session",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "container": "proxy"},
+			expectedLogs: "fake logs",
+			expectedStop: StoppedByEnd,
+		},
+		{
+			name:         "byte limit",
+			requestArgs:  map[string]interface{}{"namespace": "default", "name": "web", "limitBytes": float64(4)},
+			expectedLogs: "fake",
+			expectedStop: StoppedByLimitBytes,
+		},
+		{
+			name:           "duration too long",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "durationSeconds": float64(3600)},
+			expectedErrMsg: "durationSeconds must be between 1 and 300",
+		},
+		{
+			name:           "limit too large",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "limitBytes": float64(4 * 1024 * 1024)},
+			expectedErrMsg: "limitBytes must be between 1 and 1048576",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var stream StreamResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &stream))
+			assert.Equal(t, tc.expectedLogs, stream.Logs)
+			assert.Equal(t, tc.expectedStop, stream.StoppedBy)
+			assert.Zero(t, stream.Notifications)
+		})
+	}
+}
+
+func TestFollow(t *testing.T) {
+	t.Run("stops after the duration", func(t *testing.T) {
+		r, w := io.Pipe()
+		defer w.Close()
+		go func() {
+			_, _ = w.Write([]byte("first\nsecond\n"))
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var chunks []string
+		stoppedBy, err := follow(ctx, r, 1024, time.Millisecond, nil, func(chunk string) {
+			chunks = append(chunks, chunk)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, StoppedByDuration, stoppedBy)
+		assert.Equal(t, "first\nsecond\n", strings.Join(chunks, ""))
+	})
+
+	t.Run("keeps matching lines", func(t *testing.T) {
+		stream := strings.NewReader("GET /healthz 200\nPOST /orders 500\nGET /orders 200\nPOST /orders 503\n")
+		var logs strings.Builder
+		stoppedBy, err := follow(context.Background(), stream, 20, time.Millisecond, func(line string) bool {
+			return strings.HasPrefix(line, "POST")
+		}, func(chunk string) {
+			logs.WriteString(chunk)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, StoppedByLimitBytes, stoppedBy)
+		assert.Equal(t, "POST /orders 500\nPOS", logs.String())
+	})
+
+	t.Run("reports read errors", func(t *testing.T) {
+		r, w := io.Pipe()
+		_ = w.CloseWithError(io.ErrUnexpectedEOF)
+
+		_, err := follow(context.Background(), r, 1024, time.Millisecond, nil, func(string) {})
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestGetLogsBySelector(t *testing.T) {
+	web := func(name string) *corev1.Pod {
+		p := testPod()
+		p.Name = name
+		p.Labels = map[string]string{"app": "web"}
+		return p
+	}
+	worker := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+	}
+	client := fake.NewSimpleClientset(web("web-1"), web("web-0"), worker)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFunc := handler.GetBySelector()
+	assert.Equal(t, "get_logs_by_selector", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "labelSelector"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "default containers",
+			requestArgs:  map[string]interface{}{"namespace": "default", "labelSelector": "app=web"},
+			expectedText: "[web-0/app] fake logs\n[web-1/app] fake logs\n[worker/worker] fake logs\n",
+		},
+		{
+			name:         "named container skips pods without it",
+			requestArgs:  map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "container": "proxy", "concurrency": float64(1)},
+			expectedText: "[web-0/proxy] fake logs\n[web-1/proxy] fake logs\n",
+		},
+		{
+			name:         "all containers",
+			requestArgs:  map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "allContainers": true},
+			expectedText: "[web-0/proxy] fake logs\n[web-0/app] fake logs\n[web-1/proxy] fake logs\n[web-1/app] fake logs\n[worker/worker] fake logs\n",
+		},
+		{
+			name:         "size budget",
+			requestArgs:  map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "maxBytes": float64(50)},
+			expectedText: "[web-0/app] fake logs\n[web-1/app] fake logs\n[truncated: 26 more bytes of logs were left out to stay within maxBytes 50]\n",
+		},
+		{
+			name:         "filtered lines",
+			requestArgs:  map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "container": "proxy", "grep": "error"},
+			expectedText: "",
+		},
+		{
+			name:           "no matching pods",
+			requestArgs:    map[string]interface{}{"namespace": "default", "labelSelector": "app=api"},
+			expectedErrMsg: "no pods match app=api in namespace default",
+		},
+		{
+			name:           "no matching container",
+			requestArgs:    map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "container": "db"},
+			expectedErrMsg: "none of the 3 pods matching app=web have a container db",
+		},
+		{
+			name:           "container and all containers",
+			requestArgs:    map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "container": "app", "allContainers": true},
+			expectedErrMsg: "container and allContainers cannot be used together",
+		},
+		{
+			name:           "too much concurrency",
+			requestArgs:    map[string]interface{}{"namespace": "default", "labelSelector": "app=web", "concurrency": float64(50)},
+			expectedErrMsg: "concurrency must be between 1 and 20",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func TestLogFilter(t *testing.T) {
+	filter, err := logFilterParams(createMCPRequest(map[string]interface{}{"grep": "level=error"}))
+	require.NoError(t, err)
+	assert.Equal(t, "level=error msg=a\r\nlevel=error msg=c", filter.filter("level=error msg=a\r\nlevel=info msg=b\nlevel=error msg=c"))
+
+	options := &corev1.PodLogOptions{}
+	filter.apply(options)
+	assert.Nil(t, options.SinceSeconds)
+	assert.Nil(t, options.SinceTime)
+	assert.False(t, options.Timestamps)
+
+	filter, err = logFilterParams(createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Nil(t, filter.match)
+	assert.Equal(t, "all\nlines\n", filter.filter("all\nlines\n"))
+}
+
+func TestTruncatedLogs(t *testing.T) {
+	lines := []string{"one\n", "two\n", "three\n", "four\n"}
+
+	tests := []struct {
+		name     string
+		limit    int
+		head     bool
+		expected string
+	}{
+		{
+			name:     "within the limit",
+			limit:    100,
+			expected: "one\ntwo\nthree\nfour\n",
+		},
+		{
+			name:     "tail keeps whole recent lines",
+			limit:    12,
+			expected: "[truncated: 8 earlier bytes (2 lines) omitted before the last 11 bytes; use truncate=head, tailLines or grep to see more]\nthree\nfour\n",
+		},
+		{
+			name:     "head keeps whole early lines",
+			limit:    12,
+			head:     true,
+			expected: "one\ntwo\n[truncated: 11 later bytes (2 lines) omitted after the first 8 bytes; use truncate=tail, sinceTime or grep to see more]\n",
+		},
+		{
+			name:     "tail cuts a line longer than the limit",
+			limit:    3,
+			expected: "[truncated: 16 earlier bytes (3 lines) omitted before the last 3 bytes; use truncate=head, tailLines or grep to see more]\nur\n",
+		},
+		{
+			name:     "head cuts a line longer than the limit",
+			limit:    2,
+			head:     true,
+			expected: "on\n[truncated: 17 later bytes (3 lines) omitted after the first 2 bytes; use truncate=tail, sinceTime or grep to see more]\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logs := &truncatedLogs{limit: tc.limit, head: tc.head}
+			for _, line := range lines {
+				logs.add(line)
+			}
+			assert.Equal(t, tc.expected, logs.String())
+		})
+	}
+}
+
+func TestPrefixLogs(t *testing.T) {
+	targets := []logTarget{
+		{pod: "web-0", container: "app", logs: []byte("one\ntwo\n")},
+		{pod: "web-1", container: "app", err: assert.AnError},
+		{pod: "web-2", container: "app"},
+	}
+	assert.Equal(t, "[web-0/app] one\n[web-0/app] two\n[web-1/app] failed to get logs: "+assert.AnError.Error()+"\n", prefixLogs(targets, 1024))
+}
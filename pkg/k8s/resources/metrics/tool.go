@@ -0,0 +1,490 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+const (
+	// SortByCPU and SortByMemory order results by usage, highest first
+	SortByCPU    = "cpu"
+	SortByMemory = "memory"
+
+	// mebibyte is the unit memory is reported in, as kubectl top does
+	mebibyte = 1024 * 1024
+)
+
+// metricsUnavailableHint is appended to errors when the metrics API is not served
+const metricsUnavailableHint = "; the metrics.k8s.io API is not available, is metrics-server installed?"
+
+// Handler implements the K8sResourceHandler interface for resource usage metrics
+type Handler struct {
+	getClient        toolsets.GetClientFn
+	getMetricsClient toolsets.GetMetricsClientFn
+	t                translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new metrics handler
+func NewHandler(getClient toolsets.GetClientFn, getMetricsClient toolsets.GetMetricsClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient:        getClient,
+		getMetricsClient: getMetricsClient,
+		t:                t,
+	}
+}
+
+// ClientFromRESTConfig returns a metrics client getter that builds the client from the REST
+// config of the cluster
+func ClientFromRESTConfig(getRESTConfig toolsets.GetRESTConfigFn) toolsets.GetMetricsClientFn {
+	return func(ctx context.Context) (metricsclientset.Interface, error) {
+		config, err := getRESTConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return metricsclientset.NewForConfig(config)
+	}
+}
+
+// RegisterTools registers all metrics tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	topPodsTool, topPodsHandler := h.TopPods()
+	toolset.AddReadTool(topPodsTool, topPodsHandler)
+
+	topNodesTool, topNodesHandler := h.TopNodes()
+	toolset.AddReadTool(topNodesTool, topNodesHandler)
+}
+
+// Usage is the CPU and memory used by a pod, container or node. CPU is reported in millicores and
+// memory in mebibytes, as kubectl top does.
+type Usage struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+// Comparison is a declared amount of CPU and memory together with the share of it in use. An
+// amount is left empty when it is not declared, and its percentage is then omitted.
+type Comparison struct {
+	CPU           string `json:"cpu,omitempty"`
+	Memory        string `json:"memory,omitempty"`
+	CPUPercent    *int64 `json:"cpuPercent,omitempty"`
+	MemoryPercent *int64 `json:"memoryPercent,omitempty"`
+}
+
+// ContainerUsage is the usage of a single container
+type ContainerUsage struct {
+	Name string `json:"name"`
+	Usage
+	Requests *Comparison `json:"requests,omitempty"`
+	Limits   *Comparison `json:"limits,omitempty"`
+}
+
+// PodUsage is the usage of a pod, summed over its containers
+type PodUsage struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Usage
+	Requests   *Comparison      `json:"requests,omitempty"`
+	Limits     *Comparison      `json:"limits,omitempty"`
+	Containers []ContainerUsage `json:"containers,omitempty"`
+	Timestamp  metav1.Time      `json:"timestamp"`
+	Window     string           `json:"window"`
+}
+
+// NodeUsage is the usage of a node
+type NodeUsage struct {
+	Name string `json:"name"`
+	Usage
+	Allocatable *Comparison `json:"allocatable,omitempty"`
+	Timestamp   metav1.Time `json:"timestamp"`
+	Window      string      `json:"window"`
+}
+
+// TopPods creates a tool to report the CPU and memory usage of pods
+func (h *Handler) TopPods() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("top_pods",
+			mcp.WithDescription(h.t("TOOL_TOP_PODS_DESCRIPTION", "Show the current CPU and memory usage of pods from metrics-server, like kubectl top pods, optionally per container and compared to requests and limits")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Only report this pod"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the pods by their labels"),
+			),
+			mcp.WithBoolean("containers",
+				mcp.Description("Include the usage of each container"),
+			),
+			mcp.WithBoolean("compare",
+				mcp.Description("Compare usage to the requests and limits declared by the containers"),
+			),
+			mcp.WithString("sortBy",
+				mcp.Description("Order pods by usage, highest first; pods are ordered by name otherwise"),
+				mcp.Enum(SortByCPU, SortByMemory),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			containers, err := toolsets.OptionalParam[bool](request, "containers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			compare, err := toolsets.OptionalParam[bool](request, "compare")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sortBy, err := sortParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			metricsClient, err := h.getMetricsClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get metrics client: %w", err)
+			}
+
+			var podMetrics []metricsv1beta1.PodMetrics
+			if name != "" {
+				m, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get metrics for pod %s in namespace %s: %v", name, namespace, err)), nil
+				}
+				podMetrics = []metricsv1beta1.PodMetrics{*m}
+			} else {
+				list, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list pod metrics: %v%s", err, unavailableHint(err))), nil
+				}
+				podMetrics = list.Items
+			}
+
+			// Requests and limits come from the pod specs, which metrics-server does not report
+			specs := map[string]*corev1.Pod{}
+			if compare {
+				client, err := h.getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+				}
+				if name != "" {
+					pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+					}
+					specs[pod.Name] = pod
+				} else {
+					pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+					}
+					for i := range pods.Items {
+						specs[pods.Items[i].Name] = &pods.Items[i]
+					}
+				}
+			}
+
+			result := make([]PodUsage, 0, len(podMetrics))
+			cpu := map[string]int64{}
+			memory := map[string]int64{}
+			for _, m := range podMetrics {
+				result = append(result, summarizePod(m, specs[m.Name], containers, compare))
+				for _, c := range m.Containers {
+					cpu[m.Name] += c.Usage.Cpu().MilliValue()
+					memory[m.Name] += c.Usage.Memory().Value()
+				}
+			}
+
+			sort.SliceStable(result, func(i, j int) bool {
+				switch sortBy {
+				case SortByCPU:
+					return cpu[result[i].Name] > cpu[result[j].Name]
+				case SortByMemory:
+					return memory[result[i].Name] > memory[result[j].Name]
+				}
+				return result[i].Name < result[j].Name
+			})
+
+			return toolsets.NewToolResultJSON(result)
+		}
+}
+
+// TopNodes creates a tool to report the CPU and memory usage of nodes
+func (h *Handler) TopNodes() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("top_nodes",
+			mcp.WithDescription(h.t("TOOL_TOP_NODES_DESCRIPTION", "Show the current CPU and memory usage of nodes from metrics-server, like kubectl top nodes, optionally compared to their allocatable capacity")),
+			mcp.WithString("name",
+				mcp.Description("Only report this node"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the nodes by their labels"),
+			),
+			mcp.WithBoolean("compare",
+				mcp.Description("Compare usage to the allocatable capacity of the nodes"),
+			),
+			mcp.WithString("sortBy",
+				mcp.Description("Order nodes by usage, highest first; nodes are ordered by name otherwise"),
+				mcp.Enum(SortByCPU, SortByMemory),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			compare, err := toolsets.OptionalParam[bool](request, "compare")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sortBy, err := sortParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			metricsClient, err := h.getMetricsClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get metrics client: %w", err)
+			}
+
+			var nodeMetrics []metricsv1beta1.NodeMetrics
+			if name != "" {
+				m, err := metricsClient.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get metrics for node %s: %v", name, err)), nil
+				}
+				nodeMetrics = []metricsv1beta1.NodeMetrics{*m}
+			} else {
+				list, err := metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list node metrics: %v%s", err, unavailableHint(err))), nil
+				}
+				nodeMetrics = list.Items
+			}
+
+			allocatable := map[string]corev1.ResourceList{}
+			if compare {
+				client, err := h.getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+				}
+				if name != "" {
+					node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to get node: %v", err)), nil
+					}
+					allocatable[node.Name] = node.Status.Allocatable
+				} else {
+					nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
+					}
+					for _, node := range nodes.Items {
+						allocatable[node.Name] = node.Status.Allocatable
+					}
+				}
+			}
+
+			result := make([]NodeUsage, 0, len(nodeMetrics))
+			for _, m := range nodeMetrics {
+				usage := NodeUsage{
+					Name:      m.Name,
+					Usage:     toUsage(m.Usage),
+					Timestamp: m.Timestamp,
+					Window:    m.Window.Duration.String(),
+				}
+				if alloc, ok := allocatable[m.Name]; ok {
+					usage.Allocatable = compareTo(m.Usage, alloc)
+				}
+				result = append(result, usage)
+			}
+
+			cpu := map[string]int64{}
+			memory := map[string]int64{}
+			for _, m := range nodeMetrics {
+				cpu[m.Name] = m.Usage.Cpu().MilliValue()
+				memory[m.Name] = m.Usage.Memory().Value()
+			}
+			sort.SliceStable(result, func(i, j int) bool {
+				switch sortBy {
+				case SortByCPU:
+					return cpu[result[i].Name] > cpu[result[j].Name]
+				case SortByMemory:
+					return memory[result[i].Name] > memory[result[j].Name]
+				}
+				return result[i].Name < result[j].Name
+			})
+
+			return toolsets.NewToolResultJSON(result)
+		}
+}
+
+// sortParam reads the sortBy parameter
+func sortParam(request mcp.CallToolRequest) (string, error) {
+	sortBy, err := toolsets.OptionalParam[string](request, "sortBy")
+	if err != nil {
+		return "", err
+	}
+	switch sortBy {
+	case "", SortByCPU, SortByMemory:
+		return sortBy, nil
+	}
+	return "", fmt.Errorf("invalid sortBy %q: must be %s or %s", sortBy, SortByCPU, SortByMemory)
+}
+
+// unavailableHint explains errors caused by the metrics API not being registered
+func unavailableHint(err error) string {
+	if apierrors.IsNotFound(err) {
+		return metricsUnavailableHint
+	}
+	return ""
+}
+
+// summarizePod sums the usage of the containers of a pod. When compare is set and the pod spec is
+// known, usage is compared to the declared requests and limits.
+func summarizePod(m metricsv1beta1.PodMetrics, pod *corev1.Pod, containers, compare bool) PodUsage {
+	total := corev1.ResourceList{}
+	for _, c := range m.Containers {
+		addResources(total, c.Usage)
+	}
+
+	usage := PodUsage{
+		Namespace: m.Namespace,
+		Name:      m.Name,
+		Usage:     toUsage(total),
+		Timestamp: m.Timestamp,
+		Window:    m.Window.Duration.String(),
+	}
+
+	specs := map[string]corev1.ResourceRequirements{}
+	if compare && pod != nil {
+		requests, limits := podResources(pod)
+		usage.Requests = compareTo(total, requests)
+		usage.Limits = compareTo(total, limits)
+		for _, c := range pod.Spec.Containers {
+			specs[c.Name] = c.Resources
+		}
+	}
+
+	if containers {
+		for _, c := range m.Containers {
+			cu := ContainerUsage{Name: c.Name, Usage: toUsage(c.Usage)}
+			if spec, ok := specs[c.Name]; ok {
+				cu.Requests = compareTo(c.Usage, spec.Requests)
+				cu.Limits = compareTo(c.Usage, spec.Limits)
+			}
+			usage.Containers = append(usage.Containers, cu)
+		}
+		sort.Slice(usage.Containers, func(i, j int) bool {
+			return usage.Containers[i].Name < usage.Containers[j].Name
+		})
+	}
+
+	return usage
+}
+
+// podResources sums the requests and limits of the containers of a pod. A limit is only reported
+// when every container declares it, since the pod is otherwise unbounded.
+func podResources(pod *corev1.Pod) (requests, limits corev1.ResourceList) {
+	requests = corev1.ResourceList{}
+	limits = corev1.ResourceList{}
+	unbounded := map[corev1.ResourceName]bool{}
+	for _, c := range pod.Spec.Containers {
+		addResources(requests, c.Resources.Requests)
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			q, ok := c.Resources.Limits[name]
+			if !ok {
+				unbounded[name] = true
+				continue
+			}
+			sum := limits[name]
+			sum.Add(q)
+			limits[name] = sum
+		}
+	}
+	for name := range unbounded {
+		delete(limits, name)
+	}
+	return requests, limits
+}
+
+// addResources adds the CPU and memory of b to a
+func addResources(a, b corev1.ResourceList) {
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		q, ok := b[name]
+		if !ok {
+			continue
+		}
+		sum := a[name]
+		sum.Add(q)
+		a[name] = sum
+	}
+}
+
+// compareTo compares usage to declared amounts, returning nil when neither CPU nor memory is
+// declared
+func compareTo(usage, declared corev1.ResourceList) *Comparison {
+	c := &Comparison{}
+	if q, ok := declared[corev1.ResourceCPU]; ok && !q.IsZero() {
+		c.CPU = formatCPU(q)
+		c.CPUPercent = percent(usage.Cpu().MilliValue(), q.MilliValue())
+	}
+	if q, ok := declared[corev1.ResourceMemory]; ok && !q.IsZero() {
+		c.Memory = formatMemory(q)
+		c.MemoryPercent = percent(usage.Memory().Value(), q.Value())
+	}
+	if c.CPU == "" && c.Memory == "" {
+		return nil
+	}
+	return c
+}
+
+// percent returns used as a whole percentage of total
+func percent(used, total int64) *int64 {
+	p := used * 100 / total
+	return &p
+}
+
+// toUsage formats the CPU and memory of a resource list
+func toUsage(r corev1.ResourceList) Usage {
+	return Usage{
+		CPU:    formatCPU(*r.Cpu()),
+		Memory: formatMemory(*r.Memory()),
+	}
+}
+
+// formatCPU formats a CPU quantity in millicores
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+// formatMemory formats a memory quantity in mebibytes
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/mebibyte)
+}
@@ -0,0 +1,307 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a fake metrics client
+func stubGetMetricsClientFn(client metricsclientset.Interface) toolsets.GetMetricsClientFn {
+	return func(ctx context.Context) (metricsclientset.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// Helper function to create a fake metrics client. The tracker cannot guess the resource of the
+// metrics kinds, so objects are added under their resource explicitly.
+func newMetricsClient(t *testing.T, pods []metricsv1beta1.PodMetrics, nodes []metricsv1beta1.NodeMetrics) *metricsfake.Clientset {
+	client := metricsfake.NewSimpleClientset()
+	for i := range pods {
+		err := client.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), &pods[i], pods[i].Namespace)
+		require.NoError(t, err)
+	}
+	for i := range nodes {
+		err := client.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("nodes"), &nodes[i], "")
+		require.NoError(t, err)
+	}
+	return client
+}
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestTopPods(t *testing.T) {
+	podMetrics := []metricsv1beta1.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", Labels: map[string]string{"app": "api"}},
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "server", Usage: resources("250m", "256Mi")},
+				{Name: "sidecar", Usage: resources("50m", "64Mi")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop", Labels: map[string]string{"app": "worker"}},
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "worker", Usage: resources("900m", "128Mi")},
+			},
+		},
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", Labels: map[string]string{"app": "api"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "server", Resources: corev1.ResourceRequirements{Requests: resources("500m", "512Mi"), Limits: resources("1", "1Gi")}},
+				{Name: "sidecar", Resources: corev1.ResourceRequirements{Requests: resources("100m", "64Mi")}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop", Labels: map[string]string{"app": "worker"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(&pods[0], &pods[1])), stubGetMetricsClientFn(newMetricsClient(t, podMetrics, nil)), translations.NullTranslationHelper)
+	tool, handlerFunc := handler.TopPods()
+	assert.Equal(t, "top_pods", tool.Name)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+		validate       func(t *testing.T, usage []PodUsage)
+	}{
+		{
+			name:        "usage ordered by name",
+			requestArgs: map[string]interface{}{"namespace": "shop"},
+			validate: func(t *testing.T, usage []PodUsage) {
+				require.Len(t, usage, 2)
+				assert.Equal(t, "api", usage[0].Name)
+				assert.Equal(t, "300m", usage[0].CPU)
+				assert.Equal(t, "320Mi", usage[0].Memory)
+				assert.Equal(t, "30s", usage[0].Window)
+				assert.Nil(t, usage[0].Requests)
+				assert.Empty(t, usage[0].Containers)
+			},
+		},
+		{
+			name:        "sorted by cpu",
+			requestArgs: map[string]interface{}{"namespace": "shop", "sortBy": "cpu"},
+			validate: func(t *testing.T, usage []PodUsage) {
+				require.Len(t, usage, 2)
+				assert.Equal(t, "worker", usage[0].Name)
+				assert.Equal(t, "api", usage[1].Name)
+			},
+		},
+		{
+			name:        "containers compared to requests and limits",
+			requestArgs: map[string]interface{}{"namespace": "shop", "labelSelector": "app=api", "containers": true, "compare": true},
+			validate: func(t *testing.T, usage []PodUsage) {
+				require.Len(t, usage, 1)
+				pod := usage[0]
+				require.NotNil(t, pod.Requests)
+				assert.Equal(t, "600m", pod.Requests.CPU)
+				assert.Equal(t, int64(50), *pod.Requests.CPUPercent)
+				assert.Equal(t, int64(55), *pod.Requests.MemoryPercent)
+				// The sidecar has no limits, so the pod is unbounded
+				assert.Nil(t, pod.Limits)
+
+				require.Len(t, pod.Containers, 2)
+				server := pod.Containers[0]
+				assert.Equal(t, "server", server.Name)
+				assert.Equal(t, int64(50), *server.Requests.CPUPercent)
+				require.NotNil(t, server.Limits)
+				assert.Equal(t, "1000m", server.Limits.CPU)
+				assert.Equal(t, int64(25), *server.Limits.CPUPercent)
+				assert.Equal(t, int64(25), *server.Limits.MemoryPercent)
+				assert.Nil(t, pod.Containers[1].Limits)
+			},
+		},
+		{
+			name:        "single pod without declared resources",
+			requestArgs: map[string]interface{}{"namespace": "shop", "name": "worker", "compare": true},
+			validate: func(t *testing.T, usage []PodUsage) {
+				require.Len(t, usage, 1)
+				assert.Equal(t, "900m", usage[0].CPU)
+				assert.Nil(t, usage[0].Requests)
+				assert.Nil(t, usage[0].Limits)
+			},
+		},
+		{
+			name:           "missing pod metrics",
+			requestArgs:    map[string]interface{}{"namespace": "shop", "name": "missing"},
+			expectedErrMsg: "failed to get metrics for pod missing in namespace shop",
+		},
+		{
+			name:           "invalid sort",
+			requestArgs:    map[string]interface{}{"namespace": "shop", "sortBy": "restarts"},
+			expectedErrMsg: "invalid sortBy",
+		},
+		{
+			name:           "missing namespace",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: namespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var usage []PodUsage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &usage))
+			tc.validate(t, usage)
+		})
+	}
+}
+
+func TestTopNodes(t *testing.T) {
+	nodeMetrics := []metricsv1beta1.NodeMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"pool": "general"}},
+			Window:     metav1.Duration{Duration: 20 * time.Second},
+			Usage:      resources("1", "2Gi"),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"pool": "memory"}},
+			Window:     metav1.Duration{Duration: 20 * time.Second},
+			Usage:      resources("500m", "12Gi"),
+		},
+	}
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"pool": "general"}},
+			Status:     corev1.NodeStatus{Allocatable: resources("4", "8Gi")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"pool": "memory"}},
+			Status:     corev1.NodeStatus{Allocatable: resources("2", "16Gi")},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(&nodes[0], &nodes[1])), stubGetMetricsClientFn(newMetricsClient(t, nil, nodeMetrics)), translations.NullTranslationHelper)
+	tool, handlerFunc := handler.TopNodes()
+	assert.Equal(t, "top_nodes", tool.Name)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+		validate       func(t *testing.T, usage []NodeUsage)
+	}{
+		{
+			name:        "usage ordered by name",
+			requestArgs: map[string]interface{}{},
+			validate: func(t *testing.T, usage []NodeUsage) {
+				require.Len(t, usage, 2)
+				assert.Equal(t, "node-a", usage[0].Name)
+				assert.Equal(t, "1000m", usage[0].CPU)
+				assert.Equal(t, "2048Mi", usage[0].Memory)
+				assert.Equal(t, "20s", usage[0].Window)
+				assert.Nil(t, usage[0].Allocatable)
+			},
+		},
+		{
+			name:        "sorted by memory and compared to allocatable",
+			requestArgs: map[string]interface{}{"sortBy": "memory", "compare": true},
+			validate: func(t *testing.T, usage []NodeUsage) {
+				require.Len(t, usage, 2)
+				assert.Equal(t, "node-b", usage[0].Name)
+				require.NotNil(t, usage[0].Allocatable)
+				assert.Equal(t, "2000m", usage[0].Allocatable.CPU)
+				assert.Equal(t, int64(25), *usage[0].Allocatable.CPUPercent)
+				assert.Equal(t, int64(75), *usage[0].Allocatable.MemoryPercent)
+			},
+		},
+		{
+			name:        "label selector",
+			requestArgs: map[string]interface{}{"labelSelector": "pool=general"},
+			validate: func(t *testing.T, usage []NodeUsage) {
+				require.Len(t, usage, 1)
+				assert.Equal(t, "node-a", usage[0].Name)
+			},
+		},
+		{
+			name:           "missing node metrics",
+			requestArgs:    map[string]interface{}{"name": "node-c"},
+			expectedErrMsg: "failed to get metrics for node node-c",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var usage []NodeUsage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &usage))
+			tc.validate(t, usage)
+		})
+	}
+}
@@ -4,14 +4,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
+// HibernatedReplicasAnnotation records the replica count a workload had before its namespace was hibernated
+const HibernatedReplicasAnnotation = "k8s-mcp-server/hibernated-replicas"
+
+// Network policy presets applied by provision_namespace
+const (
+	NetworkPolicyDenyIngress = "deny-ingress"
+	NetworkPolicyDenyAll     = "deny-all"
+	NetworkPolicyNone        = "none"
+)
+
+// Default blueprint applied by provision_namespace when a setting is omitted
+var (
+	DefaultQuota = map[string]string{
+		"requests.cpu":    "4",
+		"requests.memory": "8Gi",
+		"limits.cpu":      "8",
+		"limits.memory":   "16Gi",
+		"pods":            "50",
+	}
+	DefaultContainerRequests = map[string]string{"cpu": "100m", "memory": "128Mi"}
+	DefaultContainerLimits   = map[string]string{"cpu": "500m", "memory": "512Mi"}
+)
+
+// Names of the objects created by provision_namespace
+const (
+	blueprintQuotaName         = "default-quota"
+	blueprintLimitRangeName    = "default-limits"
+	blueprintNetworkPolicyName = "default-deny"
+	blueprintRoleBindingName   = "team"
+	defaultTeamClusterRole     = "edit"
+)
+
+// protectedNamespaces are system namespaces delete_namespace refuses to delete
+var protectedNamespaces = map[string]bool{
+	metav1.NamespaceDefault:   true,
+	metav1.NamespaceSystem:    true,
+	metav1.NamespacePublic:    true,
+	corev1.NamespaceNodeLease: true,
+}
+
 // Handler implements the K8sResourceHandler interface for Namespace resources
 type Handler struct {
 	getClient toolsets.GetClientFn
@@ -29,8 +76,123 @@ func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelper
 // RegisterTools registers all Namespace resource tools with the provided toolset
 func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 	// Register read tools
+	getTool, getHandler := h.Get()
+	toolset.AddReadTool(getTool, getHandler)
+
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
+
+	// Register write tools
+	createTool, createHandler := h.Create()
+	toolset.AddWriteTool(createTool, createHandler)
+
+	deleteTool, deleteHandler := h.Delete()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
+
+	provisionTool, provisionHandler := h.Provision()
+	toolset.AddWriteTool(provisionTool, provisionHandler)
+
+	hibernateTool, hibernateHandler := h.Hibernate()
+	toolset.AddWriteTool(hibernateTool, hibernateHandler)
+
+	wakeTool, wakeHandler := h.Wake()
+	toolset.AddWriteTool(wakeTool, wakeHandler)
+}
+
+// Details is a namespace with the usage of its resource quotas
+type Details struct {
+	Namespace      *corev1.Namespace `json:"namespace"`
+	ResourceQuotas []QuotaUsage      `json:"resourceQuotas"`
+}
+
+// QuotaUsage is the hard limits and current usage of a resource quota
+type QuotaUsage struct {
+	Name string              `json:"name"`
+	Hard corev1.ResourceList `json:"hard"`
+	Used corev1.ResourceList `json:"used"`
+}
+
+// ProvisionResult lists the objects created by provision_namespace
+type ProvisionResult struct {
+	Namespace string              `json:"namespace"`
+	Created   []ProvisionedObject `json:"created"`
+}
+
+// ProvisionedObject is an object created in a provisioned namespace
+type ProvisionedObject struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// WorkloadState is the replica change made to a single workload by hibernate or wake
+type WorkloadState struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Replicas int32  `json:"replicas"`
+	Skipped  string `json:"skipped,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// HibernationResult summarises a hibernate or wake operation on a namespace
+type HibernationResult struct {
+	Namespace string          `json:"namespace"`
+	Workloads []WorkloadState `json:"workloads"`
+}
+
+// scalable is a deployment or statefulset reduced to what hibernation needs
+type scalable struct {
+	kind        string
+	name        string
+	replicas    int32
+	annotations map[string]string
+}
+
+// Get creates a tool to get a namespace with its status and resource quotas
+func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_namespace",
+			mcp.WithDescription(h.t("TOOL_GET_NAMESPACE_DESCRIPTION", "Get a namespace with its status and conditions, and the hard limits and current usage of its resource quotas")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Namespace name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			namespace, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get namespace: %v", err)), nil
+			}
+
+			quotas, err := client.CoreV1().ResourceQuotas(name).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list resource quotas: %v", err)), nil
+			}
+
+			details := Details{Namespace: namespace, ResourceQuotas: make([]QuotaUsage, 0, len(quotas.Items))}
+			for _, quota := range quotas.Items {
+				details.ResourceQuotas = append(details.ResourceQuotas, QuotaUsage{
+					Name: quota.Name,
+					Hard: quota.Status.Hard,
+					Used: quota.Status.Used,
+				})
+			}
+
+			r, err := json.Marshal(details)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
 }
 
 // List creates a tool to list namespaces
@@ -43,6 +205,7 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
@@ -64,13 +227,62 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			namespaces, err := client.CoreV1().Namespaces().List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list namespaces: %v", err)), nil
 			}
 
-			r, err := json.Marshal(namespaces)
+			return toolsets.NewListPageResult(namespaces.Items, namespaces.ListMeta)
+		}
+}
+
+// Create creates a tool to create a namespace
+func (h *Handler) Create() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_namespace",
+			mcp.WithDescription(h.t("TOOL_CREATE_NAMESPACE_DESCRIPTION", "Create a namespace with optional labels and annotations")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Namespace name"),
+			),
+			mcp.WithObject("labels",
+				mcp.Description("Labels applied to the namespace; values must be strings"),
+			),
+			mcp.WithObject("annotations",
+				mcp.Description("Annotations applied to the namespace; values must be strings"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := toolsets.StringMapParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			annotations, err := toolsets.StringMapParam(request, "annotations")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			}
+			created, err := client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create namespace: %v", err)), nil
+			}
+
+			r, err := json.Marshal(created)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -78,3 +290,388 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// Delete creates a tool to delete a namespace and everything in it
+func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_namespace",
+			mcp.WithDescription(h.t("TOOL_DELETE_NAMESPACE_DESCRIPTION", "Delete a namespace and every resource in it. Deletion continues in the background while the namespace is Terminating. System namespaces such as default and kube-system cannot be deleted")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Namespace name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if protectedNamespaces[name] {
+				return mcp.NewToolResultError(fmt.Sprintf("namespace %s is a system namespace and cannot be deleted", name)), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			err = client.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete namespace: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Namespace %s is being deleted", name)), nil
+		}
+}
+
+// Provision creates a tool to create a namespace together with a blueprint of tenant defaults
+func (h *Handler) Provision() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("provision_namespace",
+			mcp.WithDescription(h.t("TOOL_PROVISION_NAMESPACE_DESCRIPTION", "Onboard a tenant by creating a namespace with a blueprint of defaults: a ResourceQuota, a LimitRange with default container requests and limits, a deny NetworkPolicy and a RoleBinding granting a team group a cluster role. If any object fails, the namespace is deleted again")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Namespace name"),
+			),
+			mcp.WithObject("labels",
+				mcp.Description("Labels applied to the namespace; values must be strings"),
+			),
+			mcp.WithObject("annotations",
+				mcp.Description("Annotations applied to the namespace; values must be strings"),
+			),
+			mcp.WithObject("quota",
+				mcp.Description("Hard limits of the ResourceQuota as quantities, e.g. {\"requests.cpu\": \"4\", \"pods\": \"50\"}; defaults to 4/8 CPU and 8Gi/16Gi memory requests/limits and 50 pods, an empty object skips the quota"),
+			),
+			mcp.WithObject("defaultRequests",
+				mcp.Description("Container requests set by the LimitRange when a container has none; defaults to 100m CPU and 128Mi memory"),
+			),
+			mcp.WithObject("defaultLimits",
+				mcp.Description("Container limits set by the LimitRange when a container has none; defaults to 500m CPU and 512Mi memory"),
+			),
+			mcp.WithString("networkPolicy",
+				mcp.Description("Network policy preset: deny-ingress blocks ingress from all pods, deny-all also blocks egress, none creates no policy (default deny-ingress)"),
+				mcp.Enum(NetworkPolicyDenyIngress, NetworkPolicyDenyAll, NetworkPolicyNone),
+			),
+			mcp.WithString("group",
+				mcp.Description("Team group bound to the cluster role in the namespace; omit to create no role binding"),
+			),
+			mcp.WithString("clusterRole",
+				mcp.Description("Cluster role granted to the team group (default edit)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := toolsets.StringMapParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			annotations, err := toolsets.StringMapParam(request, "annotations")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			quota, err := resourceListParam(request, "quota", DefaultQuota)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultRequests, err := resourceListParam(request, "defaultRequests", DefaultContainerRequests)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultLimits, err := resourceListParam(request, "defaultLimits", DefaultContainerLimits)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			networkPolicy, err := toolsets.OptionalParam[string](request, "networkPolicy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch networkPolicy {
+			case "":
+				networkPolicy = NetworkPolicyDenyIngress
+			case NetworkPolicyDenyIngress, NetworkPolicyDenyAll, NetworkPolicyNone:
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid networkPolicy %q: must be one of %s, %s or %s", networkPolicy, NetworkPolicyDenyIngress, NetworkPolicyDenyAll, NetworkPolicyNone)), nil
+			}
+			group, err := toolsets.OptionalParam[string](request, "group")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			clusterRole, err := toolsets.OptionalParam[string](request, "clusterRole")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if clusterRole == "" {
+				clusterRole = defaultTeamClusterRole
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			}
+			if _, err := client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create namespace: %v", err)), nil
+			}
+			result := ProvisionResult{Namespace: name, Created: []ProvisionedObject{{Kind: "Namespace", Name: name}}}
+
+			// Create the blueprint, deleting the namespace again if any object fails so a retry starts clean
+			for _, create := range blueprint(name, quota, defaultRequests, defaultLimits, networkPolicy, group, clusterRole) {
+				object, err := create(ctx, client)
+				if err != nil {
+					rollback := "the namespace was deleted"
+					if err := client.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+						rollback = fmt.Sprintf("deleting the namespace failed: %v", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create %s: %v; %s", object.Kind, err, rollback)), nil
+				}
+				result.Created = append(result.Created, object)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Hibernate creates a tool to scale every workload in a namespace to zero, recording the previous replicas
+func (h *Handler) Hibernate() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("hibernate_namespace",
+			mcp.WithDescription(h.t("TOOL_HIBERNATE_NAMESPACE_DESCRIPTION", "Scale all deployments and statefulsets in a namespace to zero, recording their current replica counts in an annotation so wake_namespace can restore them")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Namespace to hibernate"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			workloads, err := listScalables(ctx, client, namespace)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result := HibernationResult{Namespace: namespace, Workloads: make([]WorkloadState, 0, len(workloads))}
+			for _, w := range workloads {
+				state := WorkloadState{Kind: w.kind, Name: w.name, Replicas: w.replicas}
+				switch {
+				case w.annotations[HibernatedReplicasAnnotation] != "":
+					state.Skipped = "already hibernated"
+				case w.replicas == 0:
+					state.Skipped = "already scaled to zero"
+				default:
+					patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}},"spec":{"replicas":0}}`,
+						HibernatedReplicasAnnotation, strconv.Itoa(int(w.replicas)))
+					if err := patchScalable(ctx, client, namespace, w, patch); err != nil {
+						state.Error = err.Error()
+					}
+				}
+				result.Workloads = append(result.Workloads, state)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Wake creates a tool to restore the replica counts recorded by hibernate_namespace
+func (h *Handler) Wake() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wake_namespace",
+			mcp.WithDescription(h.t("TOOL_WAKE_NAMESPACE_DESCRIPTION", "Restore the replica counts recorded by hibernate_namespace for all deployments and statefulsets in a namespace")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Namespace to wake"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			workloads, err := listScalables(ctx, client, namespace)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result := HibernationResult{Namespace: namespace, Workloads: []WorkloadState{}}
+			for _, w := range workloads {
+				recorded, ok := w.annotations[HibernatedReplicasAnnotation]
+				if !ok {
+					continue
+				}
+				state := WorkloadState{Kind: w.kind, Name: w.name}
+				replicas, err := strconv.ParseInt(recorded, 10, 32)
+				if err != nil || replicas < 0 {
+					state.Error = fmt.Sprintf("invalid %s annotation %q", HibernatedReplicasAnnotation, recorded)
+					result.Workloads = append(result.Workloads, state)
+					continue
+				}
+				state.Replicas = int32(replicas)
+				patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}},"spec":{"replicas":%d}}`,
+					HibernatedReplicasAnnotation, replicas)
+				if err := patchScalable(ctx, client, namespace, w, patch); err != nil {
+					state.Error = err.Error()
+				}
+				result.Workloads = append(result.Workloads, state)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func listScalables(ctx context.Context, client kubernetes.Interface, namespace string) ([]scalable, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %v", err)
+	}
+
+	workloads := make([]scalable, 0, len(deployments.Items)+len(statefulSets.Items))
+	for _, d := range deployments.Items {
+		workloads = append(workloads, scalable{kind: "Deployment", name: d.Name, replicas: replicasOrDefault(d.Spec.Replicas), annotations: d.Annotations})
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, scalable{kind: "StatefulSet", name: s.Name, replicas: replicasOrDefault(s.Spec.Replicas), annotations: s.Annotations})
+	}
+	return workloads, nil
+}
+
+func patchScalable(ctx context.Context, client kubernetes.Interface, namespace string, w scalable, patch string) error {
+	var err error
+	switch w.kind {
+	case "Deployment":
+		_, err = client.AppsV1().Deployments(namespace).Patch(ctx, w.name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(namespace).Patch(ctx, w.name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to patch %s: %v", w.kind, err)
+	}
+	return nil
+}
+
+// replicasOrDefault returns the replica count, which the API server defaults to 1 when unset
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// blueprintObject creates one object of a namespace blueprint
+type blueprintObject func(ctx context.Context, client kubernetes.Interface) (ProvisionedObject, error)
+
+// blueprint returns the objects provisioned into a new namespace, skipping empty settings
+func blueprint(namespace string, quota, defaultRequests, defaultLimits corev1.ResourceList, networkPolicy, group, clusterRole string) []blueprintObject {
+	var objects []blueprintObject
+
+	if len(quota) > 0 {
+		objects = append(objects, func(ctx context.Context, client kubernetes.Interface) (ProvisionedObject, error) {
+			object := ProvisionedObject{Kind: "ResourceQuota", Name: blueprintQuotaName}
+			_, err := client.CoreV1().ResourceQuotas(namespace).Create(ctx, &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: blueprintQuotaName, Namespace: namespace},
+				Spec:       corev1.ResourceQuotaSpec{Hard: quota},
+			}, metav1.CreateOptions{})
+			return object, err
+		})
+	}
+
+	if len(defaultRequests) > 0 || len(defaultLimits) > 0 {
+		objects = append(objects, func(ctx context.Context, client kubernetes.Interface) (ProvisionedObject, error) {
+			object := ProvisionedObject{Kind: "LimitRange", Name: blueprintLimitRangeName}
+			_, err := client.CoreV1().LimitRanges(namespace).Create(ctx, &corev1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{Name: blueprintLimitRangeName, Namespace: namespace},
+				Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+					Type:           corev1.LimitTypeContainer,
+					DefaultRequest: defaultRequests,
+					Default:        defaultLimits,
+				}}},
+			}, metav1.CreateOptions{})
+			return object, err
+		})
+	}
+
+	if networkPolicy != NetworkPolicyNone {
+		policyTypes := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+		if networkPolicy == NetworkPolicyDenyAll {
+			policyTypes = append(policyTypes, networkingv1.PolicyTypeEgress)
+		}
+		objects = append(objects, func(ctx context.Context, client kubernetes.Interface) (ProvisionedObject, error) {
+			object := ProvisionedObject{Kind: "NetworkPolicy", Name: blueprintNetworkPolicyName}
+			// An empty pod selector with no rules denies the listed policy types for every pod
+			_, err := client.NetworkingV1().NetworkPolicies(namespace).Create(ctx, &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: blueprintNetworkPolicyName, Namespace: namespace},
+				Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: policyTypes},
+			}, metav1.CreateOptions{})
+			return object, err
+		})
+	}
+
+	if group != "" {
+		objects = append(objects, func(ctx context.Context, client kubernetes.Interface) (ProvisionedObject, error) {
+			object := ProvisionedObject{Kind: "RoleBinding", Name: blueprintRoleBindingName}
+			_, err := client.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: blueprintRoleBindingName, Namespace: namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole},
+				Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: group}},
+			}, metav1.CreateOptions{})
+			return object, err
+		})
+	}
+
+	return objects
+}
+
+// resourceListParam reads an optional object parameter of resource quantities, returning the
+// defaults when it is omitted. An empty object returns an empty list.
+func resourceListParam(request mcp.CallToolRequest, p string, defaults map[string]string) (corev1.ResourceList, error) {
+	values, err := toolsets.StringMapParam(request, p)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := request.GetArguments()[p]; !ok {
+		values = defaults
+	}
+
+	list := make(corev1.ResourceList, len(values))
+	for k, v := range values {
+		quantity, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: invalid quantity %q", p, k, v)
+		}
+		list[corev1.ResourceName(k)] = quantity
+	}
+	return list, nil
+}
@@ -3,6 +3,7 @@ package namespace
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
@@ -10,10 +11,15 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // Helper function to get text result from tool response
@@ -34,13 +40,7 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -172,3 +172,392 @@ func TestListNamespaces(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "shop"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+			},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "dev"},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.Get()
+	assert.Equal(t, "get_namespace", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "namespace with quota",
+			requestArgs: map[string]interface{}{"name": "shop"},
+		},
+		{
+			name:           "namespace not found",
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to get namespace",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var details Details
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &details))
+			assert.Equal(t, "shop", details.Namespace.Name)
+			assert.Equal(t, corev1.NamespaceActive, details.Namespace.Status.Phase)
+			require.Len(t, details.ResourceQuotas, 1)
+			assert.Equal(t, "compute", details.ResourceQuotas[0].Name)
+			assert.Equal(t, "10", details.ResourceQuotas[0].Hard.Pods().String())
+			assert.Equal(t, "4", details.ResourceQuotas[0].Used.Pods().String())
+		})
+	}
+}
+
+func TestCreateNamespace(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "with labels and annotations",
+			requestArgs: map[string]interface{}{
+				"name":        "shop",
+				"labels":      map[string]interface{}{"team": "payments"},
+				"annotations": map[string]interface{}{"owner": "payments@example.com"},
+			},
+		},
+		{
+			name:           "namespace already exists",
+			requestArgs:    map[string]interface{}{"name": "default"},
+			expectedErrMsg: "failed to create namespace",
+		},
+		{
+			name:           "non-string label",
+			requestArgs:    map[string]interface{}{"name": "shop", "labels": map[string]interface{}{"tier": float64(1)}},
+			expectedErrMsg: "labels.tier must be a string",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Create()
+			assert.Equal(t, "create_namespace", tool.Name)
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			created, err := client.CoreV1().Namespaces().Get(context.Background(), "shop", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"team": "payments"}, created.Labels)
+			assert.Equal(t, map[string]string{"owner": "payments@example.com"}, created.Annotations)
+		})
+	}
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "delete namespace",
+			requestArgs: map[string]interface{}{"name": "shop"},
+		},
+		{
+			name:           "system namespace",
+			requestArgs:    map[string]interface{}{"name": "kube-system"},
+			expectedErrMsg: "namespace kube-system is a system namespace and cannot be deleted",
+		},
+		{
+			name:           "namespace not found",
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to delete namespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			)
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Delete()
+			assert.Equal(t, "delete_namespace", tool.Name)
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Namespace shop is being deleted", getTextResult(t, result).Text)
+			_, err = client.CoreV1().Namespaces().Get(context.Background(), "shop", metav1.GetOptions{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProvisionNamespace(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		failPolicies     bool
+		expectedCreated  []ProvisionedObject
+		expectedPolicies []networkingv1.PolicyType
+		expectedErrMsg   string
+	}{
+		{
+			name:        "default blueprint with team binding",
+			requestArgs: map[string]interface{}{"name": "shop", "labels": map[string]interface{}{"team": "payments"}, "group": "payments-devs"},
+			expectedCreated: []ProvisionedObject{
+				{Kind: "Namespace", Name: "shop"},
+				{Kind: "ResourceQuota", Name: "default-quota"},
+				{Kind: "LimitRange", Name: "default-limits"},
+				{Kind: "NetworkPolicy", Name: "default-deny"},
+				{Kind: "RoleBinding", Name: "team"},
+			},
+			expectedPolicies: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+		{
+			name: "custom blueprint",
+			requestArgs: map[string]interface{}{
+				"name":          "shop",
+				"quota":         map[string]interface{}{},
+				"defaultLimits": map[string]interface{}{"memory": "1Gi"},
+				"networkPolicy": "deny-all",
+			},
+			expectedCreated: []ProvisionedObject{
+				{Kind: "Namespace", Name: "shop"},
+				{Kind: "LimitRange", Name: "default-limits"},
+				{Kind: "NetworkPolicy", Name: "default-deny"},
+			},
+			expectedPolicies: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+		{
+			name:           "failed object rolls back the namespace",
+			requestArgs:    map[string]interface{}{"name": "shop"},
+			failPolicies:   true,
+			expectedErrMsg: "failed to create NetworkPolicy: admission denied; the namespace was deleted",
+		},
+		{
+			name:           "namespace already exists",
+			requestArgs:    map[string]interface{}{"name": "default"},
+			expectedErrMsg: "failed to create namespace",
+		},
+		{
+			name:           "invalid quantity",
+			requestArgs:    map[string]interface{}{"name": "shop", "quota": map[string]interface{}{"pods": "many"}},
+			expectedErrMsg: `quota.pods: invalid quantity "many"`,
+		},
+		{
+			name:           "invalid network policy",
+			requestArgs:    map[string]interface{}{"name": "shop", "networkPolicy": "allow-all"},
+			expectedErrMsg: `invalid networkPolicy "allow-all"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+			if tc.failPolicies {
+				client.PrependReactor("create", "networkpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, fmt.Errorf("admission denied")
+				})
+			}
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Provision()
+			assert.Equal(t, "provision_namespace", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				if tc.failPolicies {
+					_, err := client.CoreV1().Namespaces().Get(context.Background(), "shop", metav1.GetOptions{})
+					assert.Error(t, err)
+				}
+				return
+			}
+
+			require.False(t, result.IsError)
+			var provisioned ProvisionResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &provisioned))
+			assert.Equal(t, "shop", provisioned.Namespace)
+			assert.Equal(t, tc.expectedCreated, provisioned.Created)
+
+			policy, err := client.NetworkingV1().NetworkPolicies("shop").Get(context.Background(), "default-deny", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicies, policy.Spec.PolicyTypes)
+
+			limits, err := client.CoreV1().LimitRanges("shop").Get(context.Background(), "default-limits", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, limits.Spec.Limits, 1)
+			assert.Equal(t, corev1.LimitTypeContainer, limits.Spec.Limits[0].Type)
+		})
+	}
+
+	// The default blueprint's quota and team binding
+	client := fake.NewSimpleClientset()
+	_, handlerFn := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper).Provision()
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "shop", "group": "payments-devs", "clusterRole": "admin"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	quota, err := client.CoreV1().ResourceQuotas("shop").Get(context.Background(), "default-quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "50", quota.Spec.Hard.Pods().String())
+	assert.Equal(t, "8Gi", quota.Spec.Hard.Name("requests.memory", resource.BinarySI).String())
+
+	binding, err := client.RbacV1().RoleBindings("shop").Get(context.Background(), "team", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", binding.RoleRef.Name)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "Group", binding.Subjects[0].Kind)
+	assert.Equal(t, "payments-devs", binding.Subjects[0].Name)
+}
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestHibernateAndWakeNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "dev"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "dev"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(0)},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "dev"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(2)},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(5)},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	hibernateTool, hibernateFn := handler.Hibernate()
+	wakeTool, wakeFn := handler.Wake()
+
+	assert.Equal(t, "hibernate_namespace", hibernateTool.Name)
+	assert.ElementsMatch(t, hibernateTool.InputSchema.Required, []string{"namespace"})
+	assert.Equal(t, "wake_namespace", wakeTool.Name)
+	assert.ElementsMatch(t, wakeTool.InputSchema.Required, []string{"namespace"})
+
+	replicas := func(kind, name string) int32 {
+		if kind == "StatefulSet" {
+			s, err := client.AppsV1().StatefulSets("dev").Get(context.Background(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+			return *s.Spec.Replicas
+		}
+		d, err := client.AppsV1().Deployments("dev").Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return *d.Spec.Replicas
+	}
+
+	result, err := hibernateFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "dev"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var hibernated HibernationResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &hibernated))
+	assert.ElementsMatch(t, []WorkloadState{
+		{Kind: "Deployment", Name: "web", Replicas: 3},
+		{Kind: "Deployment", Name: "batch", Replicas: 0, Skipped: "already scaled to zero"},
+		{Kind: "StatefulSet", Name: "db", Replicas: 2},
+	}, hibernated.Workloads)
+	assert.Equal(t, int32(0), replicas("Deployment", "web"))
+	assert.Equal(t, int32(0), replicas("StatefulSet", "db"))
+
+	// Hibernating twice must not overwrite the recorded replica counts
+	result, err = hibernateFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "dev"}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &hibernated))
+	for _, w := range hibernated.Workloads {
+		assert.NotEmpty(t, w.Skipped, w.Name)
+	}
+
+	result, err = wakeFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "dev"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var woken HibernationResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &woken))
+	assert.ElementsMatch(t, []WorkloadState{
+		{Kind: "Deployment", Name: "web", Replicas: 3},
+		{Kind: "StatefulSet", Name: "db", Replicas: 2},
+	}, woken.Workloads)
+	assert.Equal(t, int32(3), replicas("Deployment", "web"))
+	assert.Equal(t, int32(2), replicas("StatefulSet", "db"))
+	assert.Equal(t, int32(0), replicas("Deployment", "batch"))
+
+	web, err := client.AppsV1().Deployments("dev").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, web.Annotations, HibernatedReplicasAnnotation)
+
+	prod, err := client.AppsV1().Deployments("prod").Get(context.Background(), "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), *prod.Spec.Replicas)
+
+	result, err = wakeFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "missing required parameter: namespace")
+}
@@ -2,27 +2,55 @@ package node
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// nodeUserPrefix is the username prefix kubelets use when requesting certificates
+	nodeUserPrefix = "system:node:"
+	// defaultCertWarningDays flags certificates expiring within this many days
+	defaultCertWarningDays = 30
+	// defaultDrainTimeoutSeconds and maxDrainTimeoutSeconds bound how long drain_node waits for evictions
+	defaultDrainTimeoutSeconds = 300
+	maxDrainTimeoutSeconds     = 1800
 )
 
 // Handler implements the K8sResourceHandler interface for Node resources
 type Handler struct {
-	getClient toolsets.GetClientFn
-	t         translations.TranslationHelperFunc
+	getClient    toolsets.GetClientFn
+	t            translations.TranslationHelperFunc
+	pollInterval time.Duration
 }
 
 // NewHandler creates a new Node resource handler
 func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
 	return &Handler{
-		getClient: getClient,
-		t:         t,
+		getClient:    getClient,
+		t:            t,
+		pollInterval: 2 * time.Second,
 	}
 }
 
@@ -34,6 +62,25 @@ func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
+
+	certTool, certHandler := h.CheckKubeletCertificates()
+	toolset.AddReadTool(certTool, certHandler)
+
+	// Register write tools
+	cordonTool, cordonHandler := h.Cordon()
+	toolset.AddWriteTool(cordonTool, cordonHandler)
+
+	uncordonTool, uncordonHandler := h.Uncordon()
+	toolset.AddWriteTool(uncordonTool, uncordonHandler)
+
+	drainTool, drainHandler := h.Drain()
+	toolset.AddWriteTool(drainTool, drainHandler)
+
+	taintTool, taintHandler := h.Taint()
+	toolset.AddWriteTool(taintTool, taintHandler)
+
+	untaintTool, untaintHandler := h.Untaint()
+	toolset.AddWriteTool(untaintTool, untaintHandler)
 }
 
 // Get creates a tool to get details of a specific node
@@ -80,6 +127,11 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			mcp.WithBoolean("notReady",
+				mcp.Description("Only return nodes whose Ready condition is not True"),
+			),
+			toolsets.WithSortBy(toolsets.SortKeys[corev1.Node]{}, ""),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
@@ -92,6 +144,11 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			notReady, err := toolsets.OptionalParam[bool](request, "notReady")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := h.getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
@@ -101,13 +158,233 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			nodes, err := client.CoreV1().Nodes().List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
 			}
 
-			r, err := json.Marshal(nodes)
+			items := nodes.Items[:0]
+			for _, node := range nodes.Items {
+				if !notReady || !isReady(&node) {
+					items = append(items, node)
+				}
+			}
+			if err := toolsets.SortItems(request, items, toolsets.SortKeys[corev1.Node]{}); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return toolsets.NewListPageResult(items, nodes.ListMeta)
+		}
+}
+
+// isReady reports whether the Ready condition of a node is True
+func isReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Cordon creates a tool to mark a node unschedulable
+func (h *Handler) Cordon() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.setUnschedulable("cordon_node",
+		h.t("TOOL_CORDON_NODE_DESCRIPTION", "Mark a node unschedulable so no new pods are placed on it, like kubectl cordon. Pods already running on the node are not affected"),
+		true)
+}
+
+// Uncordon creates a tool to mark a node schedulable again
+func (h *Handler) Uncordon() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return h.setUnschedulable("uncordon_node",
+		h.t("TOOL_UNCORDON_NODE_DESCRIPTION", "Mark a cordoned or drained node schedulable again, like kubectl uncordon"),
+		false)
+}
+
+// setUnschedulable creates a tool that sets spec.unschedulable of a node
+func (h *Handler) setUnschedulable(name, description string, unschedulable bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Node name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get node: %v", err)), nil
+			}
+			if node.Spec.Unschedulable == unschedulable {
+				state := "uncordoned"
+				if unschedulable {
+					state = "cordoned"
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("node %s is already %s", name, state)), nil
+			}
+
+			updated, err := cordon(ctx, client, name, unschedulable)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update node: %v", err)), nil
+			}
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func cordon(ctx context.Context, client kubernetes.Interface, name string, unschedulable bool) (*corev1.Node, error) {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	return client.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+}
+
+// DrainPod is a pod on a drained node together with why it was skipped or is still running
+type DrainPod struct {
+	Pod    string `json:"pod"`
+	Reason string `json:"reason"`
+}
+
+// DrainResult is the outcome of draining a node
+type DrainResult struct {
+	Node      string     `json:"node"`
+	Evicted   []string   `json:"evicted"`
+	Skipped   []DrainPod `json:"skipped,omitempty"`
+	Remaining []DrainPod `json:"remaining,omitempty"`
+	TimedOut  bool       `json:"timedOut,omitempty"`
+}
+
+// Drain creates a tool to cordon a node and evict its pods
+func (h *Handler) Drain() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("drain_node",
+			mcp.WithDescription(h.t("TOOL_DRAIN_NODE_DESCRIPTION", "Cordon a node and evict its pods through the Eviction API, like kubectl drain, so PodDisruptionBudgets are respected. DaemonSet and mirror pods are skipped. Evictions blocked by a budget are retried until the timeout, after which the remaining pods are reported")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Node name"),
+			),
+			mcp.WithNumber("gracePeriodSeconds",
+				mcp.Description("Seconds each pod is given to terminate (default: the pod's terminationGracePeriodSeconds)"),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait for all pods to be evicted (default %d, max %d)", defaultDrainTimeoutSeconds, maxDrainTimeoutSeconds)),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Also evict pods not managed by a controller, which will not be recreated"),
+			),
+			mcp.WithBoolean("deleteEmptyDirData",
+				mcp.Description("Also evict pods using emptyDir volumes, whose data is lost"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var gracePeriod *int64
+			if _, ok := request.GetArguments()["gracePeriodSeconds"]; ok {
+				seconds, err := toolsets.OptionalParam[float64](request, "gracePeriodSeconds")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if seconds < 0 {
+					return mcp.NewToolResultError("gracePeriodSeconds must not be negative"), nil
+				}
+				gracePeriod = ptr.To(int64(seconds))
+			}
+			timeoutSeconds, err := toolsets.OptionalParam[float64](request, "timeoutSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds == 0 {
+				timeoutSeconds = defaultDrainTimeoutSeconds
+			}
+			if timeoutSeconds < 0 || timeoutSeconds > maxDrainTimeoutSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("timeoutSeconds must be between 1 and %d", maxDrainTimeoutSeconds)), nil
+			}
+			force, err := toolsets.OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deleteEmptyDirData, err := toolsets.OptionalParam[bool](request, "deleteEmptyDirData")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get node: %v", err)), nil
+			}
+			pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+
+			// Refuse before cordoning when pods would be lost, so a failed drain leaves the node untouched
+			result := DrainResult{Node: name, Evicted: []string{}}
+			var evict []corev1.Pod
+			var blockers []string
+			for _, pod := range pods.Items {
+				skip, blocker := drainFilter(pod, force, deleteEmptyDirData)
+				switch {
+				case blocker != "":
+					blockers = append(blockers, fmt.Sprintf("%s/%s %s", pod.Namespace, pod.Name, blocker))
+				case skip != "":
+					result.Skipped = append(result.Skipped, DrainPod{Pod: pod.Namespace + "/" + pod.Name, Reason: skip})
+				default:
+					evict = append(evict, pod)
+				}
+			}
+			if len(blockers) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("cannot drain node %s: %s", name, strings.Join(blockers, "; "))), nil
+			}
+
+			if !node.Spec.Unschedulable {
+				if _, err := cordon(ctx, client, name, true); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to cordon node: %v", err)), nil
+				}
+			}
+
+			remaining, err := h.evictPods(ctx, client, evict, gracePeriod, time.Duration(timeoutSeconds*float64(time.Second)))
+			switch {
+			case wait.Interrupted(err):
+				result.TimedOut = true
+			case err != nil:
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pod := range evict {
+				key := pod.Namespace + "/" + pod.Name
+				if reason, ok := remaining[key]; ok {
+					result.Remaining = append(result.Remaining, DrainPod{Pod: key, Reason: reason})
+				} else {
+					result.Evicted = append(result.Evicted, key)
+				}
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -115,3 +392,446 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// drainFilter decides how drain treats a pod: skipped with a reason, blocking the drain with a
+// reason, or evicted when both are empty
+func drainFilter(pod corev1.Pod, force, deleteEmptyDirData bool) (skip, blocker string) {
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return "mirror pod", ""
+	}
+	controller := metav1.GetControllerOf(&pod)
+	if controller != nil && controller.Kind == "DaemonSet" {
+		return "managed by DaemonSet " + controller.Name, ""
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return "", ""
+	}
+	if controller == nil && !force {
+		return "", "is not managed by a controller; set force to evict it"
+	}
+	if !deleteEmptyDirData {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				return "", fmt.Sprintf("uses emptyDir volume %s; set deleteEmptyDirData to evict it", volume.Name)
+			}
+		}
+	}
+	return "", ""
+}
+
+// evictPods evicts pods until they are gone or the timeout expires, retrying evictions refused
+// by a PodDisruptionBudget. It returns the pods still on the node keyed by namespace/name, with
+// the reason they remain.
+func (h *Handler) evictPods(ctx context.Context, client kubernetes.Interface, pods []corev1.Pod, gracePeriod *int64, timeout time.Duration) (map[string]string, error) {
+	remaining := map[string]string{}
+	for _, pod := range pods {
+		remaining[pod.Namespace+"/"+pod.Name] = "eviction not attempted"
+	}
+	evicted := map[string]bool{}
+
+	err := wait.PollUntilContextTimeout(ctx, h.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, pod := range pods {
+			key := pod.Namespace + "/" + pod.Name
+			if _, ok := remaining[key]; !ok {
+				continue
+			}
+
+			if !evicted[key] {
+				err := client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+					ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+					DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: gracePeriod},
+				})
+				switch {
+				case err == nil:
+					evicted[key] = true
+				case apierrors.IsNotFound(err):
+					delete(remaining, key)
+					continue
+				case apierrors.IsTooManyRequests(err):
+					remaining[key] = err.Error()
+					continue
+				default:
+					return false, fmt.Errorf("failed to evict pod %s: %w", key, err)
+				}
+			}
+
+			current, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID):
+				delete(remaining, key)
+			case err != nil:
+				return false, fmt.Errorf("failed to get pod %s: %w", key, err)
+			default:
+				remaining[key] = "terminating"
+			}
+		}
+		return len(remaining) == 0, nil
+	})
+	return remaining, err
+}
+
+// TaintChange is the outcome of adding or removing a node taint
+type TaintChange struct {
+	Node    string         `json:"node"`
+	Changed bool           `json:"changed"`
+	Taints  []corev1.Taint `json:"taints"`
+}
+
+// Taint creates a tool to add or update a taint on a node
+func (h *Handler) Taint() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("taint_node",
+			mcp.WithDescription(h.t("TOOL_TAINT_NODE_DESCRIPTION", "Add a taint to a node, like kubectl taint. A taint with the same key and effect is updated to the new value; calling again with the same taint changes nothing")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Node name"),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("Taint key"),
+			),
+			mcp.WithString("value",
+				mcp.Description("Taint value"),
+			),
+			mcp.WithString("effect",
+				mcp.Required(),
+				mcp.Description("Taint effect"),
+				mcp.Enum(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := toolsets.RequiredParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := toolsets.OptionalParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			effect, err := toolsets.RequiredParam[string](request, "effect")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taint := corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)}
+			if err := validateTaint(taint); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return h.updateTaints(ctx, name, func(taints []corev1.Taint) ([]corev1.Taint, bool) {
+				for i, existing := range taints {
+					if existing.MatchTaint(&taint) {
+						if existing.Value == taint.Value {
+							return taints, false
+						}
+						taints[i].Value = taint.Value
+						return taints, true
+					}
+				}
+				return append(taints, taint), true
+			})
+		}
+}
+
+// Untaint creates a tool to remove taints from a node
+func (h *Handler) Untaint() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("untaint_node",
+			mcp.WithDescription(h.t("TOOL_UNTAINT_NODE_DESCRIPTION", "Remove taints from a node, like kubectl taint with a trailing dash. Removing a taint the node does not have changes nothing")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Node name"),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("Taint key"),
+			),
+			mcp.WithString("effect",
+				mcp.Description("Only remove the taint with this effect (default: all taints with the key)"),
+				mcp.Enum(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := toolsets.RequiredParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			effect, err := toolsets.OptionalParam[string](request, "effect")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return h.updateTaints(ctx, name, func(taints []corev1.Taint) ([]corev1.Taint, bool) {
+				kept := []corev1.Taint{}
+				for _, existing := range taints {
+					if existing.Key == key && (effect == "" || string(existing.Effect) == effect) {
+						continue
+					}
+					kept = append(kept, existing)
+				}
+				return kept, len(kept) != len(taints)
+			})
+		}
+}
+
+// updateTaints applies change to the taints of a node, retrying on conflicting updates. The node
+// is only updated when change reports a difference.
+func (h *Handler) updateTaints(ctx context.Context, name string, change func([]corev1.Taint) ([]corev1.Taint, bool)) (*mcp.CallToolResult, error) {
+	client, err := h.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
+
+	result := TaintChange{Node: name}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get node: %w", err)
+		}
+		taints, changed := change(node.Spec.Taints)
+		result.Changed = changed
+		result.Taints = taints
+		if !changed {
+			return nil
+		}
+		node.Spec.Taints = taints
+		updated, err := client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update node: %w", err)
+		}
+		result.Taints = updated.Spec.Taints
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if result.Taints == nil {
+		result.Taints = []corev1.Taint{}
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// validateTaint checks a taint key and value with the rules the API server applies
+func validateTaint(taint corev1.Taint) error {
+	if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+		return fmt.Errorf("invalid taint key %q: %s", taint.Key, strings.Join(errs, "; "))
+	}
+	if taint.Value != "" {
+		if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid taint value %q: %s", taint.Value, strings.Join(errs, "; "))
+		}
+	}
+	switch taint.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		return nil
+	}
+	return fmt.Errorf("invalid taint effect %q: must be NoSchedule, PreferNoSchedule or NoExecute", taint.Effect)
+}
+
+// CertificateInfo describes a kubelet certificate issued through a CertificateSigningRequest
+type CertificateInfo struct {
+	CSR           string    `json:"csr"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	Expired       bool      `json:"expired"`
+}
+
+// KubeletCertificateStatus is the certificate health of a single node's kubelet
+type KubeletCertificateStatus struct {
+	Node         string           `json:"node"`
+	Ready        bool             `json:"ready"`
+	ReadyMessage string           `json:"readyMessage,omitempty"`
+	Serving      *CertificateInfo `json:"serving,omitempty"`
+	Client       *CertificateInfo `json:"client,omitempty"`
+	PendingCSRs  []string         `json:"pendingCSRs,omitempty"`
+	Warnings     []string         `json:"warnings,omitempty"`
+}
+
+// CheckKubeletCertificates creates a tool that inspects kubelet certificate expiry and rotation
+func (h *Handler) CheckKubeletCertificates() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_kubelet_certificates",
+			mcp.WithDescription(h.t("TOOL_CHECK_KUBELET_CERTIFICATES_DESCRIPTION", "Inspect kubelet serving and client certificate expiry using CertificateSigningRequests and node status, flagging nodes with imminent expiry or stuck rotation. Issued CSRs are garbage-collected by the cluster, so certificates may be unknown for nodes that rotated long ago")),
+			mcp.WithString("name",
+				mcp.Description("Only check this node"),
+			),
+			mcp.WithNumber("warningDays",
+				mcp.Description("Flag certificates expiring within this many days (default: 30)"),
+			),
+			mcp.WithBoolean("onlyWarnings",
+				mcp.Description("Only return nodes that have warnings"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			warningDays, err := toolsets.OptionalParam[float64](request, "warningDays")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if warningDays <= 0 {
+				warningDays = defaultCertWarningDays
+			}
+			onlyWarnings, err := toolsets.OptionalParam[bool](request, "onlyWarnings")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			var nodes []corev1.Node
+			if name != "" {
+				node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get node: %v", err)), nil
+				}
+				nodes = []corev1.Node{*node}
+			} else {
+				list, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list nodes: %v", err)), nil
+				}
+				nodes = list.Items
+			}
+
+			csrs, err := client.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list certificate signing requests: %v", err)), nil
+			}
+
+			statuses := kubeletCertificateStatuses(nodes, csrs.Items, time.Now(), int(warningDays))
+			if onlyWarnings {
+				filtered := []KubeletCertificateStatus{}
+				for _, status := range statuses {
+					if len(status.Warnings) > 0 {
+						filtered = append(filtered, status)
+					}
+				}
+				statuses = filtered
+			}
+
+			r, err := json.Marshal(statuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// kubeletCertificateStatuses matches kubelet CSRs to nodes and evaluates the newest certificate of each kind
+func kubeletCertificateStatuses(nodes []corev1.Node, csrs []certificatesv1.CertificateSigningRequest, now time.Time, warningDays int) []KubeletCertificateStatus {
+	byNode := map[string][]certificatesv1.CertificateSigningRequest{}
+	for _, csr := range csrs {
+		if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) {
+			continue
+		}
+		nodeName := strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix)
+		byNode[nodeName] = append(byNode[nodeName], csr)
+	}
+
+	statuses := make([]KubeletCertificateStatus, 0, len(nodes))
+	for _, node := range nodes {
+		status := KubeletCertificateStatus{Node: node.Name}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady {
+				status.Ready = cond.Status == corev1.ConditionTrue
+				if !status.Ready {
+					status.ReadyMessage = cond.Message
+				}
+			}
+		}
+
+		for _, csr := range byNode[node.Name] {
+			if isPending(csr) {
+				status.PendingCSRs = append(status.PendingCSRs, csr.Name)
+				continue
+			}
+			info := issuedCertificate(csr, now)
+			if info == nil {
+				continue
+			}
+			switch csr.Spec.SignerName {
+			case certificatesv1.KubeletServingSignerName:
+				if status.Serving == nil || info.NotAfter.After(status.Serving.NotAfter) {
+					status.Serving = info
+				}
+			case certificatesv1.KubeAPIServerClientKubeletSignerName:
+				if status.Client == nil || info.NotAfter.After(status.Client.NotAfter) {
+					status.Client = info
+				}
+			}
+		}
+		sort.Strings(status.PendingCSRs)
+
+		status.Warnings = certificateWarnings(status, warningDays)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func certificateWarnings(status KubeletCertificateStatus, warningDays int) []string {
+	var warnings []string
+	for _, cert := range []struct {
+		kind string
+		info *CertificateInfo
+	}{{"serving", status.Serving}, {"client", status.Client}} {
+		switch {
+		case cert.info == nil:
+			continue
+		case cert.info.Expired:
+			warnings = append(warnings, fmt.Sprintf("%s certificate expired on %s", cert.kind, cert.info.NotAfter.Format(time.RFC3339)))
+		case cert.info.DaysRemaining < warningDays:
+			warnings = append(warnings, fmt.Sprintf("%s certificate expires in %d days", cert.kind, cert.info.DaysRemaining))
+		}
+	}
+	if len(status.PendingCSRs) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d certificate signing request(s) awaiting approval; rotation may be stuck", len(status.PendingCSRs)))
+	}
+	if !status.Ready && strings.Contains(strings.ToLower(status.ReadyMessage), "certificate") {
+		warnings = append(warnings, "node is NotReady with a certificate-related message")
+	}
+	return warnings
+}
+
+func isPending(csr certificatesv1.CertificateSigningRequest) bool {
+	for _, cond := range csr.Status.Conditions {
+		if cond.Type == certificatesv1.CertificateApproved || cond.Type == certificatesv1.CertificateDenied || cond.Type == certificatesv1.CertificateFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// issuedCertificate parses the leaf certificate issued for a CSR, if any
+func issuedCertificate(csr certificatesv1.CertificateSigningRequest, now time.Time) *CertificateInfo {
+	block, _ := pem.Decode(csr.Status.Certificate)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return &CertificateInfo{
+		CSR:           csr.Name,
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: int(cert.NotAfter.Sub(now).Hours() / 24),
+		Expired:       now.After(cert.NotAfter),
+	}
+}
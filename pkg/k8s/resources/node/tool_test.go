@@ -2,18 +2,33 @@ package node
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 )
 
 // Helper function to get text result from tool response
@@ -34,13 +49,7 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -287,3 +296,494 @@ func TestListNodes(t *testing.T) {
 		})
 	}
 }
+
+// Helper function to create a PEM encoded self-signed certificate expiring at notAfter
+func testCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "system:node:test"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func kubeletCSR(name, node, signer string, approved bool, cert []byte) *certificatesv1.CertificateSigningRequest {
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Username:   "system:node:" + node,
+			SignerName: signer,
+		},
+		Status: certificatesv1.CertificateSigningRequestStatus{Certificate: cert},
+	}
+	if approved {
+		csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{{Type: certificatesv1.CertificateApproved, Status: corev1.ConditionTrue}}
+	}
+	return csr
+}
+
+func TestListNodesNotReady(t *testing.T) {
+	newNode := func(name string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NodeStatus{Conditions: conditions}}
+	}
+	client := fake.NewSimpleClientset(
+		newNode("node-c", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}),
+		newNode("node-b", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown}),
+		newNode("node-a"),
+	)
+	_, handlerFn := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper).List()
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"notReady": true, "sortBy": "name"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var nodes corev1.NodeList
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &nodes))
+	require.Len(t, nodes.Items, 2)
+	assert.Equal(t, "node-a", nodes.Items[0].Name)
+	assert.Equal(t, "node-b", nodes.Items[1].Name)
+}
+
+func TestCheckKubeletCertificates(t *testing.T) {
+	now := time.Now()
+	readyNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		readyNode("healthy"),
+		readyNode("expiring"),
+		kubeletCSR("csr-healthy-serving", "healthy", certificatesv1.KubeletServingSignerName, true, testCertificate(t, now.Add(200*24*time.Hour))),
+		kubeletCSR("csr-healthy-client", "healthy", certificatesv1.KubeAPIServerClientKubeletSignerName, true, testCertificate(t, now.Add(300*24*time.Hour))),
+		kubeletCSR("csr-expiring-old", "expiring", certificatesv1.KubeletServingSignerName, true, testCertificate(t, now.Add(-24*time.Hour))),
+		kubeletCSR("csr-expiring-serving", "expiring", certificatesv1.KubeletServingSignerName, true, testCertificate(t, now.Add(3*24*time.Hour+time.Hour))),
+		kubeletCSR("csr-expiring-pending", "expiring", certificatesv1.KubeletServingSignerName, false, nil),
+	)
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.CheckKubeletCertificates()
+
+	assert.Equal(t, "check_kubelet_certificates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	t.Run("all nodes", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var statuses []KubeletCertificateStatus
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+		require.Len(t, statuses, 2)
+
+		byNode := map[string]KubeletCertificateStatus{}
+		for _, s := range statuses {
+			byNode[s.Node] = s
+		}
+
+		healthy := byNode["healthy"]
+		require.NotNil(t, healthy.Serving)
+		require.NotNil(t, healthy.Client)
+		assert.Equal(t, "csr-healthy-client", healthy.Client.CSR)
+		assert.Empty(t, healthy.Warnings)
+
+		expiring := byNode["expiring"]
+		require.NotNil(t, expiring.Serving)
+		assert.Equal(t, "csr-expiring-serving", expiring.Serving.CSR, "newest certificate wins")
+		assert.Equal(t, 3, expiring.Serving.DaysRemaining)
+		assert.Equal(t, []string{"csr-expiring-pending"}, expiring.PendingCSRs)
+		assert.Len(t, expiring.Warnings, 2)
+	})
+
+	t.Run("only warnings with custom threshold", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"onlyWarnings": true, "warningDays": float64(250)}))
+		require.NoError(t, err)
+
+		var statuses []KubeletCertificateStatus
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+		assert.Len(t, statuses, 2, "healthy serving certificate is within 250 days")
+	})
+
+	t.Run("node not found", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "missing"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to get node")
+	})
+}
+
+func TestCordonAndUncordonNode(t *testing.T) {
+	schedulable := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	cordoned := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Spec: corev1.NodeSpec{Unschedulable: true}}
+
+	tests := []struct {
+		name                  string
+		cordon                bool
+		requestArgs           map[string]interface{}
+		expectedUnschedulable bool
+		expectedErrMsg        string
+	}{
+		{
+			name:                  "cordon schedulable node",
+			cordon:                true,
+			requestArgs:           map[string]interface{}{"name": "node-1"},
+			expectedUnschedulable: true,
+		},
+		{
+			name:           "cordon cordoned node",
+			cordon:         true,
+			requestArgs:    map[string]interface{}{"name": "node-2"},
+			expectedErrMsg: "node node-2 is already cordoned",
+		},
+		{
+			name:                  "uncordon cordoned node",
+			requestArgs:           map[string]interface{}{"name": "node-2"},
+			expectedUnschedulable: false,
+		},
+		{
+			name:           "uncordon schedulable node",
+			requestArgs:    map[string]interface{}{"name": "node-1"},
+			expectedErrMsg: "node node-1 is already uncordoned",
+		},
+		{
+			name:           "node not found",
+			cordon:         true,
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to get node",
+		},
+		{
+			name:           "missing required param: name",
+			cordon:         true,
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(schedulable.DeepCopy(), cordoned.DeepCopy())
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Uncordon()
+			if tc.cordon {
+				tool, handlerFn = handler.Cordon()
+				assert.Equal(t, "cordon_node", tool.Name)
+			} else {
+				assert.Equal(t, "uncordon_node", tool.Name)
+			}
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var node corev1.Node
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &node))
+			assert.Equal(t, tc.expectedUnschedulable, node.Spec.Unschedulable)
+
+			stored, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUnschedulable, stored.Spec.Unschedulable)
+		})
+	}
+}
+
+func drainTestPod(name string, owner *metav1.OwnerReference, mutate func(*corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", UID: types.UID(name)},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+func TestDrainNode(t *testing.T) {
+	replicaSet := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "web-5d8f", Controller: ptr.To(true)}
+	statefulSet := &metav1.OwnerReference{Kind: "StatefulSet", Name: "db", Controller: ptr.To(true)}
+	daemonSet := &metav1.OwnerReference{Kind: "DaemonSet", Name: "fluent-bit", Controller: ptr.To(true)}
+	withEmptyDir := func(pod *corev1.Pod) {
+		pod.Spec.Volumes = []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	}
+	mirror := func(pod *corev1.Pod) {
+		pod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+	}
+	pdbBlocked := "Cannot evict pod as it would violate the pod's disruption budget."
+
+	tests := []struct {
+		name                  string
+		pods                  []*corev1.Pod
+		blockedPods           []string
+		requestArgs           map[string]interface{}
+		expectedResult        DrainResult
+		expectedUnschedulable bool
+		expectedErrMsg        string
+	}{
+		{
+			name: "evicts controller managed pods and skips daemonset and mirror pods",
+			pods: []*corev1.Pod{
+				drainTestPod("web-1", replicaSet, nil),
+				drainTestPod("web-2", replicaSet, nil),
+				drainTestPod("fluent-bit-x", daemonSet, nil),
+				drainTestPod("kube-proxy-node-1", nil, mirror),
+			},
+			requestArgs: map[string]interface{}{"name": "node-1"},
+			expectedResult: DrainResult{
+				Node:    "node-1",
+				Evicted: []string{"shop/web-1", "shop/web-2"},
+				Skipped: []DrainPod{
+					{Pod: "shop/fluent-bit-x", Reason: "managed by DaemonSet fluent-bit"},
+					{Pod: "shop/kube-proxy-node-1", Reason: "mirror pod"},
+				},
+			},
+			expectedUnschedulable: true,
+		},
+		{
+			name: "eviction blocked by disruption budget times out",
+			pods: []*corev1.Pod{
+				drainTestPod("web-1", replicaSet, nil),
+				drainTestPod("db-0", statefulSet, nil),
+			},
+			blockedPods: []string{"db-0"},
+			requestArgs: map[string]interface{}{"name": "node-1", "timeoutSeconds": float64(0.05)},
+			expectedResult: DrainResult{
+				Node:      "node-1",
+				Evicted:   []string{"shop/web-1"},
+				Remaining: []DrainPod{{Pod: "shop/db-0", Reason: pdbBlocked}},
+				TimedOut:  true,
+			},
+			expectedUnschedulable: true,
+		},
+		{
+			name: "unmanaged and emptyDir pods are evicted when allowed",
+			pods: []*corev1.Pod{
+				drainTestPod("debug", nil, nil),
+				drainTestPod("web-1", replicaSet, withEmptyDir),
+			},
+			requestArgs: map[string]interface{}{"name": "node-1", "force": true, "deleteEmptyDirData": true, "gracePeriodSeconds": float64(0)},
+			expectedResult: DrainResult{
+				Node:    "node-1",
+				Evicted: []string{"shop/debug", "shop/web-1"},
+			},
+			expectedUnschedulable: true,
+		},
+		{
+			name: "unmanaged and emptyDir pods block the drain",
+			pods: []*corev1.Pod{
+				drainTestPod("debug", nil, nil),
+				drainTestPod("web-1", replicaSet, withEmptyDir),
+			},
+			requestArgs:    map[string]interface{}{"name": "node-1"},
+			expectedErrMsg: "cannot drain node node-1: shop/debug is not managed by a controller; set force to evict it; shop/web-1 uses emptyDir volume cache; set deleteEmptyDirData to evict it",
+		},
+		{
+			name: "completed unmanaged pods do not block the drain",
+			pods: []*corev1.Pod{
+				drainTestPod("job-run", nil, func(pod *corev1.Pod) { pod.Status.Phase = corev1.PodSucceeded }),
+			},
+			requestArgs:           map[string]interface{}{"name": "node-1"},
+			expectedResult:        DrainResult{Node: "node-1", Evicted: []string{"shop/job-run"}},
+			expectedUnschedulable: true,
+		},
+		{
+			name:           "negative grace period",
+			requestArgs:    map[string]interface{}{"name": "node-1", "gracePeriodSeconds": float64(-1)},
+			expectedErrMsg: "gracePeriodSeconds must not be negative",
+		},
+		{
+			name:           "timeout too long",
+			requestArgs:    map[string]interface{}{"name": "node-1", "timeoutSeconds": float64(7200)},
+			expectedErrMsg: "timeoutSeconds must be between 1 and 1800",
+		},
+		{
+			name:           "node not found",
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to get node",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := []runtime.Object{
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+			}
+			for _, pod := range tc.pods {
+				objects = append(objects, pod)
+			}
+			client := fake.NewSimpleClientset(objects...)
+			// The fake clientset does not implement evictions, delete the pod unless it is blocked
+			client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+				for _, blocked := range tc.blockedPods {
+					if eviction.Name == blocked {
+						return true, nil, apierrors.NewTooManyRequests(pdbBlocked, 10)
+					}
+				}
+				return true, nil, client.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+			})
+
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			handler.pollInterval = 10 * time.Millisecond
+			tool, handlerFn := handler.Drain()
+			assert.Equal(t, "drain_node", tool.Name)
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				node, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.False(t, node.Spec.Unschedulable, "a refused drain must not cordon the node")
+				return
+			}
+
+			require.False(t, result.IsError)
+			var drainResult DrainResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &drainResult))
+			assert.Equal(t, tc.expectedResult, drainResult)
+
+			node, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUnschedulable, node.Spec.Unschedulable)
+		})
+	}
+}
+
+func TestTaintAndUntaintNode(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+		}},
+	}
+	noSchedule := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	noExecute := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute}
+
+	tests := []struct {
+		name           string
+		untaint        bool
+		requestArgs    map[string]interface{}
+		expectedResult TaintChange
+		expectedErrMsg string
+	}{
+		{
+			name:        "add taint",
+			requestArgs: map[string]interface{}{"name": "node-1", "key": "maintenance", "effect": "NoSchedule"},
+			expectedResult: TaintChange{Node: "node-1", Changed: true, Taints: []corev1.Taint{
+				noSchedule, noExecute, {Key: "maintenance", Effect: corev1.TaintEffectNoSchedule},
+			}},
+		},
+		{
+			name:           "existing taint is unchanged",
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "dedicated", "value": "gpu", "effect": "NoSchedule"},
+			expectedResult: TaintChange{Node: "node-1", Taints: []corev1.Taint{noSchedule, noExecute}},
+		},
+		{
+			name:        "taint with new value is updated",
+			requestArgs: map[string]interface{}{"name": "node-1", "key": "dedicated", "value": "tpu", "effect": "NoSchedule"},
+			expectedResult: TaintChange{Node: "node-1", Changed: true, Taints: []corev1.Taint{
+				{Key: "dedicated", Value: "tpu", Effect: corev1.TaintEffectNoSchedule}, noExecute,
+			}},
+		},
+		{
+			name:           "invalid taint key",
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "bad key", "effect": "NoSchedule"},
+			expectedErrMsg: `invalid taint key "bad key"`,
+		},
+		{
+			name:           "invalid taint effect",
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "dedicated", "effect": "Evict"},
+			expectedErrMsg: `invalid taint effect "Evict"`,
+		},
+		{
+			name:           "remove taint by key and effect",
+			untaint:        true,
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "dedicated", "effect": "NoExecute"},
+			expectedResult: TaintChange{Node: "node-1", Changed: true, Taints: []corev1.Taint{noSchedule}},
+		},
+		{
+			name:           "remove all taints with key",
+			untaint:        true,
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "dedicated"},
+			expectedResult: TaintChange{Node: "node-1", Changed: true, Taints: []corev1.Taint{}},
+		},
+		{
+			name:           "remove missing taint is unchanged",
+			untaint:        true,
+			requestArgs:    map[string]interface{}{"name": "node-1", "key": "maintenance"},
+			expectedResult: TaintChange{Node: "node-1", Taints: []corev1.Taint{noSchedule, noExecute}},
+		},
+		{
+			name:           "node not found",
+			untaint:        true,
+			requestArgs:    map[string]interface{}{"name": "missing", "key": "dedicated"},
+			expectedErrMsg: "failed to get node",
+		},
+		{
+			name:           "missing required param: key",
+			requestArgs:    map[string]interface{}{"name": "node-1", "effect": "NoSchedule"},
+			expectedErrMsg: "missing required parameter: key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(node.DeepCopy())
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Taint()
+			if tc.untaint {
+				tool, handlerFn = handler.Untaint()
+				assert.Equal(t, "untaint_node", tool.Name)
+			} else {
+				assert.Equal(t, "taint_node", tool.Name)
+			}
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var change TaintChange
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &change))
+			assert.Equal(t, tc.expectedResult, change)
+
+			stored, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedResult.Taints, stored.Spec.Taints)
+		})
+	}
+}
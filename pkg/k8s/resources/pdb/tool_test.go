@@ -0,0 +1,218 @@
+package pdb
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func newTestPDB(name string, labels map[string]string) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			CurrentHealthy:     2,
+			DesiredHealthy:     1,
+			DisruptionsAllowed: 1,
+			ExpectedPods:       2,
+		},
+	}
+}
+
+func TestGetPDB(t *testing.T) {
+	testPDB := newTestPDB("test-pdb", map[string]string{"app": "test"})
+
+	// Verify tool definition
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(testPDB)), translations.NullTranslationHelper)
+	tool, _ := handler.Get()
+
+	assert.Equal(t, "get_pdb", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "namespace")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	tests := []struct {
+		name           string
+		client         kubernetes.Interface
+		requestArgs    map[string]interface{}
+		expectedPDB    *policyv1.PodDisruptionBudget
+		expectedErrMsg string
+	}{
+		{
+			name:   "successful pdb fetch",
+			client: fake.NewSimpleClientset(testPDB),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "test-pdb",
+			},
+			expectedPDB: testPDB,
+		},
+		{
+			name:   "pdb not found",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+				"name":      "non-existent-pdb",
+			},
+			expectedErrMsg: "failed to get pod disruption budget",
+		},
+		{
+			name:   "missing required param: name",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+			},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.Get()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the returned pdb
+			assert.False(t, result.IsError)
+			var returnedPDB policyv1.PodDisruptionBudget
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedPDB)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPDB.Name, returnedPDB.Name)
+			assert.Equal(t, tc.expectedPDB.Status.CurrentHealthy, returnedPDB.Status.CurrentHealthy)
+			assert.Equal(t, tc.expectedPDB.Status.DisruptionsAllowed, returnedPDB.Status.DisruptionsAllowed)
+		})
+	}
+}
+
+func TestListPDBs(t *testing.T) {
+	pdb1 := newTestPDB("test-pdb-1", map[string]string{"app": "test"})
+	pdb2 := newTestPDB("test-pdb-2", map[string]string{"app": "other"})
+
+	// Verify tool definition
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), translations.NullTranslationHelper)
+	tool, _ := handler.List()
+
+	assert.Equal(t, "list_pdbs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "fieldSelector")
+	assert.Contains(t, tool.InputSchema.Properties, "labelSelector")
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		client         kubernetes.Interface
+		requestArgs    map[string]interface{}
+		expectedNames  []string
+		expectedErrMsg string
+	}{
+		{
+			name:   "successful pdbs list",
+			client: fake.NewSimpleClientset(pdb1, pdb2),
+			requestArgs: map[string]interface{}{
+				"namespace": "default",
+			},
+			expectedNames: []string{"test-pdb-1", "test-pdb-2"},
+		},
+		{
+			name:   "with label selector",
+			client: fake.NewSimpleClientset(pdb1, pdb2),
+			requestArgs: map[string]interface{}{
+				"namespace":     "default",
+				"labelSelector": "app=other",
+			},
+			expectedNames: []string{"test-pdb-2"},
+		},
+		{
+			name:           "missing required param: namespace",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: namespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.List()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the returned pdb list
+			assert.False(t, result.IsError)
+			var returnedList policyv1.PodDisruptionBudgetList
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedList)
+			require.NoError(t, err)
+
+			var names []string
+			for _, pdb := range returnedList.Items {
+				names = append(names, pdb.Name)
+				assert.Equal(t, int32(1), pdb.Status.DisruptionsAllowed)
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
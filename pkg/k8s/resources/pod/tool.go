@@ -1,31 +1,201 @@
 package pod
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
+// DefaultContainerAnnotation names the container kubectl targets when none is given
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Limits for exec_in_pod
+const (
+	defaultExecTimeoutSeconds = 30
+	maxExecTimeoutSeconds     = 300
+	defaultExecOutputBytes    = 64 * 1024
+	maxExecOutputBytes        = 1024 * 1024
+)
+
+// Limits for pod_cp_from and pod_cp_to
+const (
+	defaultCopyBytes = 256 * 1024
+	maxCopyBytes     = 1024 * 1024
+)
+
+// Defaults for debug_pod, which keeps the debug container alive so commands can be run in it with
+// exec_in_pod
+const (
+	defaultDebugImage    = "busybox:1.36"
+	debugContainerPrefix = "debugger-"
+)
+
+var defaultDebugCommand = []string{"sleep", "3600"}
+
+// maxSummaryEvents is the number of most recent events included in a pod status summary
+const maxSummaryEvents = 10
+
+// Encodings of file content copied to and from pods
+const (
+	EncodingText   = "text"
+	EncodingBase64 = "base64"
+)
+
+// ExecutorFactory creates the executor streaming an exec request to the API server
+type ExecutorFactory func(config *rest.Config, u *url.URL) (remotecommand.Executor, error)
+
 // Handler implements the K8sResourceHandler interface for Pod resources
 type Handler struct {
-	getClient toolsets.GetClientFn
-	t         translations.TranslationHelperFunc
+	getClient     toolsets.GetClientFn
+	getRESTConfig toolsets.GetRESTConfigFn
+	newExecutor   ExecutorFactory
+	t             translations.TranslationHelperFunc
 }
 
 // NewHandler creates a new Pod resource handler
-func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+func NewHandler(getClient toolsets.GetClientFn, getRESTConfig toolsets.GetRESTConfigFn, t translations.TranslationHelperFunc) *Handler {
 	return &Handler{
-		getClient: getClient,
-		t:         t,
+		getClient:     getClient,
+		getRESTConfig: getRESTConfig,
+		newExecutor:   NewExecutor,
+		t:             t,
 	}
 }
 
+// NewExecutor creates an executor that uses the websocket exec protocol, falling back to SPDY
+// for API servers and proxies that do not support it, as kubectl does
+func NewExecutor(config *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(config, "POST", u)
+	if err != nil {
+		return nil, err
+	}
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(config, "GET", u.String())
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// ExecResult is the outcome of a command run with exec_in_pod
+type ExecResult struct {
+	Pod             string   `json:"pod"`
+	Container       string   `json:"container"`
+	Command         []string `json:"command"`
+	ExitCode        *int     `json:"exitCode,omitempty"`
+	TimedOut        bool     `json:"timedOut,omitempty"`
+	Stdout          string   `json:"stdout"`
+	Stderr          string   `json:"stderr"`
+	StdoutTruncated bool     `json:"stdoutTruncated,omitempty"`
+	StderrTruncated bool     `json:"stderrTruncated,omitempty"`
+}
+
+// FileContent is a file read from a container with pod_cp_from. Content is base64 encoded when
+// the file is not valid UTF-8.
+type FileContent struct {
+	Pod       string    `json:"pod"`
+	Container string    `json:"container"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	Mode      string    `json:"mode"`
+	ModTime   time.Time `json:"modTime"`
+	Encoding  string    `json:"encoding"`
+	Content   string    `json:"content"`
+}
+
+// FileCopy is a file written into a container with pod_cp_to
+type FileCopy struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+}
+
+// StatusSummary is a describe-style digest of a pod's status
+type StatusSummary struct {
+	Name        string             `json:"name"`
+	Namespace   string             `json:"namespace"`
+	Phase       corev1.PodPhase    `json:"phase"`
+	Reason      string             `json:"reason,omitempty"`
+	Message     string             `json:"message,omitempty"`
+	Node        string             `json:"node,omitempty"`
+	PodIP       string             `json:"podIP,omitempty"`
+	QOSClass    corev1.PodQOSClass `json:"qosClass,omitempty"`
+	StartTime   *metav1.Time       `json:"startTime,omitempty"`
+	Deleting    bool               `json:"deleting,omitempty"`
+	Conditions  []ConditionSummary `json:"conditions"`
+	Containers  []ContainerSummary `json:"containers"`
+	Tolerations []string           `json:"tolerations"`
+	Events      []EventSummary     `json:"events"`
+}
+
+// ContainerSummary is the state of a single init or app container
+type ContainerSummary struct {
+	Name            string              `json:"name"`
+	Image           string              `json:"image"`
+	Init            bool                `json:"init,omitempty"`
+	Ready           bool                `json:"ready"`
+	RestartCount    int32               `json:"restartCount"`
+	State           string              `json:"state"`
+	Reason          string              `json:"reason,omitempty"`
+	Message         string              `json:"message,omitempty"`
+	ExitCode        *int32              `json:"exitCode,omitempty"`
+	Since           *metav1.Time        `json:"since,omitempty"`
+	LastTermination *TerminationSummary `json:"lastTermination,omitempty"`
+}
+
+// TerminationSummary is how a container last terminated
+type TerminationSummary struct {
+	Reason     string      `json:"reason,omitempty"`
+	ExitCode   int32       `json:"exitCode"`
+	FinishedAt metav1.Time `json:"finishedAt"`
+}
+
+// ConditionSummary is a pod condition
+type ConditionSummary struct {
+	Type    corev1.PodConditionType `json:"type"`
+	Status  corev1.ConditionStatus  `json:"status"`
+	Reason  string                  `json:"reason,omitempty"`
+	Message string                  `json:"message,omitempty"`
+}
+
+// EventSummary is an event recorded for a pod
+type EventSummary struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count,omitempty"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
 // RegisterTools registers all Pod resource tools with the provided toolset
 func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 	// Register read tools
@@ -35,9 +205,37 @@ func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
 
+	summaryTool, summaryHandler := h.StatusSummary()
+	toolset.AddReadTool(summaryTool, summaryHandler)
+
 	// Register write tools
 	deleteTool, deleteHandler := h.Delete()
 	toolset.AddWriteTool(deleteTool, deleteHandler)
+
+	execTool, execHandler := h.Exec()
+	toolset.AddWriteTool(execTool, execHandler)
+
+	// Reading a file runs tar in the container, so it is gated like exec_in_pod
+	copyFromTool, copyFromHandler := h.CopyFrom()
+	toolset.AddWriteTool(copyFromTool, copyFromHandler)
+
+	copyToTool, copyToHandler := h.CopyTo()
+	toolset.AddWriteTool(copyToTool, copyToHandler)
+
+	debugTool, debugHandler := h.Debug()
+	toolset.AddWriteTool(debugTool, debugHandler)
+}
+
+// RegisterResources exposes pods as MCP resources, read with get_pod and list_pods
+func (h *Handler) RegisterResources(resourceSet *toolsets.ResourceSet) {
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/pods/{name}", "pod",
+		mcp.WithTemplateDescription("A pod of a namespace"),
+		mcp.WithTemplateMIMEType("application/json"),
+	), "get_pod")
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/pods", "pods",
+		mcp.WithTemplateDescription("Summaries of the pods of a namespace"),
+		mcp.WithTemplateMIMEType("application/json"),
+	), "list_pods")
 }
 
 // Get creates a tool to get details of a specific pod
@@ -85,20 +283,26 @@ func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 // List creates a tool to list pods in a namespace
 func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_pods",
-			mcp.WithDescription(h.t("TOOL_LIST_PODS_DESCRIPTION", "List pods in a namespace")),
-			mcp.WithString("namespace",
-				mcp.Required(),
-				mcp.Description("Kubernetes namespace"),
-			),
+			mcp.WithDescription(h.t("TOOL_LIST_PODS_DESCRIPTION", "List pods in a namespace or across all namespaces")),
+			toolsets.WithListNamespace(),
 			mcp.WithString("fieldSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their fields"),
 			),
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			mcp.WithString("phase",
+				mcp.Description("Only return pods in this phase"),
+				mcp.Enum(string(corev1.PodPending), string(corev1.PodRunning), string(corev1.PodSucceeded), string(corev1.PodFailed), string(corev1.PodUnknown)),
+			),
+			mcp.WithBoolean("notReady",
+				mcp.Description("Only return pods that have not completed and are not ready, such as crash-looping or unschedulable pods"),
+			),
+			toolsets.WithSortBy(podSortKeys, ", restarts most first"),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			namespace, err := toolsets.ListNamespace(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -113,6 +317,16 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			phase, err := toolsets.OptionalParam[string](request, "phase")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			notReady, err := toolsets.OptionalParam[bool](request, "notReady")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := h.getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
@@ -122,13 +336,99 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			pods, err := client.CoreV1().Pods(namespace).List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
 			}
 
-			r, err := json.Marshal(pods)
+			items := pods.Items[:0]
+			for _, pod := range pods.Items {
+				if (phase == "" || string(pod.Status.Phase) == phase) && (!notReady || isNotReady(&pod)) {
+					items = append(items, pod)
+				}
+			}
+			if err := toolsets.SortItems(request, items, podSortKeys); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return toolsets.NewListPageResult(items, pods.ListMeta)
+		}
+}
+
+// podSortKeys are the sort keys of list_pods besides name and age
+var podSortKeys = toolsets.SortKeys[corev1.Pod]{
+	"restarts": func(a, b *corev1.Pod) bool { return restarts(a) > restarts(b) },
+}
+
+// restarts returns the number of container restarts of a pod
+func restarts(pod *corev1.Pod) int32 {
+	var count int32
+	for _, status := range pod.Status.InitContainerStatuses {
+		count += status.RestartCount
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		count += status.RestartCount
+	}
+	return count
+}
+
+// isNotReady reports whether a pod that has not completed lacks a true Ready condition
+func isNotReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status != corev1.ConditionTrue
+		}
+	}
+	return true
+}
+
+// StatusSummary creates a tool to get a describe-style digest of a pod
+func (h *Handler) StatusSummary() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pod_status_summary",
+			mcp.WithDescription(h.t("TOOL_GET_POD_STATUS_SUMMARY_DESCRIPTION", "Get a kubectl describe-like digest of a pod: phase, node assignment, conditions, container states with reasons, exit codes and restart counts, tolerations and the most recent events. Prefer this over get_pod when troubleshooting")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+
+			selector := fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": name}.AsSelector().String()
+			events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list events: %v", err)), nil
+			}
+
+			r, err := json.Marshal(summarizePod(pod, events.Items))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -173,3 +473,811 @@ func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 			return mcp.NewToolResultText(fmt.Sprintf("Pod %s in namespace %s deleted", name, namespace)), nil
 		}
 }
+
+// Exec creates a tool to run a command in a pod container
+func (h *Handler) Exec() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("exec_in_pod",
+			mcp.WithDescription(h.t("TOOL_EXEC_IN_POD_DESCRIPTION", "Run a command in a pod container, like kubectl exec without a TTY, and return its exit code, stdout and stderr. The command is run directly, not through a shell; use [\"sh\", \"-c\", \"...\"] for pipes and redirects")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Container name (defaults to the kubectl.kubernetes.io/default-container annotation or the first container)"),
+			),
+			mcp.WithArray("command",
+				mcp.Required(),
+				mcp.Description("Command and arguments to run"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait for the command to finish (default %d, max %d)", defaultExecTimeoutSeconds, maxExecTimeoutSeconds)),
+			),
+			mcp.WithNumber("maxOutputBytes",
+				mcp.Description(fmt.Sprintf("Maximum bytes kept from each of stdout and stderr (default %d, max %d)", defaultExecOutputBytes, maxExecOutputBytes)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rawCommand, err := toolsets.OptionalParam[[]interface{}](request, "command")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(rawCommand) == 0 {
+				return mcp.NewToolResultError("missing required parameter: command"), nil
+			}
+			command := make([]string, 0, len(rawCommand))
+			for _, arg := range rawCommand {
+				s, ok := arg.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("command arguments must be strings, got %T", arg)), nil
+				}
+				command = append(command, s)
+			}
+			timeoutSeconds, err := toolsets.OptionalParam[float64](request, "timeoutSeconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds == 0 {
+				timeoutSeconds = defaultExecTimeoutSeconds
+			}
+			if timeoutSeconds < 0 || timeoutSeconds > maxExecTimeoutSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("timeoutSeconds must be between 1 and %d", maxExecTimeoutSeconds)), nil
+			}
+			maxOutputBytes, err := toolsets.OptionalParam[float64](request, "maxOutputBytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxOutputBytes == 0 {
+				maxOutputBytes = defaultExecOutputBytes
+			}
+			if maxOutputBytes < 0 || maxOutputBytes > maxExecOutputBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("maxOutputBytes must be between 1 and %d", maxExecOutputBytes)), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+			config, err := h.getRESTConfig(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes REST config: %w", err)
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			container, err = execContainer(pod, container)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			executor, err := h.podExecutor(config, namespace, name, &corev1.PodExecOptions{
+				Container: container,
+				Command:   command,
+				Stdout:    true,
+				Stderr:    true,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			execCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds*float64(time.Second)))
+			defer cancel()
+			stdout := &cappedBuffer{max: int(maxOutputBytes)}
+			stderr := &cappedBuffer{max: int(maxOutputBytes)}
+			err = executor.StreamWithContext(execCtx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+
+			result := ExecResult{
+				Pod:             name,
+				Container:       container,
+				Command:         command,
+				Stdout:          stdout.String(),
+				Stderr:          stderr.String(),
+				StdoutTruncated: stdout.truncated,
+				StderrTruncated: stderr.truncated,
+			}
+			var exitErr utilexec.ExitError
+			switch {
+			case err == nil:
+				exitCode := 0
+				result.ExitCode = &exitCode
+			case errors.As(err, &exitErr):
+				exitCode := exitErr.ExitStatus()
+				result.ExitCode = &exitCode
+			case errors.Is(execCtx.Err(), context.DeadlineExceeded):
+				result.TimedOut = true
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("failed to exec in pod: %v", err)), nil
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CopyFrom creates a tool to read a file from a pod container
+func (h *Handler) CopyFrom() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("pod_cp_from",
+			mcp.WithDescription(h.t("TOOL_POD_CP_FROM_DESCRIPTION", "Read a small file from a pod container, like kubectl cp, for grabbing config or dump files while debugging. The container image must include tar. Text files are returned as-is, binary files base64 encoded")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Container name (defaults to the kubectl.kubernetes.io/default-container annotation or the first container)"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Absolute path of the file in the container"),
+			),
+			mcp.WithNumber("maxBytes",
+				mcp.Description(fmt.Sprintf("Maximum file size to read (default %d, max %d)", defaultCopyBytes, maxCopyBytes)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err := toolsets.RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err = containerFilePath(filePath)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := toolsets.OptionalParam[float64](request, "maxBytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxBytes == 0 {
+				maxBytes = defaultCopyBytes
+			}
+			if maxBytes < 0 || maxBytes > maxCopyBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("maxBytes must be between 1 and %d", maxCopyBytes)), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+			config, err := h.getRESTConfig(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes REST config: %w", err)
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			container, err = execContainer(pod, container)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			executor, err := h.podExecutor(config, namespace, name, &corev1.PodExecOptions{
+				Container: container,
+				Command:   []string{"tar", "cf", "-", filePath},
+				Stdout:    true,
+				Stderr:    true,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			// Read the archive while it streams, so an oversized file is rejected from its header
+			// without transferring it
+			execCtx, cancel := context.WithTimeout(ctx, defaultExecTimeoutSeconds*time.Second)
+			defer cancel()
+			reader, writer := io.Pipe()
+			stderr := &cappedBuffer{max: defaultExecOutputBytes}
+			streamErr := make(chan error, 1)
+			go func() {
+				err := executor.StreamWithContext(execCtx, remotecommand.StreamOptions{Stdout: writer, Stderr: stderr})
+				writer.CloseWithError(err)
+				streamErr <- err
+			}()
+			header, content, readErr := readArchivedFile(reader, filePath, int64(maxBytes))
+			cancel()
+			_ = reader.Close()
+			err = <-streamErr
+
+			var tooLarge *fileTooLargeError
+			switch {
+			case errors.As(readErr, &tooLarge):
+				return mcp.NewToolResultError(readErr.Error()), nil
+			case readErr != nil && err != nil:
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read file from pod: %s", execFailure(err, stderr))), nil
+			case readErr != nil:
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read file from pod: %v", readErr)), nil
+			}
+
+			result := FileContent{
+				Pod:       name,
+				Container: container,
+				Path:      filePath,
+				Size:      header.Size,
+				Mode:      fs.FileMode(header.Mode).Perm().String(),
+				ModTime:   header.ModTime.UTC(),
+				Encoding:  EncodingText,
+				Content:   string(content),
+			}
+			if !utf8.Valid(content) {
+				result.Encoding = EncodingBase64
+				result.Content = base64.StdEncoding.EncodeToString(content)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CopyTo creates a tool to write a file into a pod container
+func (h *Handler) CopyTo() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("pod_cp_to",
+			mcp.WithDescription(h.t("TOOL_POD_CP_TO_DESCRIPTION", "Write a small file into a pod container, like kubectl cp, replacing any existing file at the path. The container image must include tar and the parent directory must exist")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("container",
+				mcp.Description("Container name (defaults to the kubectl.kubernetes.io/default-container annotation or the first container)"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Absolute path of the file in the container"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("File content, at most %d bytes once decoded", maxCopyBytes)),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of content (default text)"),
+				mcp.Enum(EncodingText, EncodingBase64),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.OptionalParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err := toolsets.RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err = containerFilePath(filePath)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Empty content is allowed, it creates an empty file
+			if _, ok := request.GetArguments()["content"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: content"), nil
+			}
+			text, err := toolsets.OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := toolsets.OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var content []byte
+			switch encoding {
+			case "", EncodingText:
+				content = []byte(text)
+			case EncodingBase64:
+				content, err = base64.StdEncoding.DecodeString(text)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid base64 content: %v", err)), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid encoding %q: must be %s or %s", encoding, EncodingText, EncodingBase64)), nil
+			}
+			if len(content) > maxCopyBytes {
+				return mcp.NewToolResultError((&fileTooLargeError{path: filePath, size: int64(len(content)), limit: maxCopyBytes}).Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+			config, err := h.getRESTConfig(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes REST config: %w", err)
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			container, err = execContainer(pod, container)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			archive, err := archiveFile(path.Base(filePath), content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create archive: %w", err)
+			}
+			executor, err := h.podExecutor(config, namespace, name, &corev1.PodExecOptions{
+				Container: container,
+				Command:   []string{"tar", "xmf", "-", "-C", path.Dir(filePath)},
+				Stdin:     true,
+				Stdout:    true,
+				Stderr:    true,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			execCtx, cancel := context.WithTimeout(ctx, defaultExecTimeoutSeconds*time.Second)
+			defer cancel()
+			stdout := &cappedBuffer{max: defaultExecOutputBytes}
+			stderr := &cappedBuffer{max: defaultExecOutputBytes}
+			err = executor.StreamWithContext(execCtx, remotecommand.StreamOptions{Stdin: archive, Stdout: stdout, Stderr: stderr})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to write file to pod: %s", execFailure(err, stderr))), nil
+			}
+
+			r, err := json.Marshal(FileCopy{
+				Pod:       name,
+				Container: container,
+				Path:      filePath,
+				Size:      int64(len(content)),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Debug creates a tool to attach an ephemeral debug container to a running pod
+func (h *Handler) Debug() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("debug_pod",
+			mcp.WithDescription(h.t("TOOL_DEBUG_POD_DESCRIPTION", "Attach an ephemeral debug container to a running pod, like kubectl debug, sharing the process namespace of a target container. The container keeps running so commands can be run in it with exec_in_pod. Ephemeral containers cannot be removed; they stay until the pod is deleted")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Pod name"),
+			),
+			mcp.WithString("image",
+				mcp.Description(fmt.Sprintf("Image of the debug container (default %s)", defaultDebugImage)),
+			),
+			mcp.WithString("targetContainer",
+				mcp.Description("Container whose processes the debug container can see (defaults to the kubectl.kubernetes.io/default-container annotation or the first container)"),
+			),
+			mcp.WithString("containerName",
+				mcp.Description(fmt.Sprintf("Name of the debug container (defaults to %s followed by a random suffix)", debugContainerPrefix)),
+			),
+			mcp.WithArray("command",
+				mcp.Description(fmt.Sprintf("Command of the debug container (default %s)", strings.Join(defaultDebugCommand, " "))),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			image, err := toolsets.OptionalParam[string](request, "image")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if image == "" {
+				image = defaultDebugImage
+			}
+			target, err := toolsets.OptionalParam[string](request, "targetContainer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			containerName, err := toolsets.OptionalParam[string](request, "containerName")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if containerName == "" {
+				containerName = debugContainerPrefix + utilrand.String(5)
+			}
+			rawCommand, err := toolsets.OptionalParam[[]interface{}](request, "command")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			command := defaultDebugCommand
+			if len(rawCommand) > 0 {
+				command = make([]string, 0, len(rawCommand))
+				for _, arg := range rawCommand {
+					s, ok := arg.(string)
+					if !ok {
+						return mcp.NewToolResultError(fmt.Sprintf("command arguments must be strings, got %T", arg)), nil
+					}
+					command = append(command, s)
+				}
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pod: %v", err)), nil
+			}
+			if pod.Status.Phase != corev1.PodRunning {
+				return mcp.NewToolResultError(fmt.Sprintf("cannot debug pod %s: ephemeral containers can only be added to running pods; current phase is %s", name, pod.Status.Phase)), nil
+			}
+			if target == "" {
+				target = pod.Annotations[DefaultContainerAnnotation]
+			}
+			if target == "" && len(pod.Spec.Containers) > 0 {
+				target = pod.Spec.Containers[0].Name
+			}
+			var names []string
+			targetFound := false
+			for _, c := range pod.Spec.Containers {
+				names = append(names, c.Name)
+				targetFound = targetFound || c.Name == target
+			}
+			if !targetFound {
+				return mcp.NewToolResultError(fmt.Sprintf("target container %s not found in pod %s; available containers: %s", target, name, strings.Join(names, ", "))), nil
+			}
+			for _, c := range pod.Spec.InitContainers {
+				names = append(names, c.Name)
+			}
+			for _, c := range pod.Spec.EphemeralContainers {
+				names = append(names, c.Name)
+			}
+			for _, n := range names {
+				if n == containerName {
+					return mcp.NewToolResultError(fmt.Sprintf("pod %s already has a container named %s", name, containerName)), nil
+				}
+			}
+
+			pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+					Name:                     containerName,
+					Image:                    image,
+					Command:                  command,
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				TargetContainerName: target,
+			})
+			updated, err := client.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, name, pod, metav1.UpdateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add debug container: %v", err)), nil
+			}
+
+			return toolsets.NewToolResultJSON(updated)
+		}
+}
+
+// podExecutor creates an executor for an exec request against a pod
+func (h *Handler) podExecutor(config *rest.Config, namespace, name string, options *corev1.PodExecOptions) (remotecommand.Executor, error) {
+	coreClient, err := corev1client.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes REST client: %w", err)
+	}
+	execURL := coreClient.RESTClient().Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(name).
+		SubResource("exec").
+		VersionedParams(options, scheme.ParameterCodec).
+		URL()
+	executor, err := h.newExecutor(config, execURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create executor: %w", err)
+	}
+	return executor, nil
+}
+
+// execContainer resolves the container to exec into, validating that the pod is still running and
+// that the container exists in it
+func execContainer(pod *corev1.Pod, container string) (string, error) {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return "", fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
+	}
+	if container == "" {
+		container = pod.Annotations[DefaultContainerAnnotation]
+	}
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	var names []string
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return container, nil
+		}
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == container {
+			return container, nil
+		}
+		names = append(names, c.Name)
+	}
+	return "", fmt.Errorf("container %s not found in pod %s; available containers: %s", container, pod.Name, strings.Join(names, ", "))
+}
+
+// cappedBuffer keeps the first max bytes written to it and records whether more output was discarded
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	remaining := b.max - b.buf.Len()
+	if len(p) > remaining {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *cappedBuffer) String() string {
+	return b.buf.String()
+}
+
+// fileTooLargeError is returned when a copied file exceeds the size limit
+type fileTooLargeError struct {
+	path  string
+	size  int64
+	limit int64
+}
+
+func (e *fileTooLargeError) Error() string {
+	return fmt.Sprintf("file %s is %d bytes, larger than the %d byte limit", e.path, e.size, e.limit)
+}
+
+// containerFilePath validates that a file path in a container is absolute and names a file
+func containerFilePath(p string) (string, error) {
+	if !path.IsAbs(p) {
+		return "", fmt.Errorf("path must be absolute, got %q", p)
+	}
+	cleaned := path.Clean(p)
+	if cleaned == "/" || strings.HasSuffix(p, "/") {
+		return "", fmt.Errorf("path must name a file, got %q", p)
+	}
+	return cleaned, nil
+}
+
+// readArchivedFile reads the single file in a tar archive produced by tar cf - <path>, rejecting it
+// before reading its content when it is larger than limit
+func readArchivedFile(r io.Reader, filePath string, limit int64) (*tar.Header, []byte, error) {
+	archive := tar.NewReader(r)
+	header, err := archive.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("file %s not found", filePath)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	switch header.Typeflag {
+	case tar.TypeReg:
+	case tar.TypeDir:
+		return nil, nil, fmt.Errorf("%s is a directory", filePath)
+	case tar.TypeSymlink:
+		return nil, nil, fmt.Errorf("%s is a symbolic link to %s", filePath, header.Linkname)
+	default:
+		return nil, nil, fmt.Errorf("%s is not a regular file", filePath)
+	}
+	if header.Size > limit {
+		return nil, nil, &fileTooLargeError{path: filePath, size: header.Size, limit: limit}
+	}
+	content, err := io.ReadAll(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, content, nil
+}
+
+// archiveFile creates a tar archive holding a single file
+func archiveFile(name string, content []byte) (io.Reader, error) {
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	if err := archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := archive.Write(content); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// execFailure describes a failed exec, preferring the error output of the command
+func execFailure(err error, stderr *cappedBuffer) string {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return message
+	}
+	return err.Error()
+}
+
+// summarizePod builds the status summary of a pod from the pod and the events of its namespace
+func summarizePod(pod *corev1.Pod, events []corev1.Event) StatusSummary {
+	summary := StatusSummary{
+		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		Phase:       pod.Status.Phase,
+		Reason:      pod.Status.Reason,
+		Message:     pod.Status.Message,
+		Node:        pod.Spec.NodeName,
+		PodIP:       pod.Status.PodIP,
+		QOSClass:    pod.Status.QOSClass,
+		StartTime:   pod.Status.StartTime,
+		Deleting:    pod.DeletionTimestamp != nil,
+		Conditions:  make([]ConditionSummary, 0, len(pod.Status.Conditions)),
+		Containers:  make([]ContainerSummary, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)),
+		Tolerations: make([]string, 0, len(pod.Spec.Tolerations)),
+		Events:      []EventSummary{},
+	}
+
+	for _, c := range pod.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, ConditionSummary{Type: c.Type, Status: c.Status, Reason: c.Reason, Message: c.Message})
+	}
+	for _, c := range pod.Spec.InitContainers {
+		summary.Containers = append(summary.Containers, summarizeContainer(c, pod.Status.InitContainerStatuses, true))
+	}
+	for _, c := range pod.Spec.Containers {
+		summary.Containers = append(summary.Containers, summarizeContainer(c, pod.Status.ContainerStatuses, false))
+	}
+	for _, t := range pod.Spec.Tolerations {
+		summary.Tolerations = append(summary.Tolerations, formatToleration(t))
+	}
+
+	// The field selector is not supported by every API server cache, so match the pod again
+	for _, e := range events {
+		if e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != pod.Name || (e.InvolvedObject.UID != "" && e.InvolvedObject.UID != pod.UID) {
+			continue
+		}
+		summary.Events = append(summary.Events, EventSummary{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Message:  e.Message,
+			Count:    e.Count,
+			LastSeen: eventTime(e),
+		})
+	}
+	sort.SliceStable(summary.Events, func(i, j int) bool {
+		return summary.Events[i].LastSeen.After(summary.Events[j].LastSeen)
+	})
+	if len(summary.Events) > maxSummaryEvents {
+		summary.Events = summary.Events[:maxSummaryEvents]
+	}
+
+	return summary
+}
+
+// summarizeContainer merges the spec and status of a container
+func summarizeContainer(c corev1.Container, statuses []corev1.ContainerStatus, init bool) ContainerSummary {
+	summary := ContainerSummary{Name: c.Name, Image: c.Image, Init: init, State: "pending"}
+	for _, status := range statuses {
+		if status.Name != c.Name {
+			continue
+		}
+		summary.Ready = status.Ready
+		summary.RestartCount = status.RestartCount
+		switch state := status.State; {
+		case state.Running != nil:
+			summary.State = "running"
+			summary.Since = &state.Running.StartedAt
+		case state.Waiting != nil:
+			summary.State = "waiting"
+			summary.Reason = state.Waiting.Reason
+			summary.Message = state.Waiting.Message
+		case state.Terminated != nil:
+			summary.State = "terminated"
+			summary.Reason = state.Terminated.Reason
+			summary.Message = state.Terminated.Message
+			summary.ExitCode = &state.Terminated.ExitCode
+			summary.Since = &state.Terminated.FinishedAt
+		}
+		if last := status.LastTerminationState.Terminated; last != nil {
+			summary.LastTermination = &TerminationSummary{Reason: last.Reason, ExitCode: last.ExitCode, FinishedAt: last.FinishedAt}
+		}
+	}
+	return summary
+}
+
+// formatToleration formats a toleration like kubectl describe, e.g. "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+func formatToleration(t corev1.Toleration) string {
+	s := t.Key
+	if t.Value != "" {
+		s += "=" + t.Value
+	}
+	if t.Effect != "" {
+		s += ":" + string(t.Effect)
+	}
+	if t.Operator == corev1.TolerationOpExists && t.Value == "" {
+		s += " op=Exists"
+	}
+	if t.TolerationSeconds != nil {
+		s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return strings.TrimSpace(s)
+}
+
+// eventTime returns when an event was last seen
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	}
+	return e.CreationTimestamp.Time
+}
@@ -1,9 +1,16 @@
 package pod
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
@@ -12,8 +19,12 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 // Helper function to get text result from tool response
@@ -31,16 +42,17 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 	}
 }
 
+// Helper function to create a stub REST config
+func stubGetRESTConfigFn() toolsets.GetRESTConfigFn {
+	return func(ctx context.Context) (*rest.Config, error) {
+		return &rest.Config{Host: "https://cluster.example.com"}, nil
+	}
+}
+
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -77,7 +89,7 @@ func TestGetPod(t *testing.T) {
 
 	// Verify tool definition
 	fakeClient := fake.NewSimpleClientset(testPod)
-	handler := NewHandler(stubGetClientFn(fakeClient), translations.NullTranslationHelper)
+	handler := NewHandler(stubGetClientFn(fakeClient), stubGetRESTConfigFn(), translations.NullTranslationHelper)
 	tool, _ := handler.Get()
 
 	assert.Equal(t, "get_pod", tool.Name)
@@ -136,7 +148,7 @@ func TestGetPod(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			handler := NewHandler(stubGetClientFn(tc.client), stubGetRESTConfigFn(), translations.NullTranslationHelper)
 			_, handlerFn := handler.Get()
 			request := createMCPRequest(tc.requestArgs)
 			result, err := handlerFn(context.Background(), request)
@@ -206,9 +218,14 @@ func TestListPods(t *testing.T) {
 		},
 	}
 
+	otherNamespacePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop-pod", Namespace: "shop"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
 	// Verify tool definition
 	fakeClient := fake.NewSimpleClientset(&testPods.Items[0], &testPods.Items[1])
-	handler := NewHandler(stubGetClientFn(fakeClient), translations.NullTranslationHelper)
+	handler := NewHandler(stubGetClientFn(fakeClient), stubGetRESTConfigFn(), translations.NullTranslationHelper)
 	tool, _ := handler.List()
 
 	assert.Equal(t, "list_pods", tool.Name)
@@ -216,7 +233,8 @@ func TestListPods(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "namespace")
 	assert.Contains(t, tool.InputSchema.Properties, "fieldSelector")
 	assert.Contains(t, tool.InputSchema.Properties, "labelSelector")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace"})
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
 
 	tests := []struct {
 		name            string
@@ -254,6 +272,34 @@ func TestListPods(t *testing.T) {
 			expectError:     false,
 			expectedPodList: testPods,
 		},
+		{
+			name:   "all namespaces",
+			client: fake.NewSimpleClientset(&testPods.Items[0], &testPods.Items[1], otherNamespacePod),
+			requestArgs: map[string]interface{}{
+				"allNamespaces": true,
+			},
+			expectError:     false,
+			expectedPodList: &corev1.PodList{Items: append([]corev1.Pod{*otherNamespacePod}, testPods.Items...)},
+		},
+		{
+			name:   "namespace wildcard",
+			client: fake.NewSimpleClientset(&testPods.Items[0], otherNamespacePod),
+			requestArgs: map[string]interface{}{
+				"namespace": "*",
+			},
+			expectError:     false,
+			expectedPodList: &corev1.PodList{Items: []corev1.Pod{*otherNamespacePod, testPods.Items[0]}},
+		},
+		{
+			name:   "namespace with allNamespaces",
+			client: fake.NewSimpleClientset(),
+			requestArgs: map[string]interface{}{
+				"namespace":     "default",
+				"allNamespaces": true,
+			},
+			expectError:    false, // Error is returned in tool result
+			expectedErrMsg: `namespace "default" cannot be combined with allNamespaces`,
+		},
 		{
 			name:   "missing required param: namespace",
 			client: fake.NewSimpleClientset(),
@@ -267,7 +313,7 @@ func TestListPods(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			handler := NewHandler(stubGetClientFn(tc.client), stubGetRESTConfigFn(), translations.NullTranslationHelper)
 			_, handlerFn := handler.List()
 			request := createMCPRequest(tc.requestArgs)
 			result, err := handlerFn(context.Background(), request)
@@ -322,3 +368,692 @@ func TestListPods(t *testing.T) {
 		})
 	}
 }
+
+// fakeExecutor writes canned output and returns a canned error, recording the exec URL it was created for
+type fakeExecutor struct {
+	url    *url.URL
+	stdout string
+	stderr string
+	err    error
+	block  bool
+	stdin  []byte
+}
+
+func (e *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return e.StreamWithContext(context.Background(), options)
+}
+
+func (e *fakeExecutor) StreamWithContext(ctx context.Context, options remotecommand.StreamOptions) error {
+	if options.Stdin != nil {
+		e.stdin, _ = io.ReadAll(options.Stdin)
+	}
+	_, _ = io.WriteString(options.Stdout, e.stdout)
+	_, _ = io.WriteString(options.Stderr, e.stderr)
+	if e.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return e.err
+}
+
+func TestListPodsFilterAndSort(t *testing.T) {
+	now := time.Now()
+	newPod := func(name string, phase corev1.PodPhase, ready corev1.ConditionStatus, restarts int32, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status: corev1.PodStatus{
+				Phase:             phase,
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		newPod("api", corev1.PodRunning, corev1.ConditionTrue, 0, time.Hour),
+		newPod("crashing", corev1.PodRunning, corev1.ConditionFalse, 12, 2*time.Hour),
+		newPod("migrate", corev1.PodSucceeded, corev1.ConditionFalse, 1, 3*time.Hour),
+		newPod("pending", corev1.PodPending, corev1.ConditionFalse, 0, time.Minute),
+	)
+	handler := NewHandler(stubGetClientFn(client), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+	tool, handlerFn := handler.List()
+	assert.Contains(t, tool.InputSchema.Properties, "phase")
+	assert.Contains(t, tool.InputSchema.Properties, "notReady")
+	assert.Equal(t, []string{"name", "age", "restarts"}, tool.InputSchema.Properties["sortBy"].(map[string]interface{})["enum"])
+
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		expectedNames []string
+	}{
+		{name: "sort by name", args: map[string]interface{}{"sortBy": "name"}, expectedNames: []string{"api", "crashing", "migrate", "pending"}},
+		{name: "sort by age", args: map[string]interface{}{"sortBy": "age"}, expectedNames: []string{"pending", "api", "crashing", "migrate"}},
+		{name: "sort by restarts", args: map[string]interface{}{"sortBy": "restarts"}, expectedNames: []string{"crashing", "migrate", "api", "pending"}},
+		{name: "phase", args: map[string]interface{}{"phase": "Pending"}, expectedNames: []string{"pending"}},
+		{name: "not ready skips completed pods", args: map[string]interface{}{"notReady": true, "sortBy": "name"}, expectedNames: []string{"crashing", "pending"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.args["namespace"] = "default"
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var pods corev1.PodList
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pods))
+			var names []string
+			for _, pod := range pods.Items {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "sortBy": "cpu"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "sortBy must be one of name, age, restarts", getTextResult(t, result).Text)
+}
+
+func TestRegisterResources(t *testing.T) {
+	mockClient := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"},
+	})
+	handler := NewHandler(stubGetClientFn(mockClient), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+
+	resourceSet := toolsets.NewResourceSet()
+	handler.RegisterResources(resourceSet)
+	templates := resourceSet.Templates()
+	require.Len(t, templates, 2)
+	assert.Equal(t, "k8s://{namespace}/pods/{name}", templates[0].Template.URITemplate.Raw())
+	assert.Equal(t, "get_pod", templates[0].Tool)
+	assert.Equal(t, "k8s://{namespace}/pods", templates[1].Template.URITemplate.Raw())
+	assert.Equal(t, "list_pods", templates[1].Tool)
+
+	// Reading a pod resource calls get_pod with the variables of the URI
+	_, getHandler := handler.Get()
+	var request mcp.ReadResourceRequest
+	request.Params.URI = "k8s://shop/pods/web-0"
+	request.Params.Arguments = map[string]any{"namespace": []string{"shop"}, "name": []string{"web-0"}}
+	contents, err := toolsets.ReadWithTool("get_pod", getHandler)(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	text := contents[0].(mcp.TextResourceContents)
+	assert.Equal(t, "k8s://shop/pods/web-0", text.URI)
+	var pod corev1.Pod
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &pod))
+	assert.Equal(t, "web-0", pod.Name)
+
+	request.Params.Arguments = map[string]any{"namespace": []string{"shop"}, "name": []string{"missing"}}
+	_, err = toolsets.ReadWithTool("get_pod", getHandler)(context.Background(), request)
+	assert.Error(t, err)
+}
+
+func TestExecInPod(t *testing.T) {
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Annotations: map[string]string{DefaultContainerAnnotation: "app"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "istio-proxy"}, {Name: "app"}}},
+	}
+	completed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+
+	tests := []struct {
+		name              string
+		executor          *fakeExecutor
+		requestArgs       map[string]interface{}
+		expectedResult    ExecResult
+		expectedContainer string
+		expectedErrMsg    string
+	}{
+		{
+			name:              "successful exec in default container",
+			executor:          &fakeExecutor{stdout: "hello\n"},
+			requestArgs:       map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"echo", "hello"}},
+			expectedContainer: "app",
+			expectedResult:    ExecResult{Pod: "web", Container: "app", Command: []string{"echo", "hello"}, ExitCode: intPtr(0), Stdout: "hello\n"},
+		},
+		{
+			name:              "non-zero exit code",
+			executor:          &fakeExecutor{stderr: "no such file\n", err: utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}},
+			requestArgs:       map[string]interface{}{"namespace": "default", "name": "web", "container": "istio-proxy", "command": []interface{}{"cat", "/missing"}},
+			expectedContainer: "istio-proxy",
+			expectedResult:    ExecResult{Pod: "web", Container: "istio-proxy", Command: []string{"cat", "/missing"}, ExitCode: intPtr(2), Stderr: "no such file\n"},
+		},
+		{
+			name:              "output is capped",
+			executor:          &fakeExecutor{stdout: "0123456789"},
+			requestArgs:       map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"seq", "10"}, "maxOutputBytes": float64(4)},
+			expectedContainer: "app",
+			expectedResult:    ExecResult{Pod: "web", Container: "app", Command: []string{"seq", "10"}, ExitCode: intPtr(0), Stdout: "0123", StdoutTruncated: true},
+		},
+		{
+			name:              "timeout",
+			executor:          &fakeExecutor{stdout: "partial", block: true},
+			requestArgs:       map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"sleep", "60"}, "timeoutSeconds": float64(0.01)},
+			expectedContainer: "app",
+			expectedResult:    ExecResult{Pod: "web", Container: "app", Command: []string{"sleep", "60"}, TimedOut: true, Stdout: "partial"},
+		},
+		{
+			name:           "stream failure",
+			executor:       &fakeExecutor{err: errors.New("connection refused")},
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"ls"}},
+			expectedErrMsg: "failed to exec in pod: connection refused",
+		},
+		{
+			name:           "unknown container",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "container": "db", "command": []interface{}{"ls"}},
+			expectedErrMsg: "container db not found in pod web; available containers: istio-proxy, app",
+		},
+		{
+			name:           "completed pod",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "job", "command": []interface{}{"ls"}},
+			expectedErrMsg: "cannot exec into a container in a completed pod",
+		},
+		{
+			name:           "pod not found",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing", "command": []interface{}{"ls"}},
+			expectedErrMsg: "failed to get pod",
+		},
+		{
+			name:           "non-string command",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"sleep", float64(1)}},
+			expectedErrMsg: "command arguments must be strings",
+		},
+		{
+			name:           "timeout too long",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"ls"}, "timeoutSeconds": float64(3600)},
+			expectedErrMsg: "timeoutSeconds must be between 1 and 300",
+		},
+		{
+			name:           "missing required param: command",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedErrMsg: "missing required parameter: command",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(running, completed)), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+			handler.newExecutor = func(config *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+				tc.executor.url = u
+				return tc.executor, nil
+			}
+			tool, handlerFn := handler.Exec()
+			assert.Equal(t, "exec_in_pod", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name", "command"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var execResult ExecResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &execResult))
+			assert.Equal(t, tc.expectedResult, execResult)
+
+			assert.Equal(t, "/api/v1/namespaces/default/pods/web/exec", tc.executor.url.Path)
+			query := tc.executor.url.Query()
+			assert.Equal(t, tc.expectedContainer, query.Get("container"))
+			assert.Equal(t, tc.expectedResult.Command, query["command"])
+			assert.Equal(t, "true", query.Get("stdout"))
+			assert.Empty(t, query.Get("stdin"))
+		})
+	}
+}
+
+func intPtr(i int) *int { return &i }
+
+// tarball creates a tar archive as written by tar cf - for the given entry
+func tarball(t *testing.T, header *tar.Header, content string) string {
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	require.NoError(t, archive.WriteHeader(header))
+	_, err := archive.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	return buf.String()
+}
+
+func TestCopyFromPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	modTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	config := "listen: 8080\n"
+	binary := string([]byte{0xff, 0xfe, 0x00, 0x01})
+
+	tests := []struct {
+		name           string
+		executor       *fakeExecutor
+		requestArgs    map[string]interface{}
+		expectedResult FileContent
+		expectedErrMsg string
+	}{
+		{
+			name:        "text file",
+			executor:    &fakeExecutor{stdout: tarball(t, &tar.Header{Name: "etc/app/config.yaml", Mode: 0o640, Size: int64(len(config)), ModTime: modTime}, config)},
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web", "path": "/etc/app/config.yaml"},
+			expectedResult: FileContent{
+				Pod: "web", Container: "app", Path: "/etc/app/config.yaml", Size: int64(len(config)),
+				Mode: "-rw-r-----", ModTime: modTime, Encoding: EncodingText, Content: config,
+			},
+		},
+		{
+			name:        "binary file is base64 encoded",
+			executor:    &fakeExecutor{stdout: tarball(t, &tar.Header{Name: "tmp/heap.bin", Mode: 0o600, Size: int64(len(binary)), ModTime: modTime}, binary)},
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/heap.bin"},
+			expectedResult: FileContent{
+				Pod: "web", Container: "app", Path: "/tmp/heap.bin", Size: int64(len(binary)),
+				Mode: "-rw-------", ModTime: modTime, Encoding: EncodingBase64, Content: "//4AAQ==",
+			},
+		},
+		{
+			name:           "file larger than limit",
+			executor:       &fakeExecutor{stdout: tarball(t, &tar.Header{Name: "etc/app/config.yaml", Mode: 0o644, Size: int64(len(config))}, config)},
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/etc/app/config.yaml", "maxBytes": float64(4)},
+			expectedErrMsg: "file /etc/app/config.yaml is 13 bytes, larger than the 4 byte limit",
+		},
+		{
+			name:           "directory",
+			executor:       &fakeExecutor{stdout: tarball(t, &tar.Header{Typeflag: tar.TypeDir, Name: "etc/app/", Mode: 0o755}, "")},
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/etc/app"},
+			expectedErrMsg: "/etc/app is a directory",
+		},
+		{
+			name:           "missing file",
+			executor:       &fakeExecutor{stderr: "tar: /etc/missing: No such file or directory\n", err: utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}},
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/etc/missing"},
+			expectedErrMsg: "failed to read file from pod: tar: /etc/missing: No such file or directory",
+		},
+		{
+			name:           "relative path",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "config.yaml"},
+			expectedErrMsg: `path must be absolute, got "config.yaml"`,
+		},
+		{
+			name:           "limit too large",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/etc/app/config.yaml", "maxBytes": float64(10 * 1024 * 1024)},
+			expectedErrMsg: "maxBytes must be between 1 and 1048576",
+		},
+		{
+			name:           "missing required param: path",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedErrMsg: "missing required parameter: path",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(pod)), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+			handler.newExecutor = func(config *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+				tc.executor.url = u
+				return tc.executor, nil
+			}
+			tool, handlerFn := handler.CopyFrom()
+			assert.Equal(t, "pod_cp_from", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name", "path"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var content FileContent
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &content))
+			assert.Equal(t, tc.expectedResult, content)
+			assert.Equal(t, []string{"tar", "cf", "-", tc.expectedResult.Path}, tc.executor.url.Query()["command"])
+		})
+	}
+}
+
+func TestCopyToPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+
+	tests := []struct {
+		name            string
+		executor        *fakeExecutor
+		requestArgs     map[string]interface{}
+		expectedResult  FileCopy
+		expectedContent string
+		expectedErrMsg  string
+	}{
+		{
+			name:            "text content",
+			executor:        &fakeExecutor{},
+			requestArgs:     map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/app/debug.conf", "content": "level: debug\n"},
+			expectedResult:  FileCopy{Pod: "web", Container: "app", Path: "/tmp/app/debug.conf", Size: 13},
+			expectedContent: "level: debug\n",
+		},
+		{
+			name:            "base64 content",
+			executor:        &fakeExecutor{},
+			requestArgs:     map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/blob", "content": "//4AAQ==", "encoding": "base64"},
+			expectedResult:  FileCopy{Pod: "web", Container: "app", Path: "/tmp/blob", Size: 4},
+			expectedContent: string([]byte{0xff, 0xfe, 0x00, 0x01}),
+		},
+		{
+			name:           "tar failure",
+			executor:       &fakeExecutor{stderr: "tar: can't change directory to '/missing': No such file or directory\n", err: utilexec.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1}},
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/missing/file", "content": "x"},
+			expectedErrMsg: "failed to write file to pod: tar: can't change directory to '/missing'",
+		},
+		{
+			name:           "content too large",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/big", "content": string(make([]byte, 1024*1024+1))},
+			expectedErrMsg: "file /tmp/big is 1048577 bytes, larger than the 1048576 byte limit",
+		},
+		{
+			name:           "invalid base64",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/blob", "content": "not base64!", "encoding": "base64"},
+			expectedErrMsg: "invalid base64 content",
+		},
+		{
+			name:           "directory path",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/", "content": "x"},
+			expectedErrMsg: `path must name a file, got "/tmp/"`,
+		},
+		{
+			name:           "missing required param: content",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "path": "/tmp/file"},
+			expectedErrMsg: "missing required parameter: content",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(pod)), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+			handler.newExecutor = func(config *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+				tc.executor.url = u
+				return tc.executor, nil
+			}
+			tool, handlerFn := handler.CopyTo()
+			assert.Equal(t, "pod_cp_to", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name", "path", "content"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var fileCopy FileCopy
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fileCopy))
+			assert.Equal(t, tc.expectedResult, fileCopy)
+
+			query := tc.executor.url.Query()
+			assert.Equal(t, []string{"tar", "xmf", "-", "-C", path.Dir(tc.expectedResult.Path)}, query["command"])
+			assert.Equal(t, "true", query.Get("stdin"))
+
+			archive := tar.NewReader(bytes.NewReader(tc.executor.stdin))
+			header, err := archive.Next()
+			require.NoError(t, err)
+			assert.Equal(t, path.Base(tc.expectedResult.Path), header.Name)
+			content, err := io.ReadAll(archive)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, string(content))
+		})
+	}
+}
+
+func TestGetPodStatusSummary(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tolerationSeconds := int64(300)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid"},
+		Spec: corev1.PodSpec{
+			NodeName:       "node-1",
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1"}},
+			Containers:     []corev1.Container{{Name: "app", Image: "app:2"}, {Name: "sidecar", Image: "proxy:1"}},
+			Tolerations: []corev1.Toleration{
+				{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &tolerationSeconds},
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:    corev1.PodRunning,
+			PodIP:    "10.0.0.7",
+			QOSClass: corev1.PodQOSBurstable,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady", Message: "containers with unready status: [app]"},
+			},
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "migrate",
+				Ready: true,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed", ExitCode: 0, FinishedAt: metav1.NewTime(now.Add(-time.Hour))}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         5,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, FinishedAt: metav1.NewTime(now.Add(-time.Minute))}},
+			}},
+		},
+	}
+	event := func(name, reason, uid string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web", UID: types.UID(uid)},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        reason + " message",
+			Count:          2,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	client := fake.NewSimpleClientset(pod,
+		event("web.1", "Pulled", "web-uid", now.Add(-10*time.Minute)),
+		event("web.2", "BackOff", "web-uid", now.Add(-time.Minute)),
+		// An event of an earlier pod with the same name is not reported
+		event("web.3", "Killing", "old-uid", now),
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "api.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api"},
+			Reason:         "Started",
+		},
+	)
+
+	handler := NewHandler(stubGetClientFn(client), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+	tool, handlerFn := handler.StatusSummary()
+	assert.Equal(t, "get_pod_status_summary", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "name": "web"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var summary StatusSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+	assert.Equal(t, corev1.PodRunning, summary.Phase)
+	assert.Equal(t, "node-1", summary.Node)
+	assert.Equal(t, corev1.PodQOSBurstable, summary.QOSClass)
+	assert.Equal(t, []ConditionSummary{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady", Message: "containers with unready status: [app]"},
+	}, summary.Conditions)
+	assert.Equal(t, []string{
+		"node.kubernetes.io/not-ready:NoExecute op=Exists for 300s",
+		"dedicated=web:NoSchedule",
+	}, summary.Tolerations)
+
+	require.Len(t, summary.Containers, 3)
+	assert.Equal(t, "migrate", summary.Containers[0].Name)
+	assert.True(t, summary.Containers[0].Init)
+	assert.Equal(t, "terminated", summary.Containers[0].State)
+	assert.Equal(t, "Completed", summary.Containers[0].Reason)
+	require.NotNil(t, summary.Containers[0].ExitCode)
+	assert.Equal(t, int32(0), *summary.Containers[0].ExitCode)
+
+	app := summary.Containers[1]
+	assert.Equal(t, "waiting", app.State)
+	assert.Equal(t, "CrashLoopBackOff", app.Reason)
+	assert.Equal(t, int32(5), app.RestartCount)
+	require.NotNil(t, app.LastTermination)
+	assert.Equal(t, "Error", app.LastTermination.Reason)
+	assert.Equal(t, int32(1), app.LastTermination.ExitCode)
+
+	// A container without status has not been created yet
+	assert.Equal(t, "pending", summary.Containers[2].State)
+
+	// Events are newest first
+	require.Len(t, summary.Events, 2)
+	assert.Equal(t, "BackOff", summary.Events[0].Reason)
+	assert.Equal(t, "Pulled", summary.Events[1].Reason)
+	assert.Equal(t, now.Add(-time.Minute), summary.Events[0].LastSeen.UTC())
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "default", "name": "missing"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "failed to get pod")
+}
+
+func TestDebugPod(t *testing.T) {
+	running := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "default",
+				Annotations: map[string]string{DefaultContainerAnnotation: "app"},
+			},
+			Spec: corev1.PodSpec{
+				Containers:          []corev1.Container{{Name: "istio-proxy"}, {Name: "app"}},
+				EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-old"}}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "queued", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]interface{}
+		expectedContainer corev1.EphemeralContainer
+		expectedErrMsg    string
+	}{
+		{
+			name:        "defaults",
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web"},
+			expectedContainer: corev1.EphemeralContainer{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+					Image:                    defaultDebugImage,
+					Command:                  defaultDebugCommand,
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				TargetContainerName: "app",
+			},
+		},
+		{
+			name: "custom image, target and command",
+			requestArgs: map[string]interface{}{
+				"namespace":       "default",
+				"name":            "web",
+				"image":           "nicolaka/netshoot",
+				"targetContainer": "istio-proxy",
+				"containerName":   "netshoot",
+				"command":         []interface{}{"sleep", "600"},
+			},
+			expectedContainer: corev1.EphemeralContainer{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+					Name:                     "netshoot",
+					Image:                    "nicolaka/netshoot",
+					Command:                  []string{"sleep", "600"},
+					ImagePullPolicy:          corev1.PullIfNotPresent,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+				TargetContainerName: "istio-proxy",
+			},
+		},
+		{
+			name:           "container name in use",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "containerName": "debugger-old"},
+			expectedErrMsg: "pod web already has a container named debugger-old",
+		},
+		{
+			name:           "unknown target",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "targetContainer": "db"},
+			expectedErrMsg: "target container db not found in pod web; available containers: istio-proxy, app",
+		},
+		{
+			name:           "pod not running",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "queued"},
+			expectedErrMsg: "ephemeral containers can only be added to running pods; current phase is Pending",
+		},
+		{
+			name:           "non-string command",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "web", "command": []interface{}{"sleep", float64(600)}},
+			expectedErrMsg: "command arguments must be strings, got float64",
+		},
+		{
+			name:           "missing pod",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "api"},
+			expectedErrMsg: "failed to get pod",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(running(), pending)
+			handler := NewHandler(stubGetClientFn(client), stubGetRESTConfigFn(), translations.NullTranslationHelper)
+			tool, handlerFunc := handler.Debug()
+			assert.Equal(t, "debug_pod", tool.Name)
+
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handlerFunc(context.Background(), request)
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var pod corev1.Pod
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pod))
+			require.Len(t, pod.Spec.EphemeralContainers, 2)
+			added := pod.Spec.EphemeralContainers[1]
+			if tc.expectedContainer.Name == "" {
+				assert.Regexp(t, `^debugger-[a-z0-9]{5}$`, added.Name)
+				tc.expectedContainer.Name = added.Name
+			}
+			assert.Equal(t, tc.expectedContainer, added)
+
+			stored, err := client.CoreV1().Pods("default").Get(context.Background(), "web", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Len(t, stored.Spec.EphemeralContainers, 2)
+		})
+	}
+}
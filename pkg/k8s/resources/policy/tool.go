@@ -0,0 +1,375 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// PolicyReportGVR is the namespaced policy report written by Kyverno (and other wgpolicyk8s.io producers)
+	PolicyReportGVR = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "policyreports"}
+	// ClusterPolicyReportGVR is the cluster-scoped policy report written by Kyverno
+	ClusterPolicyReportGVR = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "clusterpolicyreports"}
+	// ConstraintTemplateGVR is the Gatekeeper constraint template
+	ConstraintTemplateGVR = schema.GroupVersionResource{Group: "templates.gatekeeper.sh", Version: "v1", Resource: "constrainttemplates"}
+	// constraintGroupVersion is the group/version of the constraint kinds generated by Gatekeeper
+	constraintGroupVersion = schema.GroupVersion{Group: "constraints.gatekeeper.sh", Version: "v1beta1"}
+)
+
+// clusterScope is the namespace key used for results on cluster-scoped resources
+const clusterScope = "<cluster>"
+
+// Handler implements the K8sResourceHandler interface for policy engine reports
+type Handler struct {
+	getDynamicClient toolsets.GetDynamicClientFn
+	t                translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new policy report handler
+func NewHandler(getDynamicClient toolsets.GetDynamicClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getDynamicClient: getDynamicClient,
+		t:                t,
+	}
+}
+
+// RegisterTools registers all policy report tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	policyReportsTool, policyReportsHandler := h.ListPolicyReports()
+	toolset.AddReadTool(policyReportsTool, policyReportsHandler)
+
+	violationsTool, violationsHandler := h.ListConstraintViolations()
+	toolset.AddReadTool(violationsTool, violationsHandler)
+}
+
+// PolicyFailure is a single failing policy result
+type PolicyFailure struct {
+	Policy   string `json:"policy"`
+	Rule     string `json:"rule,omitempty"`
+	Result   string `json:"result"`
+	Severity string `json:"severity,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// NamespacePolicySummary aggregates policy report results for a namespace
+type NamespacePolicySummary struct {
+	Namespace string          `json:"namespace"`
+	Pass      int64           `json:"pass"`
+	Fail      int64           `json:"fail"`
+	Warn      int64           `json:"warn"`
+	Error     int64           `json:"error"`
+	Skip      int64           `json:"skip"`
+	Failures  []PolicyFailure `json:"failures,omitempty"`
+}
+
+// ConstraintViolation is a single Gatekeeper audit violation
+type ConstraintViolation struct {
+	Constraint        string `json:"constraint"`
+	Kind              string `json:"kind"`
+	Name              string `json:"name"`
+	Message           string `json:"message,omitempty"`
+	EnforcementAction string `json:"enforcementAction,omitempty"`
+}
+
+// NamespaceViolationSummary aggregates Gatekeeper violations for a namespace
+type NamespaceViolationSummary struct {
+	Namespace  string                `json:"namespace"`
+	Violations int                   `json:"violations"`
+	Details    []ConstraintViolation `json:"details"`
+}
+
+// ConstraintSummary describes a Gatekeeper constraint and its audit status
+type ConstraintSummary struct {
+	Kind              string `json:"kind"`
+	Name              string `json:"name"`
+	EnforcementAction string `json:"enforcementAction,omitempty"`
+	TotalViolations   int64  `json:"totalViolations"`
+}
+
+// ConstraintViolationsResult is the result of the list_constraint_violations tool
+type ConstraintViolationsResult struct {
+	Constraints []ConstraintSummary         `json:"constraints"`
+	Namespaces  []NamespaceViolationSummary `json:"namespaces"`
+}
+
+// ListPolicyReports creates a tool to summarize Kyverno policy reports per namespace
+func (h *Handler) ListPolicyReports() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_policy_reports",
+			mcp.WithDescription(h.t("TOOL_LIST_POLICY_REPORTS_DESCRIPTION", "Summarize Kyverno PolicyReports (wgpolicyk8s.io) per namespace, with pass/fail counts and failing results")),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (defaults to all namespaces, including cluster-scoped reports)"),
+			),
+			mcp.WithBoolean("includePassing",
+				mcp.Description("Include namespaces without failures or warnings in the summary (default false)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			includePassing, err := toolsets.OptionalParam[bool](request, "includePassing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			reports, err := client.Resource(PolicyReportGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return mcp.NewToolResultError("PolicyReport resources not found; is Kyverno installed?"), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list policy reports: %v", err)), nil
+			}
+			items := reports.Items
+
+			// Cluster-scoped reports are only included when listing across all namespaces
+			if namespace == "" {
+				clusterReports, err := client.Resource(ClusterPolicyReportGVR).List(ctx, metav1.ListOptions{})
+				if err != nil && !apierrors.IsNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list cluster policy reports: %v", err)), nil
+				}
+				if err == nil {
+					items = append(items, clusterReports.Items...)
+				}
+			}
+
+			r, err := json.Marshal(summarizePolicyReports(items, includePassing))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListConstraintViolations creates a tool to summarize Gatekeeper constraint violations per namespace
+func (h *Handler) ListConstraintViolations() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_constraint_violations",
+			mcp.WithDescription(h.t("TOOL_LIST_CONSTRAINT_VIOLATIONS_DESCRIPTION", "Summarize OPA Gatekeeper constraint audit violations per namespace")),
+			mcp.WithString("namespace",
+				mcp.Description("Only report violations for resources in this namespace (defaults to all namespaces)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes dynamic client: %w", err)
+			}
+
+			templates, err := client.Resource(ConstraintTemplateGVR).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return mcp.NewToolResultError("ConstraintTemplate resources not found; is Gatekeeper installed?"), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list constraint templates: %v", err)), nil
+			}
+
+			// Each constraint template generates a cluster-scoped constraint kind
+			var constraints []unstructured.Unstructured
+			for _, template := range templates.Items {
+				kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+				if kind == "" {
+					continue
+				}
+				gvr := constraintGroupVersion.WithResource(strings.ToLower(kind))
+				list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						continue
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list %s constraints: %v", kind, err)), nil
+				}
+				constraints = append(constraints, list.Items...)
+			}
+
+			r, err := json.Marshal(summarizeConstraints(constraints, namespace))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// summarizePolicyReports aggregates policy report summaries and failing results per namespace
+func summarizePolicyReports(reports []unstructured.Unstructured, includePassing bool) []NamespacePolicySummary {
+	byNamespace := make(map[string]*NamespacePolicySummary)
+	for _, report := range reports {
+		ns := report.GetNamespace()
+		if ns == "" {
+			ns = clusterScope
+		}
+		summary, ok := byNamespace[ns]
+		if !ok {
+			summary = &NamespacePolicySummary{Namespace: ns}
+			byNamespace[ns] = summary
+		}
+
+		counts, _, _ := unstructured.NestedMap(report.Object, "summary")
+		summary.Pass += toInt64(counts["pass"])
+		summary.Fail += toInt64(counts["fail"])
+		summary.Warn += toInt64(counts["warn"])
+		summary.Error += toInt64(counts["error"])
+		summary.Skip += toInt64(counts["skip"])
+
+		results, _, _ := unstructured.NestedSlice(report.Object, "results")
+		scope, _, _ := unstructured.NestedMap(report.Object, "scope")
+		for _, raw := range results {
+			result, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			outcome, _ := result["result"].(string)
+			if outcome != "fail" && outcome != "warn" && outcome != "error" {
+				continue
+			}
+			failure := PolicyFailure{Result: outcome}
+			failure.Policy, _ = result["policy"].(string)
+			failure.Rule, _ = result["rule"].(string)
+			failure.Severity, _ = result["severity"].(string)
+			failure.Message, _ = result["message"].(string)
+			failure.Resource = resultResource(result, scope)
+			summary.Failures = append(summary.Failures, failure)
+		}
+	}
+
+	summaries := make([]NamespacePolicySummary, 0, len(byNamespace))
+	for _, summary := range byNamespace {
+		if !includePassing && summary.Fail == 0 && summary.Warn == 0 && summary.Error == 0 && len(summary.Failures) == 0 {
+			continue
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Fail != summaries[j].Fail {
+			return summaries[i].Fail > summaries[j].Fail
+		}
+		return summaries[i].Namespace < summaries[j].Namespace
+	})
+	return summaries
+}
+
+// resultResource formats the resource a policy result applies to. Kyverno 1.10+ writes one
+// report per resource and records it in the report scope instead of the result.
+func resultResource(result map[string]interface{}, scope map[string]interface{}) string {
+	if resources, ok := result["resources"].([]interface{}); ok && len(resources) > 0 {
+		if ref, ok := resources[0].(map[string]interface{}); ok {
+			return formatRef(ref)
+		}
+	}
+	if scope != nil {
+		return formatRef(scope)
+	}
+	return ""
+}
+
+func formatRef(ref map[string]interface{}) string {
+	kind, _ := ref["kind"].(string)
+	name, _ := ref["name"].(string)
+	if ns, _ := ref["namespace"].(string); ns != "" {
+		return fmt.Sprintf("%s/%s/%s", kind, ns, name)
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// summarizeConstraints aggregates Gatekeeper constraint audit violations per namespace
+func summarizeConstraints(constraints []unstructured.Unstructured, namespace string) ConstraintViolationsResult {
+	result := ConstraintViolationsResult{
+		Constraints: []ConstraintSummary{},
+		Namespaces:  []NamespaceViolationSummary{},
+	}
+	byNamespace := make(map[string]*NamespaceViolationSummary)
+
+	for _, constraint := range constraints {
+		action, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction")
+		total, _, _ := unstructured.NestedFieldNoCopy(constraint.Object, "status", "totalViolations")
+		result.Constraints = append(result.Constraints, ConstraintSummary{
+			Kind:              constraint.GetKind(),
+			Name:              constraint.GetName(),
+			EnforcementAction: action,
+			TotalViolations:   toInt64(total),
+		})
+
+		violations, _, _ := unstructured.NestedSlice(constraint.Object, "status", "violations")
+		for _, raw := range violations {
+			v, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ns, _ := v["namespace"].(string)
+			if namespace != "" && ns != namespace {
+				continue
+			}
+			if ns == "" {
+				ns = clusterScope
+			}
+			summary, ok := byNamespace[ns]
+			if !ok {
+				summary = &NamespaceViolationSummary{Namespace: ns}
+				byNamespace[ns] = summary
+			}
+			violation := ConstraintViolation{Constraint: constraint.GetKind() + "/" + constraint.GetName()}
+			violation.Kind, _ = v["kind"].(string)
+			violation.Name, _ = v["name"].(string)
+			violation.Message, _ = v["message"].(string)
+			violation.EnforcementAction, _ = v["enforcementAction"].(string)
+			summary.Violations++
+			summary.Details = append(summary.Details, violation)
+		}
+	}
+
+	for _, summary := range byNamespace {
+		result.Namespaces = append(result.Namespaces, *summary)
+	}
+	sort.Slice(result.Namespaces, func(i, j int) bool {
+		if result.Namespaces[i].Violations != result.Namespaces[j].Violations {
+			return result.Namespaces[i].Violations > result.Namespaces[j].Violations
+		}
+		return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace
+	})
+	sort.Slice(result.Constraints, func(i, j int) bool {
+		return result.Constraints[i].TotalViolations > result.Constraints[j].TotalViolations
+	})
+	return result
+}
+
+// toInt64 converts the numeric types found in unstructured objects to int64
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case float64:
+		return int64(n)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake dynamic client
+func stubGetDynamicClientFn(client dynamic.Interface) toolsets.GetDynamicClientFn {
+	return func(ctx context.Context) (dynamic.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+var requiredLabelsGVR = schema.GroupVersionResource{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Resource: "k8srequiredlabels"}
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			PolicyReportGVR:        "PolicyReportList",
+			ClusterPolicyReportGVR: "ClusterPolicyReportList",
+			ConstraintTemplateGVR:  "ConstraintTemplateList",
+			requiredLabelsGVR:      "K8sRequiredLabelsList",
+		}, objects...)
+}
+
+func policyReport(namespace, name string, fail int64, results []interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "wgpolicyk8s.io/v1alpha2",
+		"kind":       "PolicyReport",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"scope":      map[string]interface{}{"kind": "Deployment", "name": "web", "namespace": namespace},
+		"summary":    map[string]interface{}{"pass": int64(3), "fail": fail, "warn": int64(0), "error": int64(0), "skip": int64(0)},
+		"results":    results,
+	}}
+}
+
+func TestListPolicyReports(t *testing.T) {
+	failing := policyReport("payments", "report-1", 1, []interface{}{
+		map[string]interface{}{"policy": "require-labels", "rule": "check-team", "result": "fail", "message": "label team is required", "severity": "medium"},
+		map[string]interface{}{"policy": "disallow-latest", "rule": "validate-image", "result": "pass"},
+	})
+	passing := policyReport("default", "report-2", 0, nil)
+
+	handler := NewHandler(stubGetDynamicClientFn(newFakeDynamicClient(failing, passing)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListPolicyReports()
+
+	assert.Equal(t, "list_policy_reports", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	t.Run("failing namespaces only", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var summaries []NamespacePolicySummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summaries))
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "payments", summaries[0].Namespace)
+		assert.Equal(t, int64(1), summaries[0].Fail)
+		assert.Equal(t, int64(3), summaries[0].Pass)
+		require.Len(t, summaries[0].Failures, 1)
+		assert.Equal(t, "require-labels", summaries[0].Failures[0].Policy)
+		assert.Equal(t, "Deployment/payments/web", summaries[0].Failures[0].Resource)
+	})
+
+	t.Run("include passing", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"includePassing": true}))
+		require.NoError(t, err)
+
+		var summaries []NamespacePolicySummary
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summaries))
+		assert.Len(t, summaries, 2)
+	})
+
+	t.Run("kyverno not installed", func(t *testing.T) {
+		client := newFakeDynamicClient()
+		client.PrependReactor("list", "policyreports", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(PolicyReportGVR.GroupResource(), "")
+		})
+		_, handlerFn := NewHandler(stubGetDynamicClientFn(client), translations.NullTranslationHelper).ListPolicyReports()
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "is Kyverno installed")
+	})
+}
+
+func TestListConstraintViolations(t *testing.T) {
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "templates.gatekeeper.sh/v1",
+		"kind":       "ConstraintTemplate",
+		"metadata":   map[string]interface{}{"name": "k8srequiredlabels"},
+		"spec": map[string]interface{}{
+			"crd": map[string]interface{}{"spec": map[string]interface{}{"names": map[string]interface{}{"kind": "K8sRequiredLabels"}}},
+		},
+	}}
+	constraint := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "constraints.gatekeeper.sh/v1beta1",
+		"kind":       "K8sRequiredLabels",
+		"metadata":   map[string]interface{}{"name": "ns-must-have-owner"},
+		"spec":       map[string]interface{}{"enforcementAction": "deny"},
+		"status": map[string]interface{}{
+			"totalViolations": int64(2),
+			"violations": []interface{}{
+				map[string]interface{}{"kind": "Deployment", "name": "web", "namespace": "payments", "message": "missing owner", "enforcementAction": "deny"},
+				map[string]interface{}{"kind": "Namespace", "name": "sandbox", "message": "missing owner", "enforcementAction": "deny"},
+			},
+		},
+	}}
+
+	// Gatekeeper constraint resources are the lowercase kind, which the fake tracker can't guess
+	client := newFakeDynamicClient(template)
+	_, err := client.Resource(requiredLabelsGVR).Create(context.Background(), constraint, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	handler := NewHandler(stubGetDynamicClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListConstraintViolations()
+
+	assert.Equal(t, "list_constraint_violations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]interface{}
+		expectedNamespaces []string
+	}{
+		{
+			name:               "all namespaces",
+			requestArgs:        map[string]interface{}{},
+			expectedNamespaces: []string{"<cluster>", "payments"},
+		},
+		{
+			name:               "single namespace",
+			requestArgs:        map[string]interface{}{"namespace": "payments"},
+			expectedNamespaces: []string{"payments"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var summary ConstraintViolationsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+			require.Len(t, summary.Constraints, 1)
+			assert.Equal(t, int64(2), summary.Constraints[0].TotalViolations)
+			assert.Equal(t, "deny", summary.Constraints[0].EnforcementAction)
+
+			var namespaces []string
+			for _, ns := range summary.Namespaces {
+				namespaces = append(namespaces, ns.Namespace)
+			}
+			assert.ElementsMatch(t, tc.expectedNamespaces, namespaces)
+		})
+	}
+}
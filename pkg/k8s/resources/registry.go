@@ -1,20 +1,42 @@
 package resources
 
 import (
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/bundle"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/cluster"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/configmap"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/cronjob"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/deployment"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/diagnose"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/dns"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/gateway"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/generic"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/image"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/lease"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/logs"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/metrics"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/namespace"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/node"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/pdb"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/pod"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/policy"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/scheduling"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/security"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/service"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/storage"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/webhook"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources/workload"
+	"github.com/briankscheong/k8s-mcp-server/pkg/scanner"
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
 )
 
 // RegisterAllK8sResources registers all k8s resource handlers with the registry
-func RegisterAllK8sResources(registry *toolsets.K8sResourceRegistry, getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) {
+func RegisterAllK8sResources(registry *toolsets.K8sResourceRegistry, getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, getRESTConfig toolsets.GetRESTConfigFn, t translations.TranslationHelperFunc, imageScanner scanner.Scanner) {
 	// Register Pod resource handler
-	registry.Register("pod", pod.NewHandler(getClient, t))
+	registry.Register("pod", pod.NewHandler(getClient, getRESTConfig, t))
+
+	// Register container logs handler
+	registry.Register("logs", logs.NewHandler(getClient, t))
 
 	// Register Deployment resource handler
 	registry.Register("deployment", deployment.NewHandler(getClient, t))
@@ -30,14 +52,68 @@ func RegisterAllK8sResources(registry *toolsets.K8sResourceRegistry, getClient t
 
 	// Register Node resource handler
 	registry.Register("node", node.NewHandler(getClient, t))
+
+	// Register PodDisruptionBudget resource handler
+	registry.Register("pdb", pdb.NewHandler(getClient, t))
+
+	// Register container image handler
+	registry.Register("image", image.NewHandler(getClient, t, imageScanner))
+
+	// Register storage resource handler
+	registry.Register("storage", storage.NewHandler(getClient, t))
+
+	// Register policy report handler
+	registry.Register("policy", policy.NewHandler(getDynamicClient, t))
+
+	// Register scheduling resource handler
+	registry.Register("scheduling", scheduling.NewHandler(getClient, t))
+
+	// Register admission webhook configuration handler
+	registry.Register("webhook", webhook.NewHandler(getClient, t))
+
+	// Register DNS handler
+	registry.Register("dns", dns.NewHandler(getClient, t))
+
+	// Register Lease resource handler
+	registry.Register("lease", lease.NewHandler(getClient, t))
+
+	// Register cluster inspection handler
+	registry.Register("cluster", cluster.NewHandler(getClient, t))
+
+	// Register IngressClass and Gateway API handler
+	registry.Register("gateway", gateway.NewHandler(getClient, getDynamicClient, t))
+
+	// Register desired-state bundle handler
+	registry.Register("bundle", bundle.NewHandler(getClient, getDynamicClient, t))
+
+	// Register generic resource handler
+	registry.Register("generic", generic.NewHandler(getClient, getDynamicClient, t))
+
+	// Register security audit handler
+	registry.Register("security", security.NewHandler(getClient, t))
+
+	// Register workload handler
+	registry.Register("workload", workload.NewHandler(getClient, t))
+
+	// Register CronJob resource handler
+	registry.Register("cronjob", cronjob.NewHandler(getClient, t))
+
+	// Register resource usage metrics handler
+	registry.Register("metrics", metrics.NewHandler(getClient, metrics.ClientFromRESTConfig(getRESTConfig), t))
+
+	// Register troubleshooting analyzers handler
+	registry.Register("diagnose", diagnose.NewHandler(getClient, t))
 }
 
 // RegisterSelectedK8sResources registers only the specified resource handlers with the registry
-func RegisterSelectedK8sResources(registry *toolsets.K8sResourceRegistry, getClient toolsets.GetClientFn, t translations.TranslationHelperFunc, resourceTypes []string) {
+func RegisterSelectedK8sResources(registry *toolsets.K8sResourceRegistry, getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, getRESTConfig toolsets.GetRESTConfigFn, t translations.TranslationHelperFunc, imageScanner scanner.Scanner, resourceTypes []string) {
 	// Map of resource types to their registration functions
 	resourceMap := map[string]func(){
 		"pod": func() {
-			registry.Register("pod", pod.NewHandler(getClient, t))
+			registry.Register("pod", pod.NewHandler(getClient, getRESTConfig, t))
+		},
+		"logs": func() {
+			registry.Register("logs", logs.NewHandler(getClient, t))
 		},
 		"deployment": func() {
 			registry.Register("deployment", deployment.NewHandler(getClient, t))
@@ -54,6 +130,57 @@ func RegisterSelectedK8sResources(registry *toolsets.K8sResourceRegistry, getCli
 		"node": func() {
 			registry.Register("node", node.NewHandler(getClient, t))
 		},
+		"pdb": func() {
+			registry.Register("pdb", pdb.NewHandler(getClient, t))
+		},
+		"image": func() {
+			registry.Register("image", image.NewHandler(getClient, t, imageScanner))
+		},
+		"storage": func() {
+			registry.Register("storage", storage.NewHandler(getClient, t))
+		},
+		"policy": func() {
+			registry.Register("policy", policy.NewHandler(getDynamicClient, t))
+		},
+		"scheduling": func() {
+			registry.Register("scheduling", scheduling.NewHandler(getClient, t))
+		},
+		"webhook": func() {
+			registry.Register("webhook", webhook.NewHandler(getClient, t))
+		},
+		"dns": func() {
+			registry.Register("dns", dns.NewHandler(getClient, t))
+		},
+		"lease": func() {
+			registry.Register("lease", lease.NewHandler(getClient, t))
+		},
+		"cluster": func() {
+			registry.Register("cluster", cluster.NewHandler(getClient, t))
+		},
+		"gateway": func() {
+			registry.Register("gateway", gateway.NewHandler(getClient, getDynamicClient, t))
+		},
+		"bundle": func() {
+			registry.Register("bundle", bundle.NewHandler(getClient, getDynamicClient, t))
+		},
+		"generic": func() {
+			registry.Register("generic", generic.NewHandler(getClient, getDynamicClient, t))
+		},
+		"security": func() {
+			registry.Register("security", security.NewHandler(getClient, t))
+		},
+		"workload": func() {
+			registry.Register("workload", workload.NewHandler(getClient, t))
+		},
+		"cronjob": func() {
+			registry.Register("cronjob", cronjob.NewHandler(getClient, t))
+		},
+		"metrics": func() {
+			registry.Register("metrics", metrics.NewHandler(getClient, metrics.ClientFromRESTConfig(getRESTConfig), t))
+		},
+		"diagnose": func() {
+			registry.Register("diagnose", diagnose.NewHandler(getClient, t))
+		},
 	}
 
 	// Register only the specified resources
@@ -64,15 +191,34 @@ func RegisterSelectedK8sResources(registry *toolsets.K8sResourceRegistry, getCli
 	}
 }
 
-// CreateToolset creates a toolset with all registered resource handlers
-func CreateToolset(registry *toolsets.K8sResourceRegistry, name string, readOnly bool) *toolsets.Toolset {
+// CreateToolset creates a toolset with all registered resource handlers, remembering the named
+// toolset each tool belongs to. When configure is set, it is called with the tools of each
+// handler before they are added, to apply settings of a single resource type.
+func CreateToolset(registry *toolsets.K8sResourceRegistry, name string, readOnly bool, configure func(resourceType string, tools *toolsets.Toolset)) *toolsets.Toolset {
 	// Create a new toolset
 	toolset := toolsets.NewToolset(name, "K8s resources related tools", readOnly)
 
 	// Register all resource handlers with the toolset
-	for _, handler := range registry.GetAllHandlers() {
-		handler.RegisterTools(toolset)
+	for resourceType, handler := range registry.GetAllHandlers() {
+		tools := toolsets.NewToolset(ToolsetOf(resourceType), "", readOnly)
+		handler.RegisterTools(tools)
+		if configure != nil {
+			configure(resourceType, tools)
+		}
+		toolset.AddTools(tools)
 	}
 
 	return toolset
 }
+
+// CreateResourceSet collects the MCP resource templates of the registered handlers that expose
+// their objects as resources
+func CreateResourceSet(registry *toolsets.K8sResourceRegistry) *toolsets.ResourceSet {
+	resourceSet := toolsets.NewResourceSet()
+	for _, handler := range registry.GetAllHandlers() {
+		if provider, ok := handler.(toolsets.K8sResourceProvider); ok {
+			provider.RegisterResources(resourceSet)
+		}
+	}
+	return resourceSet
+}
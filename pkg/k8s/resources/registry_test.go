@@ -6,9 +6,15 @@ import (
 
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 )
 
 func TestRegisterAllK8sResources(t *testing.T) {
@@ -17,22 +23,46 @@ func TestRegisterAllK8sResources(t *testing.T) {
 	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
 		return fakeClient, nil
 	}
+	getDynamicClient := func(ctx context.Context) (dynamic.Interface, error) {
+		return dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+	getRESTConfig := func(ctx context.Context) (*rest.Config, error) {
+		return &rest.Config{}, nil
+	}
 
 	// Create a registry
 	registry := toolsets.NewK8sResourceRegistry()
 
 	// Register all resources
-	RegisterAllK8sResources(registry, getClient, translations.NullTranslationHelper)
+	RegisterAllK8sResources(registry, getClient, getDynamicClient, getRESTConfig, translations.NullTranslationHelper, nil)
 
 	// Verify that all resources are registered
 	handlers := registry.GetAllHandlers()
 	assert.NotEmpty(t, handlers)
 	assert.Contains(t, handlers, "pod")
+	assert.Contains(t, handlers, "logs")
 	assert.Contains(t, handlers, "deployment")
 	assert.Contains(t, handlers, "service")
 	assert.Contains(t, handlers, "configmap")
 	assert.Contains(t, handlers, "namespace")
 	assert.Contains(t, handlers, "node")
+	assert.Contains(t, handlers, "pdb")
+	assert.Contains(t, handlers, "image")
+	assert.Contains(t, handlers, "storage")
+	assert.Contains(t, handlers, "policy")
+	assert.Contains(t, handlers, "scheduling")
+	assert.Contains(t, handlers, "webhook")
+	assert.Contains(t, handlers, "dns")
+	assert.Contains(t, handlers, "lease")
+	assert.Contains(t, handlers, "cluster")
+	assert.Contains(t, handlers, "gateway")
+	assert.Contains(t, handlers, "bundle")
+	assert.Contains(t, handlers, "generic")
+	assert.Contains(t, handlers, "security")
+	assert.Contains(t, handlers, "workload")
+	assert.Contains(t, handlers, "cronjob")
+	assert.Contains(t, handlers, "metrics")
+	assert.Contains(t, handlers, "diagnose")
 }
 
 func TestCreateToolset(t *testing.T) {
@@ -41,6 +71,12 @@ func TestCreateToolset(t *testing.T) {
 	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
 		return fakeClient, nil
 	}
+	getDynamicClient := func(ctx context.Context) (dynamic.Interface, error) {
+		return dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+	getRESTConfig := func(ctx context.Context) (*rest.Config, error) {
+		return &rest.Config{}, nil
+	}
 
 	// Create a registry
 	registry := toolsets.NewK8sResourceRegistry()
@@ -49,10 +85,10 @@ func TestCreateToolset(t *testing.T) {
 	readOnly := true
 
 	// Register all resources
-	RegisterAllK8sResources(registry, getClient, translations.NullTranslationHelper)
+	RegisterAllK8sResources(registry, getClient, getDynamicClient, getRESTConfig, translations.NullTranslationHelper, nil)
 
 	// Create a toolset
-	toolset := CreateToolset(registry, "test_toolset", readOnly)
+	toolset := CreateToolset(registry, "test_toolset", readOnly, nil)
 
 	// Verify that the toolset is created
 	assert.NotNil(t, toolset)
@@ -60,3 +96,88 @@ func TestCreateToolset(t *testing.T) {
 	// Check that the toolset has tools
 	assert.NotEmpty(t, toolset.Name)
 }
+
+func TestCreateToolsetConfigure(t *testing.T) {
+	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+
+	registry := toolsets.NewK8sResourceRegistry()
+	RegisterSelectedK8sResources(registry, getClient, nil, nil, translations.NullTranslationHelper, nil, []string{"pod", "namespace"})
+
+	configured := map[string][]string{}
+	toolset := CreateToolset(registry, "test_toolset", true, func(resourceType string, tools *toolsets.Toolset) {
+		for _, tool := range tools.GetAvailableTools() {
+			configured[resourceType] = append(configured[resourceType], tool.Tool.Name)
+		}
+	})
+
+	assert.Contains(t, configured["pod"], "get_pod")
+	assert.NotContains(t, configured["pod"], "list_namespaces")
+	assert.Contains(t, configured["namespace"], "list_namespaces")
+	assert.Len(t, toolset.GetAvailableTools(), len(configured["pod"])+len(configured["namespace"]))
+	assert.Equal(t, "core", toolset.Group("get_pod"))
+	assert.Equal(t, "core", toolset.Group("list_namespaces"))
+}
+
+func TestToolsets(t *testing.T) {
+	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+	registry := toolsets.NewK8sResourceRegistry()
+	RegisterAllK8sResources(registry, getClient, nil, nil, translations.NullTranslationHelper, nil)
+
+	// Every resource type belongs to exactly one toolset
+	seen := map[string]string{}
+	for _, toolset := range Toolsets {
+		assert.NotEmpty(t, toolset.Description)
+		for _, resourceType := range toolset.ResourceTypes {
+			assert.Empty(t, seen[resourceType], "%s is in %s and %s", resourceType, seen[resourceType], toolset.Name)
+			seen[resourceType] = toolset.Name
+			assert.Contains(t, registry.GetAllHandlers(), resourceType)
+		}
+	}
+	for resourceType := range registry.GetAllHandlers() {
+		assert.NotEmpty(t, ToolsetOf(resourceType), resourceType)
+	}
+
+	resourceTypes, err := ToolsetResourceTypes([]string{"networking", "storage"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service", "dns", "gateway", "storage"}, resourceTypes)
+
+	resourceTypes, err = ToolsetResourceTypes([]string{"all"})
+	require.NoError(t, err)
+	assert.Len(t, resourceTypes, len(registry.GetAllHandlers()))
+
+	_, err = ToolsetResourceTypes([]string{"core", "workload"})
+	assert.EqualError(t, err, `unknown toolset "workload": must be one of core, workloads, networking, storage, rbac, diagnostics, admin`)
+}
+
+func TestCreateToolsetAnnotations(t *testing.T) {
+	getClient := func(ctx context.Context) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+
+	registry := toolsets.NewK8sResourceRegistry()
+	RegisterAllK8sResources(registry, getClient, nil, nil, translations.NullTranslationHelper, nil)
+	toolset := CreateToolset(registry, "test_toolset", false, nil)
+
+	tools := map[string]mcp.ToolAnnotation{}
+	for _, tool := range toolset.GetActiveTools() {
+		tools[tool.Tool.Name] = tool.Tool.Annotations
+	}
+	for name, hints := range map[string][3]bool{
+		"list_pods":           {true, false, true},
+		"delete_pod":          {false, true, true},
+		"drain_node":          {false, true, true},
+		"exec_in_pod":         {false, true, false},
+		"rollout_undo":        {false, true, false},
+		"create_deployment":   {false, false, false},
+		"label_resource":      {false, false, true},
+		"scale_deployment":    {false, true, true},
+		"provision_namespace": {false, false, false},
+	} {
+		require.Contains(t, tools, name)
+		assert.Equal(t, hints, [3]bool{*tools[name].ReadOnlyHint, *tools[name].DestructiveHint, *tools[name].IdempotentHint}, name)
+	}
+}
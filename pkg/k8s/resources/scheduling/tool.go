@@ -0,0 +1,201 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Handler implements the K8sResourceHandler interface for scheduling-related cluster-scoped resources
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new scheduling resource handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// RegisterTools registers all scheduling resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	getPriorityClassTool, getPriorityClassHandler := h.GetPriorityClass()
+	toolset.AddReadTool(getPriorityClassTool, getPriorityClassHandler)
+
+	listPriorityClassesTool, listPriorityClassesHandler := h.ListPriorityClasses()
+	toolset.AddReadTool(listPriorityClassesTool, listPriorityClassesHandler)
+
+	getRuntimeClassTool, getRuntimeClassHandler := h.GetRuntimeClass()
+	toolset.AddReadTool(getRuntimeClassTool, getRuntimeClassHandler)
+
+	listRuntimeClassesTool, listRuntimeClassesHandler := h.ListRuntimeClasses()
+	toolset.AddReadTool(listRuntimeClassesTool, listRuntimeClassesHandler)
+}
+
+// GetPriorityClass creates a tool to get details of a specific priority class
+func (h *Handler) GetPriorityClass() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_priorityclass",
+			mcp.WithDescription(h.t("TOOL_GET_PRIORITYCLASS_DESCRIPTION", "Get details of a specific priority class")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("PriorityClass name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			priorityClass, err := client.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get priority class: %v", err)), nil
+			}
+
+			r, err := json.Marshal(priorityClass)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListPriorityClasses creates a tool to list all priority classes
+func (h *Handler) ListPriorityClasses() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_priorityclasses",
+			mcp.WithDescription(h.t("TOOL_LIST_PRIORITYCLASSES_DESCRIPTION", "List all priority classes in the cluster")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			priorityClasses, err := client.SchedulingV1().PriorityClasses().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list priority classes: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(priorityClasses.Items, priorityClasses.ListMeta)
+		}
+}
+
+// GetRuntimeClass creates a tool to get details of a specific runtime class
+func (h *Handler) GetRuntimeClass() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_runtimeclass",
+			mcp.WithDescription(h.t("TOOL_GET_RUNTIMECLASS_DESCRIPTION", "Get details of a specific runtime class")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("RuntimeClass name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			runtimeClass, err := client.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get runtime class: %v", err)), nil
+			}
+
+			r, err := json.Marshal(runtimeClass)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListRuntimeClasses creates a tool to list all runtime classes
+func (h *Handler) ListRuntimeClasses() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_runtimeclasses",
+			mcp.WithDescription(h.t("TOOL_LIST_RUNTIMECLASSES_DESCRIPTION", "List all runtime classes in the cluster")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			runtimeClasses, err := client.NodeV1().RuntimeClasses().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list runtime classes: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(runtimeClasses.Items, runtimeClasses.ListMeta)
+		}
+}
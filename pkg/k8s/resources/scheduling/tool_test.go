@@ -0,0 +1,168 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	nodev1 "k8s.io/api/node/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func TestGetPriorityClass(t *testing.T) {
+	testPriorityClass := &schedulingv1.PriorityClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "high-priority"},
+		Value:       1000000,
+		Description: "Critical workloads",
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), translations.NullTranslationHelper)
+	tool, _ := handler.GetPriorityClass()
+
+	assert.Equal(t, "get_priorityclass", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	tests := []struct {
+		name           string
+		client         kubernetes.Interface
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful priority class fetch",
+			client:      fake.NewSimpleClientset(testPriorityClass),
+			requestArgs: map[string]interface{}{"name": "high-priority"},
+		},
+		{
+			name:           "priority class not found",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to get priority class",
+		},
+		{
+			name:           "missing required param: name",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.GetPriorityClass()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			var returned schedulingv1.PriorityClass
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int32(1000000), returned.Value)
+		})
+	}
+}
+
+func TestListPriorityClasses(t *testing.T) {
+	pc1 := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high", Labels: map[string]string{"tier": "critical"}}, Value: 1000}
+	pc2 := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(pc1, pc2)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListPriorityClasses()
+
+	assert.Equal(t, "list_priorityclasses", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"labelSelector": "tier=critical"}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var returned schedulingv1.PriorityClassList
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.Items, 1)
+	assert.Equal(t, "high", returned.Items[0].Name)
+}
+
+func TestGetRuntimeClass(t *testing.T) {
+	testRuntimeClass := &nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gvisor"},
+		Handler:    "runsc",
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(testRuntimeClass)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.GetRuntimeClass()
+
+	assert.Equal(t, "get_runtimeclass", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "gvisor"}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var returned nodev1.RuntimeClass
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "runsc", returned.Handler)
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"name": "kata"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "failed to get runtime class")
+}
+
+func TestListRuntimeClasses(t *testing.T) {
+	rc1 := &nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "gvisor"}, Handler: "runsc"}
+	rc2 := &nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "kata"}, Handler: "kata"}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(rc1, rc2)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListRuntimeClasses()
+
+	assert.Equal(t, "list_runtimeclasses", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var returned nodev1.RuntimeClassList
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Len(t, returned.Items, 2)
+}
@@ -0,0 +1,736 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Encryption-at-rest detection results
+const (
+	EncryptionEnabled  = "enabled"
+	EncryptionDisabled = "disabled"
+	EncryptionUnknown  = "unknown"
+)
+
+// Handler implements the K8sResourceHandler interface for security audits
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new security audit handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// EncryptionAtRest reports whether the API server encrypts Secrets in etcd
+type EncryptionAtRest struct {
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// SecretExposure lists how a single Secret is consumed by pods
+type SecretExposure struct {
+	Namespace     string   `json:"namespace"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type,omitempty"`
+	Missing       bool     `json:"missing,omitempty"`
+	EnvConsumers  []string `json:"envConsumers,omitempty"`
+	FileConsumers []string `json:"fileConsumers,omitempty"`
+}
+
+// SharedSecret is a Secret name present in many namespaces
+type SharedSecret struct {
+	Name          string   `json:"name"`
+	Namespaces    []string `json:"namespaces"`
+	IdenticalData bool     `json:"identicalData"`
+}
+
+// SecretAudit is the result of a secret exposure audit
+type SecretAudit struct {
+	EncryptionAtRest EncryptionAtRest `json:"encryptionAtRest"`
+	Secrets          []SecretExposure `json:"secrets"`
+	Shared           []SharedSecret   `json:"shared"`
+}
+
+// RegisterTools registers all security audit tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	auditTool, auditHandler := h.AuditSecretExposure()
+	toolset.AddReadTool(auditTool, auditHandler)
+
+	portsTool, portsHandler := h.ListExposedPorts()
+	toolset.AddReadTool(portsTool, portsHandler)
+
+	canITool, canIHandler := h.CanI()
+	toolset.AddReadTool(canITool, canIHandler)
+}
+
+// AuditSecretExposure creates a tool reporting how Secrets are consumed and protected
+func (h *Handler) AuditSecretExposure() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("audit_secret_exposure",
+			mcp.WithDescription(h.t("TOOL_AUDIT_SECRET_EXPOSURE_DESCRIPTION", "Audit Secret hygiene: which pods consume which Secrets as environment variables versus mounted files, whether Secrets are encrypted at rest (where detectable), and Secrets copied across many namespaces. Secret values are never returned")),
+			mcp.WithString("namespace",
+				mcp.Description("Only audit pods and Secrets in this namespace (all namespaces if omitted)"),
+			),
+			mcp.WithNumber("minSharedNamespaces",
+				mcp.Description("Report Secret names present in at least this many namespaces (default: 3)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			minShared, err := toolsets.OptionalParam[float64](request, "minSharedNamespaces")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if minShared == 0 {
+				minShared = 3
+			}
+			if minShared < 2 {
+				return mcp.NewToolResultError("minSharedNamespaces must be at least 2"), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+			secrets, err := client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list secrets: %v", err)), nil
+			}
+
+			audit := SecretAudit{
+				EncryptionAtRest: detectEncryptionAtRest(ctx, client),
+				Secrets:          secretExposures(pods.Items, secrets.Items),
+				Shared:           sharedSecrets(secrets.Items, int(minShared)),
+			}
+
+			r, err := json.Marshal(audit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// detectEncryptionAtRest inspects the kube-apiserver static pods for an encryption provider
+// config. Managed control planes hide these pods, in which case the status is unknown.
+func detectEncryptionAtRest(ctx context.Context, client kubernetes.Interface) EncryptionAtRest {
+	pods, err := client.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{LabelSelector: "component=kube-apiserver"})
+	if err != nil {
+		return EncryptionAtRest{Status: EncryptionUnknown, Detail: fmt.Sprintf("failed to list kube-apiserver pods: %v", err)}
+	}
+	if len(pods.Items) == 0 {
+		return EncryptionAtRest{Status: EncryptionUnknown, Detail: "kube-apiserver pods are not visible (managed control plane?); check the provider's encryption settings"}
+	}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			for _, arg := range append(append([]string{}, c.Command...), c.Args...) {
+				if strings.HasPrefix(arg, "--encryption-provider-config") {
+					return EncryptionAtRest{Status: EncryptionEnabled, Detail: fmt.Sprintf("%s sets %s", pod.Name, arg)}
+				}
+			}
+		}
+	}
+	return EncryptionAtRest{Status: EncryptionDisabled, Detail: "kube-apiserver does not set --encryption-provider-config; Secrets are stored unencrypted in etcd"}
+}
+
+// secretExposures maps every Secret referenced by a pod to its env and file consumers
+func secretExposures(pods []corev1.Pod, secrets []corev1.Secret) []SecretExposure {
+	types := map[string]string{}
+	for _, s := range secrets {
+		types[s.Namespace+"/"+s.Name] = string(s.Type)
+	}
+
+	exposures := map[string]*SecretExposure{}
+	get := func(namespace, name string) *SecretExposure {
+		key := namespace + "/" + name
+		if e, ok := exposures[key]; ok {
+			return e
+		}
+		secretType, found := types[key]
+		e := &SecretExposure{Namespace: namespace, Name: name, Type: secretType, Missing: !found}
+		exposures[key] = e
+		return e
+	}
+
+	for _, pod := range pods {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, c := range containers {
+			consumer := pod.Name + "/" + c.Name
+			seen := map[string]bool{}
+			for _, env := range c.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && !seen[env.ValueFrom.SecretKeyRef.Name] {
+					seen[env.ValueFrom.SecretKeyRef.Name] = true
+					e := get(pod.Namespace, env.ValueFrom.SecretKeyRef.Name)
+					e.EnvConsumers = append(e.EnvConsumers, consumer)
+				}
+			}
+			for _, from := range c.EnvFrom {
+				if from.SecretRef != nil && !seen[from.SecretRef.Name] {
+					seen[from.SecretRef.Name] = true
+					e := get(pod.Namespace, from.SecretRef.Name)
+					e.EnvConsumers = append(e.EnvConsumers, consumer)
+				}
+			}
+		}
+
+		for _, name := range volumeSecrets(pod.Spec.Volumes) {
+			e := get(pod.Namespace, name)
+			e.FileConsumers = append(e.FileConsumers, pod.Name)
+		}
+	}
+
+	result := make([]SecretExposure, 0, len(exposures))
+	for _, e := range exposures {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// volumeSecrets returns the distinct Secrets mounted through secret or projected volumes
+func volumeSecrets(volumes []corev1.Volume) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, v := range volumes {
+		if v.Secret != nil {
+			add(v.Secret.SecretName)
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil {
+					add(source.Secret.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// sharedSecrets finds Secret names present in at least minNamespaces namespaces, comparing
+// their contents by hash so that no value leaves the cluster
+func sharedSecrets(secrets []corev1.Secret, minNamespaces int) []SharedSecret {
+	byName := map[string][]corev1.Secret{}
+	for _, s := range secrets {
+		if s.Type == corev1.SecretTypeServiceAccountToken {
+			continue
+		}
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+
+	shared := []SharedSecret{}
+	for name, copies := range byName {
+		if len(copies) < minNamespaces {
+			continue
+		}
+		entry := SharedSecret{Name: name, IdenticalData: true}
+		first := hashData(copies[0].Data)
+		for _, s := range copies {
+			entry.Namespaces = append(entry.Namespaces, s.Namespace)
+			if hashData(s.Data) != first {
+				entry.IdenticalData = false
+			}
+		}
+		sort.Strings(entry.Namespaces)
+		shared = append(shared, entry)
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].Name < shared[j].Name })
+	return shared
+}
+
+func hashData(data map[string][]byte) [sha256.Size]byte {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%x;", k, data[k])
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// Exposure levels of a host or node port
+const (
+	ExposurePublic     = "public"
+	ExposureRestricted = "restricted"
+	ExposureNode       = "node"
+)
+
+// HostPortUsage is a container port bound on the node, through hostPort or host networking
+type HostPortUsage struct {
+	Port        int32  `json:"port"`
+	Protocol    string `json:"protocol"`
+	HostIP      string `json:"hostIP,omitempty"`
+	HostNetwork bool   `json:"hostNetwork,omitempty"`
+	Node        string `json:"node,omitempty"`
+	Namespace   string `json:"namespace"`
+	Pod         string `json:"pod"`
+	Owner       string `json:"owner"`
+}
+
+// NodePortUsage is a service port allocated on every node
+type NodePortUsage struct {
+	Port         int32    `json:"port"`
+	Protocol     string   `json:"protocol"`
+	Namespace    string   `json:"namespace"`
+	Service      string   `json:"service"`
+	Type         string   `json:"type"`
+	Exposure     string   `json:"exposure"`
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+}
+
+// PortCollision is a port claimed by more than one workload or service
+type PortCollision struct {
+	Port     int32    `json:"port"`
+	Protocol string   `json:"protocol"`
+	Reason   string   `json:"reason"`
+	Users    []string `json:"users"`
+}
+
+// PortReport lists host ports and node ports in use and the collisions between them
+type PortReport struct {
+	HostPorts  []HostPortUsage `json:"hostPorts"`
+	NodePorts  []NodePortUsage `json:"nodePorts"`
+	Collisions []PortCollision `json:"collisions"`
+}
+
+// ListExposedPorts creates a tool reporting host ports and node ports in use across the cluster
+func (h *Handler) ListExposedPorts() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_exposed_ports",
+			mcp.WithDescription(h.t("TOOL_LIST_EXPOSED_PORTS_DESCRIPTION", "Enumerate hostPorts (including host-network pods) and NodePorts in use across the cluster, map them to their owning workloads and services, and flag collisions and publicly exposed load balancers")),
+			mcp.WithString("nodePortRange",
+				mcp.Description("Service node port range configured on the API server (default: 30000-32767)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			nodePortRange, err := toolsets.OptionalParam[string](request, "nodePortRange")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if nodePortRange == "" {
+				nodePortRange = "30000-32767"
+			}
+			rangeStart, rangeEnd, err := parsePortRange(nodePortRange)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pods: %v", err)), nil
+			}
+			replicaSets, err := client.AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list replicasets: %v", err)), nil
+			}
+			services, err := client.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list services: %v", err)), nil
+			}
+
+			// Pods created by deployments are owned through a ReplicaSet
+			replicaSetOwners := map[string]string{}
+			for _, rs := range replicaSets.Items {
+				if ref := metav1.GetControllerOf(&rs); ref != nil {
+					replicaSetOwners[rs.Namespace+"/"+rs.Name] = ref.Kind + "/" + ref.Name
+				}
+			}
+
+			report := PortReport{
+				HostPorts: hostPorts(pods.Items, replicaSetOwners),
+				NodePorts: nodePorts(services.Items),
+			}
+			report.Collisions = portCollisions(report.HostPorts, report.NodePorts, rangeStart, rangeEnd)
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func parsePortRange(s string) (int32, int32, error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid nodePortRange %q: expected <start>-<end>", s)
+	}
+	start, err1 := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 32)
+	end, err2 := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 32)
+	if err1 != nil || err2 != nil || start < 1 || end > 65535 || start > end {
+		return 0, 0, fmt.Errorf("invalid nodePortRange %q: expected <start>-<end> within 1-65535", s)
+	}
+	return int32(start), int32(end), nil
+}
+
+// hostPorts lists the ports bound on nodes by running or pending pods
+func hostPorts(pods []corev1.Pod, replicaSetOwners map[string]string) []HostPortUsage {
+	usages := []HostPortUsage{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		owner := "Pod/" + pod.Name
+		if ref := metav1.GetControllerOf(&pod); ref != nil {
+			owner = ref.Kind + "/" + ref.Name
+			if ref.Kind == "ReplicaSet" && replicaSetOwners[pod.Namespace+"/"+ref.Name] != "" {
+				owner = replicaSetOwners[pod.Namespace+"/"+ref.Name]
+			}
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				port := p.HostPort
+				if pod.Spec.HostNetwork && port == 0 {
+					port = p.ContainerPort
+				}
+				if port == 0 {
+					continue
+				}
+				protocol := p.Protocol
+				if protocol == "" {
+					protocol = corev1.ProtocolTCP
+				}
+				usages = append(usages, HostPortUsage{
+					Port:        port,
+					Protocol:    string(protocol),
+					HostIP:      p.HostIP,
+					HostNetwork: pod.Spec.HostNetwork,
+					Node:        pod.Spec.NodeName,
+					Namespace:   pod.Namespace,
+					Pod:         pod.Name,
+					Owner:       owner,
+				})
+			}
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].Port < usages[j].Port })
+	return usages
+}
+
+// nodePorts lists the node ports allocated to NodePort and LoadBalancer services
+func nodePorts(services []corev1.Service) []NodePortUsage {
+	usages := []NodePortUsage{}
+	for _, svc := range services {
+		if svc.Spec.Type != corev1.ServiceTypeNodePort && svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		exposure := ExposureNode
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			exposure = ExposurePublic
+			if len(svc.Spec.LoadBalancerSourceRanges) > 0 {
+				exposure = ExposureRestricted
+			}
+			for _, cidr := range svc.Spec.LoadBalancerSourceRanges {
+				if cidr == "0.0.0.0/0" || cidr == "::/0" {
+					exposure = ExposurePublic
+				}
+			}
+		}
+		for _, p := range svc.Spec.Ports {
+			if p.NodePort == 0 {
+				continue
+			}
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			usages = append(usages, NodePortUsage{
+				Port:         p.NodePort,
+				Protocol:     string(protocol),
+				Namespace:    svc.Namespace,
+				Service:      svc.Name,
+				Type:         string(svc.Spec.Type),
+				Exposure:     exposure,
+				SourceRanges: svc.Spec.LoadBalancerSourceRanges,
+			})
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].Port < usages[j].Port })
+	return usages
+}
+
+// portCollisions flags host ports claimed by several workloads, which can then never share
+// a node, and host ports inside the node port range that shadow an allocated node port
+func portCollisions(hostPorts []HostPortUsage, nodePorts []NodePortUsage, rangeStart, rangeEnd int32) []PortCollision {
+	type key struct {
+		port     int32
+		protocol string
+	}
+	owners := map[key][]string{}
+	var keys []key
+	for _, u := range hostPorts {
+		k := key{u.Port, u.Protocol}
+		user := u.Namespace + "/" + u.Owner
+		if _, ok := owners[k]; !ok {
+			keys = append(keys, k)
+		}
+		if !containsString(owners[k], user) {
+			owners[k] = append(owners[k], user)
+		}
+	}
+	services := map[key][]string{}
+	for _, u := range nodePorts {
+		k := key{u.Port, u.Protocol}
+		services[k] = append(services[k], "Service/"+u.Namespace+"/"+u.Service)
+	}
+
+	collisions := []PortCollision{}
+	for _, k := range keys {
+		users := owners[k]
+		sort.Strings(users)
+		if len(users) > 1 {
+			collisions = append(collisions, PortCollision{Port: k.port, Protocol: k.protocol, Reason: "hostPort used by multiple workloads; their pods cannot share a node", Users: users})
+		}
+		if svc, ok := services[k]; ok {
+			collisions = append(collisions, PortCollision{Port: k.port, Protocol: k.protocol, Reason: "hostPort shadows an allocated NodePort", Users: append(append([]string{}, users...), svc...)})
+		} else if k.port >= rangeStart && k.port <= rangeEnd {
+			collisions = append(collisions, PortCollision{Port: k.port, Protocol: k.protocol, Reason: "hostPort inside the NodePort range may collide with future NodePort allocations", Users: users})
+		}
+	}
+	return collisions
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessRule is a rule of the caller's permissions in a namespace
+type AccessRule struct {
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// AccessCheck is the result of a permission check
+type AccessCheck struct {
+	Verb      string `json:"verb"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Allowed   bool   `json:"allowed"`
+	// Denied is set when an authorizer explicitly denied the request, rather than no rule allowing it
+	Denied          bool   `json:"denied,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+	Explanation     string `json:"explanation"`
+	// Rules are the caller's permissions in the namespace, listed when requested
+	Rules []AccessRule `json:"rules,omitempty"`
+	// RulesIncomplete is set when an authorizer could not list all of the caller's rules
+	RulesIncomplete bool `json:"rulesIncomplete,omitempty"`
+}
+
+// CanI creates a tool checking whether the caller may perform an action, like kubectl auth can-i
+func (h *Handler) CanI() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("can_i",
+			mcp.WithDescription(h.t("TOOL_CAN_I_DESCRIPTION", "Check whether the identity the server acts as may perform an action, like kubectl auth can-i. Use it before attempting a write and to explain why an operation was denied. Optionally lists every permission held in the namespace")),
+			mcp.WithString("verb",
+				mcp.Required(),
+				mcp.Description("Verb to check, such as get, list, watch, create, update, patch, delete or * for all"),
+			),
+			mcp.WithString("resource",
+				mcp.Required(),
+				mcp.Description("Plural resource name, qualified with its API group and subresource when needed (e.g. pods, deployments.apps, pods/exec, * for all)"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace to check in (default: cluster-wide)"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Name of a single object to check"),
+			),
+			mcp.WithBoolean("listRules",
+				mcp.Description("Also list every permission held in the namespace (requires namespace)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			verb, err := toolsets.RequiredParam[string](request, "verb")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resource, err := toolsets.RequiredParam[string](request, "resource")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listRules, err := toolsets.OptionalParam[bool](request, "listRules")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if listRules && namespace == "" {
+				return mcp.NewToolResultError("listRules requires a namespace"), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			attributes := resourceAttributes(verb, resource)
+			attributes.Namespace = namespace
+			attributes.Name = name
+			review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to review access: %v", err)), nil
+			}
+
+			check := AccessCheck{
+				Verb:            verb,
+				Resource:        resource,
+				Namespace:       namespace,
+				Name:            name,
+				Allowed:         review.Status.Allowed,
+				Denied:          review.Status.Denied,
+				Reason:          review.Status.Reason,
+				EvaluationError: review.Status.EvaluationError,
+			}
+			check.Explanation = explainAccess(check)
+
+			if listRules {
+				rules, err := client.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+					Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to review rules: %v", err)), nil
+				}
+				check.Rules = accessRules(rules.Status)
+				check.RulesIncomplete = rules.Status.Incomplete
+			}
+
+			r, err := json.Marshal(check)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// resourceAttributes parses a resource written like kubectl auth can-i, such as
+// deployments.apps or pods/exec
+func resourceAttributes(verb, resource string) *authorizationv1.ResourceAttributes {
+	attributes := &authorizationv1.ResourceAttributes{Verb: verb}
+	resource, attributes.Subresource, _ = strings.Cut(resource, "/")
+	attributes.Resource, attributes.Group, _ = strings.Cut(resource, ".")
+	return attributes
+}
+
+// explainAccess describes the result of a permission check and, when denied, how to get access
+func explainAccess(check AccessCheck) string {
+	action := check.Verb + " " + check.Resource
+	if check.Name != "" {
+		action += fmt.Sprintf(" %q", check.Name)
+	}
+	scope := "cluster-wide"
+	if check.Namespace != "" {
+		scope = fmt.Sprintf("in namespace %q", check.Namespace)
+	}
+
+	if check.Allowed {
+		explanation := fmt.Sprintf("Allowed to %s %s", action, scope)
+		if check.Reason != "" {
+			explanation += ": " + check.Reason
+		}
+		return explanation
+	}
+
+	explanation := fmt.Sprintf("Not allowed to %s %s", action, scope)
+	switch {
+	case check.Denied && check.Reason != "":
+		explanation += ", explicitly denied: " + check.Reason
+	case check.Denied:
+		explanation += ", explicitly denied by an authorizer"
+	case check.Reason != "":
+		explanation += ": " + check.Reason
+	}
+	if check.EvaluationError != "" {
+		explanation += fmt.Sprintf(" (evaluation error: %s)", check.EvaluationError)
+	}
+	if check.Denied {
+		return explanation
+	}
+	binding := "a ClusterRole and ClusterRoleBinding"
+	if check.Namespace != "" {
+		binding = fmt.Sprintf("a Role and RoleBinding in namespace %q", check.Namespace)
+	}
+	return explanation + fmt.Sprintf(". Access requires %s granting the %q verb on %s", binding, check.Verb, check.Resource)
+}
+
+// accessRules flattens the resource and non-resource rules of a rules review
+func accessRules(status authorizationv1.SubjectRulesReviewStatus) []AccessRule {
+	rules := make([]AccessRule, 0, len(status.ResourceRules)+len(status.NonResourceRules))
+	for _, rule := range status.ResourceRules {
+		rules = append(rules, AccessRule{
+			Verbs:         rule.Verbs,
+			APIGroups:     rule.APIGroups,
+			Resources:     rule.Resources,
+			ResourceNames: rule.ResourceNames,
+		})
+	}
+	for _, rule := range status.NonResourceRules {
+		rules = append(rules, AccessRule{Verbs: rule.Verbs, NonResourceURLs: rule.NonResourceURLs})
+	}
+	return rules
+}
@@ -0,0 +1,371 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func secret(namespace, name, value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"value": []byte(value)},
+	}
+}
+
+func apiServerPod(args ...string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver-cp1", Namespace: "kube-system", Labels: map[string]string{"component": "kube-apiserver"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "kube-apiserver", Command: append([]string{"kube-apiserver"}, args...)}}},
+	}
+}
+
+func TestAuditSecretExposure(t *testing.T) {
+	app := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "web",
+				Env: []corev1.EnvVar{{
+					Name: "DB_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "value",
+					}},
+				}},
+				EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "api-keys"}}}},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "tls"}}},
+				{Name: "creds", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+					{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}}},
+				}}}},
+			},
+		},
+	}
+	objects := []runtime.Object{
+		app,
+		secret("shop", "db", "s3cret"),
+		secret("shop", "tls", "cert"),
+		secret("shop", "registry", "token"),
+		secret("blog", "registry", "token"),
+		secret("ci", "registry", "other"),
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(objects...)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.AuditSecretExposure()
+
+	assert.Equal(t, "audit_secret_exposure", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := getTextResult(t, result).Text
+	assert.NotContains(t, text, "s3cret")
+
+	var audit SecretAudit
+	require.NoError(t, json.Unmarshal([]byte(text), &audit))
+	assert.Equal(t, EncryptionUnknown, audit.EncryptionAtRest.Status)
+	assert.Equal(t, []SecretExposure{
+		{Namespace: "shop", Name: "api-keys", Missing: true, EnvConsumers: []string{"app/web"}},
+		{Namespace: "shop", Name: "db", Type: "Opaque", EnvConsumers: []string{"app/web"}, FileConsumers: []string{"app"}},
+		{Namespace: "shop", Name: "tls", Type: "Opaque", FileConsumers: []string{"app"}},
+	}, audit.Secrets)
+	assert.Equal(t, []SharedSecret{
+		{Name: "registry", Namespaces: []string{"blog", "ci", "shop"}, IdenticalData: false},
+	}, audit.Shared)
+
+	t.Run("single namespace with lower share threshold", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "blog", "minSharedNamespaces": float64(2)}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var audit SecretAudit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &audit))
+		assert.Empty(t, audit.Secrets)
+		assert.Empty(t, audit.Shared)
+	})
+
+	t.Run("invalid share threshold", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"minSharedNamespaces": float64(1)}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "minSharedNamespaces must be at least 2")
+	})
+}
+
+func TestDetectEncryptionAtRest(t *testing.T) {
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		expectedStatus string
+	}{
+		{
+			name:           "control plane not visible",
+			expectedStatus: EncryptionUnknown,
+		},
+		{
+			name:           "encryption provider configured",
+			objects:        []runtime.Object{apiServerPod("--encryption-provider-config=/etc/kubernetes/enc.yaml")},
+			expectedStatus: EncryptionEnabled,
+		},
+		{
+			name:           "encryption provider missing",
+			objects:        []runtime.Object{apiServerPod("--secure-port=6443")},
+			expectedStatus: EncryptionDisabled,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status := detectEncryptionAtRest(context.Background(), fake.NewSimpleClientset(tc.objects...))
+			assert.Equal(t, tc.expectedStatus, status.Status)
+		})
+	}
+}
+
+func hostPortPod(namespace, name, node string, owner *metav1.OwnerReference, ports ...corev1.ContainerPort) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Name: "main", Ports: ports}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func controller(kind, name string) *metav1.OwnerReference {
+	isController := true
+	return &metav1.OwnerReference{Kind: kind, Name: name, Controller: &isController}
+}
+
+func TestListExposedPorts(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "proxy-5d9c", Namespace: "edge", OwnerReferences: []metav1.OwnerReference{*controller("Deployment", "proxy")},
+	}}
+	hostNetworkPod := hostPortPod("kube-system", "node-exporter-abc", "node-1", controller("DaemonSet", "node-exporter"),
+		corev1.ContainerPort{ContainerPort: 9100})
+	hostNetworkPod.Spec.HostNetwork = true
+
+	objects := []runtime.Object{
+		replicaSet,
+		hostPortPod("edge", "proxy-5d9c-a", "node-1", controller("ReplicaSet", "proxy-5d9c"), corev1.ContainerPort{ContainerPort: 80, HostPort: 8080}),
+		hostPortPod("edge", "proxy-5d9c-b", "node-2", controller("ReplicaSet", "proxy-5d9c"), corev1.ContainerPort{ContainerPort: 80, HostPort: 8080}),
+		hostPortPod("debug", "netcat", "node-3", nil, corev1.ContainerPort{ContainerPort: 80, HostPort: 8080}),
+		hostPortPod("debug", "listener", "node-3", nil, corev1.ContainerPort{ContainerPort: 53, HostPort: 30053, Protocol: corev1.ProtocolUDP}),
+		hostPortPod("shop", "web", "node-1", nil, corev1.ContainerPort{ContainerPort: 80, HostPort: 30080}),
+		hostNetworkPod,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, Ports: []corev1.ServicePort{{Port: 80, NodePort: 30080}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Ports: []corev1.ServicePort{{Port: 443, NodePort: 31443}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "shop"},
+			Spec: corev1.ServiceSpec{
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				Ports:                    []corev1.ServicePort{{Port: 443, NodePort: 31444}},
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{{Port: 80}}},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(objects...)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListExposedPorts()
+
+	assert.Equal(t, "list_exposed_ports", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var report PortReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+
+	require.Len(t, report.HostPorts, 6)
+	owners := map[string]string{}
+	for _, u := range report.HostPorts {
+		owners[u.Pod] = u.Owner
+	}
+	assert.Equal(t, "Deployment/proxy", owners["proxy-5d9c-a"])
+	assert.Equal(t, "DaemonSet/node-exporter", owners["node-exporter-abc"])
+	assert.Equal(t, "Pod/netcat", owners["netcat"])
+
+	exposure := map[string]string{}
+	for _, u := range report.NodePorts {
+		exposure[u.Service] = u.Exposure
+	}
+	assert.Equal(t, map[string]string{"web": ExposureNode, "public": ExposurePublic, "admin": ExposureRestricted}, exposure)
+
+	assert.Equal(t, []PortCollision{
+		{Port: 8080, Protocol: "TCP", Reason: "hostPort used by multiple workloads; their pods cannot share a node", Users: []string{"debug/Pod/netcat", "edge/Deployment/proxy"}},
+		{Port: 30053, Protocol: "UDP", Reason: "hostPort inside the NodePort range may collide with future NodePort allocations", Users: []string{"debug/Pod/listener"}},
+		{Port: 30080, Protocol: "TCP", Reason: "hostPort shadows an allocated NodePort", Users: []string{"shop/Pod/web", "Service/shop/web"}},
+	}, report.Collisions)
+
+	t.Run("invalid node port range", func(t *testing.T) {
+		result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"nodePortRange": "32767-30000"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "invalid nodePortRange")
+	})
+}
+
+func TestCanI(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var reviewed []*authorizationv1.ResourceAttributes
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		reviewed = append(reviewed, attributes)
+		switch {
+		case attributes.Verb == "get":
+			review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: `RBAC: allowed by RoleBinding "viewers/shop"`}
+		case attributes.Subresource == "exec":
+			review.Status = authorizationv1.SubjectAccessReviewStatus{Denied: true, Reason: "exec is disabled by policy"}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		review.Status = authorizationv1.SubjectRulesReviewStatus{
+			ResourceRules:    []authorizationv1.ResourceRule{{Verbs: []string{"get", "list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}}},
+			NonResourceRules: []authorizationv1.NonResourceRule{{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz"}}},
+		}
+		return true, review, nil
+	})
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.CanI()
+
+	assert.Equal(t, "can_i", tool.Name)
+	assert.ElementsMatch(t, []string{"verb", "resource"}, tool.InputSchema.Required)
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]interface{}
+		expectedAttributes *authorizationv1.ResourceAttributes
+		expectedCheck      AccessCheck
+		expectedErrMsg     string
+	}{
+		{
+			name:               "allowed",
+			requestArgs:        map[string]interface{}{"verb": "get", "resource": "deployments.apps", "namespace": "shop", "name": "web"},
+			expectedAttributes: &authorizationv1.ResourceAttributes{Verb: "get", Group: "apps", Resource: "deployments", Namespace: "shop", Name: "web"},
+			expectedCheck: AccessCheck{
+				Verb: "get", Resource: "deployments.apps", Namespace: "shop", Name: "web", Allowed: true,
+				Reason:      `RBAC: allowed by RoleBinding "viewers/shop"`,
+				Explanation: `Allowed to get deployments.apps "web" in namespace "shop": RBAC: allowed by RoleBinding "viewers/shop"`,
+			},
+		},
+		{
+			name:               "no rule allows",
+			requestArgs:        map[string]interface{}{"verb": "delete", "resource": "nodes"},
+			expectedAttributes: &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "nodes"},
+			expectedCheck: AccessCheck{
+				Verb: "delete", Resource: "nodes",
+				Explanation: `Not allowed to delete nodes cluster-wide. Access requires a ClusterRole and ClusterRoleBinding granting the "delete" verb on nodes`,
+			},
+		},
+		{
+			name:               "explicitly denied subresource with rules",
+			requestArgs:        map[string]interface{}{"verb": "create", "resource": "pods/exec", "namespace": "shop", "listRules": true},
+			expectedAttributes: &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: "shop"},
+			expectedCheck: AccessCheck{
+				Verb: "create", Resource: "pods/exec", Namespace: "shop", Denied: true,
+				Reason:      "exec is disabled by policy",
+				Explanation: `Not allowed to create pods/exec in namespace "shop", explicitly denied: exec is disabled by policy`,
+				Rules: []AccessRule{
+					{Verbs: []string{"get", "list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+					{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz"}},
+				},
+			},
+		},
+		{
+			name:           "rules without namespace",
+			requestArgs:    map[string]interface{}{"verb": "get", "resource": "pods", "listRules": true},
+			expectedErrMsg: "listRules requires a namespace",
+		},
+		{
+			name:           "missing verb",
+			requestArgs:    map[string]interface{}{"resource": "pods"},
+			expectedErrMsg: "missing required parameter: verb",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewed = nil
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			text := getTextResult(t, result).Text
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			require.Len(t, reviewed, 1)
+			assert.Equal(t, tc.expectedAttributes, reviewed[0])
+
+			var check AccessCheck
+			require.NoError(t, json.Unmarshal([]byte(text), &check))
+			assert.Equal(t, tc.expectedCheck, check)
+		})
+	}
+}
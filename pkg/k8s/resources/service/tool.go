@@ -34,6 +34,10 @@ func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
 
 	listTool, listHandler := h.List()
 	toolset.AddReadTool(listTool, listHandler)
+
+	// Register write tools
+	deleteTool, deleteHandler := h.Delete()
+	toolset.AddWriteTool(deleteTool, deleteHandler)
 }
 
 // Get creates a tool to get details of a specific service
@@ -81,20 +85,18 @@ func (h *Handler) Get() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 // List creates a tool to list services in a namespace
 func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_services",
-			mcp.WithDescription(h.t("TOOL_LIST_SERVICES_DESCRIPTION", "List services in a namespace")),
-			mcp.WithString("namespace",
-				mcp.Required(),
-				mcp.Description("Kubernetes namespace"),
-			),
+			mcp.WithDescription(h.t("TOOL_LIST_SERVICES_DESCRIPTION", "List services in a namespace or across all namespaces")),
+			toolsets.WithListNamespace(),
 			mcp.WithString("fieldSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their fields"),
 			),
 			mcp.WithString("labelSelector",
 				mcp.Description("Selector to restrict the list of returned objects by their labels"),
 			),
+			toolsets.WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			namespace, err := toolsets.ListNamespace(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -118,17 +120,52 @@ func (h *Handler) List() (tool mcp.Tool, handler server.ToolHandlerFunc) {
 				FieldSelector: fieldSelector,
 				LabelSelector: labelSelector,
 			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			services, err := client.CoreV1().Services(namespace).List(ctx, options)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list services: %v", err)), nil
 			}
 
-			r, err := json.Marshal(services)
+			return toolsets.NewListPageResult(services.Items, services.ListMeta)
+		}
+}
+
+// Delete creates a tool to delete a service
+func (h *Handler) Delete() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_service",
+			mcp.WithDescription(h.t("TOOL_DELETE_SERVICE_DESCRIPTION", "Delete a service")),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Service name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			err = client.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete service: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Service %s in namespace %s deleted", name, namespace)), nil
 		}
 }
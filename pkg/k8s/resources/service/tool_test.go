@@ -34,13 +34,7 @@ func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
 // Helper function to create a MCP request
 func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
@@ -231,7 +225,8 @@ func TestListServices(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "namespace")
 	assert.Contains(t, tool.InputSchema.Properties, "fieldSelector")
 	assert.Contains(t, tool.InputSchema.Properties, "labelSelector")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace"})
+	assert.Contains(t, tool.InputSchema.Properties, "allNamespaces")
+	assert.Empty(t, tool.InputSchema.Required)
 
 	tests := []struct {
 		name                string
@@ -338,3 +333,51 @@ func TestListServices(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteService(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "delete service",
+			requestArgs: map[string]interface{}{"namespace": "default", "name": "web"},
+		},
+		{
+			name:           "service not found",
+			requestArgs:    map[string]interface{}{"namespace": "default", "name": "missing"},
+			expectedErrMsg: "failed to delete service",
+		},
+		{
+			name:           "missing name",
+			requestArgs:    map[string]interface{}{"namespace": "default"},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+			tool, handlerFn := handler.Delete()
+			assert.Equal(t, "delete_service", tool.Name)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"namespace", "name"})
+
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "Service web in namespace default deleted", getTextResult(t, result).Text)
+			_, err = client.CoreV1().Services("default").Get(context.Background(), "web", metav1.GetOptions{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeAttachmentError describes an attach or detach failure reported by the external attacher
+type VolumeAttachmentError struct {
+	Time    *metav1.Time `json:"time,omitempty"`
+	Message string       `json:"message"`
+}
+
+// VolumeAttachmentSummary is a compact view of a VolumeAttachment focused on its health
+type VolumeAttachmentSummary struct {
+	Name             string                 `json:"name"`
+	Attacher         string                 `json:"attacher"`
+	NodeName         string                 `json:"nodeName"`
+	PersistentVolume string                 `json:"persistentVolume,omitempty"`
+	Attached         bool                   `json:"attached"`
+	Detaching        bool                   `json:"detaching,omitempty"`
+	AttachError      *VolumeAttachmentError `json:"attachError,omitempty"`
+	DetachError      *VolumeAttachmentError `json:"detachError,omitempty"`
+	CreatedAt        metav1.Time            `json:"createdAt"`
+}
+
+// Handler implements the K8sResourceHandler interface for cluster-scoped storage resources
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new storage resource handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// RegisterTools registers all storage resource tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	getStorageClassTool, getStorageClassHandler := h.GetStorageClass()
+	toolset.AddReadTool(getStorageClassTool, getStorageClassHandler)
+
+	listStorageClassesTool, listStorageClassesHandler := h.ListStorageClasses()
+	toolset.AddReadTool(listStorageClassesTool, listStorageClassesHandler)
+
+	listCSIDriversTool, listCSIDriversHandler := h.ListCSIDrivers()
+	toolset.AddReadTool(listCSIDriversTool, listCSIDriversHandler)
+
+	listCSINodesTool, listCSINodesHandler := h.ListCSINodes()
+	toolset.AddReadTool(listCSINodesTool, listCSINodesHandler)
+
+	listVolumeAttachmentsTool, listVolumeAttachmentsHandler := h.ListVolumeAttachments()
+	toolset.AddReadTool(listVolumeAttachmentsTool, listVolumeAttachmentsHandler)
+}
+
+// GetStorageClass creates a tool to get details of a specific storage class
+func (h *Handler) GetStorageClass() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_storageclass",
+			mcp.WithDescription(h.t("TOOL_GET_STORAGECLASS_DESCRIPTION", "Get details of a specific storage class")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("StorageClass name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			storageClass, err := client.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get storage class: %v", err)), nil
+			}
+
+			r, err := json.Marshal(storageClass)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListStorageClasses creates a tool to list all storage classes
+func (h *Handler) ListStorageClasses() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_storageclasses",
+			mcp.WithDescription(h.t("TOOL_LIST_STORAGECLASSES_DESCRIPTION", "List all storage classes in the cluster")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			storageClasses, err := client.StorageV1().StorageClasses().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list storage classes: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(storageClasses.Items, storageClasses.ListMeta)
+		}
+}
+
+// ListCSIDrivers creates a tool to list all CSI drivers
+func (h *Handler) ListCSIDrivers() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_csidrivers",
+			mcp.WithDescription(h.t("TOOL_LIST_CSIDRIVERS_DESCRIPTION", "List all CSI drivers registered in the cluster")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			csiDrivers, err := client.StorageV1().CSIDrivers().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list CSI drivers: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(csiDrivers.Items, csiDrivers.ListMeta)
+		}
+}
+
+// ListCSINodes creates a tool to list CSI node objects and the drivers installed on each node
+func (h *Handler) ListCSINodes() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_csinodes",
+			mcp.WithDescription(h.t("TOOL_LIST_CSINODES_DESCRIPTION", "List CSINode objects showing which CSI drivers are registered on each node and their volume limits")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their fields"),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldSelector, err := toolsets.OptionalParam[string](request, "fieldSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{
+				FieldSelector: fieldSelector,
+				LabelSelector: labelSelector,
+			}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			csiNodes, err := client.StorageV1().CSINodes().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list CSI nodes: %v", err)), nil
+			}
+
+			return toolsets.NewListPageResult(csiNodes.Items, csiNodes.ListMeta)
+		}
+}
+
+// ListVolumeAttachments creates a tool to list volume attachments with their attach/detach errors
+func (h *Handler) ListVolumeAttachments() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_volumeattachments",
+			mcp.WithDescription(h.t("TOOL_LIST_VOLUMEATTACHMENTS_DESCRIPTION", "List VolumeAttachments with their attach state and any attach/detach errors, useful for diagnosing stuck volumes")),
+			mcp.WithString("nodeName",
+				mcp.Description("Only show attachments for this node"),
+			),
+			mcp.WithString("persistentVolume",
+				mcp.Description("Only show attachments for this PersistentVolume"),
+			),
+			mcp.WithBoolean("onlyProblems",
+				mcp.Description("Only show attachments that have errors, are not yet attached, or are stuck detaching"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			nodeName, err := toolsets.OptionalParam[string](request, "nodeName")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			persistentVolume, err := toolsets.OptionalParam[string](request, "persistentVolume")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			onlyProblems, err := toolsets.OptionalParam[bool](request, "onlyProblems")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			attachments, err := client.StorageV1().VolumeAttachments().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list volume attachments: %v", err)), nil
+			}
+
+			summaries := make([]VolumeAttachmentSummary, 0, len(attachments.Items))
+			for _, va := range attachments.Items {
+				summary := summarizeVolumeAttachment(va)
+				if nodeName != "" && summary.NodeName != nodeName {
+					continue
+				}
+				if persistentVolume != "" && summary.PersistentVolume != persistentVolume {
+					continue
+				}
+				if onlyProblems && !summary.hasProblem() {
+					continue
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return toolsets.NewListPageResult(summaries, attachments.ListMeta)
+		}
+}
+
+func summarizeVolumeAttachment(va storagev1.VolumeAttachment) VolumeAttachmentSummary {
+	summary := VolumeAttachmentSummary{
+		Name:      va.Name,
+		Attacher:  va.Spec.Attacher,
+		NodeName:  va.Spec.NodeName,
+		Attached:  va.Status.Attached,
+		Detaching: va.DeletionTimestamp != nil,
+		CreatedAt: va.CreationTimestamp,
+	}
+	if va.Spec.Source.PersistentVolumeName != nil {
+		summary.PersistentVolume = *va.Spec.Source.PersistentVolumeName
+	}
+	if e := va.Status.AttachError; e != nil {
+		summary.AttachError = &VolumeAttachmentError{Time: &e.Time, Message: e.Message}
+	}
+	if e := va.Status.DetachError; e != nil {
+		summary.DetachError = &VolumeAttachmentError{Time: &e.Time, Message: e.Message}
+	}
+	return summary
+}
+
+func (s VolumeAttachmentSummary) hasProblem() bool {
+	return s.AttachError != nil || s.DetachError != nil || !s.Attached || s.Detaching
+}
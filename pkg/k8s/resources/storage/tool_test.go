@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func TestGetStorageClass(t *testing.T) {
+	bindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	testStorageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "standard",
+			Annotations: map[string]string{
+				"storageclass.kubernetes.io/is-default-class": "true",
+			},
+		},
+		Provisioner:       "ebs.csi.aws.com",
+		VolumeBindingMode: &bindingMode,
+	}
+
+	// Verify tool definition
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset()), translations.NullTranslationHelper)
+	tool, _ := handler.GetStorageClass()
+
+	assert.Equal(t, "get_storageclass", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	tests := []struct {
+		name           string
+		client         kubernetes.Interface
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "successful storage class fetch",
+			client:      fake.NewSimpleClientset(testStorageClass),
+			requestArgs: map[string]interface{}{"name": "standard"},
+		},
+		{
+			name:           "storage class not found",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{"name": "missing"},
+			expectedErrMsg: "failed to get storage class",
+		},
+		{
+			name:           "missing required param: name",
+			client:         fake.NewSimpleClientset(),
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+			_, handlerFn := handler.GetStorageClass()
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			var returned storagev1.StorageClass
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, testStorageClass.Provisioner, returned.Provisioner)
+			assert.Equal(t, bindingMode, *returned.VolumeBindingMode)
+		})
+	}
+}
+
+func TestListStorageClasses(t *testing.T) {
+	sc1 := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast", Labels: map[string]string{"tier": "ssd"}}, Provisioner: "pd.csi.storage.gke.io"}
+	sc2 := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "slow"}, Provisioner: "pd.csi.storage.gke.io"}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(sc1, sc2)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListStorageClasses()
+
+	assert.Equal(t, "list_storageclasses", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]interface{}
+		expectedNames []string
+	}{
+		{
+			name:          "all storage classes",
+			requestArgs:   map[string]interface{}{},
+			expectedNames: []string{"fast", "slow"},
+		},
+		{
+			name:          "with label selector",
+			requestArgs:   map[string]interface{}{"labelSelector": "tier=ssd"},
+			expectedNames: []string{"fast"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+
+			var returned storagev1.StorageClassList
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+
+			var names []string
+			for _, sc := range returned.Items {
+				names = append(names, sc.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestListCSIDrivers(t *testing.T) {
+	attachRequired := true
+	driver := &storagev1.CSIDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "ebs.csi.aws.com"},
+		Spec:       storagev1.CSIDriverSpec{AttachRequired: &attachRequired},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(driver)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListCSIDrivers()
+
+	assert.Equal(t, "list_csidrivers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var returned storagev1.CSIDriverList
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	require.Len(t, returned.Items, 1)
+	assert.Equal(t, "ebs.csi.aws.com", returned.Items[0].Name)
+	assert.True(t, *returned.Items[0].Spec.AttachRequired)
+}
+
+func TestListCSINodes(t *testing.T) {
+	csiNode := &storagev1.CSINode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec: storagev1.CSINodeSpec{
+			Drivers: []storagev1.CSINodeDriver{{Name: "ebs.csi.aws.com", NodeID: "i-0123"}},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(csiNode)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListCSINodes()
+
+	assert.Equal(t, "list_csinodes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var returned storagev1.CSINodeList
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	require.Len(t, returned.Items, 1)
+	assert.Equal(t, "ebs.csi.aws.com", returned.Items[0].Spec.Drivers[0].Name)
+}
+
+func TestListVolumeAttachments(t *testing.T) {
+	pvHealthy := "pv-healthy"
+	pvStuck := "pv-stuck"
+	healthy := &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "csi-healthy"},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: "ebs.csi.aws.com",
+			NodeName: "node-1",
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvHealthy},
+		},
+		Status: storagev1.VolumeAttachmentStatus{Attached: true},
+	}
+	stuck := &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "csi-stuck"},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: "ebs.csi.aws.com",
+			NodeName: "node-2",
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvStuck},
+		},
+		Status: storagev1.VolumeAttachmentStatus{
+			Attached:    false,
+			AttachError: &storagev1.VolumeError{Message: "volume is already attached to another node"},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(healthy, stuck)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListVolumeAttachments()
+
+	assert.Equal(t, "list_volumeattachments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]interface{}
+		expectedNames []string
+	}{
+		{
+			name:          "all attachments",
+			requestArgs:   map[string]interface{}{},
+			expectedNames: []string{"csi-healthy", "csi-stuck"},
+		},
+		{
+			name:          "only problems",
+			requestArgs:   map[string]interface{}{"onlyProblems": true},
+			expectedNames: []string{"csi-stuck"},
+		},
+		{
+			name:          "filter by node",
+			requestArgs:   map[string]interface{}{"nodeName": "node-1"},
+			expectedNames: []string{"csi-healthy"},
+		},
+		{
+			name:          "filter by persistent volume",
+			requestArgs:   map[string]interface{}{"persistentVolume": "pv-stuck"},
+			expectedNames: []string{"csi-stuck"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+
+			var page struct {
+				Items []VolumeAttachmentSummary `json:"items"`
+			}
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &page)
+			require.NoError(t, err)
+
+			var names []string
+			for _, va := range page.Items {
+				names = append(names, va.Name)
+				if va.Name == "csi-stuck" {
+					require.NotNil(t, va.AttachError)
+					assert.Contains(t, va.AttachError.Message, "already attached")
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Toolset is a named group of resource types whose tools are enabled together
+type Toolset struct {
+	Name          string
+	Description   string
+	ResourceTypes []string
+}
+
+// Toolsets are the toolsets the --toolsets flag selects from. Every resource type belongs to
+// exactly one of them.
+var Toolsets = []Toolset{
+	{
+		Name:          "core",
+		Description:   "Pods, their logs, ConfigMaps, namespaces and generic resources of any kind",
+		ResourceTypes: []string{"pod", "logs", "configmap", "namespace", "generic"},
+	},
+	{
+		Name:          "workloads",
+		Description:   "Deployments, CronJobs, PodDisruptionBudgets, container images and desired-state bundles",
+		ResourceTypes: []string{"deployment", "workload", "cronjob", "pdb", "image", "bundle"},
+	},
+	{
+		Name:          "networking",
+		Description:   "Services, DNS, IngressClasses and the Gateway API",
+		ResourceTypes: []string{"service", "dns", "gateway"},
+	},
+	{
+		Name:          "storage",
+		Description:   "StorageClasses, CSI drivers and volume attachments",
+		ResourceTypes: []string{"storage"},
+	},
+	{
+		Name:          "rbac",
+		Description:   "Permission checks, secret exposure, policy reports and admission webhooks",
+		ResourceTypes: []string{"security", "policy", "webhook"},
+	},
+	{
+		Name:          "diagnostics",
+		Description:   "Troubleshooting analyzers, resource usage metrics and cluster overviews",
+		ResourceTypes: []string{"diagnose", "metrics", "cluster"},
+	},
+	{
+		Name:          "admin",
+		Description:   "Nodes, scheduling classes and leases",
+		ResourceTypes: []string{"node", "scheduling", "lease"},
+	},
+}
+
+// ToolsetOf returns the name of the toolset a resource type belongs to
+func ToolsetOf(resourceType string) string {
+	for _, toolset := range Toolsets {
+		if slices.Contains(toolset.ResourceTypes, resourceType) {
+			return toolset.Name
+		}
+	}
+	return ""
+}
+
+// ValidateToolset checks that a toolset exists
+func ValidateToolset(name string) error {
+	names := make([]string, 0, len(Toolsets))
+	for _, toolset := range Toolsets {
+		if toolset.Name == name {
+			return nil
+		}
+		names = append(names, toolset.Name)
+	}
+	return fmt.Errorf("unknown toolset %q: must be one of %s", name, strings.Join(names, ", "))
+}
+
+// ToolsetResourceTypes returns the resource types of the named toolsets, or of all toolsets for
+// "all"
+func ToolsetResourceTypes(names []string) ([]string, error) {
+	var resourceTypes []string
+	for _, toolset := range Toolsets {
+		if slices.Contains(names, "all") || slices.Contains(names, toolset.Name) {
+			resourceTypes = append(resourceTypes, toolset.ResourceTypes...)
+		}
+	}
+	for _, name := range names {
+		if name == "all" {
+			continue
+		}
+		if err := ValidateToolset(name); err != nil {
+			return nil, err
+		}
+	}
+	return resourceTypes, nil
+}
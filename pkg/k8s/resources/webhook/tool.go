@@ -0,0 +1,575 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// probeLabels are set on every probe object so object selectors can be evaluated against them
+var probeLabels = map[string]string{"app.kubernetes.io/name": "webhook-probe", "app.kubernetes.io/managed-by": "k8s-mcp-server"}
+
+// webhookError extracts the webhook name from admission errors returned by the API server
+var webhookError = regexp.MustCompile(`admission webhook "([^"]+)"|failed calling webhook "([^"]+)"`)
+
+// Handler implements the K8sResourceHandler interface for admission webhook configurations
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new webhook configuration handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// WebhookRule is a flattened admission rule
+type WebhookRule struct {
+	Operations []string `json:"operations"`
+	APIGroups  []string `json:"apiGroups"`
+	Resources  []string `json:"resources"`
+	Scope      string   `json:"scope,omitempty"`
+}
+
+// WebhookSummary is a compact view of a single webhook within a configuration
+type WebhookSummary struct {
+	Name              string        `json:"name"`
+	FailurePolicy     string        `json:"failurePolicy"`
+	SideEffects       string        `json:"sideEffects,omitempty"`
+	TimeoutSeconds    int32         `json:"timeoutSeconds"`
+	Endpoint          string        `json:"endpoint"`
+	NamespaceSelector string        `json:"namespaceSelector,omitempty"`
+	ObjectSelector    string        `json:"objectSelector,omitempty"`
+	Rules             []WebhookRule `json:"rules"`
+}
+
+// ConfigurationSummary is a compact view of a mutating or validating webhook configuration
+type ConfigurationSummary struct {
+	Name     string           `json:"name"`
+	Webhooks []WebhookSummary `json:"webhooks"`
+}
+
+// RegisterTools registers all webhook configuration tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	listMutatingTool, listMutatingHandler := h.ListMutatingWebhookConfigurations()
+	toolset.AddReadTool(listMutatingTool, listMutatingHandler)
+
+	listValidatingTool, listValidatingHandler := h.ListValidatingWebhookConfigurations()
+	toolset.AddReadTool(listValidatingTool, listValidatingHandler)
+
+	// Probe requests are server-side dry runs, so nothing is ever persisted
+	probeTool, probeHandler := h.ProbeLatency()
+	toolset.AddReadTool(probeTool, probeHandler)
+}
+
+// ListMutatingWebhookConfigurations creates a tool to list mutating webhook configurations
+func (h *Handler) ListMutatingWebhookConfigurations() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_mutatingwebhookconfigurations",
+			mcp.WithDescription(h.t("TOOL_LIST_MUTATINGWEBHOOKCONFIGURATIONS_DESCRIPTION", "List mutating admission webhook configurations with a compact view of their rules, endpoints and failure policy")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{LabelSelector: labelSelector}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			configs, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list mutating webhook configurations: %v", err)), nil
+			}
+
+			summaries := make([]ConfigurationSummary, 0, len(configs.Items))
+			for _, cfg := range configs.Items {
+				summary := ConfigurationSummary{Name: cfg.Name, Webhooks: make([]WebhookSummary, 0, len(cfg.Webhooks))}
+				for _, wh := range cfg.Webhooks {
+					summary.Webhooks = append(summary.Webhooks, summarizeWebhook(wh.Name, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds,
+						wh.ClientConfig, wh.NamespaceSelector, wh.ObjectSelector, wh.Rules))
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return toolsets.NewListPageResult(summaries, configs.ListMeta)
+		}
+}
+
+// ListValidatingWebhookConfigurations creates a tool to list validating webhook configurations
+func (h *Handler) ListValidatingWebhookConfigurations() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_validatingwebhookconfigurations",
+			mcp.WithDescription(h.t("TOOL_LIST_VALIDATINGWEBHOOKCONFIGURATIONS_DESCRIPTION", "List validating admission webhook configurations with a compact view of their rules, endpoints and failure policy")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Selector to restrict the list of returned objects by their labels"),
+			),
+			toolsets.WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			labelSelector, err := toolsets.OptionalParam[string](request, "labelSelector")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			options := metav1.ListOptions{LabelSelector: labelSelector}
+			if err := toolsets.ApplyPagination(request, &options); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			configs, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, options)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list validating webhook configurations: %v", err)), nil
+			}
+
+			summaries := make([]ConfigurationSummary, 0, len(configs.Items))
+			for _, cfg := range configs.Items {
+				summary := ConfigurationSummary{Name: cfg.Name, Webhooks: make([]WebhookSummary, 0, len(cfg.Webhooks))}
+				for _, wh := range cfg.Webhooks {
+					summary.Webhooks = append(summary.Webhooks, summarizeWebhook(wh.Name, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds,
+						wh.ClientConfig, wh.NamespaceSelector, wh.ObjectSelector, wh.Rules))
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return toolsets.NewListPageResult(summaries, configs.ListMeta)
+		}
+}
+
+// summarizeWebhook flattens the fields shared by mutating and validating webhooks,
+// filling in the API server defaults for unset values
+func summarizeWebhook(
+	name string,
+	failurePolicy *admissionregistrationv1.FailurePolicyType,
+	sideEffects *admissionregistrationv1.SideEffectClass,
+	timeoutSeconds *int32,
+	clientConfig admissionregistrationv1.WebhookClientConfig,
+	namespaceSelector, objectSelector *metav1.LabelSelector,
+	rules []admissionregistrationv1.RuleWithOperations,
+) WebhookSummary {
+	summary := WebhookSummary{
+		Name:              name,
+		FailurePolicy:     string(admissionregistrationv1.Fail),
+		TimeoutSeconds:    10,
+		Endpoint:          endpoint(clientConfig),
+		NamespaceSelector: selectorString(namespaceSelector),
+		ObjectSelector:    selectorString(objectSelector),
+		Rules:             make([]WebhookRule, 0, len(rules)),
+	}
+	if failurePolicy != nil {
+		summary.FailurePolicy = string(*failurePolicy)
+	}
+	if sideEffects != nil {
+		summary.SideEffects = string(*sideEffects)
+	}
+	if timeoutSeconds != nil {
+		summary.TimeoutSeconds = *timeoutSeconds
+	}
+	for _, rule := range rules {
+		operations := make([]string, 0, len(rule.Operations))
+		for _, op := range rule.Operations {
+			operations = append(operations, string(op))
+		}
+		flattened := WebhookRule{
+			Operations: operations,
+			APIGroups:  rule.APIGroups,
+			Resources:  rule.Resources,
+		}
+		if rule.Scope != nil {
+			flattened.Scope = string(*rule.Scope)
+		}
+		summary.Rules = append(summary.Rules, flattened)
+	}
+	return summary
+}
+
+func endpoint(cfg admissionregistrationv1.WebhookClientConfig) string {
+	if cfg.URL != nil {
+		return *cfg.URL
+	}
+	if cfg.Service == nil {
+		return ""
+	}
+	port := int32(443)
+	if cfg.Service.Port != nil {
+		port = *cfg.Service.Port
+	}
+	path := ""
+	if cfg.Service.Path != nil {
+		path = *cfg.Service.Path
+	}
+	return fmt.Sprintf("service/%s/%s:%d%s", cfg.Service.Namespace, cfg.Service.Name, port, path)
+}
+
+func selectorString(selector *metav1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	s := metav1.FormatLabelSelector(selector)
+	if s == "<none>" {
+		return ""
+	}
+	return s
+}
+
+// ProbeResult is the admission outcome of dry-run creating one representative object
+type ProbeResult struct {
+	Resource  string   `json:"resource"`
+	LatencyMs float64  `json:"latencyMs"`
+	Webhooks  []string `json:"webhooks"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// WebhookImpact estimates the latency and failure impact of a single webhook
+type WebhookImpact struct {
+	Configuration      string   `json:"configuration"`
+	Name               string   `json:"name"`
+	Type               string   `json:"type"`
+	FailurePolicy      string   `json:"failurePolicy"`
+	TimeoutSeconds     int32    `json:"timeoutSeconds"`
+	Probes             []string `json:"probes"`
+	EstimatedLatencyMs float64  `json:"estimatedLatencyMs"`
+	Rejected           bool     `json:"rejected,omitempty"`
+}
+
+// LatencyReport is the result of a webhook latency probe
+type LatencyReport struct {
+	Namespace  string          `json:"namespace"`
+	Samples    int             `json:"samples"`
+	BaselineMs float64         `json:"baselineMs"`
+	Probes     []ProbeResult   `json:"probes"`
+	Webhooks   []WebhookImpact `json:"webhooks"`
+	Note       string          `json:"note"`
+}
+
+// probe is a representative object created with a server-side dry run
+type probe struct {
+	gvr    schema.GroupVersionResource
+	create func(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.CreateOptions) error
+}
+
+var probes = []probe{
+	{
+		gvr: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		create: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.CreateOptions) error {
+			_, err := client.CoreV1().ConfigMaps(namespace).Create(ctx, &corev1.ConfigMap{ObjectMeta: probeMeta(namespace)}, opts)
+			return err
+		},
+	},
+	{
+		gvr: schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		create: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.CreateOptions) error {
+			_, err := client.CoreV1().Services(namespace).Create(ctx, &corev1.Service{
+				ObjectMeta: probeMeta(namespace),
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+			}, opts)
+			return err
+		},
+	},
+	{
+		gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+		create: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.CreateOptions) error {
+			_, err := client.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
+				ObjectMeta: probeMeta(namespace),
+				Spec:       probePodSpec(),
+			}, opts)
+			return err
+		},
+	},
+	{
+		gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		create: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.CreateOptions) error {
+			_, err := client.AppsV1().Deployments(namespace).Create(ctx, &appsv1.Deployment{
+				ObjectMeta: probeMeta(namespace),
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: probeLabels},
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: probeLabels}, Spec: probePodSpec()},
+				},
+			}, opts)
+			return err
+		},
+	},
+}
+
+func probeMeta(namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: "webhook-probe-" + utilrand.String(5), Namespace: namespace, Labels: probeLabels}
+}
+
+func probePodSpec() corev1.PodSpec {
+	return corev1.PodSpec{Containers: []corev1.Container{{Name: "probe", Image: "registry.k8s.io/pause:3.10"}}}
+}
+
+// matchedWebhook is a webhook that intercepts CREATE requests for at least one probe
+type matchedWebhook struct {
+	impact WebhookImpact
+	rules  []admissionregistrationv1.RuleWithOperations
+	nsSel  *metav1.LabelSelector
+	objSel *metav1.LabelSelector
+}
+
+// ProbeLatency creates a tool to measure admission latency attributable to webhooks
+func (h *Handler) ProbeLatency() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("probe_webhook_latency",
+			mcp.WithDescription(h.t("TOOL_PROBE_WEBHOOK_LATENCY_DESCRIPTION", "Issue server-side dry-run creates of representative objects (configmap, service, pod, deployment), measure admission latency, and attribute it to the mutating and validating webhooks that intercept each request. Also reports webhooks that reject the requests")),
+			mcp.WithString("namespace",
+				mcp.Description("Namespace to dry-run the probe objects in (default: default)"),
+			),
+			mcp.WithNumber("samples",
+				mcp.Description("Dry-run requests per probe; the median latency is reported (default: 3)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			samples, err := toolsets.OptionalParam[float64](request, "samples")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if namespace == "" {
+				namespace = metav1.NamespaceDefault
+			}
+			if samples == 0 {
+				samples = 3
+			}
+			if samples < 1 || samples > 20 {
+				return mcp.NewToolResultError("samples must be between 1 and 20"), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			webhooks, err := listWebhooks(ctx, client)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var namespaceLabels labels.Set
+			if ns, err := client.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err == nil {
+				namespaceLabels = ns.Labels
+			}
+
+			report := LatencyReport{
+				Namespace: namespace,
+				Samples:   int(samples),
+				Probes:    make([]ProbeResult, 0, len(probes)),
+				Note:      "latency above the baseline is split evenly between the webhooks matching a probe; the estimate per webhook is the lowest across its probes",
+			}
+			for _, p := range probes {
+				result := ProbeResult{Resource: p.gvr.GroupResource().String(), Webhooks: []string{}}
+				for i := range webhooks {
+					if webhooks[i].matches(p.gvr, namespaceLabels) {
+						result.Webhooks = append(result.Webhooks, webhooks[i].impact.Name)
+						webhooks[i].impact.Probes = append(webhooks[i].impact.Probes, result.Resource)
+					}
+				}
+
+				latencies := make([]time.Duration, 0, int(samples))
+				for i := 0; i < int(samples); i++ {
+					start := time.Now()
+					err := p.create(ctx, client, namespace, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+					latencies = append(latencies, time.Since(start))
+					if err != nil {
+						result.Error = err.Error()
+						if m := webhookError.FindStringSubmatch(err.Error()); m != nil {
+							markRejected(webhooks, m[1]+m[2])
+						}
+						break
+					}
+				}
+				result.LatencyMs = milliseconds(median(latencies))
+				report.Probes = append(report.Probes, result)
+			}
+
+			report.BaselineMs, report.Webhooks = attribute(report.Probes, webhooks)
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func listWebhooks(ctx context.Context, client kubernetes.Interface) ([]matchedWebhook, error) {
+	mutating, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %v", err)
+	}
+	validating, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %v", err)
+	}
+
+	var webhooks []matchedWebhook
+	for _, cfg := range mutating.Items {
+		for _, wh := range cfg.Webhooks {
+			summary := summarizeWebhook(wh.Name, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.ClientConfig, wh.NamespaceSelector, wh.ObjectSelector, wh.Rules)
+			webhooks = append(webhooks, matchedWebhook{
+				impact: WebhookImpact{Configuration: cfg.Name, Name: wh.Name, Type: "mutating", FailurePolicy: summary.FailurePolicy, TimeoutSeconds: summary.TimeoutSeconds, Probes: []string{}},
+				rules:  wh.Rules, nsSel: wh.NamespaceSelector, objSel: wh.ObjectSelector,
+			})
+		}
+	}
+	for _, cfg := range validating.Items {
+		for _, wh := range cfg.Webhooks {
+			summary := summarizeWebhook(wh.Name, wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.ClientConfig, wh.NamespaceSelector, wh.ObjectSelector, wh.Rules)
+			webhooks = append(webhooks, matchedWebhook{
+				impact: WebhookImpact{Configuration: cfg.Name, Name: wh.Name, Type: "validating", FailurePolicy: summary.FailurePolicy, TimeoutSeconds: summary.TimeoutSeconds, Probes: []string{}},
+				rules:  wh.Rules, nsSel: wh.NamespaceSelector, objSel: wh.ObjectSelector,
+			})
+		}
+	}
+	return webhooks, nil
+}
+
+// matches reports whether the webhook intercepts a CREATE of the given resource in a
+// namespace with the given labels. Match conditions are not evaluated.
+func (w matchedWebhook) matches(gvr schema.GroupVersionResource, namespaceLabels labels.Set) bool {
+	if !selectorMatches(w.nsSel, namespaceLabels) || !selectorMatches(w.objSel, probeLabels) {
+		return false
+	}
+	for _, rule := range w.rules {
+		if rule.Scope != nil && *rule.Scope == admissionregistrationv1.ClusterScope {
+			continue
+		}
+		operation := false
+		for _, op := range rule.Operations {
+			if op == admissionregistrationv1.Create || op == admissionregistrationv1.OperationAll {
+				operation = true
+			}
+		}
+		if operation && containsOrWildcard(rule.APIGroups, gvr.Group) && containsOrWildcard(rule.APIVersions, gvr.Version) &&
+			(containsOrWildcard(rule.Resources, gvr.Resource) || containsOrWildcard(rule.Resources, "*/*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set labels.Set) bool {
+	if selector == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(set)
+}
+
+func containsOrWildcard(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func markRejected(webhooks []matchedWebhook, name string) {
+	for i := range webhooks {
+		if webhooks[i].impact.Name == name {
+			webhooks[i].impact.Rejected = true
+		}
+	}
+}
+
+// attribute estimates per-webhook latency from the probe results. The baseline is the
+// fastest probe no webhook intercepts; without one, the fastest probe overall.
+func attribute(results []ProbeResult, webhooks []matchedWebhook) (float64, []WebhookImpact) {
+	baseline := -1.0
+	fastest := -1.0
+	for _, r := range results {
+		if fastest < 0 || r.LatencyMs < fastest {
+			fastest = r.LatencyMs
+		}
+		if len(r.Webhooks) == 0 && (baseline < 0 || r.LatencyMs < baseline) {
+			baseline = r.LatencyMs
+		}
+	}
+	if baseline < 0 {
+		baseline = fastest
+	}
+	if baseline < 0 {
+		baseline = 0
+	}
+
+	excess := map[string]float64{}
+	for _, r := range results {
+		if len(r.Webhooks) > 0 {
+			share := (r.LatencyMs - baseline) / float64(len(r.Webhooks))
+			if share < 0 {
+				share = 0
+			}
+			excess[r.Resource] = share
+		}
+	}
+
+	impacts := []WebhookImpact{}
+	for _, w := range webhooks {
+		if len(w.impact.Probes) == 0 {
+			continue
+		}
+		impact := w.impact
+		impact.EstimatedLatencyMs = -1
+		for _, resource := range impact.Probes {
+			if share := excess[resource]; impact.EstimatedLatencyMs < 0 || share < impact.EstimatedLatencyMs {
+				impact.EstimatedLatencyMs = share
+			}
+		}
+		impacts = append(impacts, impact)
+	}
+	sort.SliceStable(impacts, func(i, j int) bool { return impacts[i].EstimatedLatencyMs > impacts[j].EstimatedLatencyMs })
+	return baseline, impacts
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
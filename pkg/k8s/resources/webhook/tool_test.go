@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+var testRules = []admissionregistrationv1.RuleWithOperations{
+	{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"apps"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"deployments"},
+		},
+	},
+}
+
+func TestListMutatingWebhookConfigurations(t *testing.T) {
+	ignore := admissionregistrationv1.Ignore
+	port := int32(9443)
+	path := "/mutate"
+	config := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:          "sidecar-injector.istio.io",
+				FailurePolicy: &ignore,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Namespace: "istio-system", Name: "istiod", Port: &port, Path: &path},
+				},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"istio-injection": "enabled"}},
+				Rules:             testRules,
+			},
+		},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(config)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListMutatingWebhookConfigurations()
+
+	assert.Equal(t, "list_mutatingwebhookconfigurations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var page struct {
+		Items []ConfigurationSummary `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+	summaries := page.Items
+	require.Len(t, summaries, 1)
+	require.Len(t, summaries[0].Webhooks, 1)
+
+	wh := summaries[0].Webhooks[0]
+	assert.Equal(t, "Ignore", wh.FailurePolicy)
+	assert.Equal(t, int32(10), wh.TimeoutSeconds)
+	assert.Equal(t, "service/istio-system/istiod:9443/mutate", wh.Endpoint)
+	assert.Equal(t, "istio-injection=enabled", wh.NamespaceSelector)
+	require.Len(t, wh.Rules, 1)
+	assert.Equal(t, []string{"CREATE", "UPDATE"}, wh.Rules[0].Operations)
+	assert.Equal(t, []string{"deployments"}, wh.Rules[0].Resources)
+}
+
+func TestListValidatingWebhookConfigurations(t *testing.T) {
+	url := "https://policy.example.com/validate"
+	timeout := int32(30)
+	withURL := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-policy", Labels: map[string]string{"team": "security"}},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:           "policy.example.com",
+				TimeoutSeconds: &timeout,
+				ClientConfig:   admissionregistrationv1.WebhookClientConfig{URL: &url},
+				Rules:          testRules,
+			},
+		},
+	}
+	other := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+	}
+
+	handler := NewHandler(stubGetClientFn(fake.NewSimpleClientset(withURL, other)), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ListValidatingWebhookConfigurations()
+
+	assert.Equal(t, "list_validatingwebhookconfigurations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]interface{}
+		expectedNames []string
+	}{
+		{
+			name:          "all configurations",
+			requestArgs:   map[string]interface{}{},
+			expectedNames: []string{"external-policy", "other"},
+		},
+		{
+			name:          "with label selector",
+			requestArgs:   map[string]interface{}{"labelSelector": "team=security"},
+			expectedNames: []string{"external-policy"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var page struct {
+				Items []ConfigurationSummary `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+
+			var names []string
+			for _, s := range page.Items {
+				names = append(names, s.Name)
+				if s.Name == "external-policy" {
+					require.Len(t, s.Webhooks, 1)
+					assert.Equal(t, "Fail", s.Webhooks[0].FailurePolicy)
+					assert.Equal(t, int32(30), s.Webhooks[0].TimeoutSeconds)
+					assert.Equal(t, url, s.Webhooks[0].Endpoint)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func createRule(group, version, resource string) admissionregistrationv1.RuleWithOperations {
+	return admissionregistrationv1.RuleWithOperations{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule:       admissionregistrationv1.Rule{APIGroups: []string{group}, APIVersions: []string{version}, Resources: []string{resource}},
+	}
+}
+
+func TestProbeWebhookLatency(t *testing.T) {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sidecar-injector"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name:  "inject.example.com",
+			Rules: []admissionregistrationv1.RuleWithOperations{createRule("", "v1", "pods")},
+		}},
+	}
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:  "deny.example.com",
+				Rules: []admissionregistrationv1.RuleWithOperations{createRule("apps", "*", "*")},
+			},
+			{
+				Name:              "prod-only.example.com",
+				Rules:             []admissionregistrationv1.RuleWithOperations{createRule("*", "*", "*")},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"env": "dev"}}}
+
+	client := fake.NewSimpleClientset(mutating, validating, namespace)
+	client.PrependReactor("create", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "probe",
+			errors.New(`admission webhook "deny.example.com" denied the request: missing owner label`))
+	})
+
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ProbeLatency()
+
+	assert.Equal(t, "probe_webhook_latency", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "shop", "samples": float64(1)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var report LatencyReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+	assert.Equal(t, "shop", report.Namespace)
+
+	probesByResource := map[string]ProbeResult{}
+	for _, p := range report.Probes {
+		probesByResource[p.Resource] = p
+	}
+	assert.Empty(t, probesByResource["configmaps"].Webhooks)
+	assert.Equal(t, []string{"inject.example.com"}, probesByResource["pods"].Webhooks)
+	assert.Equal(t, []string{"deny.example.com"}, probesByResource["deployments.apps"].Webhooks)
+	assert.Contains(t, probesByResource["deployments.apps"].Error, "denied the request")
+	assert.Empty(t, probesByResource["pods"].Error)
+
+	impacts := map[string]WebhookImpact{}
+	for _, w := range report.Webhooks {
+		impacts[w.Name] = w
+	}
+	require.Len(t, impacts, 2, "webhooks limited to other namespaces are not reported")
+	assert.True(t, impacts["deny.example.com"].Rejected)
+	assert.Equal(t, "validating", impacts["deny.example.com"].Type)
+	assert.Equal(t, "Fail", impacts["inject.example.com"].FailurePolicy)
+	assert.Equal(t, int32(10), impacts["inject.example.com"].TimeoutSeconds)
+
+	// Dry runs must never persist the probe objects
+	for _, action := range client.Actions() {
+		if create, ok := action.(k8stesting.CreateActionImpl); ok {
+			assert.Equal(t, []string{metav1.DryRunAll}, create.CreateOptions.DryRun)
+		}
+	}
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"samples": float64(50)}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "samples must be between 1 and 20")
+}
+
+func TestAttribute(t *testing.T) {
+	webhook := func(name string, probes ...string) matchedWebhook {
+		return matchedWebhook{impact: WebhookImpact{Name: name, Probes: probes}}
+	}
+	results := []ProbeResult{
+		{Resource: "configmaps", LatencyMs: 10, Webhooks: []string{}},
+		{Resource: "pods", LatencyMs: 110, Webhooks: []string{"slow", "fast"}},
+		{Resource: "services", LatencyMs: 30, Webhooks: []string{"fast"}},
+	}
+
+	baseline, impacts := attribute(results, []matchedWebhook{
+		webhook("fast", "pods", "services"),
+		webhook("slow", "pods"),
+		webhook("unused"),
+	})
+
+	assert.Equal(t, float64(10), baseline)
+	require.Len(t, impacts, 2)
+	assert.Equal(t, "slow", impacts[0].Name)
+	assert.Equal(t, float64(50), impacts[0].EstimatedLatencyMs)
+	assert.Equal(t, "fast", impacts[1].Name)
+	assert.Equal(t, float64(20), impacts[1].EstimatedLatencyMs)
+}
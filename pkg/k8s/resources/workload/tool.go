@@ -0,0 +1,379 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Finding severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// defaultGracePeriodSeconds is applied by the API server when terminationGracePeriodSeconds is unset
+const defaultGracePeriodSeconds = 30
+
+// shutdownMarginSeconds is the minimum time a container should keep after its preStop hook to exit cleanly
+const shutdownMarginSeconds = 5
+
+// proxySidecars are name fragments of common proxy sidecars that must outlive the application container
+var proxySidecars = []string{"istio-proxy", "envoy", "linkerd-proxy", "cloud-sql-proxy", "cloudsql-proxy"}
+
+var sleepCommand = regexp.MustCompile(`\bsleep\s+(\d+)`)
+
+// Handler implements the K8sResourceHandler interface for cross-kind workload tools
+type Handler struct {
+	getClient toolsets.GetClientFn
+	t         translations.TranslationHelperFunc
+}
+
+// NewHandler creates a new workload handler
+func NewHandler(getClient toolsets.GetClientFn, t translations.TranslationHelperFunc) *Handler {
+	return &Handler{
+		getClient: getClient,
+		t:         t,
+	}
+}
+
+// TerminationFinding is a shutdown handling problem found in a workload's pod template
+type TerminationFinding struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container,omitempty"`
+	Severity  string `json:"severity"`
+	Issue     string `json:"issue"`
+}
+
+// ImageChange describes a container image updated by set_image
+type ImageChange struct {
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Container     string `json:"container"`
+	PreviousImage string `json:"previousImage"`
+	Image         string `json:"image"`
+}
+
+// RegisterTools registers all workload tools with the provided toolset
+func (h *Handler) RegisterTools(toolset *toolsets.Toolset) {
+	// Register read tools
+	reviewTool, reviewHandler := h.ReviewTermination()
+	toolset.AddReadTool(reviewTool, reviewHandler)
+
+	// Register write tools
+	setImageTool, setImageHandler := h.SetImage()
+	toolset.AddWriteTool(setImageTool, setImageHandler)
+}
+
+// ReviewTermination creates a tool to review preStop hooks and termination grace periods
+func (h *Handler) ReviewTermination() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_termination_handling",
+			mcp.WithDescription(h.t("TOOL_REVIEW_TERMINATION_HANDLING_DESCRIPTION", "Review deployments, statefulsets and daemonsets for missing preStop hooks and termination grace periods that do not fit their shutdown behavior, a common cause of 502s during rollouts")),
+			mcp.WithString("namespace",
+				mcp.Description("Kubernetes namespace (all namespaces if omitted)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			namespace, err := toolsets.OptionalParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list deployments: %v", err)), nil
+			}
+			statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list statefulsets: %v", err)), nil
+			}
+			daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list daemonsets: %v", err)), nil
+			}
+
+			findings := []TerminationFinding{}
+			for _, d := range deployments.Items {
+				findings = append(findings, reviewPodSpec("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec)...)
+			}
+			for _, s := range statefulSets.Items {
+				findings = append(findings, reviewPodSpec("StatefulSet", s.Namespace, s.Name, s.Spec.Template.Spec)...)
+			}
+			for _, d := range daemonSets.Items {
+				findings = append(findings, reviewPodSpec("DaemonSet", d.Namespace, d.Name, d.Spec.Template.Spec)...)
+			}
+			sort.SliceStable(findings, func(i, j int) bool {
+				if findings[i].Namespace != findings[j].Namespace {
+					return findings[i].Namespace < findings[j].Namespace
+				}
+				return findings[i].Name < findings[j].Name
+			})
+
+			r, err := json.Marshal(findings)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetImage creates a tool to update a container image in a workload's pod template
+func (h *Handler) SetImage() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_image",
+			mcp.WithDescription(h.t("TOOL_SET_IMAGE_DESCRIPTION", "Update the image of a container in a deployment, statefulset or daemonset pod template, like kubectl set image. Init containers are matched too")),
+			mcp.WithString("kind",
+				mcp.Required(),
+				mcp.Description("Workload kind"),
+				mcp.Enum("deployment", "statefulset", "daemonset"),
+			),
+			mcp.WithString("namespace",
+				mcp.Required(),
+				mcp.Description("Kubernetes namespace"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Workload name"),
+			),
+			mcp.WithString("container",
+				mcp.Required(),
+				mcp.Description("Name of the container to update"),
+			),
+			mcp.WithString("image",
+				mcp.Required(),
+				mcp.Description("New container image"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			kind, err := toolsets.RequiredParam[string](request, "kind")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			namespace, err := toolsets.RequiredParam[string](request, "namespace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := toolsets.RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			container, err := toolsets.RequiredParam[string](request, "container")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			image, err := toolsets.RequiredParam[string](request, "image")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := h.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			spec, err := podTemplateSpec(ctx, client, kind, namespace, name)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Containers are patched by their merge key, so other containers are left untouched
+			field, previous, ok := findContainer(spec, container)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("container %s not found in %s %s; available containers: %s",
+					container, kind, name, strings.Join(containerNames(spec), ", "))), nil
+			}
+			patch := map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							field: []map[string]string{{"name": container, "image": image}},
+						},
+					},
+				},
+			}
+			body, err := json.Marshal(patch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal patch: %w", err)
+			}
+
+			switch kind {
+			case "deployment":
+				_, err = client.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, body, metav1.PatchOptions{})
+			case "statefulset":
+				_, err = client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, body, metav1.PatchOptions{})
+			case "daemonset":
+				_, err = client.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, body, metav1.PatchOptions{})
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update image: %v", err)), nil
+			}
+
+			r, err := json.Marshal(ImageChange{
+				Kind:          kind,
+				Namespace:     namespace,
+				Name:          name,
+				Container:     container,
+				PreviousImage: previous,
+				Image:         image,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// podTemplateSpec fetches the pod template of a deployment, statefulset or daemonset
+func podTemplateSpec(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (corev1.PodSpec, error) {
+	switch kind {
+	case "deployment":
+		d, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return corev1.PodSpec{}, fmt.Errorf("failed to get deployment: %v", err)
+		}
+		return d.Spec.Template.Spec, nil
+	case "statefulset":
+		s, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return corev1.PodSpec{}, fmt.Errorf("failed to get statefulset: %v", err)
+		}
+		return s.Spec.Template.Spec, nil
+	case "daemonset":
+		d, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return corev1.PodSpec{}, fmt.Errorf("failed to get daemonset: %v", err)
+		}
+		return d.Spec.Template.Spec, nil
+	default:
+		return corev1.PodSpec{}, fmt.Errorf("unsupported kind %q: must be deployment, statefulset or daemonset", kind)
+	}
+}
+
+// findContainer returns the pod spec field holding the named container and its current image
+func findContainer(spec corev1.PodSpec, name string) (string, string, bool) {
+	for _, c := range spec.Containers {
+		if c.Name == name {
+			return "containers", c.Image, true
+		}
+	}
+	for _, c := range spec.InitContainers {
+		if c.Name == name {
+			return "initContainers", c.Image, true
+		}
+	}
+	return "", "", false
+}
+
+func containerNames(spec corev1.PodSpec) []string {
+	var names []string
+	for _, c := range spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range spec.Containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// reviewPodSpec applies the termination heuristics to a pod template
+func reviewPodSpec(kind, namespace, name string, spec corev1.PodSpec) []TerminationFinding {
+	var findings []TerminationFinding
+	add := func(container, severity, issue string) {
+		findings = append(findings, TerminationFinding{Kind: kind, Namespace: namespace, Name: name, Container: container, Severity: severity, Issue: issue})
+	}
+
+	grace := int64(defaultGracePeriodSeconds)
+	if spec.TerminationGracePeriodSeconds != nil {
+		grace = *spec.TerminationGracePeriodSeconds
+	}
+	if grace == 0 {
+		add("", SeverityWarning, "terminationGracePeriodSeconds is 0: containers are killed without a chance to drain connections")
+	}
+	if kind == "Deployment" && grace > 300 {
+		add("", SeverityInfo, fmt.Sprintf("terminationGracePeriodSeconds is %ds: rollouts wait up to this long for each old pod", grace))
+	}
+
+	appHasPreStop := false
+	for _, c := range spec.Containers {
+		if isProxySidecar(c.Name) {
+			continue
+		}
+		serving := c.ReadinessProbe != nil || len(c.Ports) > 0
+		sleep, hasPreStop := preStopSeconds(c)
+		if hasPreStop {
+			appHasPreStop = true
+		}
+
+		switch {
+		case serving && !hasPreStop && grace > 0:
+			add(c.Name, SeverityWarning, "serving container has no preStop hook: endpoints may still route traffic to it after SIGTERM")
+		case hasPreStop && sleep >= grace:
+			add(c.Name, SeverityError, fmt.Sprintf("preStop sleep of %ds uses the whole %ds grace period: the container is killed before it can shut down", sleep, grace))
+		case hasPreStop && sleep+shutdownMarginSeconds > grace:
+			add(c.Name, SeverityWarning, fmt.Sprintf("preStop sleep of %ds leaves less than %ds of the %ds grace period for shutdown", sleep, shutdownMarginSeconds, grace))
+		}
+	}
+
+	// Regular sidecar proxies receive SIGTERM together with the application and may exit first
+	if len(spec.Containers) > 1 {
+		for _, c := range spec.Containers {
+			if !isProxySidecar(c.Name) {
+				continue
+			}
+			if _, hasPreStop := preStopSeconds(c); !hasPreStop && appHasPreStop {
+				add(c.Name, SeverityWarning, "proxy sidecar has no preStop hook and may exit before the application finishes draining; delay its shutdown or run it as a native sidecar")
+			}
+		}
+	}
+	return findings
+}
+
+// preStopSeconds returns the sleep performed by a container's preStop hook, if it has one
+func preStopSeconds(c corev1.Container) (int64, bool) {
+	if c.Lifecycle == nil || c.Lifecycle.PreStop == nil {
+		return 0, false
+	}
+	hook := c.Lifecycle.PreStop
+	if hook.Sleep != nil {
+		return hook.Sleep.Seconds, true
+	}
+	if hook.Exec != nil {
+		if m := sleepCommand.FindStringSubmatch(strings.Join(hook.Exec.Command, " ")); m != nil {
+			seconds, _ := strconv.ParseInt(m[1], 10, 64)
+			return seconds, true
+		}
+	}
+	return 0, true
+}
+
+func isProxySidecar(name string) bool {
+	for _, proxy := range proxySidecars {
+		if strings.Contains(name, proxy) {
+			return true
+		}
+	}
+	return false
+}
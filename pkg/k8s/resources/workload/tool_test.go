@@ -0,0 +1,278 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a fake client
+func stubGetClientFn(client kubernetes.Interface) toolsets.GetClientFn {
+	return func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	}
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func sleepHook(seconds string) *corev1.Lifecycle {
+	return &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep " + seconds}}}}
+}
+
+func deployment(namespace, name string, spec corev1.PodSpec) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+	}
+}
+
+func TestReviewPodSpec(t *testing.T) {
+	port := []corev1.ContainerPort{{ContainerPort: 8080}}
+
+	tests := []struct {
+		name             string
+		spec             corev1.PodSpec
+		expectedSeverity []string
+		expectedIssue    string
+	}{
+		{
+			name: "well configured",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Ports: port, Lifecycle: sleepHook("10")}}},
+		},
+		{
+			name:             "serving container without preStop",
+			spec:             corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Ports: port}}},
+			expectedSeverity: []string{SeverityWarning},
+			expectedIssue:    "no preStop hook",
+		},
+		{
+			name:             "worker without ports is fine",
+			spec:             corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+			expectedSeverity: nil,
+		},
+		{
+			name: "preStop sleep consumes grace period",
+			spec: corev1.PodSpec{
+				TerminationGracePeriodSeconds: int64Ptr(20),
+				Containers:                    []corev1.Container{{Name: "web", Ports: port, Lifecycle: sleepHook("20")}},
+			},
+			expectedSeverity: []string{SeverityError},
+			expectedIssue:    "uses the whole 20s grace period",
+		},
+		{
+			name: "native sleep action leaves little margin",
+			spec: corev1.PodSpec{
+				TerminationGracePeriodSeconds: int64Ptr(20),
+				Containers: []corev1.Container{{
+					Name:      "web",
+					Ports:     port,
+					Lifecycle: &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 18}}},
+				}},
+			},
+			expectedSeverity: []string{SeverityWarning},
+			expectedIssue:    "leaves less than 5s",
+		},
+		{
+			name: "zero grace period",
+			spec: corev1.PodSpec{
+				TerminationGracePeriodSeconds: int64Ptr(0),
+				Containers:                    []corev1.Container{{Name: "web", Ports: port}},
+			},
+			expectedSeverity: []string{SeverityWarning},
+			expectedIssue:    "terminationGracePeriodSeconds is 0",
+		},
+		{
+			name: "proxy sidecar exits before application",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "web", Ports: port, Lifecycle: sleepHook("10")},
+				{Name: "istio-proxy", Ports: []corev1.ContainerPort{{ContainerPort: 15090}}},
+			}},
+			expectedSeverity: []string{SeverityWarning},
+			expectedIssue:    "proxy sidecar has no preStop hook",
+		},
+		{
+			name: "long grace period slows rollouts",
+			spec: corev1.PodSpec{
+				TerminationGracePeriodSeconds: int64Ptr(600),
+				Containers:                    []corev1.Container{{Name: "worker"}},
+			},
+			expectedSeverity: []string{SeverityInfo},
+			expectedIssue:    "rollouts wait",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := reviewPodSpec("Deployment", "shop", "web", tc.spec)
+			var severities []string
+			for _, f := range findings {
+				severities = append(severities, f.Severity)
+			}
+			assert.Equal(t, tc.expectedSeverity, severities)
+			if tc.expectedIssue != "" {
+				assert.Contains(t, findings[0].Issue, tc.expectedIssue)
+			}
+		})
+	}
+}
+
+func TestReviewTermination(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		deployment("shop", "web", corev1.PodSpec{Containers: []corev1.Container{{Name: "web", ReadinessProbe: &corev1.Probe{}}}}),
+		deployment("blog", "posts", corev1.PodSpec{Containers: []corev1.Container{{Name: "posts", Ports: []corev1.ContainerPort{{ContainerPort: 80}}}}}),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				TerminationGracePeriodSeconds: int64Ptr(10),
+				Containers:                    []corev1.Container{{Name: "db", Lifecycle: sleepHook("30")}},
+			}}},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop"},
+			Spec:       appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent"}}}}},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.ReviewTermination()
+
+	assert.Equal(t, "review_termination_handling", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	result, err := handlerFn(context.Background(), createMCPRequest(map[string]interface{}{"namespace": "shop"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var findings []TerminationFinding
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &findings))
+	require.Len(t, findings, 2)
+	assert.Equal(t, "StatefulSet", findings[0].Kind)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Equal(t, "Deployment", findings[1].Kind)
+	assert.Equal(t, "web", findings[1].Container)
+
+	result, err = handlerFn(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &findings))
+	assert.Len(t, findings, 3)
+	assert.Equal(t, "blog", findings[0].Namespace)
+}
+
+func TestSetImage(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		deployment("shop", "web", corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1.0"}},
+			Containers: []corev1.Container{
+				{Name: "web", Image: "nginx:1.25"},
+				{Name: "cache", Image: "redis:7.0"},
+			},
+		}),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "db", Image: "postgres:15"}},
+			}}},
+		},
+	)
+	handler := NewHandler(stubGetClientFn(client), translations.NullTranslationHelper)
+	tool, handlerFn := handler.SetImage()
+
+	assert.Equal(t, "set_image", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"kind", "namespace", "name", "container", "image"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedChange ImageChange
+		expectedErrMsg string
+	}{
+		{
+			name:           "update deployment container",
+			requestArgs:    map[string]interface{}{"kind": "deployment", "namespace": "shop", "name": "web", "container": "web", "image": "nginx:1.26"},
+			expectedChange: ImageChange{Kind: "deployment", Namespace: "shop", Name: "web", Container: "web", PreviousImage: "nginx:1.25", Image: "nginx:1.26"},
+		},
+		{
+			name:           "update init container",
+			requestArgs:    map[string]interface{}{"kind": "deployment", "namespace": "shop", "name": "web", "container": "migrate", "image": "migrate:1.1"},
+			expectedChange: ImageChange{Kind: "deployment", Namespace: "shop", Name: "web", Container: "migrate", PreviousImage: "migrate:1.0", Image: "migrate:1.1"},
+		},
+		{
+			name:           "update statefulset container",
+			requestArgs:    map[string]interface{}{"kind": "statefulset", "namespace": "shop", "name": "db", "container": "db", "image": "postgres:16"},
+			expectedChange: ImageChange{Kind: "statefulset", Namespace: "shop", Name: "db", Container: "db", PreviousImage: "postgres:15", Image: "postgres:16"},
+		},
+		{
+			name:           "unknown container",
+			requestArgs:    map[string]interface{}{"kind": "deployment", "namespace": "shop", "name": "web", "container": "sidecar", "image": "envoy:1.30"},
+			expectedErrMsg: "container sidecar not found in deployment web; available containers: migrate, web, cache",
+		},
+		{
+			name:           "workload not found",
+			requestArgs:    map[string]interface{}{"kind": "daemonset", "namespace": "shop", "name": "agent", "container": "agent", "image": "agent:2"},
+			expectedErrMsg: "failed to get daemonset",
+		},
+		{
+			name:           "unsupported kind",
+			requestArgs:    map[string]interface{}{"kind": "job", "namespace": "shop", "name": "web", "container": "web", "image": "nginx:1.26"},
+			expectedErrMsg: "unsupported kind",
+		},
+		{
+			name:           "missing required param: image",
+			requestArgs:    map[string]interface{}{"kind": "deployment", "namespace": "shop", "name": "web", "container": "web"},
+			expectedErrMsg: "missing required parameter: image",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlerFn(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var change ImageChange
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &change))
+			assert.Equal(t, tc.expectedChange, change)
+		})
+	}
+
+	// Only the targeted containers changed
+	updated, err := client.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.26", updated.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "redis:7.0", updated.Spec.Template.Spec.Containers[1].Image)
+	assert.Equal(t, "migrate:1.1", updated.Spec.Template.Spec.InitContainers[0].Image)
+}
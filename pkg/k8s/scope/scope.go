@@ -0,0 +1,71 @@
+// Package scope restricts the Kubernetes API requests made by the server, so a single deployment
+// can be limited to one tenant's objects on a shared cluster.
+package scope
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/transport"
+)
+
+// LabelSelector returns a transport wrapper that ANDs selector to the label selector of every list
+// and watch request. Requests for single objects, subresources and discovery are left unchanged.
+func LabelSelector(selector string) (transport.WrapperFunc, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	normalized := parsed.String()
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &labelSelectorRoundTripper{selector: normalized, next: rt}
+	}, nil
+}
+
+type labelSelectorRoundTripper struct {
+	selector string
+	next     http.RoundTripper
+}
+
+func (rt *labelSelectorRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || rt.selector == "" || !IsCollectionPath(req.URL.Path) {
+		return rt.next.RoundTrip(req)
+	}
+
+	// Round trippers must not modify the caller's request
+	scoped := req.Clone(req.Context())
+	query := scoped.URL.Query()
+	if existing := query.Get("labelSelector"); existing != "" {
+		query.Set("labelSelector", existing+","+rt.selector)
+	} else {
+		query.Set("labelSelector", rt.selector)
+	}
+	scoped.URL.RawQuery = query.Encode()
+	return rt.next.RoundTrip(scoped)
+}
+
+// IsCollectionPath reports whether an API path addresses a collection of resources, such as
+// /api/v1/namespaces/shop/pods or /apis/apps/v1/deployments, rather than a single object,
+// a subresource or a discovery document
+func IsCollectionPath(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return false
+	}
+
+	// Deprecated watch paths prefix the resource path with watch
+	if len(segments) > 0 && segments[0] == "watch" {
+		segments = segments[1:]
+	}
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+	return len(segments) == 1
+}
@@ -0,0 +1,88 @@
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestIsCollectionPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/api/v1/namespaces/shop/pods", expected: true},
+		{path: "/api/v1/pods", expected: true},
+		{path: "/api/v1/namespaces", expected: true},
+		{path: "/api/v1/nodes", expected: true},
+		{path: "/apis/apps/v1/namespaces/shop/deployments", expected: true},
+		{path: "/apis/apps/v1/deployments", expected: true},
+		{path: "/api/v1/watch/namespaces/shop/pods", expected: true},
+		{path: "/api/v1/namespaces/shop", expected: false},
+		{path: "/api/v1/namespaces/shop/pods/web", expected: false},
+		{path: "/api/v1/namespaces/shop/pods/web/log", expected: false},
+		{path: "/api/v1/nodes/node-1", expected: false},
+		{path: "/apis/apps/v1/namespaces/shop/deployments/web/scale", expected: false},
+		{path: "/api", expected: false},
+		{path: "/api/v1", expected: false},
+		{path: "/apis/apps/v1", expected: false},
+		{path: "/version", expected: false},
+		{path: "/metrics", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsCollectionPath(tc.path))
+		})
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	var selectors []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selectors = append(selectors, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("labelSelector"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/shop/pods":
+			_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web"}}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	wrapper, err := LabelSelector("team = payments")
+	require.NoError(t, err)
+	config := &rest.Config{Host: apiServer.URL}
+	config.Wrap(wrapper)
+	client, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = client.CoreV1().Pods("shop").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("shop").List(ctx, metav1.ListOptions{LabelSelector: "app=web"})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("shop").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	err = client.CoreV1().Pods("shop").Delete(ctx, "web", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /api/v1/namespaces/shop/pods team=payments",
+		"GET /api/v1/namespaces/shop/pods app=web,team=payments",
+		"GET /api/v1/namespaces/shop/pods/web ",
+		"DELETE /api/v1/namespaces/shop/pods/web ",
+	}, selectors)
+
+	_, err = LabelSelector("team in (payments")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid label selector "team in (payments"`)
+}
@@ -9,7 +9,8 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	// Add default options
 	defaultOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
-		// server.WithResourceCapabilities(true, true),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
 		//server.WithLogging(),
 	}
 	opts = append(defaultOpts, opts...)
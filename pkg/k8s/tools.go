@@ -1,31 +1,110 @@
 package k8s
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/breaker"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/limits"
 	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/resources"
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/verbs"
+	"github.com/briankscheong/k8s-mcp-server/pkg/output"
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/briankscheong/k8s-mcp-server/pkg/scanner"
 	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
 	"github.com/briankscheong/k8s-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+// DefaultTools are the toolsets enabled when none are selected
 var DefaultTools = []string{"all"}
 
-func InitToolset(readOnly bool, getClient toolsets.GetClientFn, t translations.TranslationHelperFunc, enabledResourceTypes []string) (*toolsets.Toolset, error) {
+func InitToolset(readOnly bool, getClient toolsets.GetClientFn, getDynamicClient toolsets.GetDynamicClientFn, getRESTConfig toolsets.GetRESTConfigFn, t translations.TranslationHelperFunc, enabledResourceTypes []string, enabledToolsets []string, toolsetReadOnly map[string]bool, imageScanner scanner.Scanner, resourceSettings map[string]limits.Settings, policy verbs.Policy, redactor *redact.Filter) (*toolsets.Toolset, *toolsets.ResourceSet, error) {
 
 	// Create a resource registry
 	registry := toolsets.NewK8sResourceRegistry()
 
-	// Register resources based on enabledResourceTypes
-	if len(enabledResourceTypes) == 0 || contains(enabledResourceTypes, "all") {
-		// Register all k8s resources with the registry
-		resources.RegisterAllK8sResources(registry, getClient, t)
-	} else {
-		// Register only the specified k8s resources
-		resources.RegisterSelectedK8sResources(registry, getClient, t, enabledResourceTypes)
+	// Register the resource types of the enabled toolsets that enabledResourceTypes selects
+	if len(enabledToolsets) == 0 {
+		enabledToolsets = DefaultTools
+	}
+	toolsetResourceTypes, err := resources.ToolsetResourceTypes(enabledToolsets)
+	if err != nil {
+		return nil, nil, err
+	}
+	var resourceTypes []string
+	for _, resourceType := range toolsetResourceTypes {
+		if len(enabledResourceTypes) == 0 || contains(enabledResourceTypes, "all") || contains(enabledResourceTypes, resourceType) {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	if len(resourceTypes) == 0 {
+		return nil, nil, fmt.Errorf("no resource type is enabled: the toolsets %s include none of the resource types %s", strings.Join(enabledToolsets, ","), strings.Join(enabledResourceTypes, ","))
+	}
+	resources.RegisterSelectedK8sResources(registry, getClient, getDynamicClient, getRESTConfig, t, imageScanner, resourceTypes)
+
+	// A read-only override of a toolset that does not exist would silently change nothing
+	for name := range toolsetReadOnly {
+		if err := resources.ValidateToolset(name); err != nil {
+			return nil, nil, fmt.Errorf("read-only override: %w", err)
+		}
+	}
+
+	// Settings of a resource type that is not enabled would silently restrict nothing
+	handlers := registry.GetAllHandlers()
+	for resourceType := range resourceSettings {
+		if _, ok := handlers[resourceType]; !ok {
+			return nil, nil, fmt.Errorf("resource type %q has settings but is not enabled", resourceType)
+		}
 	}
+	for resourceType := range policy {
+		if _, ok := handlers[resourceType]; !ok {
+			return nil, nil, fmt.Errorf("resource type %q has an operation policy but is not enabled", resourceType)
+		}
+	}
+
+	// Create a toolset from the registry, limiting the tools of each resource type to its settings.
+	// The operation policy decides which tools of the resource types it lists are registered, the
+	// read-only override of their toolset or else read-only mode applies to the others.
+	writable := len(policy) > 0
+	for _, toolsetReadOnly := range toolsetReadOnly {
+		writable = writable || !toolsetReadOnly
+	}
+	k8sToolset := resources.CreateToolset(registry, "k8s_resources", readOnly && !writable, func(resourceType string, tools *toolsets.Toolset) {
+		if !policy.Lists(resourceType) {
+			resourceReadOnly, ok := toolsetReadOnly[resources.ToolsetOf(resourceType)]
+			if !ok {
+				resourceReadOnly = readOnly
+			}
+			if resourceReadOnly {
+				tools.SetReadOnly()
+			}
+		}
+		if len(policy) > 0 {
+			tools.RemoveTools(func(tool server.ServerTool) bool {
+				return !policy.AllowsTool(resourceType, tool.Tool.Name)
+			})
+			tools.WrapTools(policy.Wrap(resourceType))
+		}
+		if settings, ok := resourceSettings[resourceType]; ok {
+			tools.WrapTools(limits.New(resourceType, settings).Wrap)
+		}
+	})
+
+	// Remove secret values from results before they are rendered in another format
+	if redactor != nil {
+		k8sToolset.WrapTools(redactor.Wrap)
+	}
+
+	// Let read tools return selected fields only, rendered as summaries, YAML, Markdown tables or CSV
+	k8sToolset.WrapReadTools(output.WithFieldsParam)
+	k8sToolset.WrapReadTools(output.WithOutputParam)
 
-	// Create a toolset from the registry
-	k8sToolset := resources.CreateToolset(registry, "k8s_resources", readOnly)
+	// Cool down write tools that keep failing against the same object
+	k8sToolset.WrapWriteTools(breaker.New(breaker.DefaultThreshold, breaker.DefaultWindow, breaker.DefaultCooldown).Wrap)
 
-	return k8sToolset, nil
+	// Expose objects as MCP resources, read with the tools of their resource types
+	return k8sToolset, resources.CreateResourceSet(registry), nil
 }
 
 // Helper function to check if a slice contains a string
@@ -0,0 +1,174 @@
+// Package verbs restricts the operations the server performs on each resource type with a policy
+// of allowed verbs, such as get, list, logs or scale. The verb of a tool is derived from its name.
+// Tools acting on any kind, such as apply_manifest or delete_resource, are checked against the
+// policy of the kinds each call targets.
+package verbs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/k8s/manifest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// All is the verb allowing every operation on a resource type
+const All = "*"
+
+// toolVerbs are the verbs of tools whose name does not start with their verb
+var toolVerbs = map[string]string{
+	"get_pod_logs":               "logs",
+	"stream_pod_logs":            "logs",
+	"get_logs_by_selector":       "logs",
+	"pod_cp_from":                "cp",
+	"pod_cp_to":                  "cp",
+	"rollout_history":            "history",
+	"rollout_status":             "status",
+	"rollout_restart_deployment": "restart",
+	"rollout_undo":               "undo",
+	"cluster_digest":             "get",
+	"cluster_overview":           "get",
+	"diff_manifest":              "get",
+	"wait_for_condition":         "watch",
+}
+
+// relatedTypes are resource types whose tools act on the objects of another resource type, so
+// both policies apply to them
+var relatedTypes = map[string]string{
+	"logs": "pod",
+}
+
+// target ties a kind and its API resource to the resource type whose tools manage it
+type target struct {
+	kind         string
+	resource     string
+	resourceType string
+}
+
+// targets are the kinds generic tools are checked for
+var targets = []target{
+	{kind: "Pod", resource: "pods", resourceType: "pod"},
+	{kind: "Deployment", resource: "deployments", resourceType: "deployment"},
+	{kind: "Service", resource: "services", resourceType: "service"},
+	{kind: "ConfigMap", resource: "configmaps", resourceType: "configmap"},
+	{kind: "Namespace", resource: "namespaces", resourceType: "namespace"},
+	{kind: "Node", resource: "nodes", resourceType: "node"},
+	{kind: "PodDisruptionBudget", resource: "poddisruptionbudgets", resourceType: "pdb"},
+	{kind: "Lease", resource: "leases", resourceType: "lease"},
+	{kind: "CronJob", resource: "cronjobs", resourceType: "cronjob"},
+}
+
+// Of returns the verb of a tool: the first word of its name, such as get for get_pod or scale for
+// scale_deployment, except for tools named otherwise such as get_pod_logs, whose verb is logs
+func Of(tool string) string {
+	if verb, ok := toolVerbs[tool]; ok {
+		return verb
+	}
+	verb, _, _ := strings.Cut(tool, "_")
+	return verb
+}
+
+// Policy lists the verbs allowed on each resource type. Resource types it does not list are not
+// restricted by it.
+type Policy map[string][]string
+
+// Validate checks that every listed resource type allows at least one verb
+func (p Policy) Validate() error {
+	for resourceType, verbs := range p {
+		if len(verbs) == 0 {
+			return fmt.Errorf("policy of resource type %q allows no verbs", resourceType)
+		}
+		for _, verb := range verbs {
+			if strings.TrimSpace(verb) == "" {
+				return fmt.Errorf("policy of resource type %q has an empty verb", resourceType)
+			}
+		}
+	}
+	return nil
+}
+
+// Lists reports whether the policy restricts a resource type
+func (p Policy) Lists(resourceType string) bool {
+	_, ok := p[resourceType]
+	return ok
+}
+
+// Allows reports whether the policy allows a verb on a resource type
+func (p Policy) Allows(resourceType, verb string) bool {
+	verbs, ok := p[resourceType]
+	if !ok {
+		return true
+	}
+	for _, allowed := range verbs {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == All || allowed == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTool reports whether the policy allows a tool of a resource type, checking the resource
+// type its objects belong to as well
+func (p Policy) AllowsTool(resourceType, tool string) bool {
+	verb := Of(tool)
+	if !p.Allows(resourceType, verb) {
+		return false
+	}
+	if related, ok := relatedTypes[resourceType]; ok {
+		return p.Allows(related, verb)
+	}
+	return true
+}
+
+// Wrap returns a wrapper checking every call of a tool of resourceType against the policy, in
+// addition to the tools removed on registration. Calls of tools acting on any kind are checked
+// against the policy of the kinds named by their resource or manifest argument.
+func (p Policy) Wrap(resourceType string) func(server.ServerTool) server.ServerTool {
+	return func(tool server.ServerTool) server.ServerTool {
+		next := tool.Handler
+		name := tool.Tool.Name
+		verb := Of(name)
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !p.AllowsTool(resourceType, name) {
+				return mcp.NewToolResultError(fmt.Sprintf("the operation policy does not allow %s on %s", verb, resourceType)), nil
+			}
+			for _, targetType := range targetTypes(request.GetArguments()) {
+				if !p.Allows(targetType, verb) {
+					return mcp.NewToolResultError(fmt.Sprintf("the operation policy does not allow %s on %s", verb, targetType)), nil
+				}
+			}
+			return next(ctx, request)
+		}
+		return tool
+	}
+}
+
+// targetTypes returns the resource types of the objects a call of a tool acting on any kind
+// targets. Manifests that cannot be decoded are left to the tool to reject.
+func targetTypes(args map[string]interface{}) []string {
+	var types []string
+	if resource, ok := args["resource"].(string); ok {
+		for _, t := range targets {
+			if strings.EqualFold(resource, t.resource) {
+				types = append(types, t.resourceType)
+			}
+		}
+	}
+	if data, ok := args["manifest"].(string); ok {
+		objects, err := manifest.Decode([]byte(data))
+		if err != nil {
+			return types
+		}
+		for _, obj := range objects {
+			for _, t := range targets {
+				if obj.GetKind() == t.kind {
+					types = append(types, t.resourceType)
+				}
+			}
+		}
+	}
+	return types
+}
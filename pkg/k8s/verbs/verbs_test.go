@@ -0,0 +1,103 @@
+package verbs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		tool     string
+		expected string
+	}{
+		{"get_pod", "get"},
+		{"list_deployments", "list"},
+		{"scale_deployment", "scale"},
+		{"delete_resource", "delete"},
+		{"apply_manifest", "apply"},
+		{"diff_manifest", "get"},
+		{"watch_resource", "watch"},
+		{"wait_for_condition", "watch"},
+		{"get_pod_logs", "logs"},
+		{"stream_pod_logs", "logs"},
+		{"pod_cp_to", "cp"},
+		{"rollout_restart_deployment", "restart"},
+		{"cluster_overview", "get"},
+		{"diagnose", "diagnose"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, Of(tc.tool), tc.tool)
+	}
+}
+
+func TestAllowsTool(t *testing.T) {
+	policy := Policy{
+		"pod":        {"get", "list", "logs"},
+		"deployment": {"get", " list", "scale"},
+		"configmap":  {All},
+	}
+
+	assert.True(t, policy.AllowsTool("pod", "get_pod"))
+	assert.False(t, policy.AllowsTool("pod", "delete_pod"))
+	assert.False(t, policy.AllowsTool("pod", "exec_in_pod"))
+	assert.True(t, policy.AllowsTool("deployment", "list_deployments"))
+	assert.True(t, policy.AllowsTool("deployment", "scale_deployment"))
+	assert.False(t, policy.AllowsTool("deployment", "rollout_undo"))
+	assert.True(t, policy.AllowsTool("configmap", "delete_configmap"))
+	assert.True(t, policy.AllowsTool("service", "delete_service"))
+
+	// Log tools act on pods
+	assert.True(t, policy.AllowsTool("logs", "get_pod_logs"))
+	assert.False(t, Policy{"pod": {"get"}}.AllowsTool("logs", "get_pod_logs"))
+}
+
+func TestWrap(t *testing.T) {
+	policy := Policy{
+		"pod":        {"get", "list"},
+		"deployment": {"get", "list", "scale", "apply"},
+	}
+	newTool := func(name string) server.ServerTool {
+		return policy.Wrap("generic")(server.ServerTool{
+			Tool: mcp.NewTool(name),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("ok"), nil
+			},
+		})
+	}
+	call := func(tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	deleteResource := newTool("delete_resource")
+	result := call(deleteResource, map[string]interface{}{"version": "v1", "resource": "pods", "name": "web-0"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "the operation policy does not allow delete on pod", result.Content[0].(mcp.TextContent).Text)
+	assert.False(t, call(deleteResource, map[string]interface{}{"version": "v1", "resource": "secrets", "name": "db"}).IsError)
+
+	applyManifest := newTool("apply_manifest")
+	deployment := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"
+	pod := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: web-0\n"
+	assert.False(t, call(applyManifest, map[string]interface{}{"manifest": deployment}).IsError)
+	assert.True(t, call(applyManifest, map[string]interface{}{"manifest": deployment + "---\n" + pod}).IsError)
+	// Manifests that cannot be decoded are rejected by the tool itself
+	assert.False(t, call(applyManifest, map[string]interface{}{"manifest": "kind: ["}).IsError)
+
+	// Calls of tools removed on registration are rejected as well
+	execInPod := policy.Wrap("pod")(server.ServerTool{Tool: mcp.NewTool("exec_in_pod")})
+	result = call(execInPod, map[string]interface{}{"name": "web-0"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "the operation policy does not allow exec on pod", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Policy{"pod": {"get", "list"}}.Validate())
+	assert.ErrorContains(t, Policy{"pod": {}}.Validate(), `policy of resource type "pod" allows no verbs`)
+	assert.ErrorContains(t, Policy{"pod": {"get", ""}}.Validate(), `policy of resource type "pod" has an empty verb`)
+}
@@ -0,0 +1,294 @@
+// Package visibility hides tools that would always fail because the server identity lacks the
+// permissions they need, probing them with SelfSubjectAccessReviews at startup and periodically
+// after that. Hiding and showing tools notifies clients with tools/list_changed.
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/server"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultInterval is how often permissions are probed again after startup
+const DefaultInterval = 5 * time.Minute
+
+// Permission is an API access a tool needs on every call
+type Permission struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	// ClusterScoped permissions are checked across the cluster, others in the default namespace
+	ClusterScoped bool
+}
+
+// Requirements maps tool names to the permissions they need. Tools that are not listed, such as
+// tools acting on any kind, are always visible.
+var Requirements = map[string][]Permission{
+	// Nodes
+	"get_node":      {{Verb: "get", Resource: "nodes", ClusterScoped: true}},
+	"list_nodes":    {{Verb: "list", Resource: "nodes", ClusterScoped: true}},
+	"cordon_node":   {{Verb: "patch", Resource: "nodes", ClusterScoped: true}},
+	"uncordon_node": {{Verb: "patch", Resource: "nodes", ClusterScoped: true}},
+	"taint_node":    {{Verb: "update", Resource: "nodes", ClusterScoped: true}},
+	"untaint_node":  {{Verb: "update", Resource: "nodes", ClusterScoped: true}},
+	"drain_node": {
+		{Verb: "patch", Resource: "nodes", ClusterScoped: true},
+		{Verb: "list", Resource: "pods", ClusterScoped: true},
+		{Verb: "create", Resource: "pods", Subresource: "eviction", ClusterScoped: true},
+	},
+
+	// Resource usage metrics
+	"top_pods":  {{Verb: "list", Group: "metrics.k8s.io", Resource: "pods"}},
+	"top_nodes": {{Verb: "list", Group: "metrics.k8s.io", Resource: "nodes", ClusterScoped: true}},
+
+	// Troubleshooting analyzers
+	"diagnose_pod": {
+		{Verb: "get", Resource: "pods"},
+		{Verb: "list", Resource: "events"},
+		{Verb: "get", Resource: "nodes", ClusterScoped: true},
+	},
+	"diagnose_deployment": {
+		{Verb: "get", Group: "apps", Resource: "deployments"},
+		{Verb: "list", Group: "apps", Resource: "replicasets"},
+		{Verb: "list", Resource: "pods"},
+		{Verb: "list", Resource: "events"},
+	},
+	"check_service_connectivity": {
+		{Verb: "get", Resource: "services"},
+		{Verb: "list", Resource: "pods"},
+		{Verb: "list", Group: "discovery.k8s.io", Resource: "endpointslices"},
+	},
+
+	// Namespaces
+	"get_namespace":    {{Verb: "get", Resource: "namespaces", ClusterScoped: true}},
+	"list_namespaces":  {{Verb: "list", Resource: "namespaces", ClusterScoped: true}},
+	"create_namespace": {{Verb: "create", Resource: "namespaces", ClusterScoped: true}},
+	"delete_namespace": {{Verb: "delete", Resource: "namespaces", ClusterScoped: true}},
+	"provision_namespace": {
+		{Verb: "create", Resource: "namespaces", ClusterScoped: true},
+		{Verb: "delete", Resource: "namespaces", ClusterScoped: true},
+	},
+
+	// Pods
+	"get_pod":                {{Verb: "get", Resource: "pods"}},
+	"list_pods":              {{Verb: "list", Resource: "pods"}},
+	"get_pod_status_summary": {{Verb: "get", Resource: "pods"}, {Verb: "list", Resource: "events"}},
+	"get_pod_logs":           {{Verb: "get", Resource: "pods", Subresource: "log"}},
+	"stream_pod_logs":        {{Verb: "get", Resource: "pods", Subresource: "log"}},
+	"get_logs_by_selector":   {{Verb: "list", Resource: "pods"}, {Verb: "get", Resource: "pods", Subresource: "log"}},
+	"delete_pod":             {{Verb: "delete", Resource: "pods"}},
+	"exec_in_pod":            {{Verb: "create", Resource: "pods", Subresource: "exec"}},
+	"pod_cp_from":            {{Verb: "create", Resource: "pods", Subresource: "exec"}},
+	"pod_cp_to":              {{Verb: "create", Resource: "pods", Subresource: "exec"}},
+	"debug_pod":              {{Verb: "update", Resource: "pods", Subresource: "ephemeralcontainers"}},
+	"list_images":            {{Verb: "list", Resource: "pods"}},
+	"scan_images":            {{Verb: "list", Resource: "pods"}},
+
+	// Services and configmaps
+	"get_service":          {{Verb: "get", Resource: "services"}},
+	"list_services":        {{Verb: "list", Resource: "services"}},
+	"delete_service":       {{Verb: "delete", Resource: "services"}},
+	"get_configmap":        {{Verb: "get", Resource: "configmaps"}},
+	"list_configmaps":      {{Verb: "list", Resource: "configmaps"}},
+	"create_configmap":     {{Verb: "create", Resource: "configmaps"}},
+	"update_configmap":     {{Verb: "update", Resource: "configmaps"}},
+	"patch_configmap_data": {{Verb: "patch", Resource: "configmaps"}},
+	"delete_configmap":     {{Verb: "delete", Resource: "configmaps"}},
+
+	// Deployments
+	"get_deployment":             {{Verb: "get", Group: "apps", Resource: "deployments"}},
+	"list_deployments":           {{Verb: "list", Group: "apps", Resource: "deployments"}},
+	"create_deployment":          {{Verb: "create", Group: "apps", Resource: "deployments"}},
+	"scale_deployment":           {{Verb: "update", Group: "apps", Resource: "deployments"}},
+	"rollout_restart_deployment": {{Verb: "patch", Group: "apps", Resource: "deployments"}},
+	"pause_deployment":           {{Verb: "patch", Group: "apps", Resource: "deployments"}},
+	"resume_deployment":          {{Verb: "patch", Group: "apps", Resource: "deployments"}},
+	"delete_deployment":          {{Verb: "delete", Group: "apps", Resource: "deployments"}},
+
+	// Jobs
+	"analyze_cronjobs": {
+		{Verb: "list", Group: "batch", Resource: "cronjobs"},
+		{Verb: "list", Group: "batch", Resource: "jobs"},
+	},
+
+	// Policy, leases and gateways
+	"get_pdb":         {{Verb: "get", Group: "policy", Resource: "poddisruptionbudgets"}},
+	"list_pdbs":       {{Verb: "list", Group: "policy", Resource: "poddisruptionbudgets"}},
+	"get_lease":       {{Verb: "get", Group: "coordination.k8s.io", Resource: "leases"}},
+	"list_leases":     {{Verb: "list", Group: "coordination.k8s.io", Resource: "leases"}},
+	"get_gateway":     {{Verb: "get", Group: "gateway.networking.k8s.io", Resource: "gateways"}},
+	"list_gateways":   {{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "gateways"}},
+	"get_httproute":   {{Verb: "get", Group: "gateway.networking.k8s.io", Resource: "httproutes"}},
+	"list_httproutes": {{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "httproutes"}},
+
+	// Cluster-scoped classes
+	"get_priorityclass":    {{Verb: "get", Group: "scheduling.k8s.io", Resource: "priorityclasses", ClusterScoped: true}},
+	"list_priorityclasses": {{Verb: "list", Group: "scheduling.k8s.io", Resource: "priorityclasses", ClusterScoped: true}},
+	"get_runtimeclass":     {{Verb: "get", Group: "node.k8s.io", Resource: "runtimeclasses", ClusterScoped: true}},
+	"list_runtimeclasses":  {{Verb: "list", Group: "node.k8s.io", Resource: "runtimeclasses", ClusterScoped: true}},
+	"get_ingressclass":     {{Verb: "get", Group: "networking.k8s.io", Resource: "ingressclasses", ClusterScoped: true}},
+	"list_ingressclasses":  {{Verb: "list", Group: "networking.k8s.io", Resource: "ingressclasses", ClusterScoped: true}},
+
+	// Storage
+	"get_storageclass":       {{Verb: "get", Group: "storage.k8s.io", Resource: "storageclasses", ClusterScoped: true}},
+	"list_storageclasses":    {{Verb: "list", Group: "storage.k8s.io", Resource: "storageclasses", ClusterScoped: true}},
+	"list_csidrivers":        {{Verb: "list", Group: "storage.k8s.io", Resource: "csidrivers", ClusterScoped: true}},
+	"list_csinodes":          {{Verb: "list", Group: "storage.k8s.io", Resource: "csinodes", ClusterScoped: true}},
+	"list_volumeattachments": {{Verb: "list", Group: "storage.k8s.io", Resource: "volumeattachments", ClusterScoped: true}},
+
+	// Webhooks
+	"list_mutatingwebhookconfigurations":   {{Verb: "list", Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations", ClusterScoped: true}},
+	"list_validatingwebhookconfigurations": {{Verb: "list", Group: "admissionregistration.k8s.io", Resource: "validatingwebhookconfigurations", ClusterScoped: true}},
+}
+
+// Prober hides the tools of a server whose permissions are denied
+type Prober struct {
+	server       *server.MCPServer
+	getClient    toolsets.GetClientFn
+	namespace    string
+	tools        map[string]server.ServerTool
+	requirements map[string][]Permission
+
+	mu         sync.Mutex
+	hidden     map[string]bool
+	registered func(name string) bool
+}
+
+// NewProber creates a prober for tools already registered with the server, checking namespaced
+// permissions in the given default namespace with the client getClient returns on each probe
+func NewProber(s *server.MCPServer, getClient toolsets.GetClientFn, namespace string, tools []server.ServerTool) *Prober {
+	p := &Prober{
+		server:       s,
+		getClient:    getClient,
+		namespace:    namespace,
+		tools:        make(map[string]server.ServerTool, len(tools)),
+		requirements: Requirements,
+		hidden:       make(map[string]bool),
+	}
+	for _, tool := range tools {
+		p.tools[tool.Tool.Name] = tool
+	}
+	return p
+}
+
+// SetRegistered limits the tools the prober deletes from and adds back to the server to those for
+// which registered returns true, such as the tools of enabled dynamic toolsets. The others are
+// still probed, so Hidden reports them.
+func (p *Prober) SetRegistered(registered func(name string) bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.registered = registered
+}
+
+// Hidden reports whether a tool was hidden by the last probe
+func (p *Prober) Hidden(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hidden[name]
+}
+
+// Probe checks the permissions of the tools, hiding tools with a denied permission and showing
+// tools whose permissions were granted since the last probe. It returns the hidden tool names.
+// When a review fails, the visible tools are left unchanged.
+func (p *Prober) Probe(ctx context.Context) ([]string, error) {
+	allowed := make(map[Permission]bool)
+	for name := range p.tools {
+		for _, permission := range p.requirements[name] {
+			if _, ok := allowed[permission]; ok {
+				continue
+			}
+			ok, err := p.allowed(ctx, permission)
+			if err != nil {
+				return nil, err
+			}
+			allowed[permission] = ok
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var hide []string
+	var show []server.ServerTool
+	for name, tool := range p.tools {
+		denied := false
+		for _, permission := range p.requirements[name] {
+			if !allowed[permission] {
+				denied = true
+				break
+			}
+		}
+		registered := p.registered == nil || p.registered(name)
+		switch {
+		case denied && !p.hidden[name]:
+			if registered {
+				hide = append(hide, name)
+			}
+			p.hidden[name] = true
+		case !denied && p.hidden[name]:
+			if registered {
+				show = append(show, tool)
+			}
+			delete(p.hidden, name)
+		}
+	}
+
+	// Each change notifies clients that the tool list changed
+	if len(hide) > 0 {
+		p.server.DeleteTools(hide...)
+	}
+	if len(show) > 0 {
+		p.server.AddTools(show...)
+	}
+
+	hidden := make([]string, 0, len(p.hidden))
+	for name := range p.hidden {
+		hidden = append(hidden, name)
+	}
+	sort.Strings(hidden)
+	return hidden, nil
+}
+
+// allowed asks the API server whether the server identity has a permission
+func (p *Prober) allowed(ctx context.Context, permission Permission) (bool, error) {
+	attributes := &authorizationv1.ResourceAttributes{
+		Verb:        permission.Verb,
+		Group:       permission.Group,
+		Resource:    permission.Resource,
+		Subresource: permission.Subresource,
+	}
+	if !permission.ClusterScoped {
+		attributes.Namespace = p.namespace
+	}
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review %s permission on %s: %w", permission.Verb, resourceName(permission), err)
+	}
+	return review.Status.Allowed, nil
+}
+
+func resourceName(permission Permission) string {
+	name := permission.Resource
+	if permission.Subresource != "" {
+		name += "/" + permission.Subresource
+	}
+	if permission.Group != "" {
+		name += "." + permission.Group
+	}
+	return name
+}
@@ -0,0 +1,139 @@
+package visibility
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestProber(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tools := []server.ServerTool{
+		{Tool: mcp.NewTool("list_nodes"), Handler: handler},
+		{Tool: mcp.NewTool("list_pods"), Handler: handler},
+		{Tool: mcp.NewTool("drain_node"), Handler: handler},
+		{Tool: mcp.NewTool("apply_manifest"), Handler: handler},
+	}
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	s.AddTools(tools...)
+
+	// The server identity is namespace-scoped: it can use pods in its namespace only, until
+	// it is granted nodes
+	var reviews []authorizationv1.ResourceAttributes
+	grantNodes := false
+	reviewFails := false
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if reviewFails {
+			return true, nil, fmt.Errorf("connection refused")
+		}
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := *review.Spec.ResourceAttributes
+		reviews = append(reviews, attributes)
+		switch {
+		case attributes.Resource == "pods" && attributes.Subresource == "" && attributes.Namespace == "shop":
+			review.Status.Allowed = true
+		case attributes.Resource == "nodes":
+			review.Status.Allowed = grantNodes
+		}
+		return true, review, nil
+	})
+
+	prober := NewProber(s, clientFn(client), "shop", tools)
+
+	hidden, err := prober.Probe(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"drain_node", "list_nodes"}, hidden)
+	assert.Equal(t, []string{"apply_manifest", "list_pods"}, listTools(t, s))
+	assert.Contains(t, reviews, authorizationv1.ResourceAttributes{Verb: "list", Resource: "pods", Namespace: "shop"})
+	assert.Contains(t, reviews, authorizationv1.ResourceAttributes{Verb: "list", Resource: "nodes"})
+
+	// Granted permissions show the tools again on the next probe
+	grantNodes = true
+	hidden, err = prober.Probe(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"drain_node"}, hidden)
+	assert.Equal(t, []string{"apply_manifest", "list_nodes", "list_pods"}, listTools(t, s))
+
+	// A failed review leaves the visible tools unchanged
+	reviewFails = true
+	_, err = prober.Probe(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to review")
+	assert.Equal(t, []string{"apply_manifest", "list_nodes", "list_pods"}, listTools(t, s))
+}
+
+func TestProberRegistered(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tools := []server.ServerTool{
+		{Tool: mcp.NewTool("list_nodes"), Handler: handler},
+		{Tool: mcp.NewTool("list_pods"), Handler: handler},
+	}
+	// Only list_pods is registered, list_nodes belongs to a toolset that is not enabled
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	s.AddTools(tools[1])
+
+	grantNodes := false
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "nodes" || grantNodes
+		return true, review, nil
+	})
+
+	prober := NewProber(s, clientFn(client), "shop", tools)
+	prober.SetRegistered(func(name string) bool { return name != "list_nodes" })
+
+	_, err := prober.Probe(context.Background())
+	require.NoError(t, err)
+	assert.True(t, prober.Hidden("list_nodes"))
+	assert.False(t, prober.Hidden("list_pods"))
+	assert.Equal(t, []string{"list_pods"}, listTools(t, s))
+
+	// Granted permissions do not register a tool that was not registered
+	grantNodes = true
+	_, err = prober.Probe(context.Background())
+	require.NoError(t, err)
+	assert.False(t, prober.Hidden("list_nodes"))
+	assert.Equal(t, []string{"list_pods"}, listTools(t, s))
+}
+
+// listTools returns the sorted names of the tools a client sees
+func listTools(t *testing.T, s *server.MCPServer) []string {
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Result mcp.ListToolsResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	var names []string
+	for _, tool := range decoded.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clientFn returns a client getter always returning client
+func clientFn(client kubernetes.Interface) func(context.Context) (kubernetes.Interface, error) {
+	return func(context.Context) (kubernetes.Interface, error) { return client, nil }
+}
@@ -0,0 +1,108 @@
+// Package warmup pre-populates API discovery and OpenAPI schema caches in the background on startup,
+// so the first tool calls of a new agent session do not pay for cold caches, connection setup and
+// credential plugins.
+package warmup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+)
+
+// schemaGroupVersions are the OpenAPI schemas fetched during warm-up. Other group versions are
+// fetched on first use, since large clusters serve hundreds of them.
+var schemaGroupVersions = []string{"api/v1", "apis/apps/v1", "apis/batch/v1"}
+
+// cachedClientset is a clientset whose discovery client serves from an in-memory cache
+type cachedClientset struct {
+	kubernetes.Interface
+	discovery discovery.CachedDiscoveryInterface
+}
+
+// Discovery returns the cached discovery client
+func (c *cachedClientset) Discovery() discovery.DiscoveryInterface {
+	return c.discovery
+}
+
+// WithCachedDiscovery wraps a clientset so API discovery and OpenAPI schemas are cached in memory and
+// shared by every tool call. Callers resolving kinds should invalidate the cache when a kind is
+// not found, as it does not notice CRDs installed after it was filled.
+func WithCachedDiscovery(client kubernetes.Interface) kubernetes.Interface {
+	return &cachedClientset{
+		Interface: client,
+		discovery: memory.NewMemCacheClient(client.Discovery()),
+	}
+}
+
+// Task is a single warm-up step
+type Task struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of a warm-up task
+type Result struct {
+	Task     string
+	Duration time.Duration
+	Err      error
+}
+
+// Tasks returns the warm-up tasks for a clientset: API discovery, the most used OpenAPI schemas,
+// and a namespace list, which also establishes the connection and runs any credential plugin
+func Tasks(client kubernetes.Interface) []Task {
+	return []Task{
+		{
+			Name: "discovery",
+			Run: func(_ context.Context) error {
+				_, _, err := client.Discovery().ServerGroupsAndResources()
+				return err
+			},
+		},
+		{
+			Name: "openapi",
+			Run: func(_ context.Context) error {
+				paths, err := client.Discovery().OpenAPIV3().Paths()
+				if err != nil {
+					return err
+				}
+				for _, groupVersion := range schemaGroupVersions {
+					if gv, ok := paths[groupVersion]; ok {
+						if _, err := gv.Schema("application/json"); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name: "namespaces",
+			Run: func(ctx context.Context) error {
+				_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+				return err
+			},
+		},
+	}
+}
+
+// Run runs the tasks concurrently and returns their results in task order
+func Run(ctx context.Context, tasks []Task) []Result {
+	results := make([]Result, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := task.Run(ctx)
+			results[i] = Result{Task: task.Name, Duration: time.Since(start), Err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
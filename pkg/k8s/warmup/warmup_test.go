@@ -0,0 +1,59 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWithCachedDiscovery(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}}},
+	}
+	client := WithCachedDiscovery(clientset)
+
+	for i := 0; i < 3; i++ {
+		_, resources, err := client.Discovery().ServerGroupsAndResources()
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+	}
+
+	// The fake discovery client records a get of the resource list per uncached call
+	var discoveryCalls int
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "resource" {
+			discoveryCalls++
+		}
+	}
+	assert.Equal(t, 1, discoveryCalls)
+}
+
+func TestRun(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}})
+	clientset.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	tasks := Tasks(WithCachedDiscovery(clientset))
+	// The fake discovery client does not serve OpenAPI documents
+	tasks = append(tasks[:1], tasks[2:]...)
+	tasks = append(tasks, Task{Name: "custom", Run: func(ctx context.Context) error { return nil }})
+
+	results := Run(context.Background(), tasks)
+	require.Len(t, results, 3)
+	assert.Equal(t, "discovery", results[0].Task)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "namespaces", results[1].Task)
+	assert.EqualError(t, results[1].Err, "connection refused")
+	assert.Equal(t, "custom", results[2].Task)
+	assert.NoError(t, results[2].Err)
+}
@@ -3,7 +3,7 @@ package log
 import (
 	"io"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/rs/zerolog"
 )
 
 // IOLogger is a wrapper around io.Reader and io.Writer that can be used
@@ -11,11 +11,11 @@ import (
 type IOLogger struct {
 	reader io.Reader
 	writer io.Writer
-	logger *log.Logger
+	logger *zerolog.Logger
 }
 
 // NewIOLogger creates a new IOLogger instance
-func NewIOLogger(r io.Reader, w io.Writer, logger *log.Logger) *IOLogger {
+func NewIOLogger(r io.Reader, w io.Writer, logger *zerolog.Logger) *IOLogger {
 	return &IOLogger{
 		reader: r,
 		writer: w,
@@ -30,7 +30,7 @@ func (l *IOLogger) Read(p []byte) (n int, err error) {
 	}
 	n, err = l.reader.Read(p)
 	if n > 0 {
-		l.logger.Infof("[stdin]: received %d bytes: %s", n, string(p[:n]))
+		l.logger.Info().Msgf("[stdin]: received %d bytes: %s", n, string(p[:n]))
 	}
 	return n, err
 }
@@ -40,6 +40,6 @@ func (l *IOLogger) Write(p []byte) (n int, err error) {
 	if l.writer == nil {
 		return 0, io.ErrClosedPipe
 	}
-	l.logger.Infof("[stdout]: sending %d bytes: %s", len(p), string(p))
+	l.logger.Info().Msgf("[stdout]: sending %d bytes: %s", len(p), string(p))
 	return l.writer.Write(p)
 }
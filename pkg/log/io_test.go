@@ -5,7 +5,7 @@ import (
 	"strings"
 	"testing"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,13 +17,9 @@ func TestLoggedReadWriter(t *testing.T) {
 
 		// Create logger with buffer to capture output
 		var logBuffer bytes.Buffer
-		logger := log.New()
-		logger.SetOutput(&logBuffer)
-		logger.SetFormatter(&log.TextFormatter{
-			DisableTimestamp: true,
-		})
+		logger := zerolog.New(&logBuffer)
 
-		lrw := NewIOLogger(reader, nil, logger)
+		lrw := NewIOLogger(reader, nil, &logger)
 
 		// Test Read
 		buf := make([]byte, 100)
@@ -44,13 +40,9 @@ func TestLoggedReadWriter(t *testing.T) {
 
 		// Create logger with buffer to capture output
 		var logBuffer bytes.Buffer
-		logger := log.New()
-		logger.SetOutput(&logBuffer)
-		logger.SetFormatter(&log.TextFormatter{
-			DisableTimestamp: true,
-		})
+		logger := zerolog.New(&logBuffer)
 
-		lrw := NewIOLogger(nil, &writeBuffer, logger)
+		lrw := NewIOLogger(nil, &writeBuffer, &logger)
 
 		// Test Write
 		n, err := lrw.Write([]byte(outputData))
@@ -0,0 +1,223 @@
+// Package log is the structured logger of the server. Every part of the server logs through it,
+// tagging its records with a component field, in JSON or in a human-readable console format. Tool
+// handlers find a logger carrying the tool and MCP session of their call in their context.
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog"
+)
+
+// Output formats
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Levels accepted by Setup
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// ComponentKey is the field naming the part of the server a record comes from
+const ComponentKey = "component"
+
+// Options configure the logger
+type Options struct {
+	// Level is the minimum level logged, info when empty
+	Level string
+	// Format is json or console, json when empty
+	Format string
+	// Output receives the records, stderr when nil
+	Output io.Writer
+}
+
+var root = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+// Setup configures the logger every component logs through. It is called once on startup, before
+// components create their loggers.
+func Setup(opts Options) error {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	out := opts.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	switch opts.Format {
+	case "", FormatJSON:
+	case FormatConsole:
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339, NoColor: out != os.Stderr}
+	default:
+		return fmt.Errorf("unsupported log format %q, expected %s or %s", opts.Format, FormatJSON, FormatConsole)
+	}
+	root = zerolog.New(out).Level(level).With().Timestamp().Logger()
+	return nil
+}
+
+// ParseLevel parses a level name, an empty name selecting info
+func ParseLevel(name string) (zerolog.Level, error) {
+	switch strings.ToLower(name) {
+	case "", LevelInfo:
+		return zerolog.InfoLevel, nil
+	case LevelDebug:
+		return zerolog.DebugLevel, nil
+	case LevelWarn, "warning":
+		return zerolog.WarnLevel, nil
+	case LevelError:
+		return zerolog.ErrorLevel, nil
+	}
+	return zerolog.NoLevel, fmt.Errorf("unsupported log level %q, expected %s, %s, %s or %s", name, LevelDebug, LevelInfo, LevelWarn, LevelError)
+}
+
+// Logger returns the root logger
+func Logger() *zerolog.Logger {
+	return &root
+}
+
+// Component returns a logger tagging its records with the name of a part of the server
+func Component(name string) *zerolog.Logger {
+	logger := root.With().Str(ComponentKey, name).Logger()
+	return &logger
+}
+
+// Debug starts a debug record of the root logger
+func Debug() *zerolog.Event { return root.Debug() }
+
+// Info starts an info record of the root logger
+func Info() *zerolog.Event { return root.Info() }
+
+// Warn starts a warning record of the root logger
+func Warn() *zerolog.Event { return root.Warn() }
+
+// Error starts an error record of the root logger
+func Error() *zerolog.Event { return root.Error() }
+
+// Fatal starts a record of the root logger that exits the process once sent
+func Fatal() *zerolog.Event { return root.Fatal() }
+
+type loggerKey struct{}
+
+// WithContext returns a context carrying a logger
+func WithContext(ctx context.Context, logger *zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of a context, or the root logger when it carries none
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return logger
+	}
+	return &root
+}
+
+// Wrap passes the calls of a tool a logger carrying the tool and session, and logs each call once
+// it completes. Failed calls are logged as warnings. At debug level the arguments of each call are
+// logged as it starts, with sensitive values redacted.
+func Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields := Component("tools").With().Str("tool", name)
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			fields = fields.Str("session", session.SessionID())
+		}
+		logger := fields.Logger()
+		if debugEnabled(&logger) {
+			logger.Debug().Interface("arguments", redact.Arguments(request.GetArguments())).Msg("Tool call started")
+		}
+
+		start := time.Now()
+		result, err := next(WithContext(ctx, &logger), request)
+
+		var event *zerolog.Event
+		switch {
+		case err != nil:
+			event = logger.Warn().Err(err)
+		case result != nil && result.IsError:
+			event = logger.Warn().Str("error", resultText(result))
+		default:
+			event = logger.Info()
+		}
+		event.Int64("durationMs", time.Since(start).Milliseconds()).Msg("Tool call completed")
+		return result, err
+	}
+	return tool
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
+
+// WrapTransport logs every Kubernetes API request made through rt at debug level, with its status
+// and duration. Requests made by a tool call are logged with the tool's logger.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripper{next: rt}
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger, ok := req.Context().Value(loggerKey{}).(*zerolog.Logger)
+	if !ok {
+		logger = Component("kubernetes")
+	}
+	if !debugEnabled(logger) {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	event := logger.Debug().Str("method", req.Method).Str("path", req.URL.Path).
+		Int64("durationMs", time.Since(start).Milliseconds())
+	if err != nil {
+		event = event.Err(err)
+	} else {
+		event = event.Int("status", resp.StatusCode)
+	}
+	event.Msg("Kubernetes API request")
+	return resp, err
+}
+
+func debugEnabled(logger *zerolog.Logger) bool {
+	return logger.GetLevel() <= zerolog.DebugLevel && zerolog.GlobalLevel() <= zerolog.DebugLevel
+}
+
+// StdLogger returns a standard library logger writing each line it receives as a record of logger
+// at level, for libraries that only accept a *log.Logger
+func StdLogger(logger *zerolog.Logger, level zerolog.Level) *stdlog.Logger {
+	return stdlog.New(levelWriter{logger: logger, level: level}, "", 0)
+}
+
+type levelWriter struct {
+	logger *zerolog.Logger
+	level  zerolog.Level
+}
+
+func (w levelWriter) Write(p []byte) (int, error) {
+	w.logger.WithLevel(w.level).Msg(string(bytes.TrimRight(p, "\n")))
+	return len(p), nil
+}
@@ -0,0 +1,215 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBuffer sends the records of every logger to a buffer for the duration of a test
+func setupBuffer(t *testing.T, opts Options) *bytes.Buffer {
+	previous := root
+	t.Cleanup(func() { root = previous })
+
+	var buf bytes.Buffer
+	opts.Output = &buf
+	require.NoError(t, Setup(opts))
+	return &buf
+}
+
+func readRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("json records with component and level filtering", func(t *testing.T) {
+		buf := setupBuffer(t, Options{Level: "warn"})
+		Component("auth").Info().Msg("hidden")
+		Component("auth").Warn().Str("file", "tokens.csv").Msg("reload failed")
+
+		records := readRecords(t, buf)
+		require.Len(t, records, 1)
+		assert.Equal(t, "auth", records[0][ComponentKey])
+		assert.Equal(t, "warn", records[0]["level"])
+		assert.Equal(t, "tokens.csv", records[0]["file"])
+		assert.Equal(t, "reload failed", records[0]["message"])
+	})
+
+	t.Run("console records", func(t *testing.T) {
+		buf := setupBuffer(t, Options{Level: "debug", Format: FormatConsole})
+		Component("tls").Debug().Msg("certificates reloaded")
+		assert.Contains(t, buf.String(), "DBG")
+		assert.Contains(t, buf.String(), "certificates reloaded")
+		assert.Contains(t, buf.String(), "component=tls")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		assert.ErrorContains(t, Setup(Options{Level: "verbose"}), `unsupported log level "verbose"`)
+		assert.ErrorContains(t, Setup(Options{Format: "xml"}), `unsupported log format "xml"`)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected zerolog.Level
+	}{
+		{"", zerolog.InfoLevel},
+		{"debug", zerolog.DebugLevel},
+		{"INFO", zerolog.InfoLevel},
+		{"warning", zerolog.WarnLevel},
+		{"error", zerolog.ErrorLevel},
+	}
+	for _, tc := range tests {
+		level, err := ParseLevel(tc.name)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, level, tc.name)
+	}
+}
+
+// fakeSession is a client session with a fixed ID
+type fakeSession string
+
+func (s fakeSession) SessionID() string                                   { return string(s) }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+func TestWrap(t *testing.T) {
+	buf := setupBuffer(t, Options{})
+
+	tool := Wrap(server.ServerTool{
+		Tool: mcp.NewTool("get_pod"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			FromContext(ctx).Info().Msg("fetching pod")
+			switch request.GetArguments()["name"] {
+			case "missing":
+				return mcp.NewToolResultError("pod not found"), nil
+			case "broken":
+				return nil, errors.New("failed to get Kubernetes client")
+			}
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+
+	srv := server.NewMCPServer("test", "1.0")
+	ctx := srv.WithContext(context.Background(), fakeSession("session-1"))
+	for _, name := range []string{"web-0", "missing", "broken"} {
+		_, _ = tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"name": name}}})
+	}
+
+	records := readRecords(t, buf)
+	require.Len(t, records, 6)
+	for _, record := range records {
+		assert.Equal(t, "tools", record[ComponentKey])
+		assert.Equal(t, "get_pod", record["tool"])
+		assert.Equal(t, "session-1", record["session"])
+	}
+	assert.Equal(t, "fetching pod", records[0]["message"])
+	assert.Equal(t, "info", records[1]["level"])
+	assert.Equal(t, "Tool call completed", records[1]["message"])
+	assert.Contains(t, records[1], "durationMs")
+	assert.Equal(t, "warn", records[3]["level"])
+	assert.Equal(t, "pod not found", records[3]["error"])
+	assert.Equal(t, "warn", records[5]["level"])
+	assert.Equal(t, "failed to get Kubernetes client", records[5]["error"])
+}
+
+func TestWrapDebug(t *testing.T) {
+	tool := Wrap(server.ServerTool{
+		Tool: mcp.NewTool("create_secret"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("created"), nil
+		},
+	})
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"name":     "db",
+		"password": "hunter2",
+	}}}
+
+	buf := setupBuffer(t, Options{Level: LevelInfo})
+	_, _ = tool.Handler(context.Background(), request)
+	require.Len(t, readRecords(t, buf), 1)
+
+	buf = setupBuffer(t, Options{Level: LevelDebug})
+	_, _ = tool.Handler(context.Background(), request)
+	records := readRecords(t, buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Tool call started", records[0]["message"])
+	assert.Equal(t, map[string]interface{}{"name": "db", "password": "[REDACTED]"}, records[0]["arguments"])
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestWrapTransport(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer apiServer.Close()
+	client := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+
+	buf := setupBuffer(t, Options{Level: LevelInfo})
+	resp, err := client.Get(apiServer.URL + "/api/v1/namespaces/default/pods/web-0")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, buf.String())
+
+	buf = setupBuffer(t, Options{Level: LevelDebug})
+	logger := Component("tools").With().Str("tool", "get_pod").Logger()
+	req, err := http.NewRequestWithContext(WithContext(context.Background(), &logger), http.MethodGet, apiServer.URL+"/api/v1/namespaces/default/pods/web-0", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	resp, err = client.Get(apiServer.URL + "/version")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	records := readRecords(t, buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Kubernetes API request", records[0]["message"])
+	assert.Equal(t, "get_pod", records[0]["tool"])
+	assert.Equal(t, "GET", records[0]["method"])
+	assert.Equal(t, "/api/v1/namespaces/default/pods/web-0", records[0]["path"])
+	assert.Equal(t, float64(http.StatusNotFound), records[0]["status"])
+	assert.Contains(t, records[0], "durationMs")
+	assert.Equal(t, "kubernetes", records[1][ComponentKey])
+	assert.Equal(t, "/version", records[1]["path"])
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, Logger(), FromContext(context.Background()))
+
+	logger := Component("test")
+	assert.Same(t, logger, FromContext(WithContext(context.Background(), logger)))
+}
+
+func TestStdLogger(t *testing.T) {
+	buf := setupBuffer(t, Options{})
+	StdLogger(Component("stdio"), zerolog.ErrorLevel).Printf("failed to read message: %s", "EOF")
+
+	records := readRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "error", records[0]["level"])
+	assert.Equal(t, "stdio", records[0][ComponentKey])
+	assert.Equal(t, "failed to read message: EOF", records[0]["message"])
+}
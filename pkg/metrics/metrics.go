@@ -0,0 +1,148 @@
+// Package metrics instruments tool calls and Kubernetes API requests with Prometheus metrics, served
+// by the network transports on /metrics:
+//
+//	k8s_mcp_tool_calls_total{tool,result}                      tool calls by result (success or error)
+//	k8s_mcp_tool_call_duration_seconds{tool}                   tool call latency
+//	k8s_mcp_kubernetes_requests_total{code,method,host}        API requests by response code
+//	k8s_mcp_kubernetes_request_duration_seconds{verb,host}     API request latency
+//	k8s_mcp_kubernetes_rate_limiter_duration_seconds{verb,host} time requests waited for the client rate limiter
+//
+// Go runtime and process metrics are included too.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+// Path is the HTTP path metrics are served on
+const Path = "/metrics"
+
+// Tool call results
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+const namespace = "k8s_mcp"
+
+// Metrics holds the collectors of the server
+type Metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls    *prometheus.CounterVec
+	toolDuration *prometheus.HistogramVec
+
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	rateLimiterLatency *prometheus.HistogramVec
+}
+
+// New creates the collectors of the server in their own registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Tool calls by tool and result. Calls returning an error result count as errors.",
+		}, []string{"tool", "result"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Tool call latency by tool.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"tool"}),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_requests_total",
+			Help:      "Kubernetes API requests by response code, method and host.",
+		}, []string{"code", "method", "host"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_request_duration_seconds",
+			Help:      "Kubernetes API request latency by verb and host.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb", "host"}),
+		rateLimiterLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_rate_limiter_duration_seconds",
+			Help:      "Time Kubernetes API requests waited for the client-side rate limiter, by verb and host.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb", "host"}),
+	}
+	m.registry.MustRegister(
+		m.toolCalls, m.toolDuration,
+		m.requests, m.requestDuration, m.rateLimiterLatency,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+var registerClientMetrics sync.Once
+
+// RegisterClientMetrics records the requests of every Kubernetes client of the process. client-go
+// accepts a single set of request metrics, so only the first call has an effect.
+func (m *Metrics) RegisterClientMetrics() {
+	registerClientMetrics.Do(func() {
+		clientmetrics.Register(clientmetrics.RegisterOpts{
+			RequestResult:      requestResult{m.requests},
+			RequestLatency:     latency{m.requestDuration},
+			RateLimiterLatency: latency{m.rateLimiterLatency},
+		})
+	})
+}
+
+// Wrap records the calls of a tool
+func (m *Metrics) Wrap(tool server.ServerTool) server.ServerTool {
+	name := tool.Tool.Name
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+		m.toolDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+		outcome := ResultSuccess
+		if err != nil || (result != nil && result.IsError) {
+			outcome = ResultError
+		}
+		m.toolCalls.WithLabelValues(name, outcome).Inc()
+		return result, err
+	}
+	return tool
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// requestResult adapts a counter to the client-go request result metric
+type requestResult struct {
+	counter *prometheus.CounterVec
+}
+
+func (r requestResult) Increment(_ context.Context, code, method, host string) {
+	r.counter.WithLabelValues(code, method, host).Inc()
+}
+
+// latency adapts a histogram to the client-go latency metrics, dropping the URL path to keep the
+// number of series bounded
+type latency struct {
+	histogram *prometheus.HistogramVec
+}
+
+func (l latency) Observe(_ context.Context, verb string, u url.URL, d time.Duration) {
+	l.histogram.WithLabelValues(verb, u.Host).Observe(d.Seconds())
+}
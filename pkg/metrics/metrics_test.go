@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	m := New()
+	tool := m.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("get_pod"),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			switch request.GetArguments()["outcome"] {
+			case "error result":
+				return mcp.NewToolResultError("pod not found"), nil
+			case "error":
+				return nil, errors.New("failed to get Kubernetes client")
+			}
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+
+	for _, outcome := range []string{"success", "success", "error result", "error"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]interface{}{"outcome": outcome}
+		_, _ = tool.Handler(context.Background(), request)
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.toolCalls.WithLabelValues("get_pod", ResultSuccess)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.toolCalls.WithLabelValues("get_pod", ResultError)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.toolDuration))
+}
+
+func TestClientMetrics(t *testing.T) {
+	m := New()
+	u := url.URL{Scheme: "https", Host: "10.0.0.1:6443", Path: "/api/v1/namespaces/default/pods/web"}
+	requestResult{m.requests}.Increment(context.Background(), "200", "GET", u.Host)
+	requestResult{m.requests}.Increment(context.Background(), "403", "GET", u.Host)
+	latency{m.requestDuration}.Observe(context.Background(), "GET", u, 20*time.Millisecond)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.requests.WithLabelValues("403", "GET", "10.0.0.1:6443")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.requestDuration))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `k8s_mcp_kubernetes_requests_total{code="200",host="10.0.0.1:6443",method="GET"} 1`)
+	assert.Contains(t, body, `k8s_mcp_kubernetes_request_duration_seconds_count{host="10.0.0.1:6443",verb="GET"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
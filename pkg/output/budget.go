@@ -0,0 +1,144 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxResponseBytes is the default size budget of a tool result, well within the message
+// size MCP clients accept and the context of a model
+const DefaultMaxResponseBytes = 256 * 1024
+
+// Fit shrinks a tool result to at most maxBytes. JSON results of Kubernetes objects are first
+// downgraded to summaries; lists that are still too large keep as many items as fit and report
+// the number left out in omittedItems. Other text keeps its first lines followed by a note.
+// Single objects that do not fit even as a summary are an error.
+func Fit(kind, text string, maxBytes int) (string, error) {
+	if maxBytes <= 0 || len(text) <= maxBytes {
+		return text, nil
+	}
+	data := []byte(text)
+	if !json.Valid(data) {
+		return cutLines(text, maxBytes), nil
+	}
+
+	note := fmt.Sprintf("the result of %d bytes exceeded the response budget of %d bytes", len(text), maxBytes)
+	if summary, err := Summary(kind, data); err == nil {
+		note += " and was returned as a summary"
+		data = []byte(summary)
+	}
+	note += "; narrow it down with selectors, limit or fields"
+
+	value, err := decodeDocument(data)
+	if err != nil {
+		return "", err
+	}
+	var list *object
+	switch v := value.(type) {
+	case *object:
+		if _, ok := v.values["items"].([]interface{}); ok {
+			list = v
+		} else if s, err := withNote(v, note); err == nil && len(s) <= maxBytes {
+			return s, nil
+		}
+	case []interface{}:
+		list = (&object{}).set("items", v)
+	}
+	if list == nil {
+		return "", errors.New(note)
+	}
+
+	items := list.values["items"].([]interface{})
+	page := func(n int) (string, error) {
+		truncated := (&object{}).merge(list).set("items", items[:n])
+		if _, ok := list.values["count"]; ok {
+			truncated.set("count", n)
+		}
+		if n < len(items) {
+			truncated.set("omittedItems", len(items)-n)
+		}
+		return withNote(truncated, note)
+	}
+	// The largest number of items that fits, as the size grows with every item kept
+	var pageErr error
+	n := sort.Search(len(items)+1, func(n int) bool {
+		s, err := page(n)
+		if err != nil {
+			pageErr = err
+			return true
+		}
+		return len(s) > maxBytes
+	}) - 1
+	if pageErr != nil {
+		return "", pageErr
+	}
+	if n < 0 {
+		return "", errors.New(note)
+	}
+	return page(n)
+}
+
+func withNote(obj *object, note string) (string, error) {
+	b, err := json.Marshal((&object{}).merge(obj).set("note", note))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// cutLines keeps the whole lines of text that fit into maxBytes together with a note saying how
+// much was left out
+func cutLines(text string, maxBytes int) string {
+	format := "[truncated: %d more bytes were left out to stay within the response budget of %d bytes]\n"
+	keep := maxBytes - len(fmt.Sprintf(format, len(text), maxBytes))
+	if keep < 0 {
+		keep = 0
+	}
+	kept := text[:keep]
+	if i := strings.LastIndexByte(kept, '\n'); i >= 0 {
+		kept = kept[:i+1]
+	} else {
+		kept = ""
+	}
+	return kept + fmt.Sprintf(format, len(text)-len(kept), maxBytes)
+}
+
+// WithBudget returns a wrapper keeping the text results of a tool within maxBytes, as described
+// by Fit, instead of overflowing the message sent to the client. A budget of 0 leaves results
+// unchanged.
+func WithBudget(maxBytes int) func(server.ServerTool) server.ServerTool {
+	return func(tool server.ServerTool) server.ServerTool {
+		if maxBytes <= 0 {
+			return tool
+		}
+		next := tool.Handler
+		kind := KindOf(tool.Tool.Name)
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil {
+				return result, err
+			}
+			for i, content := range result.Content {
+				text, ok := content.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				fitted, err := Fit(kind, text.Text, maxBytes)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				text.Text = fitted
+				result.Content[i] = text
+			}
+			return result, nil
+		}
+		return tool
+	}
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Envelope is the standard shape of JSON tool results, telling clients what a result holds and
+// whether it is complete. Lists hold their objects in Items, other results in Object.
+type Envelope struct {
+	Kind               string          `json:"kind,omitempty"`
+	APIVersion         string          `json:"apiVersion,omitempty"`
+	Count              int             `json:"count"`
+	Truncated          bool            `json:"truncated"`
+	ContinueToken      string          `json:"continueToken,omitempty"`
+	RemainingItemCount json.RawMessage `json:"remainingItemCount,omitempty"`
+	OmittedItems       int             `json:"omittedItems,omitempty"`
+	Note               string          `json:"note,omitempty"`
+	DurationMs         int64           `json:"durationMs"`
+	Items              json.RawMessage `json:"items,omitempty"`
+	Object             json.RawMessage `json:"object,omitempty"`
+}
+
+// Envelop wraps the JSON result of a tool in an Envelope, reporting false for results that are
+// not JSON. Lists are results holding an items array, such as Kubernetes list objects, pages of
+// list tools and their summaries, and JSON arrays; lists with a continue token or items left out
+// to fit the response budget are truncated.
+func Envelop(tool, text string, duration time.Duration) (*Envelope, bool) {
+	data := bytes.TrimSpace([]byte(text))
+	if !json.Valid(data) {
+		return nil, false
+	}
+	envelope := &Envelope{DurationMs: duration.Milliseconds()}
+
+	var items []json.RawMessage
+	if json.Unmarshal(data, &items) == nil {
+		envelope.Kind = KindOf(tool)
+		envelope.Count = len(items)
+		envelope.Items = data
+		envelope.APIVersion = apiVersionOf(envelope.Kind)
+		return envelope, true
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		// Scalars have no kind, count or items to report
+		envelope.Object = data
+		return envelope, true
+	}
+	var kind, apiVersion string
+	_ = json.Unmarshal(fields["kind"], &kind)
+	_ = json.Unmarshal(fields["apiVersion"], &apiVersion)
+
+	if fields["items"] == nil || json.Unmarshal(fields["items"], &items) != nil {
+		// Only objects of Kubernetes kinds take the kind of the tool
+		if kind == "" && fields["metadata"] != nil {
+			kind = KindOf(tool)
+		}
+		envelope.Kind = kind
+		envelope.APIVersion = apiVersion
+		if envelope.APIVersion == "" {
+			envelope.APIVersion = apiVersionOf(kind)
+		}
+		envelope.Count = 1
+		envelope.Object = data
+		return envelope, true
+	}
+
+	envelope.Kind = strings.TrimSuffix(kind, "List")
+	if envelope.Kind == "" {
+		envelope.Kind = KindOf(tool)
+	}
+	envelope.APIVersion = apiVersion
+	if envelope.APIVersion == "" {
+		envelope.APIVersion = apiVersionOf(envelope.Kind)
+	}
+	envelope.Count = len(items)
+	envelope.Items = fields["items"]
+
+	// Pages of list tools and summaries carry the continue token at the top, list objects in
+	// their metadata
+	var list struct {
+		Continue           string          `json:"continue"`
+		RemainingItemCount json.RawMessage `json:"remainingItemCount"`
+		OmittedItems       int             `json:"omittedItems"`
+		Note               string          `json:"note"`
+	}
+	_ = json.Unmarshal(fields["metadata"], &list)
+	_ = json.Unmarshal(data, &list)
+	envelope.ContinueToken = list.Continue
+	envelope.RemainingItemCount = list.RemainingItemCount
+	envelope.OmittedItems = list.OmittedItems
+	envelope.Note = list.Note
+	envelope.Truncated = list.Continue != "" || list.OmittedItems > 0
+	return envelope, true
+}
+
+// apiVersionOf returns the preferred API version of a built-in kind, or an empty string for
+// kinds the client does not know
+func apiVersionOf(kind string) string {
+	if kind == "" {
+		return ""
+	}
+	for _, gv := range scheme.Scheme.PrioritizedVersionsAllGroups() {
+		if scheme.Scheme.Recognizes(gv.WithKind(kind)) {
+			return gv.String()
+		}
+	}
+	return ""
+}
+
+// WithEnvelope wraps the JSON results of a tool in an Envelope, timing the call. Error results and
+// results that are not JSON, such as pod logs or YAML, are returned unchanged.
+func WithEnvelope(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		duration := time.Since(start)
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			envelope, ok := Envelop(name, text.Text, duration)
+			if !ok {
+				continue
+			}
+			b, err := json.Marshal(envelope)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			text.Text = string(b)
+			result.Content[i] = text
+		}
+		return result, nil
+	}
+	return tool
+}
@@ -0,0 +1,206 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// FieldsParam is the parameter selecting the fields a read tool returns
+const FieldsParam = "fields"
+
+// Field selects a value of a JSON result with a dotted path such as spec.replicas or a JSONPath
+// expression such as {.spec.containers[*].image}
+type Field struct {
+	path     string
+	jsonPath *jsonpath.JSONPath
+	multiple bool
+}
+
+// ParseField parses a dotted path or JSONPath expression. The braces and leading $ of JSONPath
+// expressions are optional.
+func ParseField(path string) (*Field, error) {
+	expr := strings.TrimSpace(path)
+	if strings.HasPrefix(expr, "{") && strings.HasSuffix(expr, "}") {
+		expr = expr[1 : len(expr)-1]
+	}
+	expr = strings.TrimPrefix(expr, "$")
+	if expr == "" {
+		return nil, fmt.Errorf("invalid field %q: empty path", path)
+	}
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+
+	jp := jsonpath.New(path).AllowMissingKeys(true)
+	if err := jp.Parse("{" + expr + "}"); err != nil {
+		return nil, fmt.Errorf("invalid field %q: %v", path, err)
+	}
+	return &Field{
+		path:     path,
+		jsonPath: jp,
+		// Wildcards, filters, slices, unions and recursive descent select any number of values
+		multiple: strings.ContainsAny(expr, "*?:,") || strings.Contains(expr, ".."),
+	}, nil
+}
+
+// find returns the value the field selects in an object: a list for fields selecting any number
+// of values, nil when nothing matches
+func (f *Field) find(obj interface{}) (interface{}, error) {
+	results, err := f.jsonPath.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	if f.multiple {
+		return values, nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+// Project returns the selected fields of a JSON result as an object keyed by the requested paths.
+// The fields of lists, including Kubernetes list objects, are selected from each item; lists keep
+// their continue token and remaining item count so the next page can still be requested.
+func Project(data []byte, fields []*Field) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+
+	var projected interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		items, isList := v["items"].([]interface{})
+		if !isList {
+			obj, err := project(v, fields)
+			if err != nil {
+				return "", err
+			}
+			projected = obj
+			break
+		}
+		list, err := projectItems(items, fields)
+		if err != nil {
+			return "", err
+		}
+		result := (&object{}).set("items", list)
+		for _, meta := range []map[string]interface{}{v, metadataOf(v)} {
+			if token, ok := meta["continue"].(string); ok && token != "" {
+				result.set("continue", token)
+			}
+			if remaining, ok := meta["remainingItemCount"]; ok {
+				result.set("remainingItemCount", remaining)
+			}
+		}
+		projected = result
+	case []interface{}:
+		list, err := projectItems(v, fields)
+		if err != nil {
+			return "", err
+		}
+		projected = list
+	default:
+		return "", fmt.Errorf("result has no fields to select")
+	}
+
+	b, err := json.Marshal(projected)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func metadataOf(obj map[string]interface{}) map[string]interface{} {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	return metadata
+}
+
+func projectItems(items []interface{}, fields []*Field) ([]interface{}, error) {
+	projected := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		obj, err := project(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, obj)
+	}
+	return projected, nil
+}
+
+func project(value interface{}, fields []*Field) (*object, error) {
+	obj := &object{}
+	for _, field := range fields {
+		v, err := field.find(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select %q: %v", field.path, err)
+		}
+		obj.set(field.path, v)
+	}
+	return obj, nil
+}
+
+// WithFieldsParam adds the "fields" parameter to a tool and returns only the selected fields of its
+// JSON result. Error results and results that are not JSON are returned unchanged.
+func WithFieldsParam(tool server.ServerTool) server.ServerTool {
+	mcp.WithArray(FieldsParam,
+		mcp.Description("Return only these fields of each object, as dotted paths such as metadata.name or JSONPath expressions such as {.spec.containers[*].image}"),
+		mcp.Items(map[string]interface{}{"type": "string"}),
+	)(&tool.Tool)
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		paths, err := toolsets.OptionalParam[[]interface{}](request, FieldsParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(paths) == 0 {
+			return next(ctx, request)
+		}
+		fields := make([]*Field, 0, len(paths))
+		for _, p := range paths {
+			path, ok := p.(string)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("fields must be strings, got %T", p)), nil
+			}
+			field, err := ParseField(path)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fields = append(fields, field)
+		}
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			if projected, err := Project([]byte(text.Text), fields); err == nil {
+				text.Text = projected
+				result.Content[i] = text
+			}
+		}
+		return result, nil
+	}
+	return tool
+}
@@ -0,0 +1,162 @@
+// Package output renders JSON tool results in other formats selected with the "output" tool parameter.
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats
+const (
+	// FormatJSON is the default output format of get tools, the tool result is returned unchanged
+	FormatJSON = "json"
+	// FormatSummary is the default output format of list tools, keeping the identity and key
+	// status fields of each object so responses stay small
+	FormatSummary = "summary"
+	FormatYAML    = "yaml"
+)
+
+// Renderer formats the JSON result of a tool
+type Renderer interface {
+	Render(data []byte) (string, error)
+}
+
+// KindRenderer is a Renderer that is also given the kind of the objects a tool returns, which the
+// items of typed list results do not carry
+type KindRenderer interface {
+	Renderer
+	RenderKind(kind string, data []byte) (string, error)
+}
+
+// RendererFunc adapts a function to the Renderer interface
+type RendererFunc func(data []byte) (string, error)
+
+// Render calls f(data)
+func (f RendererFunc) Render(data []byte) (string, error) {
+	return f(data)
+}
+
+var (
+	mu        sync.RWMutex
+	renderers = map[string]Renderer{
+		"markdown":    RendererFunc(Markdown),
+		"csv":         RendererFunc(CSV),
+		FormatYAML:    RendererFunc(YAML),
+		FormatSummary: summaryRenderer{},
+	}
+)
+
+// Register makes a renderer available under the given format name, replacing any renderer
+// registered with the same name. Renderers must be registered before tools are wrapped
+// for the format to appear in the tool schemas.
+func Register(format string, renderer Renderer) {
+	mu.Lock()
+	defer mu.Unlock()
+	renderers[format] = renderer
+}
+
+// Lookup returns the renderer registered for a format
+func Lookup(format string) (Renderer, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	renderer, ok := renderers[format]
+	return renderer, ok
+}
+
+// Formats returns the supported output formats, starting with the default
+func Formats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return append([]string{FormatJSON}, formats...)
+}
+
+// YAML renders a JSON result as YAML
+func YAML(data []byte) (string, error) {
+	if !json.Valid(data) {
+		return "", errors.New("result is not JSON")
+	}
+	b, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WithOutputParam adds the "output" parameter to a tool and renders its text result in the requested
+// format. List tools default to the summary format, other tools to json. Error results are returned
+// unchanged, as are results that are not JSON or cannot be represented in the format.
+func WithOutputParam(tool server.ServerTool) server.ServerTool {
+	formats := Formats()
+	defaultFormat := FormatJSON
+	if strings.HasPrefix(tool.Tool.Name, "list_") {
+		defaultFormat = FormatSummary
+	}
+	mcp.WithString("output",
+		mcp.Description(fmt.Sprintf("Output format: %s (default %s). summary keeps the name, namespace and key status fields of each object; json returns them in full. Tables are rendered with one row per item",
+			strings.Join(formats, ", "), defaultFormat)),
+		mcp.Enum(formats...),
+	)(&tool.Tool)
+
+	kind := KindOf(tool.Tool.Name)
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		format, err := toolsets.OptionalParam[string](request, "output")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if format == "" {
+			format = defaultFormat
+			// Selected fields are returned as they are rather than summarized
+			if fields, _ := request.GetArguments()[FieldsParam].([]interface{}); len(fields) > 0 {
+				format = FormatJSON
+			}
+		}
+		if format == FormatJSON {
+			return next(ctx, request)
+		}
+		renderer, ok := Lookup(format)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported output format %q: must be one of %s", format, strings.Join(Formats(), ", "))), nil
+		}
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			if rendered, err := render(renderer, kind, []byte(text.Text)); err == nil {
+				text.Text = rendered
+				result.Content[i] = text
+			}
+		}
+		return result, nil
+	}
+	return tool
+}
+
+// render renders a result, passing the kind of the tool to renderers that use it
+func render(renderer Renderer, kind string, data []byte) (string, error) {
+	if r, ok := renderer.(KindRenderer); ok {
+		return r.RenderKind(kind, data)
+	}
+	return renderer.Render(data)
+}
@@ -0,0 +1,582 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+const podList = `{"kind":"PodList","apiVersion":"v1","metadata":{"resourceVersion":"12"},"items":[
+{"metadata":{"name":"web-1","namespace":"shop","creationTimestamp":"2024-05-01T10:00:00Z"},"spec":{"containers":[{"name":"web"}]},"status":{"phase":"Running"}},
+{"metadata":{"name":"web-2","namespace":"shop","creationTimestamp":"2024-05-01T11:00:00Z"},"spec":{"containers":[{"name":"web"}]},"status":{"phase":"Pending"}}]}`
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		expected       string
+		expectedErrMsg string
+	}{
+		{
+			name: "list of summaries",
+			data: `[{"name":"web","ready":"1/2","images":["nginx:1.25","envoy:1.30"],"limits":{"cpu":"500m"}},{"name":"db","ready":"1/1","note":"a|b"}]`,
+			expected: "| name | ready | images | limits.cpu | note |\n" +
+				"| --- | --- | --- | --- | --- |\n" +
+				"| web | 1/2 | nginx:1.25, envoy:1.30 | 500m |  |\n" +
+				"| db | 1/1 |  |  | a\\|b |\n",
+		},
+		{
+			name: "kubernetes list is summarized",
+			data: podList,
+			expected: "| namespace | name | phase | created |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| shop | web-1 | Running | 2024-05-01T10:00:00Z |\n" +
+				"| shop | web-2 | Pending | 2024-05-01T11:00:00Z |\n",
+		},
+		{
+			name: "single object",
+			data: `{"inSync":false,"objects":[{"object":"cm","status":"missing"}],"count":3}`,
+			expected: "| field | value |\n" +
+				"| --- | --- |\n" +
+				"| inSync | false |\n" +
+				"| objects | [{\"object\":\"cm\",\"status\":\"missing\"}] |\n" +
+				"| count | 3 |\n",
+		},
+		{
+			name:     "empty list",
+			data:     `[]`,
+			expected: "_No items_\n",
+		},
+		{
+			name:           "plain text",
+			data:           "deployment web scaled to 3 replicas",
+			expectedErrMsg: "invalid character",
+		},
+		{
+			name:           "scalar",
+			data:           `"done"`,
+			expectedErrMsg: "result is not tabular",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := Markdown([]byte(tc.data))
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rendered)
+		})
+	}
+}
+
+func TestCSV(t *testing.T) {
+	rendered, err := CSV([]byte(podList))
+	require.NoError(t, err)
+	assert.Equal(t, "namespace,name,phase,created\n"+
+		"shop,web-1,Running,2024-05-01T10:00:00Z\n"+
+		"shop,web-2,Pending,2024-05-01T11:00:00Z\n", rendered)
+
+	rendered, err = CSV([]byte(`[{"name":"web","message":"line one, \"quoted\""}]`))
+	require.NoError(t, err)
+	assert.Equal(t, "name,message\nweb,\"line one, \"\"quoted\"\"\"\n", rendered)
+}
+
+func TestWithOutputParam(t *testing.T) {
+	Register("names", RendererFunc(func(data []byte) (string, error) {
+		_, rows, err := tabulate(data)
+		if err != nil {
+			return "", err
+		}
+		var names string
+		for _, row := range rows {
+			names += row[1] + "\n"
+		}
+		return names, nil
+	}))
+	defer func() {
+		mu.Lock()
+		delete(renderers, "names")
+		mu.Unlock()
+	}()
+
+	text := podList
+	tool := WithOutputParam(server.ServerTool{
+		Tool: mcp.NewTool("list_pods"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if text == "" {
+				return mcp.NewToolResultError("failed to list pods"), nil
+			}
+			return mcp.NewToolResultText(text), nil
+		},
+	})
+
+	property, ok := tool.Tool.InputSchema.Properties["output"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []string{"json", "csv", "markdown", "names", "summary", "yaml"}, property["enum"])
+	assert.Contains(t, property["description"], "(default summary)")
+
+	tests := []struct {
+		name           string
+		text           string
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "list tools default to summary",
+			text:         podList,
+			requestArgs:  map[string]interface{}{},
+			expectedText: `{"kind":"Pod","count":2,"items":[{"name":"web-1","namespace":"shop","phase":"Running","ready":"0/1","restarts":0,"created":"2024-05-01T10:00:00Z"},{"name":"web-2","namespace":"shop","phase":"Pending","ready":"0/1","restarts":0,"created":"2024-05-01T11:00:00Z"}]}`,
+		},
+		{
+			name:         "json is unchanged",
+			text:         podList,
+			requestArgs:  map[string]interface{}{"output": "json"},
+			expectedText: podList,
+		},
+		{
+			name:         "registered renderer",
+			text:         podList,
+			requestArgs:  map[string]interface{}{"output": "names"},
+			expectedText: "web-1\nweb-2\n",
+		},
+		{
+			name:         "non json result is unchanged",
+			text:         "pod logs",
+			requestArgs:  map[string]interface{}{"output": "csv"},
+			expectedText: "pod logs",
+		},
+		{
+			name:           "error result is unchanged",
+			text:           "",
+			requestArgs:    map[string]interface{}{"output": "markdown"},
+			expectedErrMsg: "failed to list pods",
+		},
+		{
+			name:           "unsupported format",
+			text:           podList,
+			requestArgs:    map[string]interface{}{"output": "xml"},
+			expectedErrMsg: `unsupported output format "xml"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			text = tc.text
+			result, err := tool.Handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			// If we're expecting an error message in the result
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name           string
+		kind           string
+		data           string
+		expected       string
+		expectedErrMsg string
+	}{
+		{
+			name:     "typed pod list uses the tool kind",
+			kind:     "Pod",
+			data:     `{"metadata":{"continue":"abc"},"items":[{"metadata":{"name":"web-1","namespace":"shop"},"spec":{"nodeName":"node-1","containers":[{"name":"web"},{"name":"envoy"}]},"status":{"phase":"Running","podIP":"10.0.0.5","containerStatuses":[{"name":"web","ready":true,"restartCount":3},{"name":"envoy","ready":false,"restartCount":1,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}]}`,
+			expected: `{"kind":"Pod","count":1,"continue":"abc","items":[{"name":"web-1","namespace":"shop","phase":"Running","reason":"CrashLoopBackOff","ready":"1/2","restarts":4,"node":"node-1","ip":"10.0.0.5"}]}`,
+		},
+		{
+			name:     "deployment",
+			data:     `{"kind":"Deployment","metadata":{"name":"web","namespace":"shop","creationTimestamp":"2024-05-01T10:00:00Z"},"spec":{"replicas":3},"status":{"readyReplicas":2,"updatedReplicas":3,"availableReplicas":2}}`,
+			expected: `{"kind":"Deployment","name":"web","namespace":"shop","ready":"2/3","upToDate":3,"available":2,"created":"2024-05-01T10:00:00Z"}`,
+		},
+		{
+			name:     "nodes",
+			kind:     "Node",
+			data:     `{"items":[{"metadata":{"name":"node-1","labels":{"node-role.kubernetes.io/control-plane":"","zone":"a"}},"spec":{"unschedulable":true},"status":{"conditions":[{"type":"Ready","status":"True"}],"nodeInfo":{"kubeletVersion":"v1.32.3"}}}]}`,
+			expected: `{"kind":"Node","count":1,"items":[{"name":"node-1","status":"Ready,SchedulingDisabled","roles":["control-plane"],"version":"v1.32.3"}]}`,
+		},
+		{
+			name:     "service",
+			kind:     "Service",
+			data:     `{"metadata":{"name":"web","namespace":"shop"},"spec":{"type":"NodePort","clusterIP":"10.96.0.10","ports":[{"port":80,"nodePort":30080,"protocol":"TCP"}]}}`,
+			expected: `{"kind":"Service","name":"web","namespace":"shop","type":"NodePort","clusterIP":"10.96.0.10","ports":["80:30080/TCP"]}`,
+		},
+		{
+			name:     "configmap",
+			kind:     "ConfigMap",
+			data:     `{"metadata":{"name":"settings","namespace":"shop"},"data":{"mode":"production","flags":"a,b"}}`,
+			expected: `{"kind":"ConfigMap","name":"settings","namespace":"shop","keys":["flags","mode"]}`,
+		},
+		{
+			name:     "other kinds keep phase and readiness",
+			data:     `[{"kind":"PersistentVolumeClaim","metadata":{"name":"data","namespace":"shop"},"status":{"phase":"Bound"}}]`,
+			expected: `[{"name":"data","namespace":"shop","phase":"Bound"}]`,
+		},
+		{
+			name:           "not kubernetes objects",
+			data:           `[{"image":"nginx:1.25","pods":3}]`,
+			expectedErrMsg: "result holds no Kubernetes objects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := Summary(tc.kind, []byte(tc.data))
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rendered)
+		})
+	}
+}
+
+func TestYAML(t *testing.T) {
+	rendered, err := YAML([]byte(`{"kind":"ConfigMap","metadata":{"name":"settings"},"data":{"mode":"production"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "data:\n  mode: production\nkind: ConfigMap\nmetadata:\n  name: settings\n", rendered)
+
+	_, err = YAML([]byte("pod logs"))
+	assert.Error(t, err)
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, "Pod", KindOf("list_pods"))
+	assert.Equal(t, "Pod", KindOf("get_pod"))
+	assert.Equal(t, "PodDisruptionBudget", KindOf("list_pdbs"))
+	assert.Equal(t, "Lease", KindOf("list_leases"))
+	assert.Equal(t, "", KindOf("get_pod_logs"))
+	assert.Equal(t, "", KindOf("scale_deployment"))
+}
+
+func TestWithOutputParamGetTool(t *testing.T) {
+	pod := `{"metadata":{"name":"web-1","namespace":"shop"},"spec":{"containers":[{"name":"web"}]},"status":{"phase":"Running"}}`
+	tool := WithOutputParam(server.ServerTool{
+		Tool: mcp.NewTool("get_pod"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(pod), nil
+		},
+	})
+
+	// Get tools default to the full object
+	result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Equal(t, pod, getTextResult(t, result).Text)
+
+	result, err = tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{"output": "summary"}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"kind":"Pod","name":"web-1","namespace":"shop","phase":"Running","ready":"0/1","restarts":0}`, getTextResult(t, result).Text)
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name           string
+		fields         []string
+		data           string
+		expected       string
+		expectedErrMsg string
+	}{
+		{
+			name:     "list items",
+			fields:   []string{"metadata.name", "{.spec.containers[*].name}", "$.status.phase", "status.podIP"},
+			data:     podList,
+			expected: `{"items":[{"metadata.name":"web-1","{.spec.containers[*].name}":["web"],"$.status.phase":"Running","status.podIP":null},{"metadata.name":"web-2","{.spec.containers[*].name}":["web"],"$.status.phase":"Pending","status.podIP":null}]}`,
+		},
+		{
+			name:     "continue token is kept",
+			fields:   []string{"metadata.name"},
+			data:     `{"metadata":{"continue":"abc"},"items":[{"metadata":{"name":"web-1"}}]}`,
+			expected: `{"items":[{"metadata.name":"web-1"}],"continue":"abc"}`,
+		},
+		{
+			name:     "single object",
+			fields:   []string{"spec.replicas", `metadata.labels.app\.kubernetes\.io/name`},
+			data:     `{"metadata":{"name":"web","labels":{"app.kubernetes.io/name":"shop"}},"spec":{"replicas":3}}`,
+			expected: `{"spec.replicas":3,"metadata.labels.app\\.kubernetes\\.io/name":"shop"}`,
+		},
+		{
+			name:           "not json",
+			fields:         []string{"metadata.name"},
+			data:           "pod logs",
+			expectedErrMsg: "invalid character",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []*Field
+			for _, path := range tc.fields {
+				field, err := ParseField(path)
+				require.NoError(t, err)
+				fields = append(fields, field)
+			}
+			projected, err := Project([]byte(tc.data), fields)
+			if tc.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, projected)
+		})
+	}
+
+	_, err := ParseField("spec.containers[")
+	assert.ErrorContains(t, err, `invalid field "spec.containers["`)
+	_, err = ParseField("{}")
+	assert.ErrorContains(t, err, "empty path")
+}
+
+func TestWithFieldsParam(t *testing.T) {
+	tool := WithOutputParam(WithFieldsParam(server.ServerTool{
+		Tool: mcp.NewTool("list_pods"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(podList), nil
+		},
+	}))
+	_, ok := tool.Tool.InputSchema.Properties[FieldsParam]
+	assert.True(t, ok)
+
+	// Selected fields are returned as JSON rather than summarized
+	result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"fields": []interface{}{"metadata.name"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"items":[{"metadata.name":"web-1"},{"metadata.name":"web-2"}]}`, getTextResult(t, result).Text)
+
+	result, err = tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"fields": []interface{}{"metadata.name", "status.phase"},
+		"output": "csv",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "metadata.name,status.phase\nweb-1,Running\nweb-2,Pending\n", getTextResult(t, result).Text)
+
+	result, err = tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"fields": []interface{}{"spec.containers["},
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "invalid field")
+}
+
+func TestEnvelop(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "page of a list tool",
+			tool:     "list_pods",
+			text:     `{"items":[{"metadata":{"name":"web-1"}}],"continue":"token","remainingItemCount":4}`,
+			expected: `{"kind":"Pod","apiVersion":"v1","count":1,"truncated":true,"continueToken":"token","remainingItemCount":4,"durationMs":12,"items":[{"metadata":{"name":"web-1"}}]}`,
+		},
+		{
+			name:     "last page",
+			tool:     "list_deployments",
+			text:     `{"items":[]}`,
+			expected: `{"kind":"Deployment","apiVersion":"apps/v1","count":0,"truncated":false,"durationMs":12,"items":[]}`,
+		},
+		{
+			name:     "kubernetes list object",
+			tool:     "list_resources",
+			text:     `{"apiVersion":"example.com/v1","kind":"WidgetList","metadata":{"continue":"token"},"items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]}`,
+			expected: `{"kind":"Widget","apiVersion":"example.com/v1","count":2,"truncated":true,"continueToken":"token","durationMs":12,"items":[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]}`,
+		},
+		{
+			name:     "list cut to the response budget",
+			tool:     "list_images",
+			text:     `{"items":[{"image":"nginx"}],"omittedItems":3,"note":"the result exceeded the response budget"}`,
+			expected: `{"count":1,"truncated":true,"omittedItems":3,"note":"the result exceeded the response budget","durationMs":12,"items":[{"image":"nginx"}]}`,
+		},
+		{
+			name:     "summary",
+			tool:     "list_pods",
+			text:     `{"kind":"Pod","count":1,"items":[{"name":"web-1"}]}`,
+			expected: `{"kind":"Pod","apiVersion":"v1","count":1,"truncated":false,"durationMs":12,"items":[{"name":"web-1"}]}`,
+		},
+		{
+			name:     "array",
+			tool:     "list_images",
+			text:     `[{"image":"nginx"}]`,
+			expected: `{"count":1,"truncated":false,"durationMs":12,"items":[{"image":"nginx"}]}`,
+		},
+		{
+			name:     "object",
+			tool:     "get_node",
+			text:     `{"metadata":{"name":"node-1"}}`,
+			expected: `{"kind":"Node","apiVersion":"v1","count":1,"truncated":false,"durationMs":12,"object":{"metadata":{"name":"node-1"}}}`,
+		},
+		{
+			name:     "result that is not a Kubernetes object",
+			tool:     "get_pod_status_summary",
+			text:     `{"phase":"Running"}`,
+			expected: `{"count":1,"truncated":false,"durationMs":12,"object":{"phase":"Running"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			envelope, ok := Envelop(tc.tool, tc.text, 12*time.Millisecond)
+			require.True(t, ok)
+			b, err := json.Marshal(envelope)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(b))
+		})
+	}
+
+	_, ok := Envelop("get_pod_logs", "starting server", 0)
+	assert.False(t, ok)
+}
+
+func TestWithEnvelope(t *testing.T) {
+	tool := WithEnvelope(WithOutputParam(server.ServerTool{
+		Tool: mcp.NewTool("list_pods"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(podList), nil
+		},
+	}))
+
+	result, err := tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{"output": "json"}))
+	require.NoError(t, err)
+	var envelope struct {
+		Kind  string        `json:"kind"`
+		Count int           `json:"count"`
+		Items []interface{} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &envelope))
+	assert.Equal(t, "Pod", envelope.Kind)
+	assert.Equal(t, 2, envelope.Count)
+	assert.Len(t, envelope.Items, 2)
+
+	// Formats other than JSON are returned as rendered
+	result, err = tool.Handler(context.Background(), createMCPRequest(map[string]interface{}{"output": "csv"}))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(getTextResult(t, result).Text, "namespace,name"))
+}
+
+func TestFit(t *testing.T) {
+	// Pods carrying large annotations, which summaries leave out
+	var pods []string
+	for i := 0; i < 20; i++ {
+		pods = append(pods, fmt.Sprintf(`{"metadata":{"name":"web-%02d","namespace":"shop","annotations":{"config":%q}},"status":{"phase":"Running"}}`, i, strings.Repeat("x", 200)))
+	}
+	list := `{"items":[` + strings.Join(pods, ",") + `],"continue":"token"}`
+
+	// Results within the budget are unchanged
+	fitted, err := Fit("Pod", list, len(list))
+	require.NoError(t, err)
+	assert.Equal(t, list, fitted)
+
+	// Lists are summarized first
+	fitted, err = Fit("Pod", list, 2000)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(fitted), 2000)
+	var summary struct {
+		Kind         string                   `json:"kind"`
+		Count        int                      `json:"count"`
+		Continue     string                   `json:"continue"`
+		Items        []map[string]interface{} `json:"items"`
+		OmittedItems int                      `json:"omittedItems"`
+		Note         string                   `json:"note"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(fitted), &summary))
+	assert.Equal(t, "Pod", summary.Kind)
+	assert.Equal(t, 20, summary.Count)
+	assert.Equal(t, "token", summary.Continue)
+	assert.Len(t, summary.Items, 20)
+	assert.Zero(t, summary.OmittedItems)
+	assert.Contains(t, summary.Note, "was returned as a summary")
+
+	// and then cut to the items that fit
+	fitted, err = Fit("Pod", list, 800)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(fitted), 800)
+	summary.Items, summary.OmittedItems = nil, 0
+	require.NoError(t, json.Unmarshal([]byte(fitted), &summary))
+	require.NotEmpty(t, summary.Items)
+	assert.Equal(t, "web-00", summary.Items[0]["name"])
+	assert.Equal(t, len(summary.Items), summary.Count)
+	assert.Equal(t, 20, len(summary.Items)+summary.OmittedItems)
+
+	// Arrays that are not Kubernetes objects are cut as they are
+	var images []string
+	for i := 0; i < 10; i++ {
+		images = append(images, fmt.Sprintf(`{"image":"registry.example.com/app-%d:1.0"}`, i))
+	}
+	fitted, err = Fit("", "["+strings.Join(images, ",")+"]", 300)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(fitted), 300)
+	var cut struct {
+		Items        []map[string]interface{} `json:"items"`
+		OmittedItems int                      `json:"omittedItems"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(fitted), &cut))
+	require.NotEmpty(t, cut.Items)
+	assert.Equal(t, "registry.example.com/app-0:1.0", cut.Items[0]["image"])
+	assert.Equal(t, 10, len(cut.Items)+cut.OmittedItems)
+
+	// Objects that cannot shrink are an error
+	_, err = Fit("", `{"report":"`+strings.Repeat("x", 500)+`"}`, 100)
+	assert.ErrorContains(t, err, "exceeded the response budget of 100 bytes")
+
+	// Text keeps whole lines
+	fitted, err = Fit("", strings.Repeat("line of logs\n", 20), 130)
+	require.NoError(t, err)
+	assert.Equal(t, "line of logs\nline of logs\nline of logs\n[truncated: 221 more bytes were left out to stay within the response budget of 130 bytes]\n", fitted)
+}
+
+func TestWithBudget(t *testing.T) {
+	tool := WithBudget(100)(server.ServerTool{
+		Tool: mcp.NewTool("get_report"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"report":"` + strings.Repeat("x", 500) + `"}`), nil
+		},
+	})
+	result, err := tool.Handler(context.Background(), createMCPRequest(nil))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	// Without a budget the tool is left unchanged
+	tool = WithBudget(0)(tool)
+	assert.Equal(t, "get_report", tool.Tool.Name)
+}
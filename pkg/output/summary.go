@@ -0,0 +1,354 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// errNotKubernetes is returned for results that hold no Kubernetes objects to summarize
+var errNotKubernetes = errors.New("result holds no Kubernetes objects")
+
+// summarizers add the key status fields of a kind to its summary
+var summarizers = map[string]func(obj, summary *object){
+	"Pod":                 summarizePod,
+	"Deployment":          summarizeDeployment,
+	"Service":             summarizeService,
+	"ConfigMap":           summarizeConfigMap,
+	"Node":                summarizeNode,
+	"PodDisruptionBudget": summarizePDB,
+	"Lease":               summarizeLease,
+}
+
+// toolKinds are the kinds returned by get and list tools, keyed by the resource word of their name
+var toolKinds = map[string]string{
+	"pod":        "Pod",
+	"deployment": "Deployment",
+	"service":    "Service",
+	"configmap":  "ConfigMap",
+	"namespace":  "Namespace",
+	"node":       "Node",
+	"pdb":        "PodDisruptionBudget",
+	"lease":      "Lease",
+}
+
+// KindOf returns the kind of the objects a get or list tool returns, such as Pod for list_pods,
+// or an empty string when the tool name does not tell
+func KindOf(tool string) string {
+	verb, resource, ok := strings.Cut(tool, "_")
+	if !ok || (verb != "get" && verb != "list") || strings.Contains(resource, "_") {
+		return ""
+	}
+	if kind, ok := toolKinds[resource]; ok {
+		return kind
+	}
+	return toolKinds[strings.TrimSuffix(resource, "s")]
+}
+
+// summaryRenderer renders the summary format, using the kind of the tool for objects that do not
+// name their own
+type summaryRenderer struct{}
+
+func (summaryRenderer) Render(data []byte) (string, error) {
+	return Summary("", data)
+}
+
+func (summaryRenderer) RenderKind(kind string, data []byte) (string, error) {
+	return Summary(kind, data)
+}
+
+// Summary renders Kubernetes objects as compact JSON holding the name, namespace, creation time and
+// key status fields of each object, such as the ready containers and restarts of pods. kind is
+// used for objects that do not name their own, such as the items of typed list results. Lists keep
+// their continue token and remaining item count so the next page can still be requested.
+func Summary(kind string, data []byte) (string, error) {
+	value, err := decodeDocument(data)
+	if err != nil {
+		return "", err
+	}
+
+	var summary interface{}
+	switch v := value.(type) {
+	case *object:
+		items, isList := v.values["items"].([]interface{})
+		if !isList {
+			objKind := kindOf(v, kind)
+			s, err := summarizeObject(objKind, v)
+			if err != nil {
+				return "", err
+			}
+			summary = (&object{}).setIf("kind", objKind).merge(s)
+			break
+		}
+		if listKind := stringAt(v, "kind"); strings.HasSuffix(listKind, "List") {
+			kind = strings.TrimSuffix(listKind, "List")
+		}
+		summaries, err := summarizeItems(kind, items)
+		if err != nil {
+			return "", err
+		}
+		list := (&object{}).setIf("kind", kind).set("count", len(items))
+		keepPagination(v, list)
+		summary = list.set("items", summaries)
+	case []interface{}:
+		summaries, err := summarizeItems(kind, v)
+		if err != nil {
+			return "", err
+		}
+		summary = summaries
+	default:
+		return "", errNotKubernetes
+	}
+
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// keepPagination copies the continue token and remaining item count of a list, set by paginated
+// list tools or in the metadata of Kubernetes list objects
+func keepPagination(list, to *object) {
+	token := stringAt(list, "continue")
+	if token == "" {
+		token = stringAt(list, "metadata", "continue")
+	}
+	to.setIf("continue", token)
+	if remaining := valueAt(list, "remainingItemCount"); remaining != nil {
+		to.set("remainingItemCount", remaining)
+	} else if remaining := valueAt(list, "metadata", "remainingItemCount"); remaining != nil {
+		to.set("remainingItemCount", remaining)
+	}
+}
+
+func summarizeItems(kind string, items []interface{}) ([]interface{}, error) {
+	summaries := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(*object)
+		if !ok {
+			return nil, errNotKubernetes
+		}
+		s, err := summarizeObject(kindOf(obj, kind), obj)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, nil
+}
+
+// summarizeObject keeps the identity of an object and the status fields of its kind
+func summarizeObject(kind string, obj *object) (*object, error) {
+	name := stringAt(obj, "metadata", "name")
+	if name == "" {
+		return nil, errNotKubernetes
+	}
+	summary := (&object{}).set("name", name)
+	if namespace := stringAt(obj, "metadata", "namespace"); namespace != "" {
+		summary.set("namespace", namespace)
+	}
+	if summarize, ok := summarizers[kind]; ok {
+		summarize(obj, summary)
+	} else {
+		summarizeGeneric(obj, summary)
+	}
+	if created := stringAt(obj, "metadata", "creationTimestamp"); created != "" {
+		summary.set("created", created)
+	}
+	return summary, nil
+}
+
+func kindOf(obj *object, fallback string) string {
+	if kind := stringAt(obj, "kind"); kind != "" {
+		return kind
+	}
+	return fallback
+}
+
+func summarizePod(obj, summary *object) {
+	containers, _ := valueAt(obj, "spec", "containers").([]interface{})
+	statuses, _ := valueAt(obj, "status", "containerStatuses").([]interface{})
+	ready, restarts := 0, int64(0)
+	reason := stringAt(obj, "status", "reason")
+	for _, item := range statuses {
+		status, ok := item.(*object)
+		if !ok {
+			continue
+		}
+		if isReady, _ := status.values["ready"].(bool); isReady {
+			ready++
+		}
+		restarts += intAt(status, "restartCount")
+		if waiting := stringAt(status, "state", "waiting", "reason"); waiting != "" && reason == "" {
+			reason = waiting
+		}
+	}
+
+	summary.setIf("phase", stringAt(obj, "status", "phase"))
+	summary.setIf("reason", reason)
+	summary.set("ready", fmt.Sprintf("%d/%d", ready, len(containers)))
+	summary.set("restarts", restarts)
+	summary.setIf("node", stringAt(obj, "spec", "nodeName"))
+	summary.setIf("ip", stringAt(obj, "status", "podIP"))
+}
+
+func summarizeDeployment(obj, summary *object) {
+	replicas := int64(1)
+	if valueAt(obj, "spec", "replicas") != nil {
+		replicas = intAt(obj, "spec", "replicas")
+	}
+	summary.set("ready", fmt.Sprintf("%d/%d", intAt(obj, "status", "readyReplicas"), replicas))
+	summary.set("upToDate", intAt(obj, "status", "updatedReplicas"))
+	summary.set("available", intAt(obj, "status", "availableReplicas"))
+}
+
+func summarizeService(obj, summary *object) {
+	summary.setIf("type", stringAt(obj, "spec", "type"))
+	summary.setIf("clusterIP", stringAt(obj, "spec", "clusterIP"))
+	ports, _ := valueAt(obj, "spec", "ports").([]interface{})
+	var rendered []string
+	for _, item := range ports {
+		port, ok := item.(*object)
+		if !ok {
+			continue
+		}
+		p := formatValue(port.values["port"])
+		if nodePort := formatValue(port.values["nodePort"]); nodePort != "" {
+			p += ":" + nodePort
+		}
+		if protocol := stringAt(port, "protocol"); protocol != "" {
+			p += "/" + protocol
+		}
+		rendered = append(rendered, p)
+	}
+	if len(rendered) > 0 {
+		summary.set("ports", rendered)
+	}
+}
+
+func summarizeConfigMap(obj, summary *object) {
+	keys := []string{}
+	for _, field := range []string{"data", "binaryData"} {
+		if data, ok := obj.values[field].(*object); ok {
+			keys = append(keys, data.keys...)
+		}
+	}
+	sort.Strings(keys)
+	summary.set("keys", keys)
+}
+
+func summarizeNode(obj, summary *object) {
+	status := "Unknown"
+	switch condition(obj, "Ready") {
+	case "True":
+		status = "Ready"
+	case "False":
+		status = "NotReady"
+	}
+	if unschedulable, _ := valueAt(obj, "spec", "unschedulable").(bool); unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	summary.set("status", status)
+
+	var roles []string
+	if labels, ok := valueAt(obj, "metadata", "labels").(*object); ok {
+		for _, label := range labels.keys {
+			if role, ok := strings.CutPrefix(label, "node-role.kubernetes.io/"); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) > 0 {
+		sort.Strings(roles)
+		summary.set("roles", roles)
+	}
+	summary.setIf("version", stringAt(obj, "status", "nodeInfo", "kubeletVersion"))
+}
+
+func summarizePDB(obj, summary *object) {
+	for _, field := range []string{"minAvailable", "maxUnavailable"} {
+		if v := valueAt(obj, "spec", field); v != nil {
+			summary.set(field, v)
+		}
+	}
+	summary.set("healthy", fmt.Sprintf("%d/%d", intAt(obj, "status", "currentHealthy"), intAt(obj, "status", "desiredHealthy")))
+	summary.set("disruptionsAllowed", intAt(obj, "status", "disruptionsAllowed"))
+}
+
+func summarizeLease(obj, summary *object) {
+	summary.setIf("holder", stringAt(obj, "spec", "holderIdentity"))
+	summary.setIf("renewTime", stringAt(obj, "spec", "renewTime"))
+}
+
+// summarizeGeneric keeps the phase and readiness most kinds report
+func summarizeGeneric(obj, summary *object) {
+	summary.setIf("phase", stringAt(obj, "status", "phase"))
+	summary.setIf("ready", condition(obj, "Ready"))
+}
+
+// condition returns the status of a condition of an object, or an empty string if it has none
+func condition(obj *object, conditionType string) string {
+	conditions, _ := valueAt(obj, "status", "conditions").([]interface{})
+	for _, item := range conditions {
+		c, ok := item.(*object)
+		if ok && stringAt(c, "type") == conditionType {
+			return stringAt(c, "status")
+		}
+	}
+	return ""
+}
+
+// valueAt returns the value at a path of nested objects, or nil if there is none
+func valueAt(obj *object, path ...string) interface{} {
+	var value interface{} = obj
+	for _, key := range path {
+		o, ok := value.(*object)
+		if !ok {
+			return nil
+		}
+		value = o.values[key]
+	}
+	return value
+}
+
+func stringAt(obj *object, path ...string) string {
+	s, _ := valueAt(obj, path...).(string)
+	return s
+}
+
+func intAt(obj *object, path ...string) int64 {
+	n, _ := valueAt(obj, path...).(json.Number)
+	i, _ := n.Int64()
+	return i
+}
+
+// set adds a field to the object, keeping fields in the order they are set
+func (o *object) set(key string, value interface{}) *object {
+	if o.values == nil {
+		o.values = map[string]interface{}{}
+	}
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+	return o
+}
+
+// setIf adds a string field unless it is empty
+func (o *object) setIf(key, value string) *object {
+	if value == "" {
+		return o
+	}
+	return o.set(key, value)
+}
+
+// merge adds the fields of other to the object
+func (o *object) merge(other *object) *object {
+	for _, key := range other.keys {
+		o.set(key, other.values[key])
+	}
+	return o
+}
@@ -0,0 +1,296 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errNotTabular is returned for results, such as plain scalars, that have no table representation
+var errNotTabular = errors.New("result is not tabular")
+
+// errMultipleValues is returned for results holding several JSON values, such as JSON lines
+var errMultipleValues = errors.New("result holds more than one JSON value")
+
+// Markdown renders a JSON result as a Markdown table. Lists have one row per item and a column per
+// field; single objects have a row per field.
+func Markdown(data []byte) (string, error) {
+	columns, rows, err := tabulate(data)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "_No items_\n", nil
+	}
+
+	var b strings.Builder
+	writeMarkdownRow(&b, columns)
+	separator := make([]string, len(columns))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(&b, separator)
+	for _, row := range rows {
+		writeMarkdownRow(&b, row)
+	}
+	return b.String(), nil
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", "<br>")
+		b.WriteString(" " + cell + " |")
+	}
+	b.WriteString("\n")
+}
+
+// CSV renders a JSON result as CSV with a header row, using the same layout as Markdown
+func CSV(data []byte) (string, error) {
+	columns, rows, err := tabulate(data)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if len(rows) > 0 {
+		if err := w.Write(columns); err != nil {
+			return "", err
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// object is a decoded JSON object that keeps its keys in document order, so columns follow
+// the field order of the tool result
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON encodes the object in its original key order
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// tabulate converts a JSON result into columns and rows. Kubernetes list objects are
+// unwrapped to their items.
+func tabulate(data []byte) ([]string, [][]string, error) {
+	value, err := decodeDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if obj, ok := value.(*object); ok {
+		if items, ok := obj.values["items"].([]interface{}); ok {
+			value = items
+		}
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		return tabulateList(v)
+	case *object:
+		rows := [][]string{}
+		for _, c := range flatten(v) {
+			rows = append(rows, []string{c.column, c.value})
+		}
+		return []string{"field", "value"}, rows, nil
+	default:
+		return nil, nil, errNotTabular
+	}
+}
+
+func tabulateList(items []interface{}) ([]string, [][]string, error) {
+	var columns []string
+	index := map[string]int{}
+	var records []map[string]string
+	for _, item := range items {
+		record := map[string]string{}
+		cells := []cell{{column: "value", value: formatValue(item)}}
+		if obj, ok := item.(*object); ok {
+			cells = flatten(obj)
+		}
+		for _, c := range cells {
+			if _, ok := index[c.column]; !ok {
+				index[c.column] = len(columns)
+				columns = append(columns, c.column)
+			}
+			record[c.column] = c.value
+		}
+		records = append(records, record)
+	}
+
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = record[column]
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+type cell struct {
+	column string
+	value  string
+}
+
+// flatten turns an object into cells, nesting objects with dotted column names. Kubernetes
+// objects are summarized by their identity and phase since their full spec does not fit a table.
+func flatten(obj *object) []cell {
+	if metadata, ok := obj.values["metadata"].(*object); ok {
+		if _, named := metadata.values["name"]; named {
+			return summarize(obj, metadata)
+		}
+	}
+
+	var cells []cell
+	for _, key := range obj.keys {
+		if nested, ok := obj.values[key].(*object); ok {
+			for _, c := range flatten(nested) {
+				cells = append(cells, cell{column: key + "." + c.column, value: c.value})
+			}
+			continue
+		}
+		cells = append(cells, cell{column: key, value: formatValue(obj.values[key])})
+	}
+	return cells
+}
+
+func summarize(obj, metadata *object) []cell {
+	var cells []cell
+	if kind, ok := obj.values["kind"]; ok {
+		cells = append(cells, cell{column: "kind", value: formatValue(kind)})
+	}
+	if namespace, ok := metadata.values["namespace"]; ok {
+		cells = append(cells, cell{column: "namespace", value: formatValue(namespace)})
+	}
+	cells = append(cells, cell{column: "name", value: formatValue(metadata.values["name"])})
+	if status, ok := obj.values["status"].(*object); ok {
+		if phase, ok := status.values["phase"]; ok {
+			cells = append(cells, cell{column: "phase", value: formatValue(phase)})
+		}
+	}
+	if created, ok := metadata.values["creationTimestamp"]; ok {
+		cells = append(cells, cell{column: "created", value: formatValue(created)})
+	}
+	return cells
+}
+
+// formatValue renders a value for a single cell. Lists of scalars are comma separated, other
+// lists and objects are embedded as compact JSON.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case *object, []interface{}:
+				b, _ := json.Marshal(v)
+				return string(b)
+			}
+			parts = append(parts, formatValue(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// decodeDocument decodes a result holding a single JSON value, keeping the key order of objects
+func decodeDocument(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errMultipleValues
+	}
+	return value, nil
+}
+
+// decodeValue decodes the next JSON value, keeping the key order of objects
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := &object{values: map[string]interface{}{}}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyToken.(string)
+				value, err := decodeValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				if _, exists := obj.values[key]; !exists {
+					obj.keys = append(obj.keys, key)
+				}
+				obj.values[key] = value
+			}
+			_, err := decoder.Token()
+			return obj, err
+		case '[':
+			list := []interface{}{}
+			for decoder.More() {
+				value, err := decodeValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			_, err := decoder.Token()
+			return list, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %q", t)
+	default:
+		return t, nil
+	}
+}
@@ -0,0 +1,218 @@
+// Package prompts offers runbooks for common operational tasks as MCP prompts. Each prompt expands
+// into numbered steps naming the tools to call with the arguments filled in, so an agent can follow
+// a proven procedure instead of improvising one.
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Argument is a Kubernetes object a runbook is about
+type Argument struct {
+	Name        string
+	Description string
+	// Resource is the plural resource of the objects the argument names, such as namespaces or pods
+	Resource string
+}
+
+// Step is one instruction of a runbook. Arguments are referenced as {name}.
+type Step struct {
+	// Tool is the tool the step calls; steps are left out when the server does not offer it
+	Tool string
+	Text string
+}
+
+// Runbook is a procedure offered as an MCP prompt
+type Runbook struct {
+	Name        string
+	Description string
+	Arguments   []Argument
+	Intro       string
+	Steps       []Step
+	Outro       string
+	// Requires are the tools the runbook cannot do without; it is only offered when all are available
+	Requires []string
+}
+
+var (
+	namespaceArgument = Argument{Name: "namespace", Description: "Namespace of the pod", Resource: "namespaces"}
+	podArgument       = Argument{Name: "name", Description: "Name of the pod", Resource: "pods"}
+)
+
+// Runbooks are the runbooks the server offers
+var Runbooks = []Runbook{
+	{
+		Name:        "debug-pod",
+		Description: "Find out why a pod is not working and what to do about it",
+		Arguments:   []Argument{namespaceArgument, podArgument},
+		Intro:       "Debug the pod {name} in namespace {namespace}. Work through these steps in order, stop as soon as the cause is clear, and explain the evidence for it:",
+		Steps: []Step{
+			{Tool: "diagnose_pod", Text: "Call `diagnose_pod` with namespace={namespace} and name={name} for findings such as CrashLoopBackOff, ImagePullBackOff, OOMKilled or unschedulable reasons, each with a suggested next step."},
+			{Tool: "get_pod", Text: "Call `get_pod` with namespace={namespace} and name={name} and check the phase, container states, restart counts, probes, resource requests and limits, and the node it runs on."},
+			{Tool: "get_pod_logs", Text: "Call `get_pod_logs` with namespace={namespace} and name={name} for the recent logs of every container; for restarted containers also call it with previous=true to see why the last instance stopped."},
+			{Tool: "top_pods", Text: "Call `top_pods` with namespace={namespace} to compare the pod's CPU and memory usage with its limits."},
+			{Tool: "get_node", Text: "If the pod is scheduled, call `get_node` with the name of its node and check the node conditions for memory, disk or PID pressure."},
+			{Tool: "check_service_connectivity", Text: "If the pod runs but its clients cannot reach it, call `check_service_connectivity` for the service selecting it."},
+			{Tool: "exec_in_pod", Text: "Only if the cause is still unclear and the user agrees, call `exec_in_pod` to inspect the running container, e.g. its configuration files or network."},
+		},
+		Outro:    "Finish with the cause, the evidence and the fix you recommend. Do not change anything in the cluster without the user's approval.",
+		Requires: []string{"get_pod"},
+	},
+	{
+		Name:        "investigate-crashloop",
+		Description: "Find out why the containers of a pod keep crashing",
+		Arguments:   []Argument{namespaceArgument, podArgument},
+		Intro:       "The pod {name} in namespace {namespace} is crash looping. Find out why its containers keep exiting:",
+		Steps: []Step{
+			{Tool: "diagnose_pod", Text: "Call `diagnose_pod` with namespace={namespace} and name={name} to see which containers restart and the reason and exit code of their last termination."},
+			{Tool: "get_pod_logs", Text: "Call `get_pod_logs` with namespace={namespace}, name={name} and previous=true for the logs of the crashed instance; the last lines before it exited usually name the error."},
+			{Tool: "get_pod", Text: "Call `get_pod` with namespace={namespace} and name={name} and check the command, arguments, environment, mounted ConfigMaps and Secrets, liveness probe and memory limit of the crashing container. Exit code 137 with reason OOMKilled means the memory limit is too low; a failing liveness probe kills containers that are only slow to start."},
+			{Tool: "get_configmap", Text: "Call `get_configmap` in namespace {namespace} for the ConfigMaps the container uses and look for missing or malformed settings."},
+			{Tool: "top_pods", Text: "Call `top_pods` with namespace={namespace} to see whether memory use approaches the limit before each crash."},
+			{Tool: "rollout_history", Text: "If the pod belongs to a Deployment, call `rollout_history` for it to see whether the crashes started with a recent revision, and compare the images and configuration of the last revisions."},
+			{Tool: "rollout_undo", Text: "If a recent revision caused the crashes, propose rolling back with `rollout_undo`, but only call it once the user agrees."},
+		},
+		Outro:    "Finish with the cause of the crashes, the evidence and the fix you recommend, such as a configuration change, a higher memory limit or a rollback.",
+		Requires: []string{"get_pod_logs"},
+	},
+	{
+		Name:        "plan-node-drain",
+		Description: "Plan draining a node for maintenance without disrupting workloads",
+		Arguments:   []Argument{{Name: "node", Description: "Name of the node to drain", Resource: "nodes"}},
+		Intro:       "Plan draining the node {node} for maintenance. Collect the facts first and change nothing until the user approved the plan:",
+		Steps: []Step{
+			{Tool: "get_node", Text: "Call `get_node` with name={node} and check whether it is already cordoned, its taints and its conditions."},
+			{Tool: "list_pods", Text: "Call `list_pods` with allNamespaces=true and fieldSelector=spec.nodeName={node} to list the pods that would be evicted. Note pods without a controller and pods using emptyDir volumes, which block the drain unless forced and lose their data."},
+			{Tool: "list_pdbs", Text: "Call `list_pdbs` with allNamespaces=true and find the PodDisruptionBudgets selecting those pods; budgets allowing no disruptions will hold the drain until their pods are healthy elsewhere."},
+			{Tool: "list_nodes", Text: "Call `list_nodes` to check that the other schedulable nodes can take the evicted pods, including their node selectors, affinities and tolerations."},
+			{Tool: "top_nodes", Text: "Call `top_nodes` to check the CPU and memory headroom of the remaining nodes."},
+			{Tool: "drain_node", Text: "Present the plan: the pods that will move, the budgets that may slow it down, the pods needing force or deleteEmptyDirData, and the expected impact. Only after the user approves it, call `drain_node` with name={node}."},
+		},
+		Outro:    "If the drain would evict the last ready replica of a workload or is blocked by a budget, say so and propose how to proceed, such as scaling the workload up first.",
+		Requires: []string{"get_node", "list_pods"},
+	},
+}
+
+// ClusterArgument is the optional argument of every runbook selecting the cluster to work on,
+// offered when the tools of the server can target several clusters
+const ClusterArgument = "cluster"
+
+// Find returns the runbook with the given name
+func Find(name string) (Runbook, bool) {
+	for _, runbook := range Runbooks {
+		if runbook.Name == name {
+			return runbook, true
+		}
+	}
+	return Runbook{}, false
+}
+
+// Prompt returns the MCP prompt of the runbook, with the cluster argument if withCluster is set
+func (r Runbook) Prompt(withCluster bool) mcp.Prompt {
+	options := []mcp.PromptOption{mcp.WithPromptDescription(r.Description)}
+	for _, argument := range r.Arguments {
+		options = append(options, mcp.WithArgument(argument.Name,
+			mcp.ArgumentDescription(argument.Description),
+			mcp.RequiredArgument(),
+		))
+	}
+	if withCluster {
+		options = append(options, mcp.WithArgument(ClusterArgument,
+			mcp.ArgumentDescription("Cluster (kubeconfig context) to work on, see list_clusters (default: the current cluster)"),
+		))
+	}
+	return mcp.NewPrompt(r.Name, options...)
+}
+
+// Render returns the instructions of the runbook for the given arguments, leaving out the steps
+// whose tools are not available. A cluster argument must be one of clusters.
+func (r Runbook) Render(arguments map[string]string, available map[string]bool, clusters []string) (string, error) {
+	replacements := make([]string, 0, 2*len(r.Arguments))
+	for _, argument := range r.Arguments {
+		value := arguments[argument.Name]
+		if value == "" {
+			return "", fmt.Errorf("missing required argument: %s", argument.Name)
+		}
+		// Values become part of the instructions, so only names of Kubernetes objects are accepted
+		errs := validation.IsDNS1123Subdomain(value)
+		if argument.Resource == "namespaces" {
+			errs = validation.IsDNS1123Label(value)
+		}
+		if len(errs) > 0 {
+			return "", fmt.Errorf("invalid %s %q: %s", argument.Name, value, strings.Join(errs, "; "))
+		}
+		replacements = append(replacements, "{"+argument.Name+"}", value)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	var b strings.Builder
+	b.WriteString(replacer.Replace(r.Intro))
+	b.WriteString("\n\n")
+	if cluster := arguments[ClusterArgument]; cluster != "" {
+		if !slices.Contains(clusters, cluster) {
+			return "", fmt.Errorf("unknown cluster %q: must be one of %s", cluster, strings.Join(clusters, ", "))
+		}
+		fmt.Fprintf(&b, "Work on the cluster %s: pass cluster=%s to every tool call.\n\n", cluster, cluster)
+	}
+	n := 0
+	for _, step := range r.Steps {
+		if step.Tool != "" && !available[step.Tool] {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "%d. %s\n", n, replacer.Replace(step.Text))
+	}
+	if r.Outro != "" {
+		b.WriteString("\n")
+		b.WriteString(replacer.Replace(r.Outro))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// Register adds the runbooks whose required tools are among tools to the server as prompts. When
+// the tools take a cluster parameter, the prompts take the cluster argument accepting its values.
+func Register(s *server.MCPServer, tools []server.ServerTool) {
+	available := make(map[string]bool, len(tools))
+	var clusters []string
+	for _, tool := range tools {
+		available[tool.Tool.Name] = true
+		if property, ok := tool.Tool.InputSchema.Properties[ClusterArgument].(map[string]any); ok && clusters == nil {
+			clusters, _ = property["enum"].([]string)
+		}
+	}
+	for _, runbook := range Runbooks {
+		if !hasAll(available, runbook.Requires) {
+			continue
+		}
+		s.AddPrompt(runbook.Prompt(len(clusters) > 0), handler(runbook, available, clusters))
+	}
+}
+
+func hasAll(available map[string]bool, tools []string) bool {
+	for _, tool := range tools {
+		if !available[tool] {
+			return false
+		}
+	}
+	return true
+}
+
+func handler(runbook Runbook, available map[string]bool, clusters []string) server.PromptHandlerFunc {
+	return func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		text, err := runbook.Render(request.Params.Arguments, available, clusters)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewGetPromptResult(runbook.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+		}), nil
+	}
+}
@@ -0,0 +1,71 @@
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// sensitiveKey matches argument and field names whose values are never recorded
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|token|secret|credential|private.?key|api.?key)`)
+
+// secretManifest matches YAML or JSON manifests passed as string arguments that contain a Secret
+var secretManifest = regexp.MustCompile(`(?m)(^\s*kind:\s*["']?Secret["']?\s*$|"kind"\s*:\s*"Secret")`)
+
+// Arguments copies the arguments of a tool call for a record of it, such as a session transcript,
+// the audit log or the server log, replacing the values of sensitive keys and Secret manifests
+func Arguments(args map[string]interface{}) map[string]interface{} {
+	return redactMap(args)
+}
+
+// Output redacts JSON tool output for a record of the call, replacing the values of sensitive keys
+// and the data of Secrets. Outputs that are not JSON are returned unchanged.
+func Output(output string) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(output), &value); err != nil {
+		return output
+	}
+	b, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return output
+	}
+	return string(b)
+}
+
+// redactMap copies a map, replacing the values of sensitive keys
+func redactMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if sensitiveKey.MatchString(key) {
+			redacted[key] = Redacted
+			continue
+		}
+		if s, ok := value.(string); ok && secretManifest.MatchString(s) {
+			redacted[key] = Redacted
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := redactMap(v)
+		if isSecret(v) {
+			redactData(redacted)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
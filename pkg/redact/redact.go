@@ -0,0 +1,234 @@
+// Package redact removes sensitive values from tool results before they reach the client: the
+// data of Secrets, the values of environment variables whose names look sensitive, and the
+// ConfigMap entries that envFrom would turn into such variables. Results that are not JSON are
+// returned unchanged. The arguments and outputs of tool calls kept in records, such as session
+// transcripts, the audit log and the server log, are redacted by the names of their fields too.
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Redacted replaces sensitive values in tool results
+const Redacted = "[REDACTED]"
+
+// DefaultEnvPatterns match the names of environment variables whose values are redacted
+var DefaultEnvPatterns = []string{"PASSWORD", "TOKEN", "KEY", "SECRET"}
+
+// lastAppliedAnnotation is the annotation kubectl apply stores the previous manifest in, which
+// holds the data of a Secret applied with kubectl
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// secretRef matches the object reference of a diff_manifest result for a Secret
+var secretRef = regexp.MustCompile(`^v1 Secret `)
+
+// Filter redacts the results of tool calls
+type Filter struct {
+	envNames *regexp.Regexp
+}
+
+// New creates a Filter redacting environment variables whose names match one of the patterns,
+// regular expressions matched case-insensitively anywhere in the name
+func New(envPatterns []string) (*Filter, error) {
+	var patterns []string
+	for _, pattern := range envPatterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid environment variable pattern %q: %v", pattern, err)
+		}
+		patterns = append(patterns, "(?:"+pattern+")")
+	}
+	f := &Filter{}
+	if len(patterns) > 0 {
+		f.envNames = regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))
+	}
+	return f, nil
+}
+
+// Wrap redacts the JSON text results of a tool, error results included
+func (f *Filter) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+		for i, content := range result.Content {
+			text, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			text.Text = f.Text(text.Text)
+			result.Content[i] = text
+		}
+		return result, nil
+	}
+	return tool
+}
+
+// Text redacts a JSON document, returning text that is not JSON unchanged
+func (f *Filter) Text(text string) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return text
+	}
+	value, changed := f.value(value)
+	if !changed {
+		return text
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return text
+	}
+	return string(redacted)
+}
+
+// value redacts a decoded JSON value in place, reporting whether anything was redacted
+func (f *Filter) value(value interface{}) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch {
+		case isSecret(v):
+			changed = redactData(v) || changed
+		case isConfigMap(v):
+			changed = f.redactConfigMap(v) || changed
+		case isSecretDiff(v):
+			changed = redactSecretDiff(v) || changed
+		}
+		for key, item := range v {
+			if key == "env" {
+				changed = f.redactEnv(item) || changed
+			}
+			var itemChanged bool
+			v[key], itemChanged = f.value(item)
+			changed = itemChanged || changed
+		}
+	case []interface{}:
+		for i, item := range v {
+			var itemChanged bool
+			v[i], itemChanged = f.value(item)
+			changed = itemChanged || changed
+		}
+	}
+	return value, changed
+}
+
+// redactEnv redacts the values of environment variables, such as those of a container, whose
+// names match the patterns. References to Secrets and ConfigMaps carry no value and are kept.
+func (f *Filter) redactEnv(env interface{}) bool {
+	vars, ok := env.([]interface{})
+	if !ok || f.envNames == nil {
+		return false
+	}
+	changed := false
+	for _, item := range vars {
+		envVar, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := envVar["name"].(string)
+		if value, ok := envVar["value"].(string); ok && value != "" && f.envNames.MatchString(name) {
+			envVar["value"] = Redacted
+			changed = true
+		}
+	}
+	return changed
+}
+
+// redactConfigMap redacts the ConfigMap entries whose keys match the patterns, as envFrom turns
+// them into environment variables of the same names
+func (f *Filter) redactConfigMap(obj map[string]interface{}) bool {
+	data, ok := obj["data"].(map[string]interface{})
+	if !ok || f.envNames == nil {
+		return false
+	}
+	changed := false
+	for key := range data {
+		if f.envNames.MatchString(key) {
+			data[key] = Redacted
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isSecret reports whether an object is a Kubernetes Secret. Items of a SecretList may carry no
+// kind, so a type field next to the data is accepted as well.
+func isSecret(obj map[string]interface{}) bool {
+	if kind, _ := obj["kind"].(string); kind == "Secret" {
+		return true
+	}
+	_, hasMetadata := obj["metadata"].(map[string]interface{})
+	_, hasType := obj["type"].(string)
+	_, hasData := obj["data"].(map[string]interface{})
+	return hasMetadata && hasType && hasData
+}
+
+func isConfigMap(obj map[string]interface{}) bool {
+	kind, _ := obj["kind"].(string)
+	return kind == "ConfigMap"
+}
+
+// isSecretDiff reports whether an object is a diff_manifest result for a Secret
+func isSecretDiff(obj map[string]interface{}) bool {
+	ref, _ := obj["object"].(string)
+	_, hasChanges := obj["changes"].([]interface{})
+	return hasChanges && secretRef.MatchString(ref)
+}
+
+// redactData redacts the data of a Secret, keeping its keys, and the manifest kubectl recorded
+// when applying it
+func redactData(obj map[string]interface{}) bool {
+	changed := false
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := obj[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range data {
+			data[key] = Redacted
+			changed = true
+		}
+	}
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			if _, ok := annotations[lastAppliedAnnotation]; ok {
+				annotations[lastAppliedAnnotation] = Redacted
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// redactSecretDiff redacts the values of the changes a diff reports outside the Secret's metadata
+func redactSecretDiff(obj map[string]interface{}) bool {
+	changed := false
+	for _, item := range obj["changes"].([]interface{}) {
+		change, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if path, _ := change["path"].(string); strings.HasPrefix(path, "metadata.") && !strings.Contains(path, lastAppliedAnnotation) {
+			continue
+		}
+		for _, field := range []string{"live", "desired"} {
+			if _, ok := change[field]; ok {
+				change[field] = Redacted
+				changed = true
+			}
+		}
+	}
+	return changed
+}
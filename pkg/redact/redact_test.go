@@ -0,0 +1,144 @@
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFilter(t *testing.T) *Filter {
+	f, err := New(DefaultEnvPatterns)
+	require.NoError(t, err)
+	return f
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "secret",
+			text:     `{"kind":"Secret","metadata":{"name":"db","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"data\":{\"password\":\"aHVudGVyMg==\"}}","team":"shop"}},"type":"Opaque","data":{"password":"aHVudGVyMg=="},"stringData":{"user":"admin"}}`,
+			expected: `{"data":{"password":"[REDACTED]"},"kind":"Secret","metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"[REDACTED]","team":"shop"},"name":"db"},"stringData":{"user":"[REDACTED]"},"type":"Opaque"}`,
+		},
+		{
+			name:     "secret list items without kind",
+			text:     `{"items":[{"metadata":{"name":"db"},"type":"Opaque","data":{"password":"aHVudGVyMg=="}}]}`,
+			expected: `{"items":[{"data":{"password":"[REDACTED]"},"metadata":{"name":"db"},"type":"Opaque"}]}`,
+		},
+		{
+			name:     "container environment",
+			text:     `{"spec":{"containers":[{"name":"web","env":[{"name":"DB_PASSWORD","value":"hunter2"},{"name":"api_key","value":"abc"},{"name":"MODE","value":"production"},{"name":"SESSION_TOKEN","valueFrom":{"secretKeyRef":{"name":"web","key":"token"}}}],"envFrom":[{"secretRef":{"name":"web"}}]}]}}`,
+			expected: `{"spec":{"containers":[{"env":[{"name":"DB_PASSWORD","value":"[REDACTED]"},{"name":"api_key","value":"[REDACTED]"},{"name":"MODE","value":"production"},{"name":"SESSION_TOKEN","valueFrom":{"secretKeyRef":{"key":"token","name":"web"}}}],"envFrom":[{"secretRef":{"name":"web"}}],"name":"web"}]}}`,
+		},
+		{
+			name:     "configmap entries loaded with envFrom",
+			text:     `{"kind":"ConfigMap","metadata":{"name":"web"},"data":{"STRIPE_KEY":"sk_live","MODE":"production"}}`,
+			expected: `{"data":{"MODE":"production","STRIPE_KEY":"[REDACTED]"},"kind":"ConfigMap","metadata":{"name":"web"}}`,
+		},
+		{
+			name:     "secret diff",
+			text:     `[{"object":"v1 Secret shop/db","action":"update","changes":[{"path":"data.password","op":"replace","live":"b2xk","desired":"bmV3"},{"path":"metadata.labels.tier","op":"add","desired":"db"}]}]`,
+			expected: `[{"action":"update","changes":[{"desired":"[REDACTED]","live":"[REDACTED]","op":"replace","path":"data.password"},{"desired":"db","op":"add","path":"metadata.labels.tier"}],"object":"v1 Secret shop/db"}]`,
+		},
+		{
+			name:     "nothing to redact keeps formatting",
+			text:     "{\n  \"kind\": \"Pod\"\n}",
+			expected: "{\n  \"kind\": \"Pod\"\n}",
+		},
+		{
+			name:     "not json",
+			text:     "DB_PASSWORD=hunter2",
+			expected: "DB_PASSWORD=hunter2",
+		},
+	}
+
+	f := newFilter(t)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, f.Text(tc.text))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	f, err := New([]string{"^PGPASS$", " "})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"env":[{"name":"PGPASS","value":"[REDACTED]"},{"name":"DB_PASSWORD","value":"hunter2"}]}`,
+		f.Text(`{"env":[{"name":"PGPASS","value":"secret"},{"name":"DB_PASSWORD","value":"hunter2"}]}`))
+
+	// Without patterns only Secrets are redacted
+	f, err = New(nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"env":[{"name":"DB_PASSWORD","value":"hunter2"}]}`, f.Text(`{"env":[{"name":"DB_PASSWORD","value":"hunter2"}]}`))
+
+	_, err = New([]string{"("})
+	assert.ErrorContains(t, err, `invalid environment variable pattern "("`)
+}
+
+func TestWrap(t *testing.T) {
+	tool := newFilter(t).Wrap(server.ServerTool{
+		Tool: mcp.NewTool("get_pod"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"env":[{"name":"DB_PASSWORD","value":"hunter2"}]}`), nil
+		},
+	})
+
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	var pod map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &pod))
+	assert.Equal(t, Redacted, pod["env"].([]interface{})[0].(map[string]interface{})["value"])
+}
+
+func TestArguments(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "sensitive keys",
+			args:     map[string]interface{}{"image-scanner-token": "t", "apiKey": "k", "name": "web"},
+			expected: map[string]interface{}{"image-scanner-token": Redacted, "apiKey": Redacted, "name": "web"},
+		},
+		{
+			name:     "secret manifest",
+			args:     map[string]interface{}{"manifest": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\nstringData:\n  password: hunter2\n"},
+			expected: map[string]interface{}{"manifest": Redacted},
+		},
+		{
+			name:     "configmap manifest",
+			args:     map[string]interface{}{"manifest": "apiVersion: v1\nkind: ConfigMap\n"},
+			expected: map[string]interface{}{"manifest": "apiVersion: v1\nkind: ConfigMap\n"},
+		},
+		{
+			name:     "nested values",
+			args:     map[string]interface{}{"data": map[string]interface{}{"db-password": "x", "mode": "debug"}},
+			expected: map[string]interface{}{"data": map[string]interface{}{"db-password": Redacted, "mode": "debug"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Arguments(tc.args))
+		})
+	}
+
+	t.Run("secret list output", func(t *testing.T) {
+		output := Output(`{"items":[{"metadata":{"name":"db"},"type":"Opaque","data":{"user":"YWRtaW4="}}]}`)
+		assert.Equal(t, `{"items":[{"data":{"user":"[REDACTED]"},"metadata":{"name":"db"},"type":"Opaque"}]}`, output)
+	})
+
+	t.Run("plain text output", func(t *testing.T) {
+		assert.Equal(t, "Pod web deleted", Output("Pod web deleted"))
+	})
+}
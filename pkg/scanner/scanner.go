@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Severity levels reported by vulnerability scanners
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+// Report summarizes the vulnerabilities found in a single image
+type Report struct {
+	Image    string `json:"image"`
+	Critical int    `json:"critical"`
+	High     int    `json:"high"`
+	Medium   int    `json:"medium"`
+	Low      int    `json:"low"`
+	Unknown  int    `json:"unknown"`
+}
+
+// Scanner looks up vulnerability data for container images
+type Scanner interface {
+	Scan(ctx context.Context, image string) (*Report, error)
+}
+
+// HTTPScanner queries an HTTP endpoint that returns Trivy JSON reports for an image,
+// such as a Trivy server fronted by a thin report API or a registry vulnerability API.
+type HTTPScanner struct {
+	endpoint string
+	token    func() string
+	client   *http.Client
+}
+
+// NewHTTPScanner creates a new scanner for the given endpoint. The image reference is
+// passed to the endpoint in the "image" query parameter and the token, if set, is sent
+// as a bearer token.
+func NewHTTPScanner(endpoint string, token string) *HTTPScanner {
+	return NewHTTPScannerWithTokenSource(endpoint, func() string { return token })
+}
+
+// NewHTTPScannerWithTokenSource creates a new scanner that asks the token source for the
+// bearer token on every scan, so rotated tokens are used without recreating the scanner.
+func NewHTTPScannerWithTokenSource(endpoint string, token func() string) *HTTPScanner {
+	return &HTTPScanner{
+		endpoint: endpoint,
+		token:    token,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// trivyReport is the subset of the Trivy JSON report format used to count vulnerabilities
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Scan fetches the vulnerability report for an image and counts vulnerabilities by severity
+func (s *HTTPScanner) Scan(ctx context.Context, image string) (*Report, error) {
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scanner endpoint: %w", err)
+	}
+	q := u.Query()
+	q.Set("image", image)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token := s.token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scanner: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanner returned status %d for image %s", resp.StatusCode, image)
+	}
+
+	var tr trivyReport
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("failed to decode scanner response: %w", err)
+	}
+
+	return countVulnerabilities(image, &tr), nil
+}
+
+// countVulnerabilities tallies unique vulnerabilities in a Trivy report by severity
+func countVulnerabilities(image string, tr *trivyReport) *Report {
+	report := &Report{Image: image}
+	seen := make(map[string]bool)
+	for _, result := range tr.Results {
+		for _, v := range result.Vulnerabilities {
+			if v.VulnerabilityID != "" {
+				if seen[v.VulnerabilityID] {
+					continue
+				}
+				seen[v.VulnerabilityID] = true
+			}
+			switch strings.ToUpper(v.Severity) {
+			case SeverityCritical:
+				report.Critical++
+			case SeverityHigh:
+				report.High++
+			case SeverityMedium:
+				report.Medium++
+			case SeverityLow:
+				report.Low++
+			default:
+				report.Unknown++
+			}
+		}
+	}
+	return report
+}
@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReport = `{
+  "ArtifactName": "nginx:1.25",
+  "Results": [
+    {
+      "Target": "nginx:1.25 (debian 12.4)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2024-0002", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-2024-0003", "Severity": "LOW"}
+      ]
+    },
+    {
+      "Target": "usr/local/bin/app",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2024-0004", "Severity": "CRITICAL"}
+      ]
+    }
+  ]
+}`
+
+func TestHTTPScanner(t *testing.T) {
+	var gotImage, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotImage = r.URL.Query().Get("image")
+		gotAuth = r.Header.Get("Authorization")
+		if gotImage == "missing:latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testReport))
+	}))
+	defer srv.Close()
+
+	s := NewHTTPScanner(srv.URL+"/report", "secret")
+
+	report, err := s.Scan(context.Background(), "nginx:1.25")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.25", gotImage)
+	assert.Equal(t, "Bearer secret", gotAuth)
+	assert.Equal(t, "nginx:1.25", report.Image)
+	// Duplicate CVE IDs across results are counted once
+	assert.Equal(t, 2, report.Critical)
+	assert.Equal(t, 1, report.High)
+	assert.Equal(t, 1, report.Low)
+
+	_, err = s.Scan(context.Background(), "missing:latest")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestHTTPScannerWithTokenSource(t *testing.T) {
+	var gotAuth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(testReport))
+	}))
+	defer srv.Close()
+
+	token := "first"
+	s := NewHTTPScannerWithTokenSource(srv.URL+"/report", func() string { return token })
+
+	_, err := s.Scan(context.Background(), "nginx:1.25")
+	require.NoError(t, err)
+
+	// A rotated token is sent on the next scan
+	token = "second"
+	_, err = s.Scan(context.Background(), "nginx:1.25")
+	require.NoError(t, err)
+
+	// An empty token sends no Authorization header
+	token = ""
+	_, err = s.Scan(context.Background(), "nginx:1.25")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer first", "Bearer second", ""}, gotAuth)
+}
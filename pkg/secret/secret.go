@@ -0,0 +1,182 @@
+// Package secret resolves sensitive server settings, such as tokens and credentials, from
+// pluggable backends instead of only flat flags or environment variables. A setting names its
+// backend with a scheme prefix:
+//
+//	file:/var/run/secrets/scanner/token   contents of a file, such as a mounted Kubernetes Secret
+//	env:SCANNER_TOKEN                     another environment variable
+//	secret:namespace/name/key             a key of a Kubernetes Secret, read through the API
+//
+// Settings without one of these prefixes are used literally. Resolved values are reloaded with
+// Refresh, so rotated credentials are picked up without a restart.
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultReloadInterval is how often resolved values are reloaded from their backend
+const DefaultReloadInterval = 30 * time.Second
+
+// Reference schemes
+const (
+	SchemeFile   = "file:"
+	SchemeEnv    = "env:"
+	SchemeSecret = "secret:"
+)
+
+// ClientFunc returns the Kubernetes client secret: references are read with, called on every
+// load so a client recreated with rotated credentials is used
+type ClientFunc func(ctx context.Context) (kubernetes.Interface, error)
+
+// Backend loads the current value of a setting
+type Backend interface {
+	Load(ctx context.Context) (string, error)
+}
+
+// Value is a setting resolved from a backend, holding the value last loaded
+type Value struct {
+	ref     string
+	backend Backend
+
+	mu      sync.RWMutex
+	current string
+}
+
+// Resolve parses a setting and loads its value. getClient is only used by secret: references and
+// may be nil otherwise.
+func Resolve(ctx context.Context, ref string, getClient ClientFunc) (*Value, error) {
+	backend, err := parse(ref, getClient)
+	if err != nil {
+		return nil, err
+	}
+	v := &Value{ref: ref, backend: backend, current: ref}
+	if _, err := v.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Get returns the value last loaded
+func (v *Value) Get() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.current
+}
+
+// Reloadable reports whether the value is loaded from a backend rather than used literally
+func (v *Value) Reloadable() bool {
+	return v.backend != nil
+}
+
+// String returns the reference of a value loaded from a backend, never the value itself
+func (v *Value) String() string {
+	if v.backend == nil {
+		return "literal"
+	}
+	return v.ref
+}
+
+// Refresh reloads the value from its backend, reporting whether it changed. On error the
+// previous value is kept.
+func (v *Value) Refresh(ctx context.Context) (bool, error) {
+	if v.backend == nil {
+		return false, nil
+	}
+	value, err := v.backend.Load(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", v.ref, err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	changed := value != v.current
+	v.current = value
+	return changed, nil
+}
+
+// parse returns the backend a reference names, or nil for a literal value
+func parse(ref string, getClient ClientFunc) (Backend, error) {
+	switch {
+	case strings.HasPrefix(ref, SchemeFile):
+		path := strings.TrimPrefix(ref, SchemeFile)
+		if path == "" {
+			return nil, fmt.Errorf("invalid secret reference %q: missing file path", ref)
+		}
+		return fileBackend{path: path}, nil
+	case strings.HasPrefix(ref, SchemeEnv):
+		name := strings.TrimPrefix(ref, SchemeEnv)
+		if name == "" {
+			return nil, fmt.Errorf("invalid secret reference %q: missing environment variable name", ref)
+		}
+		return envBackend{name: name}, nil
+	case strings.HasPrefix(ref, SchemeSecret):
+		parts := strings.Split(strings.TrimPrefix(ref, SchemeSecret), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid secret reference %q: expected secret:namespace/name/key", ref)
+		}
+		if getClient == nil {
+			return nil, fmt.Errorf("invalid secret reference %q: no Kubernetes client", ref)
+		}
+		return secretBackend{getClient: getClient, namespace: parts[0], name: parts[1], key: parts[2]}, nil
+	}
+	return nil, nil
+}
+
+// fileBackend reads a file, trimming the trailing newline most editors and tools add. Mounted
+// Kubernetes Secrets are updated in place by the kubelet, so they are reloaded too.
+type fileBackend struct {
+	path string
+}
+
+func (b fileBackend) Load(_ context.Context) (string, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// envBackend reads an environment variable
+type envBackend struct {
+	name string
+}
+
+func (b envBackend) Load(_ context.Context) (string, error) {
+	value, ok := os.LookupEnv(b.name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", b.name)
+	}
+	return value, nil
+}
+
+// secretBackend reads a key of a Kubernetes Secret
+type secretBackend struct {
+	getClient ClientFunc
+	namespace string
+	name      string
+	key       string
+}
+
+func (b secretBackend) Load(ctx context.Context) (string, error) {
+	client, err := b.getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	secret, err := client.CoreV1().Secrets(b.namespace).Get(ctx, b.name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[b.key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", b.namespace, b.name, b.key)
+	}
+	return string(value), nil
+}
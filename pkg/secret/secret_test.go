@@ -0,0 +1,133 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("from-file\n"), 0600))
+	t.Setenv("TEST_SCANNER_TOKEN", "from-env")
+
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "scanner", Namespace: "mcp"},
+		Data:       map[string][]byte{"token": []byte("from-secret")},
+	})
+
+	tests := []struct {
+		name           string
+		ref            string
+		expected       string
+		expectedString string
+		expectedErrMsg string
+	}{
+		{
+			name:           "literal",
+			ref:            "plain-token",
+			expected:       "plain-token",
+			expectedString: "literal",
+		},
+		{
+			name:           "empty",
+			ref:            "",
+			expected:       "",
+			expectedString: "literal",
+		},
+		{
+			name:           "file",
+			ref:            "file:" + tokenFile,
+			expected:       "from-file",
+			expectedString: "file:" + tokenFile,
+		},
+		{
+			name:           "environment variable",
+			ref:            "env:TEST_SCANNER_TOKEN",
+			expected:       "from-env",
+			expectedString: "env:TEST_SCANNER_TOKEN",
+		},
+		{
+			name:           "kubernetes secret",
+			ref:            "secret:mcp/scanner/token",
+			expected:       "from-secret",
+			expectedString: "secret:mcp/scanner/token",
+		},
+		{
+			name:           "missing file",
+			ref:            "file:" + filepath.Join(dir, "missing"),
+			expectedErrMsg: "no such file or directory",
+		},
+		{
+			name:           "unset environment variable",
+			ref:            "env:TEST_UNSET_TOKEN",
+			expectedErrMsg: "environment variable TEST_UNSET_TOKEN is not set",
+		},
+		{
+			name:           "missing secret key",
+			ref:            "secret:mcp/scanner/password",
+			expectedErrMsg: "secret mcp/scanner has no key password",
+		},
+		{
+			name:           "invalid secret reference",
+			ref:            "secret:mcp/scanner",
+			expectedErrMsg: "expected secret:namespace/name/key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := Resolve(context.Background(), tc.ref, func(context.Context) (kubernetes.Interface, error) { return client, nil })
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value.Get())
+			assert.Equal(t, tc.expectedString, value.String())
+			assert.Equal(t, tc.expectedString != "literal", value.Reloadable())
+		})
+	}
+}
+
+func TestValueRefresh(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first"), 0600))
+
+	value, err := Resolve(context.Background(), "file:"+tokenFile, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", value.Get())
+
+	changed, err := value.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// A rotated file is picked up on the next refresh
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second\n"), 0600))
+	changed, err = value.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "second", value.Get())
+
+	// A failed reload keeps the previous value
+	require.NoError(t, os.Remove(tokenFile))
+	_, err = value.Refresh(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "second", value.Get())
+
+	// Secret references need a client
+	_, err = Resolve(context.Background(), "secret:mcp/scanner/token", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Kubernetes client")
+}
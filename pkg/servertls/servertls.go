@@ -0,0 +1,117 @@
+// Package servertls serves the HTTP transports over TLS, optionally requiring clients to present a
+// certificate signed by a client CA (mTLS). Certificates are reloaded when their files change, so
+// rotated certificates, such as those renewed by cert-manager, are picked up without a restart.
+package servertls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval is how often the certificate files are checked for changes
+const DefaultReloadInterval = 30 * time.Second
+
+// Certificates holds the server certificate and client CAs last loaded from their files
+type Certificates struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu          sync.RWMutex
+	contents    [][]byte
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// Load reads the server certificate and key, and the client CA bundle when clientCAFile is set
+func Load(certFile, keyFile, clientCAFile string) (*Certificates, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both a TLS certificate and key are required")
+	}
+	c := &Certificates{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if _, err := c.Refresh(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Refresh reloads the certificate files, reporting whether they changed. On error the
+// certificates last loaded are kept.
+func (c *Certificates) Refresh() (bool, error) {
+	files := []string{c.certFile, c.keyFile}
+	if c.clientCAFile != "" {
+		files = append(files, c.clientCAFile)
+	}
+	contents := make([][]byte, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		contents[i] = data
+	}
+
+	c.mu.RLock()
+	unchanged := c.contents != nil
+	for i := range contents {
+		unchanged = unchanged && bytes.Equal(contents[i], c.contents[i])
+	}
+	c.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair(contents[0], contents[1])
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate %s and key %s: %w", c.certFile, c.keyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if c.clientCAFile != "" {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(contents[2]) {
+			return false, fmt.Errorf("failed to load client CA %s: no PEM certificates found", c.clientCAFile)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.contents = contents
+	c.certificate = &certificate
+	c.clientCAs = clientCAs
+	return true, nil
+}
+
+// TLSConfig returns a server TLS config serving the certificates last loaded, requiring and
+// verifying client certificates when a client CA is configured
+func (c *Certificates) TLSConfig() *tls.Config {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: c.getCertificate,
+	}
+	if c.clientCAFile != "" {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		// Each handshake verifies clients against the client CAs last loaded
+		config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: c.getCertificate,
+				ClientAuth:     tls.RequireAndVerifyClientCert,
+				ClientCAs:      c.clientCAs,
+			}, nil
+		}
+	}
+	return config
+}
+
+func (c *Certificates) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.certificate, nil
+}
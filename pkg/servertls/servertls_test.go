@@ -0,0 +1,160 @@
+package servertls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyPair is a certificate with its key, in both parsed and PEM form
+type keyPair struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newKeyPair creates a certificate signed by parent, or a self-signed CA when parent is nil
+func newKeyPair(t *testing.T, name string, parent *keyPair, usage x509.ExtKeyUsage) *keyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &keyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeKeyPair(t *testing.T, dir string, pair *keyPair) (string, string) {
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pair.certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, pair.keyPEM, 0600))
+	return certFile, keyFile
+}
+
+func TestRefresh(t *testing.T) {
+	dir := t.TempDir()
+	first := newKeyPair(t, "first", nil, x509.ExtKeyUsageServerAuth)
+	certFile, keyFile := writeKeyPair(t, dir, first)
+
+	certs, err := Load(certFile, keyFile, "")
+	require.NoError(t, err)
+	current, err := certs.getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.cert.Raw, current.Certificate[0])
+
+	changed, err := certs.Refresh()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	second := newKeyPair(t, "second", nil, x509.ExtKeyUsageServerAuth)
+	writeKeyPair(t, dir, second)
+	changed, err = certs.Refresh()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	current, err = certs.getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, current.Certificate[0])
+
+	// A certificate written without its new key keeps the previous pair
+	require.NoError(t, os.WriteFile(certFile, first.certPEM, 0600))
+	_, err = certs.Refresh()
+	assert.ErrorContains(t, err, "failed to load TLS certificate")
+	current, err = certs.getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, current.Certificate[0])
+
+	_, err = Load(certFile, "", "")
+	assert.ErrorContains(t, err, "both a TLS certificate and key are required")
+	_, err = Load(certFile, keyFile, filepath.Join(dir, "missing.crt"))
+	assert.ErrorContains(t, err, "failed to read")
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newKeyPair(t, "ca", nil, x509.ExtKeyUsageAny)
+	serverPair := newKeyPair(t, "server", ca, x509.ExtKeyUsageServerAuth)
+	clientPair := newKeyPair(t, "client", ca, x509.ExtKeyUsageClientAuth)
+	otherClient := newKeyPair(t, "other", nil, x509.ExtKeyUsageClientAuth)
+
+	certFile, keyFile := writeKeyPair(t, dir, serverPair)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0600))
+
+	certs, err := Load(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = certs.TLSConfig()
+	// Rejected handshakes are expected
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(pair *keyPair) (*http.Response, error) {
+		config := &tls.Config{RootCAs: roots}
+		if pair != nil {
+			config.Certificates = []tls.Certificate{{Certificate: [][]byte{pair.cert.Raw}, PrivateKey: pair.key}}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+		return client.Get(srv.URL)
+	}
+
+	resp, err := get(clientPair)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = get(nil)
+	assert.Error(t, err, "clients without a certificate are rejected")
+	_, err = get(otherClient)
+	assert.Error(t, err, "clients signed by another CA are rejected")
+}
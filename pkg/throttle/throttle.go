@@ -0,0 +1,166 @@
+// Package throttle keeps runaway agents from hammering the API server. It caps the tool calls in
+// flight across all clients and rate limits the calls of each client session, rejecting calls over
+// either limit with an error asking the agent to slow down.
+package throttle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/time/rate"
+)
+
+// DefaultMaxInFlight is the number of tool calls the server runs at once by default
+const DefaultMaxInFlight = 32
+
+// Reasons a call is rejected
+const (
+	ReasonConcurrency = "concurrency"
+	ReasonSessionRate = "session-rate"
+)
+
+// SlowDown is the error returned, JSON encoded, for calls over a limit
+type SlowDown struct {
+	Error             string `json:"error"`
+	Tool              string `json:"tool"`
+	Reason            string `json:"reason"`
+	Limit             int    `json:"limit"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+}
+
+// Throttle limits the tool calls it wraps
+type Throttle struct {
+	maxInFlight int
+	inFlight    chan struct{}
+	sessionRate int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+type session struct {
+	limiter  *rate.Limiter
+	lastCall time.Time
+}
+
+// New creates a Throttle running at most maxInFlight calls at once and allowing each session
+// sessionRate calls per minute, with up to sessionRate calls at once after a quiet minute. Either
+// limit is off when 0.
+func New(maxInFlight, sessionRate int) *Throttle {
+	t := &Throttle{
+		maxInFlight: maxInFlight,
+		sessionRate: sessionRate,
+		now:         time.Now,
+		sessions:    map[string]*session{},
+	}
+	if maxInFlight > 0 {
+		t.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return t
+}
+
+// Wrap rejects calls of a tool over the session rate limit, and calls made while the maximum
+// number of calls is in flight. Rejected calls do not count towards the rate limit.
+func (t *Throttle) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sessionID := ""
+		if s := server.ClientSessionFromContext(ctx); s != nil {
+			sessionID = s.SessionID()
+		}
+		reservation, rejection := t.reserve(name, sessionID)
+		if rejection == nil {
+			if !t.acquire() {
+				if reservation != nil {
+					reservation.CancelAt(t.now())
+				}
+				rejection = &SlowDown{
+					Error:             fmt.Sprintf("the server is running its maximum of %d tool calls at once; retry shortly and avoid issuing many calls in parallel", t.maxInFlight),
+					Tool:              name,
+					Reason:            ReasonConcurrency,
+					Limit:             t.maxInFlight,
+					RetryAfterSeconds: 1,
+				}
+			}
+		}
+		if rejection != nil {
+			r, err := json.Marshal(rejection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultError(string(r)), nil
+		}
+		defer t.release()
+
+		return next(ctx, request)
+	}
+	return tool
+}
+
+// acquire takes a slot for a call in flight, returning false when none is free
+func (t *Throttle) acquire() bool {
+	if t.inFlight == nil {
+		return true
+	}
+	select {
+	case t.inFlight <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *Throttle) release() {
+	if t.inFlight != nil {
+		<-t.inFlight
+	}
+}
+
+// reserve takes a call from the rate limit of a session, returning the rejection when none is left
+func (t *Throttle) reserve(tool, sessionID string) (*rate.Reservation, *SlowDown) {
+	if t.sessionRate <= 0 {
+		return nil, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+	s, ok := t.sessions[sessionID]
+	if !ok {
+		s = &session{limiter: rate.NewLimiter(rate.Limit(float64(t.sessionRate)/60), t.sessionRate)}
+		t.sessions[sessionID] = s
+	}
+	s.lastCall = now
+	reservation := s.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return reservation, nil
+	}
+	reservation.CancelAt(now)
+	return nil, &SlowDown{
+		Error:             fmt.Sprintf("this session is limited to %d tool calls per minute; slow down and retry later", t.sessionRate),
+		Tool:              tool,
+		Reason:            ReasonSessionRate,
+		Limit:             t.sessionRate,
+		RetryAfterSeconds: int(math.Ceil(delay.Seconds())),
+	}
+}
+
+// prune forgets sessions without calls for a minute, whose limit has refilled, so sessions that
+// ended are not kept
+func (t *Throttle) prune(now time.Time) {
+	for id, s := range t.sessions {
+		if now.Sub(s.lastCall) >= time.Minute {
+			delete(t.sessions, id)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package throttle
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	id string
+}
+
+func (s fakeSession) SessionID() string                                   { return s.id }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+// sessionCtx returns the context of a call made by a client session
+func sessionCtx(id string) context.Context {
+	return server.NewMCPServer("test", "1.0").WithContext(context.Background(), fakeSession{id: id})
+}
+
+func newTool(handler server.ToolHandlerFunc) server.ServerTool {
+	if handler == nil {
+		handler = func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		}
+	}
+	return server.ServerTool{Tool: mcp.NewTool("list_pods"), Handler: handler}
+}
+
+func call(t *testing.T, ctx context.Context, tool server.ServerTool) *mcp.CallToolResult {
+	result, err := tool.Handler(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	return result
+}
+
+func slowDown(t *testing.T, result *mcp.CallToolResult) SlowDown {
+	require.True(t, result.IsError)
+	var rejection SlowDown
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &rejection))
+	return rejection
+}
+
+func TestSessionRate(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	th := New(0, 3)
+	th.now = func() time.Time { return now }
+	tool := th.Wrap(newTool(nil))
+
+	// A session may burst up to its limit
+	for i := 0; i < 3; i++ {
+		assert.False(t, call(t, sessionCtx("a"), tool).IsError)
+	}
+	rejection := slowDown(t, call(t, sessionCtx("a"), tool))
+	assert.Equal(t, SlowDown{
+		Error:             "this session is limited to 3 tool calls per minute; slow down and retry later",
+		Tool:              "list_pods",
+		Reason:            ReasonSessionRate,
+		Limit:             3,
+		RetryAfterSeconds: 20,
+	}, rejection)
+
+	// Other sessions have limits of their own
+	assert.False(t, call(t, sessionCtx("b"), tool).IsError)
+
+	// Calls are allowed again as the limit refills
+	now = now.Add(20 * time.Second)
+	assert.False(t, call(t, sessionCtx("a"), tool).IsError)
+	assert.True(t, call(t, sessionCtx("a"), tool).IsError)
+
+	// Sessions without calls for a minute are forgotten
+	now = now.Add(time.Minute)
+	assert.False(t, call(t, sessionCtx("c"), tool).IsError)
+	assert.Len(t, th.sessions, 1)
+}
+
+func TestMaxInFlight(t *testing.T) {
+	th := New(2, 0)
+	started := make(chan struct{})
+	done := make(chan struct{})
+	tool := th.Wrap(newTool(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started <- struct{}{}
+		<-done
+		return mcp.NewToolResultText("ok"), nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = tool.Handler(context.Background(), mcp.CallToolRequest{})
+		}()
+		<-started
+	}
+
+	// A third call is rejected while two are in flight
+	rejection := slowDown(t, call(t, context.Background(), tool))
+	assert.Equal(t, ReasonConcurrency, rejection.Reason)
+	assert.Equal(t, 2, rejection.Limit)
+	assert.Equal(t, 1, rejection.RetryAfterSeconds)
+
+	// and runs once they are done
+	close(done)
+	wg.Wait()
+	go func() { <-started }()
+	assert.False(t, call(t, context.Background(), tool).IsError)
+}
+
+func TestRejectedCallsNotCounted(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	th := New(1, 2)
+	th.now = func() time.Time { return now }
+	tool := th.Wrap(newTool(nil))
+
+	// Calls rejected for concurrency leave the rate limit of their session untouched
+	th.inFlight <- struct{}{}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, ReasonConcurrency, slowDown(t, call(t, sessionCtx("a"), tool)).Reason)
+	}
+	<-th.inFlight
+	assert.False(t, call(t, sessionCtx("a"), tool).IsError)
+	assert.False(t, call(t, sessionCtx("a"), tool).IsError)
+	assert.Equal(t, ReasonSessionRate, slowDown(t, call(t, sessionCtx("a"), tool)).Reason)
+}
+
+func TestUnlimited(t *testing.T) {
+	tool := New(0, 0).Wrap(newTool(nil))
+	for i := 0; i < 100; i++ {
+		assert.False(t, call(t, sessionCtx("a"), tool).IsError)
+	}
+}
@@ -0,0 +1,73 @@
+package toolsets
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// nonDestructiveTools are write tools that only add to the cluster or make changes that are
+// easily undone, such as creating objects or cordoning nodes. Other write tools are annotated as
+// destructive, so clients ask before calling write tools they do not know.
+var nonDestructiveTools = map[string]bool{
+	"annotate_resource":   true,
+	"cordon_node":         true,
+	"create_configmap":    true,
+	"create_deployment":   true,
+	"create_namespace":    true,
+	"debug_pod":           true,
+	"incident_mode":       true,
+	"label_resource":      true,
+	"pause_deployment":    true,
+	"pod_cp_from":         true,
+	"provision_namespace": true,
+	"resume_deployment":   true,
+	"test_dns_resolution": true,
+	"uncordon_node":       true,
+	"untaint_node":        true,
+	"wake_namespace":      true,
+}
+
+// idempotentTools are write tools that have no further effect when called again with the same
+// arguments, such as deletes or setting a replica count
+var idempotentTools = map[string]bool{
+	"annotate_resource":    true,
+	"apply_manifest":       true,
+	"cordon_node":          true,
+	"delete_bundle":        true,
+	"delete_configmap":     true,
+	"delete_deployment":    true,
+	"delete_namespace":     true,
+	"delete_pod":           true,
+	"delete_resource":      true,
+	"delete_service":       true,
+	"drain_node":           true,
+	"hibernate_namespace":  true,
+	"label_resource":       true,
+	"patch_configmap_data": true,
+	"pause_deployment":     true,
+	"pod_cp_from":          true,
+	"pod_cp_to":            true,
+	"reconcile_bundle":     true,
+	"resume_deployment":    true,
+	"save_bundle":          true,
+	"scale_deployment":     true,
+	"set_image":            true,
+	"taint_node":           true,
+	"uncordon_node":        true,
+	"untaint_node":         true,
+	"update_configmap":     true,
+	"wake_namespace":       true,
+}
+
+// annotateReadTool marks a tool as not modifying the cluster, so clients can call it without
+// asking
+func annotateReadTool(tool *mcp.Tool) {
+	tool.Annotations.ReadOnlyHint = mcp.ToBoolPtr(true)
+	tool.Annotations.DestructiveHint = mcp.ToBoolPtr(false)
+	tool.Annotations.IdempotentHint = mcp.ToBoolPtr(true)
+}
+
+// annotateWriteTool marks a tool as modifying the cluster, destructively and not idempotently
+// unless it is known otherwise
+func annotateWriteTool(tool *mcp.Tool) {
+	tool.Annotations.ReadOnlyHint = mcp.ToBoolPtr(false)
+	tool.Annotations.DestructiveHint = mcp.ToBoolPtr(!nonDestructiveTools[tool.Name])
+	tool.Annotations.IdempotentHint = mcp.ToBoolPtr(idempotentTools[tool.Name])
+}
@@ -0,0 +1,167 @@
+package toolsets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DynamicToolsets registers the tools of a toolset on demand, grouped by the toolsets they were
+// added from, so an agent starts with a minimal set of tools and enables the ones its task needs.
+// Tools added to the toolset directly are registered right away.
+type DynamicToolsets struct {
+	server  *server.MCPServer
+	visible func(name string) bool
+
+	mu      sync.Mutex
+	groups  map[string][]server.ServerTool
+	enabled map[string]bool
+}
+
+// AvailableToolset describes a toolset in the list_available_toolsets and enable_toolset results
+type AvailableToolset struct {
+	Name    string   `json:"name"`
+	Enabled bool     `json:"enabled"`
+	Tools   []string `json:"tools"`
+}
+
+// NewDynamicToolsets creates dynamic toolsets registering their tools with the server
+func NewDynamicToolsets(s *server.MCPServer) *DynamicToolsets {
+	return &DynamicToolsets{
+		server:  s,
+		groups:  make(map[string][]server.ServerTool),
+		enabled: make(map[string]bool),
+	}
+}
+
+// SetVisible leaves the tools for which visible returns false out when their toolset is enabled,
+// such as tools hidden for lack of permissions
+func (d *DynamicToolsets) SetVisible(visible func(name string) bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.visible = visible
+}
+
+// Enabled reports whether the toolset a tool belongs to is enabled, always true for tools added
+// to the toolset directly
+func (d *DynamicToolsets) Enabled(tool string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for name, tools := range d.groups {
+		for _, t := range tools {
+			if t.Tool.Name == tool {
+				return d.enabled[name]
+			}
+		}
+	}
+	return true
+}
+
+// RegisterTools registers the active tools of the toolset added to it directly and keeps the
+// others to register when their toolset is enabled
+func (d *DynamicToolsets) RegisterTools(toolset *Toolset) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var tools []server.ServerTool
+	for _, tool := range toolset.GetActiveTools() {
+		if group := toolset.Group(tool.Tool.Name); group != "" {
+			d.groups[group] = append(d.groups[group], tool)
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	if len(tools) > 0 {
+		d.server.AddTools(tools...)
+	}
+}
+
+// Enable registers the tools of the named toolset, which notifies clients that the tool list
+// changed. Enabling a toolset twice has no effect.
+func (d *DynamicToolsets) Enable(name string) (AvailableToolset, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	tools, ok := d.groups[name]
+	if !ok {
+		return AvailableToolset{}, fmt.Errorf("unknown toolset %q: must be one of %s", name, strings.Join(d.names(), ", "))
+	}
+	if !d.enabled[name] {
+		var visible []server.ServerTool
+		for _, tool := range tools {
+			if d.visible == nil || d.visible(tool.Tool.Name) {
+				visible = append(visible, tool)
+			}
+		}
+		if len(visible) > 0 {
+			d.server.AddTools(visible...)
+		}
+		d.enabled[name] = true
+	}
+	return d.describe(name), nil
+}
+
+// List describes the toolsets, sorted by name
+func (d *DynamicToolsets) List() []AvailableToolset {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	toolsets := []AvailableToolset{}
+	for _, name := range d.names() {
+		toolsets = append(toolsets, d.describe(name))
+	}
+	return toolsets
+}
+
+func (d *DynamicToolsets) names() []string {
+	names := make([]string, 0, len(d.groups))
+	for name := range d.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *DynamicToolsets) describe(name string) AvailableToolset {
+	toolset := AvailableToolset{Name: name, Enabled: d.enabled[name], Tools: []string{}}
+	for _, tool := range d.groups[name] {
+		toolset.Tools = append(toolset.Tools, tool.Tool.Name)
+	}
+	slices.Sort(toolset.Tools)
+	return toolset
+}
+
+// ListTool creates a tool listing the toolsets that can be enabled and their tools
+func (d *DynamicToolsets) ListTool() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_available_toolsets",
+			mcp.WithDescription("List the toolsets of this server, whether each is enabled and the tools it provides. Call enable_toolset to add the tools of a toolset the task needs"),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return NewToolResultJSON(d.List())
+		}
+}
+
+// EnableTool creates a tool enabling a toolset, adding its tools to the tools the server offers
+func (d *DynamicToolsets) EnableTool() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_toolset",
+			mcp.WithDescription("Enable a toolset listed by list_available_toolsets, adding its tools to the tools this server offers. Clients are notified that the tool list changed"),
+			mcp.WithString("toolset",
+				mcp.Required(),
+				mcp.Description("Name of the toolset to enable, e.g. deployment"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolset, err := d.Enable(name)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return NewToolResultJSON(toolset)
+		}
+}
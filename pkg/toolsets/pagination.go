@@ -0,0 +1,103 @@
+package toolsets
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultListLimit is the number of objects a paginated list tool returns per page when the
+// caller sets no limit
+const DefaultListLimit = 500
+
+// WithPagination adds the limit and continue parameters of a paginated list tool
+func WithPagination() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of objects to return (default %d); pass the returned continue token to get the next page", DefaultListLimit)),
+		)(tool)
+		mcp.WithString("continue",
+			mcp.Description("Continue token returned by the previous page of the same list"),
+		)(tool)
+	}
+}
+
+// ApplyPagination sets the limit and continue token of a paginated list tool call on options
+func ApplyPagination(r mcp.CallToolRequest, options *metav1.ListOptions) error {
+	limit, err := OptionalParam[float64](r, "limit")
+	if err != nil {
+		return err
+	}
+	if limit < 0 || limit != float64(int64(limit)) {
+		return fmt.Errorf("limit must be a positive integer")
+	}
+	continueToken, err := OptionalParam[string](r, "continue")
+	if err != nil {
+		return err
+	}
+
+	options.Limit = DefaultListLimit
+	if limit > 0 {
+		options.Limit = int64(limit)
+	}
+	options.Continue = continueToken
+	return nil
+}
+
+// ListPage is a page of the objects of a paginated list tool. Continue is set while more objects
+// remain, RemainingItemCount when the API server can tell how many.
+type ListPage struct {
+	Items              interface{} `json:"items"`
+	Continue           string      `json:"continue,omitempty"`
+	RemainingItemCount *int64      `json:"remainingItemCount,omitempty"`
+}
+
+// NewListPageResult encodes a page of objects, or of their summaries, together with the
+// continue token of the list it was read from
+func NewListPageResult(items interface{}, list metav1.ListMeta) (*mcp.CallToolResult, error) {
+	return NewToolResultJSON(ListPage{
+		Items:              items,
+		Continue:           list.Continue,
+		RemainingItemCount: list.RemainingItemCount,
+	})
+}
+
+// AllNamespaces is the namespace value of a namespaced list tool listing every namespace
+const AllNamespaces = "*"
+
+// WithListNamespace adds the namespace and allNamespaces parameters of a namespaced list tool.
+// Listing every namespace is paginated like any other list, so a cluster-wide query stays one
+// call per page.
+func WithListNamespace() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("namespace",
+			mcp.Description(fmt.Sprintf("Kubernetes namespace, or %s for all namespaces", AllNamespaces)),
+		)(tool)
+		mcp.WithBoolean("allNamespaces",
+			mcp.Description("List objects in all namespaces instead of one namespace"),
+		)(tool)
+	}
+}
+
+// ListNamespace returns the namespace a namespaced list tool call lists, metav1.NamespaceAll when
+// allNamespaces is set or the namespace is *
+func ListNamespace(r mcp.CallToolRequest) (string, error) {
+	allNamespaces, err := OptionalParam[bool](r, "allNamespaces")
+	if err != nil {
+		return "", err
+	}
+	namespace, err := OptionalParam[string](r, "namespace")
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case allNamespaces && namespace != "" && namespace != AllNamespaces:
+		return "", fmt.Errorf("namespace %q cannot be combined with allNamespaces", namespace)
+	case allNamespaces || namespace == AllNamespaces:
+		return metav1.NamespaceAll, nil
+	case namespace == "":
+		return "", fmt.Errorf("missing required parameter: namespace (or set allNamespaces)")
+	}
+	return namespace, nil
+}
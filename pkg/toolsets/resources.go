@@ -0,0 +1,94 @@
+package toolsets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ResourceTemplate is an MCP resource template whose resources are read with a read tool, called
+// with the variables of the template as arguments
+type ResourceTemplate struct {
+	Template mcp.ResourceTemplate
+	Tool     string
+}
+
+// ResourceSet holds the MCP resource templates exposing Kubernetes objects
+type ResourceSet struct {
+	templates []ResourceTemplate
+}
+
+// NewResourceSet creates an empty resource set
+func NewResourceSet() *ResourceSet {
+	return &ResourceSet{}
+}
+
+// AddTemplate adds a resource template read with the named tool
+func (r *ResourceSet) AddTemplate(template mcp.ResourceTemplate, tool string) {
+	r.templates = append(r.templates, ResourceTemplate{Template: template, Tool: tool})
+}
+
+// Templates returns the resource templates of the set
+func (r *ResourceSet) Templates() []ResourceTemplate {
+	return r.templates
+}
+
+// RegisterResources registers the templates whose tools are among tools with the server. Reading a
+// resource calls the tool, so the wrappers of the tool, such as redaction and namespace limits,
+// apply to resources as well, and templates of tools that are not registered are left out.
+func (r *ResourceSet) RegisterResources(s *server.MCPServer, tools []server.ServerTool) {
+	handlers := make(map[string]server.ToolHandlerFunc, len(tools))
+	for _, tool := range tools {
+		handlers[tool.Tool.Name] = tool.Handler
+	}
+	for _, template := range r.templates {
+		handler, ok := handlers[template.Tool]
+		if !ok {
+			continue
+		}
+		s.AddResourceTemplate(template.Template, ReadWithTool(template.Tool, handler))
+	}
+}
+
+// ReadWithTool returns a resource handler calling a tool with the variables of the resource URI
+// and returning its text result as the contents of the resource
+func ReadWithTool(name string, handler server.ToolHandlerFunc) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		// URI template variables are matched as lists of values
+		args := make(map[string]interface{}, len(request.Params.Arguments))
+		for key, value := range request.Params.Arguments {
+			switch v := value.(type) {
+			case []string:
+				if len(v) > 0 {
+					args[key] = v[0]
+				}
+			default:
+				args[key] = v
+			}
+		}
+
+		var call mcp.CallToolRequest
+		call.Params.Name = name
+		call.Params.Arguments = args
+		result, err := handler(ctx, call)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil || len(result.Content) == 0 {
+			return nil, fmt.Errorf("%s returned no result for %s", name, request.Params.URI)
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok {
+			return nil, fmt.Errorf("%s returned no text for %s", name, request.Params.URI)
+		}
+		if result.IsError {
+			return nil, errors.New(text.Text)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: text.Text},
+		}, nil
+	}
+}
@@ -0,0 +1,81 @@
+package toolsets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Sort keys every list tool with sorting accepts
+const (
+	SortByName = "name"
+	SortByAge  = "age"
+)
+
+// SortKeys order the objects of a list tool by a key of their kind. Less reports whether a sorts
+// before b.
+type SortKeys[T any] map[string]func(a, b *T) bool
+
+// names returns the sort keys a list tool accepts, name and age first
+func (k SortKeys[T]) names() []string {
+	var extra []string
+	for name := range k {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	return append([]string{SortByName, SortByAge}, extra...)
+}
+
+// WithSortBy adds the sortBy parameter of a list tool, accepting name, age and the given keys
+func WithSortBy[T any](keys SortKeys[T], description string) mcp.ToolOption {
+	return mcp.WithString("sortBy",
+		mcp.Description("Order of the returned objects within the page: name alphabetically, age newest first"+description),
+		mcp.Enum(keys.names()...),
+	)
+}
+
+// SortItems orders the objects of a list tool call by its sortBy parameter, keeping the order of
+// the API server when it is not set. Objects that compare equal keep their order by name.
+func SortItems[T any, PT interface {
+	*T
+	metav1.Object
+}](r mcp.CallToolRequest, items []T, keys SortKeys[T]) error {
+	sortBy, err := OptionalParam[string](r, "sortBy")
+	if err != nil || sortBy == "" {
+		return err
+	}
+
+	var less func(a, b *T) bool
+	switch sortBy {
+	case SortByName:
+	case SortByAge:
+		less = func(a, b *T) bool {
+			return PT(b).GetCreationTimestamp().Time.Before(PT(a).GetCreationTimestamp().Time)
+		}
+	default:
+		var ok bool
+		if less, ok = keys[sortBy]; !ok {
+			return fmt.Errorf("sortBy must be one of %s", strings.Join(keys.names(), ", "))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := &items[i], &items[j]
+		if less != nil {
+			if less(a, b) {
+				return true
+			}
+			if less(b, a) {
+				return false
+			}
+		}
+		if PT(a).GetName() != PT(b).GetName() {
+			return PT(a).GetName() < PT(b).GetName()
+		}
+		return PT(a).GetNamespace() < PT(b).GetNamespace()
+	})
+	return nil
+}
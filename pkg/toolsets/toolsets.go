@@ -5,12 +5,27 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // GetClientFn is a function type that returns a Kubernetes client interface
 type GetClientFn func(context.Context) (kubernetes.Interface, error)
 
+// GetDynamicClientFn is a function type that returns a Kubernetes dynamic client interface,
+// used for resources without typed clients such as CRDs
+type GetDynamicClientFn func(context.Context) (dynamic.Interface, error)
+
+// GetRESTConfigFn is a function type that returns the REST config of the cluster, used by
+// tools that stream over the API server connection such as exec
+type GetRESTConfigFn func(context.Context) (*rest.Config, error)
+
+// GetMetricsClientFn is a function type that returns a client for the metrics.k8s.io API served
+// by metrics-server
+type GetMetricsClientFn func(context.Context) (metricsclientset.Interface, error)
+
 // NewServerTool creates a new ServerTool with the given tool and handler
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
@@ -24,6 +39,10 @@ type Toolset struct {
 	readOnly    bool
 	writeTools  []server.ServerTool
 	readTools   []server.ServerTool
+	// groups maps the tools added with AddTools to the name of the toolset they came from
+	groups map[string]string
+	// disabled are the names of tools that are never registered
+	disabled map[string]bool
 }
 
 // NewToolset creates a new toolset with the given name and description
@@ -36,13 +55,13 @@ func NewToolset(name string, description string, readOnly bool) *Toolset {
 	}
 }
 
-// GetActiveTools returns all active tools for this toolset
+// GetActiveTools returns all active tools for this toolset, leaving out disabled tools
 func (t *Toolset) GetActiveTools() []server.ServerTool {
 	if t.Enabled {
 		if t.readOnly {
-			return t.readTools
+			return t.enabledTools(t.readTools)
 		}
-		return append(t.readTools, t.writeTools...)
+		return t.enabledTools(append(t.readTools, t.writeTools...))
 	}
 	return nil
 }
@@ -60,41 +79,134 @@ func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	if !t.Enabled {
 		return
 	}
-	for _, tool := range t.readTools {
+	for _, tool := range t.enabledTools(t.readTools) {
 		s.AddTool(tool.Tool, tool.Handler)
 	}
 	if !t.readOnly {
-		for _, tool := range t.writeTools {
+		for _, tool := range t.enabledTools(t.writeTools) {
 			s.AddTool(tool.Tool, tool.Handler)
 		}
 	}
 }
 
+// DisableTools disables the named tools, which are then left out of the active tools and never
+// registered, while the other tools of the toolset are. Names of tools the toolset does not have
+// are kept, disabling tools added later.
+func (t *Toolset) DisableTools(names ...string) {
+	if t.disabled == nil {
+		t.disabled = make(map[string]bool)
+	}
+	for _, name := range names {
+		t.disabled[name] = true
+	}
+}
+
+func (t *Toolset) enabledTools(tools []server.ServerTool) []server.ServerTool {
+	if len(t.disabled) == 0 {
+		return tools
+	}
+	enabled := make([]server.ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		if !t.disabled[tool.Tool.Name] {
+			enabled = append(enabled, tool)
+		}
+	}
+	return enabled
+}
+
 // SetReadOnly sets the toolset to read-only mode
 func (t *Toolset) SetReadOnly() {
 	// Set the toolset to read-only
 	t.readOnly = true
 }
 
-// AddReadTool adds a mcp tool and handler func to the toolset
+// AddReadTool adds a mcp tool and handler func to the toolset, annotated as read-only
 func (t *Toolset) AddReadTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	annotateReadTool(&tool)
 	t.readTools = append(t.readTools, NewServerTool(tool, handler))
 }
 
-// AddWriteTool adds a write tool to the toolset
+// AddWriteTool adds a write tool to the toolset, annotated with whether it is destructive and
+// idempotent
 func (t *Toolset) AddWriteTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
 	if !t.readOnly {
+		annotateWriteTool(&tool)
 		t.writeTools = append(t.writeTools, NewServerTool(tool, handler))
 	}
 }
 
+// AddTools adds the available tools of another toolset to the toolset, remembering the toolset
+// they came from
+func (t *Toolset) AddTools(other *Toolset) {
+	if t.groups == nil {
+		t.groups = make(map[string]string)
+	}
+	for _, tool := range other.GetAvailableTools() {
+		t.groups[tool.Tool.Name] = other.Name
+	}
+	t.readTools = append(t.readTools, other.readTools...)
+	if !t.readOnly && !other.readOnly {
+		t.writeTools = append(t.writeTools, other.writeTools...)
+	}
+}
+
+// Group returns the name of the toolset a tool was added from with AddTools, or "" for tools
+// added to the toolset directly
+func (t *Toolset) Group(tool string) string {
+	return t.groups[tool]
+}
+
+// RemoveTools removes every read and write tool for which remove returns true, for example to
+// drop the tools a policy does not allow
+func (t *Toolset) RemoveTools(remove func(server.ServerTool) bool) {
+	t.readTools = keepTools(t.readTools, remove)
+	t.writeTools = keepTools(t.writeTools, remove)
+}
+
+func keepTools(tools []server.ServerTool, remove func(server.ServerTool) bool) []server.ServerTool {
+	kept := tools[:0]
+	for _, tool := range tools {
+		if !remove(tool) {
+			kept = append(kept, tool)
+		}
+	}
+	return kept
+}
+
+// WrapReadTools replaces every read tool with the result of wrap, for example to add a
+// parameter shared by all read tools
+func (t *Toolset) WrapReadTools(wrap func(server.ServerTool) server.ServerTool) {
+	for i, tool := range t.readTools {
+		t.readTools[i] = wrap(tool)
+	}
+}
+
+// WrapWriteTools replaces every write tool with the result of wrap, for example to guard
+// operations that change the cluster
+func (t *Toolset) WrapWriteTools(wrap func(server.ServerTool) server.ServerTool) {
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = wrap(tool)
+	}
+}
+
+// WrapTools replaces every read and write tool with the result of wrap, for example to
+// record or instrument all tool calls
+func (t *Toolset) WrapTools(wrap func(server.ServerTool) server.ServerTool) {
+	t.WrapReadTools(wrap)
+	t.WrapWriteTools(wrap)
+}
+
 // K8sResourceHandler defines the interface for all Kubernetes resource handlers
 type K8sResourceHandler interface {
 	// RegisterTools registers all tools for a k8s resource with the provided toolset
 	RegisterTools(toolset *Toolset)
+}
 
+// K8sResourceProvider is implemented by resource handlers that also expose their objects as MCP
+// resources
+type K8sResourceProvider interface {
 	// RegisterResources registers all mcp resources for a k8s resource with the provided resource set
-	// RegisterResources(resourceSet *ResourceSet)
+	RegisterResources(resourceSet *ResourceSet)
 }
 
 // K8sResourceRegistry is a registry for all resource handlers
@@ -1,10 +1,17 @@
 package toolsets
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Tests for the K8sResourceRegistry
@@ -31,6 +38,172 @@ func TestK8sResourceRegistry(t *testing.T) {
 	assert.Contains(t, handlers, "mock")
 }
 
+func TestWrapReadTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddReadTool(mcp.NewTool("read"), handler)
+	toolset.AddWriteTool(mcp.NewTool("write"), handler)
+
+	toolset.WrapReadTools(func(tool server.ServerTool) server.ServerTool {
+		tool.Tool.Description = "wrapped"
+		return tool
+	})
+
+	tools := toolset.GetActiveTools()
+	assert.Len(t, tools, 2)
+	assert.Equal(t, "read", tools[0].Tool.Name)
+	assert.Equal(t, "wrapped", tools[0].Tool.Description)
+	assert.Equal(t, "write", tools[1].Tool.Name)
+	assert.Empty(t, tools[1].Tool.Description)
+}
+
+func TestWrapWriteTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddReadTool(mcp.NewTool("read"), handler)
+	toolset.AddWriteTool(mcp.NewTool("write"), handler)
+
+	var wrapped []string
+	toolset.WrapWriteTools(func(tool server.ServerTool) server.ServerTool {
+		wrapped = append(wrapped, tool.Tool.Name)
+		return tool
+	})
+
+	assert.Equal(t, []string{"write"}, wrapped)
+}
+
+func TestWrapTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddReadTool(mcp.NewTool("read"), handler)
+	toolset.AddWriteTool(mcp.NewTool("write"), handler)
+
+	var wrapped []string
+	toolset.WrapTools(func(tool server.ServerTool) server.ServerTool {
+		wrapped = append(wrapped, tool.Tool.Name)
+		return tool
+	})
+
+	assert.Equal(t, []string{"read", "write"}, wrapped)
+}
+
+func TestAddTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	pods := NewToolset("pod", "", false)
+	pods.AddReadTool(mcp.NewTool("get_pod"), handler)
+	pods.AddWriteTool(mcp.NewTool("delete_pod"), handler)
+
+	var names []string
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddTools(pods)
+	for _, tool := range toolset.GetActiveTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.Equal(t, []string{"get_pod", "delete_pod"}, names)
+	assert.Equal(t, "pod", toolset.Group("delete_pod"))
+	assert.Equal(t, "", toolset.Group("list_clusters"))
+
+	// Write tools of a toolset set to read-only are not added
+	pods.SetReadOnly()
+	names = nil
+	toolset = NewToolset("test", "test toolset", false)
+	toolset.AddTools(pods)
+	for _, tool := range toolset.GetActiveTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.Equal(t, []string{"get_pod"}, names)
+
+	names = nil
+	readOnly := NewToolset("test", "test toolset", true)
+	readOnly.AddTools(pods)
+	for _, tool := range readOnly.GetAvailableTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.Equal(t, []string{"get_pod"}, names)
+}
+
+func TestDisableTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("pod", "", false)
+	toolset.AddReadTool(mcp.NewTool("get_pod"), handler)
+	toolset.AddWriteTool(mcp.NewTool("delete_pod"), handler)
+	toolset.AddWriteTool(mcp.NewTool("scale_deployment"), handler)
+	// Tools added after disabling them are disabled too
+	toolset.DisableTools("delete_pod", "drain_node")
+	toolset.AddWriteTool(mcp.NewTool("drain_node"), handler)
+
+	var names []string
+	for _, tool := range toolset.GetActiveTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.Equal(t, []string{"get_pod", "scale_deployment"}, names)
+	assert.Len(t, toolset.GetAvailableTools(), 4)
+
+	s := server.NewMCPServer("test", "1.0.0")
+	toolset.RegisterTools(s)
+	assert.NotNil(t, s.GetTool("get_pod"))
+	assert.NotNil(t, s.GetTool("scale_deployment"))
+	assert.Nil(t, s.GetTool("delete_pod"))
+	assert.Nil(t, s.GetTool("drain_node"))
+}
+
+func TestToolAnnotations(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddReadTool(mcp.NewTool("get_pod"), handler)
+	toolset.AddWriteTool(mcp.NewTool("delete_pod"), handler)
+	toolset.AddWriteTool(mcp.NewTool("create_namespace"), handler)
+	toolset.AddWriteTool(mcp.NewTool("cordon_node"), handler)
+	toolset.AddWriteTool(mcp.NewTool("new_write_tool"), handler)
+
+	annotations := map[string][3]bool{}
+	for _, tool := range toolset.GetActiveTools() {
+		hints := tool.Tool.Annotations
+		annotations[tool.Tool.Name] = [3]bool{*hints.ReadOnlyHint, *hints.DestructiveHint, *hints.IdempotentHint}
+	}
+	// readOnlyHint, destructiveHint, idempotentHint
+	assert.Equal(t, map[string][3]bool{
+		"get_pod":          {true, false, true},
+		"delete_pod":       {false, true, true},
+		"create_namespace": {false, false, false},
+		"cordon_node":      {false, false, true},
+		// Write tools not known otherwise are assumed to be destructive
+		"new_write_tool": {false, true, false},
+	}, annotations)
+}
+
+func TestRemoveTools(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddReadTool(mcp.NewTool("get_pod"), handler)
+	toolset.AddReadTool(mcp.NewTool("list_pods"), handler)
+	toolset.AddWriteTool(mcp.NewTool("delete_pod"), handler)
+
+	toolset.RemoveTools(func(tool server.ServerTool) bool {
+		return tool.Tool.Name != "list_pods"
+	})
+
+	var names []string
+	for _, tool := range toolset.GetActiveTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.Equal(t, []string{"list_pods"}, names)
+}
+
 // Tests for the parameter helper functions
 
 func TestRequiredParam(t *testing.T) {
@@ -87,21 +260,340 @@ func TestOptionalParam(t *testing.T) {
 	assert.Contains(t, err.Error(), "is not of type")
 }
 
+func TestStringMapParam(t *testing.T) {
+	request := createTestRequest(map[string]interface{}{
+		"labels": map[string]interface{}{"app": "web"},
+		"data":   map[string]interface{}{"replicas": 3},
+		"name":   "web",
+	})
+
+	values, err := StringMapParam(request, "labels")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "web"}, values)
+
+	values, err = StringMapParam(request, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, values)
+
+	_, err = StringMapParam(request, "data")
+	assert.EqualError(t, err, "data.replicas must be a string")
+
+	_, err = StringMapParam(request, "name")
+	assert.ErrorContains(t, err, "is not of type")
+}
+
+func TestNewToolResultJSON(t *testing.T) {
+	list := &corev1.PodList{Items: []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "<web>"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop"}},
+	}}
+	expected, err := json.Marshal(list)
+	require.NoError(t, err)
+
+	result, err := NewToolResultJSON(list)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, string(expected), result.Content[0].(mcp.TextContent).Text)
+
+	_, err = NewToolResultJSON(map[string]interface{}{"invalid": make(chan int)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to marshal response")
+}
+
+// benchmarkPodList returns a list of pods the size of a busy namespace
+func benchmarkPodList() *corev1.PodList {
+	list := &corev1.PodList{}
+	for i := 0; i < 2000; i++ {
+		list.Items = append(list.Items, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("web-%d", i),
+				Namespace: "shop",
+				Labels:    map[string]string{"app": "web", "pod-template-hash": "5d4f8c7b9"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+				Containers: []corev1.Container{{
+					Name:  "web",
+					Image: "registry.example.com/shop/web:1.2.3",
+					Env:   []corev1.EnvVar{{Name: "MODE", Value: "production"}},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+		})
+	}
+	return list
+}
+
+// BenchmarkNewToolResultJSON measures encoding a large list result, the baseline for changes to
+// how results are encoded
+func BenchmarkNewToolResultJSON(b *testing.B) {
+	list := benchmarkPodList()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewToolResultJSON(list); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestApplyPagination(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		expected      metav1.ListOptions
+		expectedError string
+	}{
+		{
+			name:     "default limit",
+			args:     map[string]interface{}{},
+			expected: metav1.ListOptions{Limit: DefaultListLimit},
+		},
+		{
+			name:     "limit and continue token",
+			args:     map[string]interface{}{"limit": float64(20), "continue": "token"},
+			expected: metav1.ListOptions{Limit: 20, Continue: "token"},
+		},
+		{
+			name:          "negative limit",
+			args:          map[string]interface{}{"limit": float64(-1)},
+			expectedError: "limit must be a positive integer",
+		},
+		{
+			name:          "fractional limit",
+			args:          map[string]interface{}{"limit": 2.5},
+			expectedError: "limit must be a positive integer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := metav1.ListOptions{LabelSelector: "app=web"}
+			err := ApplyPagination(createTestRequest(tc.args), &options)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.expected.LabelSelector = "app=web"
+			assert.Equal(t, tc.expected, options)
+		})
+	}
+}
+
+func TestNewListPageResult(t *testing.T) {
+	remaining := int64(3)
+	result, err := NewListPageResult([]string{"web-0"}, metav1.ListMeta{Continue: "token", RemainingItemCount: &remaining})
+	require.NoError(t, err)
+	assert.Equal(t, `{"items":["web-0"],"continue":"token","remainingItemCount":3}`, result.Content[0].(mcp.TextContent).Text)
+
+	// The last page has no continue token
+	result, err = NewListPageResult([]string{}, metav1.ListMeta{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"items":[]}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestListNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		expected      string
+		expectedError string
+	}{
+		{name: "namespace", args: map[string]interface{}{"namespace": "shop"}, expected: "shop"},
+		{name: "wildcard", args: map[string]interface{}{"namespace": "*"}, expected: metav1.NamespaceAll},
+		{name: "all namespaces", args: map[string]interface{}{"allNamespaces": true}, expected: metav1.NamespaceAll},
+		{name: "all namespaces and wildcard", args: map[string]interface{}{"namespace": "*", "allNamespaces": true}, expected: metav1.NamespaceAll},
+		{name: "all namespaces and namespace", args: map[string]interface{}{"namespace": "shop", "allNamespaces": true}, expectedError: `namespace "shop" cannot be combined with allNamespaces`},
+		{name: "missing namespace", args: map[string]interface{}{}, expectedError: "missing required parameter: namespace"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			namespace, err := ListNamespace(createTestRequest(tc.args))
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, namespace)
+		})
+	}
+}
+
+func TestSortItems(t *testing.T) {
+	pods := func() []corev1.Pod {
+		return []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "blog"}},
+		}
+	}
+	names := func(items []corev1.Pod) []string {
+		var names []string
+		for _, pod := range items {
+			names = append(names, pod.Namespace+"/"+pod.Name)
+		}
+		return names
+	}
+
+	// Without sortBy the order of the API server is kept
+	items := pods()
+	require.NoError(t, SortItems(createTestRequest(map[string]interface{}{}), items, SortKeys[corev1.Pod]{}))
+	assert.Equal(t, []string{"shop/web", "shop/api", "blog/web"}, names(items))
+
+	// Objects of the same name are ordered by namespace
+	require.NoError(t, SortItems(createTestRequest(map[string]interface{}{"sortBy": "name"}), items, SortKeys[corev1.Pod]{}))
+	assert.Equal(t, []string{"shop/api", "blog/web", "shop/web"}, names(items))
+
+	err := SortItems(createTestRequest(map[string]interface{}{"sortBy": "restarts"}), items, SortKeys[corev1.Pod]{})
+	assert.EqualError(t, err, "sortBy must be one of name, age")
+}
+
+func TestResourceSet(t *testing.T) {
+	resourceSet := NewResourceSet()
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/pods/{name}", "pod"), "get_pod")
+	resourceSet.AddTemplate(mcp.NewResourceTemplate("k8s://{namespace}/deployments/{name}", "deployment"), "get_deployment")
+
+	var received map[string]interface{}
+	getPod := NewServerTool(mcp.NewTool("get_pod"), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		if request.GetArguments()["name"] == "missing" {
+			return mcp.NewToolResultError(`pods "missing" not found`), nil
+		}
+		return mcp.NewToolResultText(`{"metadata":{"name":"web-0"}}`), nil
+	})
+
+	// Templates of tools that are not registered, such as get_deployment, are left out
+	s := server.NewMCPServer("test", "1.0.0")
+	resourceSet.RegisterResources(s, []server.ServerTool{getPod})
+
+	message := func(method string, params string) map[string]interface{} {
+		response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"`+method+`","params":`+params+`}`))
+		b, err := json.Marshal(response)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		return decoded
+	}
+
+	templates := message("resources/templates/list", `{}`)["result"].(map[string]interface{})["resourceTemplates"].([]interface{})
+	require.Len(t, templates, 1)
+	assert.Equal(t, "k8s://{namespace}/pods/{name}", templates[0].(map[string]interface{})["uriTemplate"])
+
+	read := message("resources/read", `{"uri":"k8s://shop/pods/web-0"}`)
+	assert.Equal(t, map[string]interface{}{"namespace": "shop", "name": "web-0"}, received)
+	contents := read["result"].(map[string]interface{})["contents"].([]interface{})
+	require.Len(t, contents, 1)
+	assert.Equal(t, map[string]interface{}{
+		"uri":      "k8s://shop/pods/web-0",
+		"mimeType": "application/json",
+		"text":     `{"metadata":{"name":"web-0"}}`,
+	}, contents[0])
+
+	// Tool errors fail the read
+	read = message("resources/read", `{"uri":"k8s://shop/pods/missing"}`)
+	assert.Contains(t, read["error"].(map[string]interface{})["message"], `pods "missing" not found`)
+}
+
+func TestDynamicToolsets(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	pods := NewToolset("pod", "", false)
+	pods.AddReadTool(mcp.NewTool("get_pod"), handler)
+	pods.AddReadTool(mcp.NewTool("list_pods"), handler)
+	nodes := NewToolset("node", "", false)
+	nodes.AddReadTool(mcp.NewTool("get_node"), handler)
+	nodes.AddWriteTool(mcp.NewTool("drain_node"), handler)
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	dynamic := NewDynamicToolsets(s)
+	toolset := NewToolset("test", "test toolset", false)
+	toolset.AddTools(pods)
+	toolset.AddTools(nodes)
+	toolset.AddReadTool(dynamic.ListTool())
+	toolset.AddReadTool(dynamic.EnableTool())
+	// Tools hidden for lack of permissions stay hidden when their toolset is enabled
+	dynamic.SetVisible(func(name string) bool { return name != "drain_node" })
+	dynamic.RegisterTools(toolset)
+
+	session := fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+
+	message := func(method string, params string) map[string]interface{} {
+		response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"`+method+`","params":`+params+`}`))
+		b, err := json.Marshal(response)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		return decoded
+	}
+	listTools := func() []string {
+		var names []string
+		for _, tool := range message("tools/list", `{}`)["result"].(map[string]interface{})["tools"].([]interface{}) {
+			names = append(names, tool.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+	callTool := func(name string, arguments string) (string, bool) {
+		result := message("tools/call", `{"name":"`+name+`","arguments":`+arguments+`}`)["result"].(map[string]interface{})
+		isError, _ := result["isError"].(bool)
+		return result["content"].([]interface{})[0].(map[string]interface{})["text"].(string), isError
+	}
+
+	// Only the tools added directly are registered at first
+	assert.ElementsMatch(t, []string{"list_available_toolsets", "enable_toolset"}, listTools())
+	assert.True(t, dynamic.Enabled("enable_toolset"))
+	assert.False(t, dynamic.Enabled("get_node"))
+
+	text, isError := callTool("list_available_toolsets", `{}`)
+	require.False(t, isError, text)
+	assert.JSONEq(t, `[
+		{"name":"node","enabled":false,"tools":["drain_node","get_node"]},
+		{"name":"pod","enabled":false,"tools":["get_pod","list_pods"]}
+	]`, text)
+
+	text, isError = callTool("enable_toolset", `{"toolset":"node"}`)
+	require.False(t, isError, text)
+	assert.JSONEq(t, `{"name":"node","enabled":true,"tools":["drain_node","get_node"]}`, text)
+	assert.ElementsMatch(t, []string{"list_available_toolsets", "enable_toolset", "get_node"}, listTools())
+	assert.True(t, dynamic.Enabled("get_node"))
+	select {
+	case notification := <-session.notifications:
+		assert.Equal(t, "notifications/tools/list_changed", notification.Method)
+	default:
+		t.Fatal("expected a tools/list_changed notification")
+	}
+
+	// Enabling a toolset again changes nothing
+	_, isError = callTool("enable_toolset", `{"toolset":"node"}`)
+	require.False(t, isError)
+	assert.Empty(t, session.notifications)
+
+	text, isError = callTool("enable_toolset", `{"toolset":"rbac"}`)
+	assert.True(t, isError)
+	assert.Equal(t, `unknown toolset "rbac": must be one of node, pod`, text)
+}
+
 // Helper functions for testing
 
+// fakeSession is a client session collecting the notifications sent to it
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s fakeSession) SessionID() string                                   { return "session" }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
 type mockK8sResourceHandler struct{}
 
 func (m *mockK8sResourceHandler) RegisterTools(toolset *Toolset) {}
 
 func createTestRequest(args map[string]interface{}) mcp.CallToolRequest {
 	return mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: args,
 		},
 	}
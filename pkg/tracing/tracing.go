@@ -0,0 +1,204 @@
+// Package tracing exports OpenTelemetry traces of tool calls over OTLP. Each tool call is a span
+// named after the tool, carrying the namespace and resource it targets, and the Kubernetes API
+// requests made while handling it are its child spans.
+//
+// The exporters also honor the standard OTEL_EXPORTER_OTLP_* environment variables, such as
+// OTEL_EXPORTER_OTLP_HEADERS, and the SDK honors OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES and
+// OTEL_TRACES_SAMPLER.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+)
+
+// OTLP protocols
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+// ServiceName is the service.name of exported spans, unless OTEL_SERVICE_NAME overrides it
+const ServiceName = "k8s-mcp-server"
+
+// Span attributes of tool calls
+const (
+	AttributeTool         = attribute.Key("mcp.tool.name")
+	AttributeNamespace    = attribute.Key("k8s.namespace.name")
+	AttributeResourceKind = attribute.Key("k8s.resource.kind")
+	AttributeResourceName = attribute.Key("k8s.resource.name")
+)
+
+// Config selects where spans are exported
+type Config struct {
+	// Endpoint is the host:port or URL of the OTLP collector
+	Endpoint string
+	// Protocol is grpc or http (OTLP/HTTP with protobuf payloads)
+	Protocol string
+	// Insecure disables TLS towards a host:port endpoint
+	Insecure bool
+}
+
+// Tracer records the spans of tool calls and Kubernetes API requests
+type Tracer struct {
+	provider trace.TracerProvider
+	tracer   trace.Tracer
+	shutdown func(context.Context) error
+}
+
+// New creates a tracer exporting to the collector of cfg. The tracer is also installed as the
+// global OpenTelemetry tracer provider, with W3C trace context propagation.
+func New(ctx context.Context, cfg Config, version string) (*Tracer, error) {
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName), semconv.ServiceVersion(version)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return newTracer(provider, provider.Shutdown), nil
+}
+
+func newTracer(provider trace.TracerProvider, shutdown func(context.Context) error) *Tracer {
+	return &Tracer{
+		provider: provider,
+		tracer:   provider.Tracer("github.com/briankscheong/k8s-mcp-server/pkg/tracing"),
+		shutdown: shutdown,
+	}
+}
+
+// newExporter creates the OTLP exporter of cfg
+func newExporter(ctx context.Context, cfg Config) (*otlptrace.Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("an OTLP endpoint is required")
+	}
+	isURL := strings.Contains(cfg.Endpoint, "://")
+
+	var exporter *otlptrace.Exporter
+	var err error
+	switch cfg.Protocol {
+	case "", ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if isURL {
+			opts = []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(cfg.Endpoint)}
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if isURL {
+			opts = []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.Endpoint)}
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q, expected %s or %s", cfg.Protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// Shutdown exports the spans not exported yet and stops the tracer
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	return t.shutdown(ctx)
+}
+
+// Wrap records the calls of a tool as spans, marking calls that fail or return an error result
+func (t *Tracer) Wrap(tool server.ServerTool) server.ServerTool {
+	name := tool.Tool.Name
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, span := t.tracer.Start(ctx, "tools/call "+name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(toolAttributes(name, request.GetArguments())...),
+		)
+		defer span.End()
+
+		result, err := next(ctx, request)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case result != nil && result.IsError:
+			span.SetStatus(codes.Error, resultText(result))
+		}
+		return result, err
+	}
+	return tool
+}
+
+// toolAttributes returns the span attributes of a tool call
+func toolAttributes(name string, args map[string]interface{}) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{AttributeTool.String(name)}
+	if namespace, ok := args["namespace"].(string); ok && namespace != "" {
+		attrs = append(attrs, AttributeNamespace.String(namespace))
+	}
+	for _, param := range []string{"kind", "resource", "resourceType"} {
+		if kind, ok := args[param].(string); ok && kind != "" {
+			attrs = append(attrs, AttributeResourceKind.String(kind))
+			break
+		}
+	}
+	if resourceName, ok := args["name"].(string); ok && resourceName != "" {
+		attrs = append(attrs, AttributeResourceName.String(resourceName))
+	}
+	return attrs
+}
+
+// resultText returns the text of an error result
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool returned an error result"
+}
+
+// WrapConfig makes the clients created from config record their API requests as spans, children
+// of the tool call span of the request context. Configs already traced are left as is.
+func (t *Tracer) WrapConfig(config *rest.Config) {
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		if _, ok := rt.(*otelhttp.Transport); ok {
+			return rt
+		}
+		return otelhttp.NewTransport(rt,
+			otelhttp.WithTracerProvider(t.provider),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return "kubernetes " + r.Method
+			}),
+		)
+	})
+}
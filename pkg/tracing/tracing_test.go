@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func newTestTracer() (*Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return newTracer(provider, provider.Shutdown), recorder
+}
+
+func TestWrap(t *testing.T) {
+	tracer, recorder := newTestTracer()
+
+	tool := tracer.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("get_pod"),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if request.GetArguments()["name"] == "missing" {
+				return mcp.NewToolResultError("pod not found"), nil
+			}
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+
+	call := func(args map[string]interface{}) {
+		_, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+	}
+	call(map[string]interface{}{"namespace": "payments", "name": "api-0"})
+	call(map[string]interface{}{"namespace": "payments", "name": "missing"})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "tools/call get_pod", spans[0].Name())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		AttributeTool.String("get_pod"),
+		AttributeNamespace.String("payments"),
+		AttributeResourceName.String("api-0"),
+	}, spans[0].Attributes())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "pod not found", spans[1].Status().Description)
+}
+
+func TestToolAttributes(t *testing.T) {
+	attrs := toolAttributes("get_resource", map[string]interface{}{"kind": "Deployment", "resource": "deployments", "namespace": ""})
+	assert.Equal(t, []attribute.KeyValue{
+		AttributeTool.String("get_resource"),
+		AttributeResourceKind.String("Deployment"),
+	}, attrs)
+}
+
+func TestWrapConfig(t *testing.T) {
+	tracer, recorder := newTestTracer()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"32","gitVersion":"v1.32.0"}`))
+	}))
+	defer apiServer.Close()
+
+	config := &rest.Config{Host: apiServer.URL}
+	tracer.WrapConfig(config)
+	// Wrapping a traced config again must not record every request twice
+	tracer.WrapConfig(config)
+	client, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	tool := tracer.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("get_cluster_version"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			_, err := client.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+			require.NoError(t, err)
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+	_, err = tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "kubernetes GET", spans[0].Name())
+	assert.Equal(t, "tools/call get_cluster_version", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), Config{}, "test")
+	assert.ErrorContains(t, err, "an OTLP endpoint is required")
+
+	_, err = New(context.Background(), Config{Endpoint: "localhost:4317", Protocol: "udp"}, "test")
+	assert.ErrorContains(t, err, `unsupported OTLP protocol "udp"`)
+
+	for _, protocol := range []string{ProtocolGRPC, ProtocolHTTP} {
+		tracer, err := New(context.Background(), Config{Endpoint: "http://localhost:1", Protocol: protocol}, "test")
+		require.NoError(t, err)
+		assert.NoError(t, tracer.Shutdown(context.Background()))
+	}
+}
@@ -0,0 +1,384 @@
+// Package transcript records the tool calls of each MCP session so they can be exported as an
+// incident artifact, with sensitive values redacted. Sessions are owned by the credentials their
+// calls were made with, and only those credentials can download a session's transcript.
+package transcript
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/auth"
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/briankscheong/k8s-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Limits keeping the recorder's memory bounded
+const (
+	DefaultMaxSessions = 100
+	DefaultMaxEntries  = 500
+	DefaultMaxOutput   = 4096
+)
+
+// Export formats
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// ClusterIdentity identifies the cluster the tool calls ran against
+type ClusterIdentity struct {
+	Server string `json:"server"`
+}
+
+// Entry is a single recorded tool call
+type Entry struct {
+	Tool       string                 `json:"tool"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Output     string                 `json:"output"`
+	Truncated  bool                   `json:"truncated,omitempty"`
+	IsError    bool                   `json:"isError,omitempty"`
+	IncidentID string                 `json:"incidentId,omitempty"`
+	StartedAt  time.Time              `json:"startedAt"`
+	DurationMs int64                  `json:"durationMs"`
+}
+
+// Transcript is the exported tool call history of a session
+type Transcript struct {
+	SessionID     string          `json:"sessionId"`
+	ServerVersion string          `json:"serverVersion"`
+	Cluster       ClusterIdentity `json:"cluster"`
+	ExportedAt    time.Time       `json:"exportedAt"`
+	Dropped       int             `json:"dropped,omitempty"`
+	Entries       []Entry         `json:"entries"`
+}
+
+type session struct {
+	owner      string
+	entries    []Entry
+	dropped    int
+	lastActive time.Time
+}
+
+// Recorder records tool calls per session
+type Recorder struct {
+	mu            sync.Mutex
+	sessions      map[string]*session
+	serverVersion string
+	cluster       ClusterIdentity
+	maxSessions   int
+	maxEntries    int
+	maxOutput     int
+	now           func() time.Time
+	incidentID    func() string
+}
+
+// NewRecorder creates a recorder for tool calls made against the given cluster
+func NewRecorder(serverVersion string, cluster ClusterIdentity) *Recorder {
+	return &Recorder{
+		sessions:      map[string]*session{},
+		serverVersion: serverVersion,
+		cluster:       cluster,
+		maxSessions:   DefaultMaxSessions,
+		maxEntries:    DefaultMaxEntries,
+		maxOutput:     DefaultMaxOutput,
+		now:           time.Now,
+	}
+}
+
+// SetIncidentID tags every call recorded from now on with the ID returned by incidentID, if any
+func (r *Recorder) SetIncidentID(incidentID func() string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.incidentID = incidentID
+}
+
+// SessionID returns the ID of the MCP session a request belongs to
+func SessionID(ctx context.Context) string {
+	if s := server.ClientSessionFromContext(ctx); s != nil {
+		return s.SessionID()
+	}
+	return ""
+}
+
+type ownerKey struct{}
+
+// ContextFromRequest records the owner of the HTTP request carrying tool calls, identified by the
+// credentials it sends, so the transcript of their session is only served to the same credentials
+func ContextFromRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, ownerKey{}, requestOwner(r))
+}
+
+// requestOwner hashes the credentials of a request, the API key and the Authorization header, so
+// callers sharing an API key but passing through different tokens own separate sessions. Requests
+// without credentials have no owner.
+func requestOwner(r *http.Request) string {
+	apiKey, authorization := r.Header.Get(auth.APIKeyHeader), r.Header.Get("Authorization")
+	if apiKey == "" && authorization == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey + "\x00" + authorization))
+	return hex.EncodeToString(sum[:])
+}
+
+// Wrap records every call of a tool in the transcript of the calling session
+func (r *Recorder) Wrap(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := r.now()
+		incidentID := r.currentIncidentID()
+		result, err := next(ctx, request)
+
+		entry := Entry{
+			Tool:       name,
+			Arguments:  redact.Arguments(request.GetArguments()),
+			IncidentID: incidentID,
+			StartedAt:  start,
+			DurationMs: r.now().Sub(start).Milliseconds(),
+		}
+		switch {
+		case err != nil:
+			entry.IsError = true
+			entry.Output = err.Error()
+		case result != nil:
+			entry.IsError = result.IsError
+			entry.Output = resultText(result)
+		}
+		entry.Output, entry.Truncated = truncate(redact.Output(entry.Output), r.maxOutput)
+		owner, _ := ctx.Value(ownerKey{}).(string)
+		r.record(SessionID(ctx), owner, entry)
+
+		return result, err
+	}
+	return tool
+}
+
+func (r *Recorder) currentIncidentID() string {
+	r.mu.Lock()
+	incidentID := r.incidentID
+	r.mu.Unlock()
+	if incidentID == nil {
+		return ""
+	}
+	return incidentID()
+}
+
+func (r *Recorder) record(sessionID, owner string, entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		if len(r.sessions) >= r.maxSessions {
+			r.evictOldestLocked()
+		}
+		s = &session{owner: owner}
+		r.sessions[sessionID] = s
+	}
+	s.lastActive = entry.StartedAt
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > r.maxEntries {
+		s.dropped += len(s.entries) - r.maxEntries
+		s.entries = s.entries[len(s.entries)-r.maxEntries:]
+	}
+}
+
+func (r *Recorder) evictOldestLocked() {
+	var oldest string
+	var oldestTime time.Time
+	for id, s := range r.sessions {
+		if oldestTime.IsZero() || s.lastActive.Before(oldestTime) {
+			oldest, oldestTime = id, s.lastActive
+		}
+	}
+	delete(r.sessions, oldest)
+}
+
+// Transcript returns the recorded tool calls of a session
+func (r *Recorder) Transcript(sessionID string) Transcript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := Transcript{
+		SessionID:     sessionID,
+		ServerVersion: r.serverVersion,
+		Cluster:       r.cluster,
+		ExportedAt:    r.now(),
+		Entries:       []Entry{},
+	}
+	if s, ok := r.sessions[sessionID]; ok {
+		t.Dropped = s.dropped
+		t.Entries = append(t.Entries, s.entries...)
+	}
+	return t
+}
+
+// ExportTool creates a tool that exports the transcript of the calling session
+func (r *Recorder) ExportTool() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("export_session_transcript",
+			mcp.WithDescription("Export the tool calls of the current session, with their arguments, redacted outputs, timing and the cluster they ran against, as a Markdown or JSON artifact for incident postmortems"),
+			mcp.WithString("format",
+				mcp.Description("Export format: markdown or json (default markdown)"),
+				mcp.Enum(FormatMarkdown, FormatJSON),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			format, err := toolsets.OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			text, err := Render(r.Transcript(SessionID(ctx)), format)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(text), nil
+		}
+}
+
+// owns reports whether a session is known and owned by owner. Sessions without an owner, recorded
+// from requests without credentials, are owned by nobody.
+func (r *Recorder) owns(sessionID, owner string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[sessionID]
+	return ok && owner != "" && s.owner == owner
+}
+
+// ServeHTTP exports the transcript of the session named by the sessionId query parameter, in the
+// format given by the format query parameter. Only requests with the credentials the session's
+// calls were made with get its transcript, others are told it does not exist.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sessionID := req.URL.Query().Get("sessionId")
+	if sessionID == "" {
+		http.Error(w, "missing sessionId query parameter", http.StatusBadRequest)
+		return
+	}
+
+	if !r.owns(sessionID, requestOwner(req)) {
+		http.Error(w, fmt.Sprintf("no transcript of session %s for these credentials", sessionID), http.StatusNotFound)
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+	text, err := Render(r.Transcript(sessionID), format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if format == FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	}
+	_, _ = w.Write([]byte(text))
+}
+
+// Render formats a transcript as Markdown or JSON. An empty format selects Markdown.
+func Render(t Transcript, format string) (string, error) {
+	switch format {
+	case "", FormatMarkdown:
+		return Markdown(t), nil
+	case FormatJSON:
+		b, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal transcript: %w", err)
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q: must be markdown or json", format)
+	}
+}
+
+// Markdown renders a transcript as a Markdown document
+func Markdown(t Transcript) string {
+	var b strings.Builder
+	b.WriteString("# Session transcript\n\n")
+	fmt.Fprintf(&b, "- **Session:** %s\n", valueOr(t.SessionID, "unknown"))
+	fmt.Fprintf(&b, "- **Cluster:** %s\n", valueOr(t.Cluster.Server, "unknown"))
+	fmt.Fprintf(&b, "- **Server version:** %s\n", valueOr(t.ServerVersion, "unknown"))
+	fmt.Fprintf(&b, "- **Exported at:** %s\n", t.ExportedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- **Tool calls:** %d", len(t.Entries))
+	if t.Dropped > 0 {
+		fmt.Fprintf(&b, " (%d earlier calls dropped)", t.Dropped)
+	}
+	b.WriteString("\n")
+
+	for i, entry := range t.Entries {
+		status := "ok"
+		if entry.IsError {
+			status = "error"
+		}
+		fmt.Fprintf(&b, "\n## %d. %s (%s, %d ms)\n\n", i+1, entry.Tool, status, entry.DurationMs)
+		fmt.Fprintf(&b, "Started at %s", entry.StartedAt.UTC().Format(time.RFC3339))
+		if entry.IncidentID != "" {
+			fmt.Fprintf(&b, " during incident %s", entry.IncidentID)
+		}
+		b.WriteString("\n\n")
+
+		args := "{}"
+		if len(entry.Arguments) > 0 {
+			if encoded, err := json.MarshalIndent(entry.Arguments, "", "  "); err == nil {
+				args = string(encoded)
+			}
+		}
+		b.WriteString("**Arguments**\n\n")
+		writeCodeBlock(&b, "json", args)
+
+		b.WriteString("\n**Output**")
+		if entry.Truncated {
+			b.WriteString(" (truncated)")
+		}
+		b.WriteString("\n\n")
+		writeCodeBlock(&b, "", entry.Output)
+	}
+	return b.String()
+}
+
+// writeCodeBlock writes a fenced code block, lengthening the fence when the content contains one
+func writeCodeBlock(b *strings.Builder, language, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n", fence, language, strings.TrimRight(content, "\n"), fence)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func truncate(s string, max int) (string, bool) {
+	if len(s) <= max {
+		return s, false
+	}
+	return s[:max], true
+}
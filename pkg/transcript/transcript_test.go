@@ -0,0 +1,313 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/briankscheong/k8s-mcp-server/pkg/redact"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to get text result from tool response
+func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.Content)
+	require.Equal(t, "text", result.Content[0].(mcp.TextContent).Type)
+	return result.Content[0].(mcp.TextContent)
+}
+
+// Helper function to create a MCP request
+func createMCPRequest(args map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: args,
+		},
+	}
+}
+
+// fakeSession is a client session with a fixed ID
+type fakeSession string
+
+func (s fakeSession) SessionID() string                                   { return string(s) }
+func (s fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s fakeSession) Initialize()                                         {}
+func (s fakeSession) Initialized() bool                                   { return true }
+
+// sessionContext returns a context carrying the given client session
+func sessionContext(srv *server.MCPServer, id string) context.Context {
+	return srv.WithContext(context.Background(), fakeSession(id))
+}
+
+// newTestRecorder creates a recorder with a fake clock advancing 5ms per reading
+func newTestRecorder() *Recorder {
+	r := NewRecorder("v1.2.3", ClusterIdentity{Server: "https://cluster.example.com"})
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		clock = clock.Add(5 * time.Millisecond)
+		return clock
+	}
+	return r
+}
+
+func textTool(name, text string, isError bool) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if isError {
+				return mcp.NewToolResultError(text), nil
+			}
+			return mcp.NewToolResultText(text), nil
+		},
+	}
+}
+
+func TestRecorderWrap(t *testing.T) {
+	srv := server.NewMCPServer("test", "1.0")
+	recorder := newTestRecorder()
+
+	listPods := recorder.Wrap(textTool("list_pods", `{"items":[{"metadata":{"name":"web"}}]}`, false))
+	getSecret := recorder.Wrap(textTool("get_secret", `{"kind":"Secret","metadata":{"name":"db"},"type":"Opaque","data":{"password":"c2VjcmV0"}}`, false))
+	failing := recorder.Wrap(textTool("delete_pod", "failed to delete pod: not found", true))
+	broken := recorder.Wrap(server.ServerTool{
+		Tool: mcp.NewTool("broken"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, errors.New("failed to get Kubernetes client")
+		},
+	})
+
+	ctxA := sessionContext(srv, "session-a")
+	ctxB := sessionContext(srv, "session-b")
+
+	result, err := listPods.Handler(ctxA, createMCPRequest(map[string]interface{}{"namespace": "shop"}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"items":[{"metadata":{"name":"web"}}]}`, getTextResult(t, result).Text)
+
+	_, err = getSecret.Handler(ctxA, createMCPRequest(map[string]interface{}{"name": "db", "token": "abc"}))
+	require.NoError(t, err)
+	_, err = failing.Handler(ctxA, createMCPRequest(map[string]interface{}{"name": "missing"}))
+	require.NoError(t, err)
+	_, err = broken.Handler(ctxA, createMCPRequest(nil))
+	require.Error(t, err)
+	_, err = listPods.Handler(ctxB, createMCPRequest(nil))
+	require.NoError(t, err)
+
+	transcript := recorder.Transcript("session-a")
+	assert.Equal(t, "session-a", transcript.SessionID)
+	assert.Equal(t, "https://cluster.example.com", transcript.Cluster.Server)
+	require.Len(t, transcript.Entries, 4)
+
+	assert.Equal(t, "list_pods", transcript.Entries[0].Tool)
+	assert.Equal(t, map[string]interface{}{"namespace": "shop"}, transcript.Entries[0].Arguments)
+	assert.Equal(t, int64(5), transcript.Entries[0].DurationMs)
+
+	assert.Equal(t, map[string]interface{}{"name": "db", "token": redact.Redacted}, transcript.Entries[1].Arguments)
+	assert.NotContains(t, transcript.Entries[1].Output, "c2VjcmV0")
+	assert.Contains(t, transcript.Entries[1].Output, `"password":"[REDACTED]"`)
+
+	assert.True(t, transcript.Entries[2].IsError)
+	assert.Equal(t, "failed to delete pod: not found", transcript.Entries[2].Output)
+	assert.True(t, transcript.Entries[3].IsError)
+	assert.Equal(t, "failed to get Kubernetes client", transcript.Entries[3].Output)
+
+	assert.Len(t, recorder.Transcript("session-b").Entries, 1)
+	assert.Empty(t, recorder.Transcript("unknown").Entries)
+}
+
+func TestRecorderLimits(t *testing.T) {
+	recorder := newTestRecorder()
+	recorder.maxEntries = 2
+	recorder.maxSessions = 2
+	recorder.maxOutput = 10
+
+	tool := recorder.Wrap(textTool("get_pod_logs", "0123456789abcdef", false))
+	srv := server.NewMCPServer("test", "1.0")
+	for _, id := range []string{"a", "a", "a", "b", "c"} {
+		_, err := tool.Handler(sessionContext(srv, id), createMCPRequest(nil))
+		require.NoError(t, err)
+	}
+
+	transcript := recorder.Transcript("a")
+	assert.Empty(t, transcript.Entries, "oldest session is evicted")
+
+	transcript = recorder.Transcript("b")
+	require.Len(t, transcript.Entries, 1)
+	assert.Equal(t, "0123456789", transcript.Entries[0].Output)
+	assert.True(t, transcript.Entries[0].Truncated)
+
+	recorder = newTestRecorder()
+	recorder.maxEntries = 2
+	tool = recorder.Wrap(textTool("list_pods", "[]", false))
+	for i := 0; i < 5; i++ {
+		_, err := tool.Handler(context.Background(), createMCPRequest(nil))
+		require.NoError(t, err)
+	}
+	transcript = recorder.Transcript("")
+	assert.Len(t, transcript.Entries, 2)
+	assert.Equal(t, 3, transcript.Dropped)
+}
+
+func TestExportTool(t *testing.T) {
+	srv := server.NewMCPServer("test", "1.0")
+	recorder := newTestRecorder()
+	tool := recorder.Wrap(textTool("get_pod", "```\nlog line\n```", false))
+	ctx := sessionContext(srv, "session-a")
+	_, err := tool.Handler(ctx, createMCPRequest(map[string]interface{}{"name": "web"}))
+	require.NoError(t, err)
+
+	exportTool, exportFn := recorder.ExportTool()
+	assert.Equal(t, "export_session_transcript", exportTool.Name)
+	assert.Empty(t, exportTool.InputSchema.Required)
+
+	t.Run("markdown", func(t *testing.T) {
+		result, err := exportFn(ctx, createMCPRequest(map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		text := getTextResult(t, result).Text
+		assert.Contains(t, text, "- **Session:** session-a\n")
+		assert.Contains(t, text, "- **Cluster:** https://cluster.example.com\n")
+		assert.Contains(t, text, "## 1. get_pod (ok, 5 ms)")
+		assert.Contains(t, text, "````\n```\nlog line\n```\n````\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		result, err := exportFn(ctx, createMCPRequest(map[string]interface{}{"format": "json"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var transcript Transcript
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &transcript))
+		assert.Equal(t, "v1.2.3", transcript.ServerVersion)
+		require.Len(t, transcript.Entries, 1)
+		assert.Equal(t, "get_pod", transcript.Entries[0].Tool)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		result, err := exportFn(ctx, createMCPRequest(map[string]interface{}{"format": "pdf"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `unsupported format "pdf"`)
+	})
+}
+
+func TestServeHTTP(t *testing.T) {
+	srv := server.NewMCPServer("test", "1.0")
+	recorder := newTestRecorder()
+	tool := recorder.Wrap(textTool("list_pods", "[]", false))
+	call := func(session string, headers map[string]string) {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		_, err := tool.Handler(ContextFromRequest(sessionContext(srv, session), r), createMCPRequest(nil))
+		require.NoError(t, err)
+	}
+	alice := map[string]string{"X-API-Key": "shared-key", "Authorization": "Bearer alice-token"}
+	call("session-a", alice)
+	call("session-b", map[string]string{"X-API-Key": "shared-key", "Authorization": "Bearer bob-token"})
+	call("session-anonymous", nil)
+
+	tests := []struct {
+		name                string
+		method              string
+		query               string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "markdown export",
+			method:              http.MethodGet,
+			query:               "sessionId=session-a",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/markdown; charset=utf-8",
+			expectedBody:        "## 1. list_pods",
+		},
+		{
+			name:                "json export",
+			method:              http.MethodGet,
+			query:               "sessionId=session-a&format=json",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `"tool": "list_pods"`,
+		},
+		{
+			name:           "missing session",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "missing sessionId",
+		},
+		{
+			name:           "session of other credentials",
+			method:         http.MethodGet,
+			query:          "sessionId=session-b",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "no transcript of session session-b for these credentials",
+		},
+		{
+			name:           "unknown session",
+			method:         http.MethodGet,
+			query:          "sessionId=session-c",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "session without credentials",
+			method:         http.MethodGet,
+			query:          "sessionId=session-anonymous",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unsupported method",
+			method:         http.MethodPost,
+			query:          "sessionId=session-a",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/mcp/transcript?"+tc.query, nil)
+			for k, v := range alice {
+				r.Header.Set(k, v)
+			}
+			recorder.ServeHTTP(rec, r)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedContentType != "" {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+			}
+			assert.True(t, strings.Contains(rec.Body.String(), tc.expectedBody), rec.Body.String())
+		})
+	}
+}
+
+func TestRecorderIncidentID(t *testing.T) {
+	srv := server.NewMCPServer("test", "1.0")
+	recorder := newTestRecorder()
+	listPods := recorder.Wrap(textTool("list_pods", `{"items":[]}`, false))
+	ctx := sessionContext(srv, "session-a")
+
+	_, err := listPods.Handler(ctx, createMCPRequest(nil))
+	require.NoError(t, err)
+
+	incidentID := "INC-42"
+	recorder.SetIncidentID(func() string { return incidentID })
+	_, err = listPods.Handler(ctx, createMCPRequest(nil))
+	require.NoError(t, err)
+
+	entries := recorder.Transcript("session-a").Entries
+	require.Len(t, entries, 2)
+	assert.Empty(t, entries[0].IncidentID)
+	assert.Equal(t, "INC-42", entries[1].IncidentID)
+	assert.Contains(t, Markdown(recorder.Transcript("session-a")), "during incident INC-42")
+}
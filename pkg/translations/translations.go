@@ -3,10 +3,10 @@ package translations
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"strings"
 
+	"github.com/briankscheong/k8s-mcp-server/pkg/log"
 	"github.com/spf13/viper"
 )
 
@@ -28,7 +28,7 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 	if err := v.ReadInConfig(); err != nil {
 		// ignore error if file not found as it is not required
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			log.Printf("Could not read JSON config: %v", err)
+			log.Component("translations").Warn().Err(err).Msg("Could not read JSON config")
 		}
 	}
 
@@ -51,7 +51,7 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 		}, func() {
 			// dump the translationKeyMap to a json file
 			if err := DumpTranslationKeyMap(translationKeyMap); err != nil {
-				log.Fatalf("Could not dump translation key map: %v", err)
+				log.Component("translations").Fatal().Err(err).Msg("Could not dump translation key map")
 			}
 		}
 }